@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/config"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
+	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	minKafkaReconnectDelay = 5 * time.Second
+	maxKafkaReconnectDelay = time.Minute
+)
+
+// The course service keeps serving requests while Kafka is unavailable.
+// Published events wait in the outbox and user events in their topic, and the
+// connection is retried in the background until Kafka is reachable.
+
+// relayOutbox publishes the events written to the outbox to Kafka until ctx is
+// done
+func relayOutbox(ctx context.Context, cfg config.KafkaConfig, db *database.DB) {
+	producer, ok := connectKafka(ctx, "producer", func() (*kafka.Producer, error) {
+		return kafka.NewProducer(cfg)
+	})
+	if !ok {
+		return
+	}
+	defer producer.Close()
+
+	kafka.NewOutboxRelay(db, producer).Start(ctx)
+}
+
+// consumeUserEvents handles the messages of the user events topic until ctx is
+// done. The consumer is recreated if it stops with an error.
+func consumeUserEvents(ctx context.Context, cfg config.KafkaConfig, topic string, handler kafka.MessageHandler) {
+	for {
+		consumer, ok := connectKafka(ctx, "consumer", func() (*kafka.Consumer, error) {
+			return kafka.NewConsumer(cfg, []string{topic}, handler)
+		})
+		if !ok {
+			return
+		}
+
+		err := consumer.Start(ctx)
+		consumer.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error("User event consumer stopped, reconnecting", zap.Error(err))
+	}
+}
+
+// connectKafka calls connect until it succeeds, waiting longer after each
+// failure. It returns false if ctx is done first.
+func connectKafka[T any](ctx context.Context, client string, connect func() (T, error)) (T, bool) {
+	delay := minKafkaReconnectDelay
+	for {
+		conn, err := connect()
+		if err == nil {
+			return conn, true
+		}
+		logger.Warn("Kafka is unavailable, running degraded",
+			zap.String("client", client),
+			zap.Duration("retry_in", delay),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxKafkaReconnectDelay)
+	}
+}
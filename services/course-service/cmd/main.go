@@ -6,15 +6,20 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	httphandler "github.com/SureshAmal/NimbusU-backend/services/course-service/internal/handler/http"
+	kafkahandler "github.com/SureshAmal/NimbusU-backend/services/course-service/internal/handler/kafka"
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/repository/postgres"
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/service"
 	"github.com/SureshAmal/NimbusU-backend/shared/config"
 	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
 	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"github.com/SureshAmal/NimbusU-backend/shared/middleware"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
@@ -41,13 +46,44 @@ func main() {
 
 	// Connect to PostgreSQL
 	logger.Info("Connecting to PostgreSQL", zap.String("url", cfg.Database.URL))
-	db, err := database.NewPostgresPool(cfg.Database)
+	pgPool, err := database.NewPostgresPool(cfg.Database)
 	if err != nil {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
-	defer database.ClosePostgresPool(db)
+	defer database.ClosePostgresPool(pgPool)
+	db := database.NewDB(pgPool)
 	logger.Info("Connected to PostgreSQL")
 
+	// Connect to Redis (shared with the user service for access token revocation)
+	logger.Info("Connecting to Redis", zap.String("url", cfg.Redis.URL))
+	redisClient, err := database.NewRedisClient(cfg.Redis)
+	if err != nil {
+		logger.Fatal("Failed to connect to Redis", zap.Error(err))
+	}
+	defer database.CloseRedisClient(redisClient)
+
+	// Fetch the public keys tokens are signed with from the user service.
+	// Keys are refetched periodically and whenever a token has an unknown kid,
+	// so starting before the user service is reachable is not fatal.
+	jwks := utils.NewJWKSClient(cfg.JWT.JWKSURL)
+	if err := jwks.Refresh(context.Background()); err != nil {
+		logger.Warn("Failed to fetch JWKS, tokens are rejected until it is reachable", zap.Error(err))
+	}
+
+	jwksCtx, stopJWKS := context.WithCancel(context.Background())
+	defer stopJWKS()
+	go jwks.Start(jwksCtx, 10*time.Minute)
+
+	// Initialize JWT manager (validates tokens issued by the user service)
+	jwtManager := utils.NewJWTManager(
+		jwks,
+		cfg.JWT.AccessTokenExpiry,
+		cfg.JWT.RefreshTokenExpiry,
+	)
+
+	// Access tokens revoked by the user service are rejected until they expire
+	tokenRevocations := middleware.NewTokenRevocationList(redisClient, time.Duration(cfg.JWT.AccessTokenExpiry)*time.Second)
+
 	// Initialize repositories
 	logger.Info("Initializing repositories")
 	deptRepo := postgres.NewDepartmentRepository(db)
@@ -60,23 +96,66 @@ func main() {
 	fcRepo := postgres.NewFacultyCourseRepository(db)
 	enrollRepo := postgres.NewEnrollmentRepository(db)
 	calendarRepo := postgres.NewCalendarRepository(db)
-
-	// Initialize services (nil producer for now - can add Kafka later)
+	userDirRepo := postgres.NewUserDirectoryRepository(db)
+	scaleRepo := postgres.NewGradingScaleRepository(db)
+	gradebookRepo := postgres.NewGradebookRepository(db)
+	transcriptRepo := postgres.NewTranscriptRepository(db)
+	curriculumRepo := postgres.NewCurriculumRepository(db)
+	rolloverRepo := postgres.NewSemesterRolloverRepository(db)
+
+	// Events are written to the outbox in the transaction of the change that
+	// raised them. They are kept there until a relay publishes them to Kafka.
+	outbox := kafka.NewOutbox(db)
+
+	// Initialize services
 	logger.Info("Initializing services")
-	deptService := service.NewDepartmentService(deptRepo, nil)
-	progService := service.NewProgramService(progRepo, deptRepo, nil)
-	subjService := service.NewSubjectService(subjRepo, deptRepo, nil)
-	semService := service.NewSemesterService(semRepo, nil)
-	courseService := service.NewCourseService(courseRepo, subjRepo, semRepo, enrollRepo, nil)
-	facultyService := service.NewFacultyService(facultyRepo, deptRepo, fcRepo, nil)
-	studentService := service.NewStudentService(studentRepo, deptRepo, progRepo, nil)
-	facultyAssignService := service.NewFacultyAssignmentService(fcRepo, facultyRepo, courseRepo, nil)
-	enrollService := service.NewEnrollmentService(enrollRepo, courseRepo, studentRepo, subjRepo, semRepo, nil)
-	calendarService := service.NewCalendarService(calendarRepo, semRepo, nil)
-
-	// Unused services - log for documentation
-	_ = facultyService
-	_ = studentService
+	deptService := service.NewDepartmentService(deptRepo, db, outbox)
+	progService := service.NewProgramService(progRepo, deptRepo, scaleRepo, db, outbox)
+	subjService := service.NewSubjectService(subjRepo, deptRepo, scaleRepo, db, outbox)
+	semService := service.NewSemesterService(semRepo, db, outbox)
+	courseService := service.NewCourseService(courseRepo, subjRepo, semRepo, enrollRepo, db, outbox)
+	facultyService := service.NewFacultyService(facultyRepo, deptRepo, fcRepo, db, outbox)
+	studentService := service.NewStudentService(studentRepo, deptRepo, progRepo, db, outbox)
+	facultyAssignService := service.NewFacultyAssignmentService(fcRepo, facultyRepo, courseRepo, db, outbox)
+	enrollService := service.NewEnrollmentService(enrollRepo, courseRepo, studentRepo, subjRepo, semRepo, db, outbox)
+	calendarService := service.NewCalendarService(calendarRepo, semRepo, db, outbox)
+	userDirService := service.NewUserDirectoryService(userDirRepo, facultyRepo, studentRepo, db)
+	scaleService := service.NewGradingScaleService(scaleRepo)
+	gradebookService := service.NewGradebookService(gradebookRepo, enrollRepo, courseRepo, deptRepo, fcRepo, studentRepo, scaleRepo, db, outbox)
+	transcriptService := service.NewTranscriptService(transcriptRepo, studentRepo, enrollRepo, scaleRepo, []byte(cfg.Transcript.SigningKey))
+	curriculumService := service.NewCurriculumService(curriculumRepo, progRepo, studentRepo, enrollRepo, scaleRepo)
+	rolloverService := service.NewSemesterRolloverService(rolloverRepo, semRepo)
+
+	// Repair enrollment counts that drifted from the enrollments table
+	reconcileCtx, stopReconcile := context.WithCancel(context.Background())
+	defer stopReconcile()
+	go service.NewEnrollmentReconciler(courseRepo).Start(reconcileCtx, time.Hour)
+
+	// Run started semester rollovers, resuming those left unfinished by a
+	// stopped instance
+	rolloverCtx, stopRollovers := context.WithCancel(context.Background())
+	defer stopRollovers()
+	go service.NewSemesterRolloverRunner(rolloverRepo, semRepo, studentRepo, db, outbox).Start(rolloverCtx, 30*time.Second)
+
+	// Relay outbox events to Kafka, and keep the local user directory in sync
+	// with the user service. Redelivered user events are skipped through the
+	// processed events ledger.
+	ledger := kafka.NewEventLedger(db, cfg.Kafka.ConsumerGroup)
+	userEventHandler := kafkahandler.NewUserEventHandler(userDirService)
+
+	kafkaCtx, stopKafka := context.WithCancel(context.Background())
+	defer stopKafka()
+	var kafkaClients sync.WaitGroup
+	kafkaClients.Add(2)
+	go func() {
+		defer kafkaClients.Done()
+		relayOutbox(kafkaCtx, cfg.Kafka, db)
+	}()
+	go func() {
+		defer kafkaClients.Done()
+		consumeUserEvents(kafkaCtx, cfg.Kafka, kafkahandler.UserEventTopic, ledger.Idempotent(userEventHandler.Handle))
+	}()
+	go ledger.Start(kafkaCtx)
 
 	// Setup routes
 	logger.Info("Setting up routes")
@@ -86,9 +165,18 @@ func main() {
 		subjService,
 		semService,
 		courseService,
+		facultyService,
+		studentService,
 		facultyAssignService,
 		enrollService,
 		calendarService,
+		scaleService,
+		gradebookService,
+		transcriptService,
+		curriculumService,
+		rolloverService,
+		jwtManager,
+		tokenRevocations,
 	)
 
 	// Create HTTP server
@@ -126,5 +214,9 @@ func main() {
 		logger.Error("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Stop the Kafka clients once no more requests can publish events
+	stopKafka()
+	kafkaClients.Wait()
+
 	logger.Info("Course Service stopped")
 }
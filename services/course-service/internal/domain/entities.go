@@ -38,8 +38,71 @@ type Program struct {
 	TotalCredits  *int      `json:"total_credits,omitempty" db:"total_credits"`
 	Description   *string   `json:"description,omitempty" db:"description"`
 	IsActive      bool      `json:"is_active" db:"is_active"`
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	// GradingScaleID is nil for programs graded on the default scale
+	GradingScaleID *uuid.UUID `json:"grading_scale_id,omitempty" db:"grading_scale_id"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// GradingScale maps letter grades to grade points. Grades of at least
+// PassGradePoints pass, and RepeatPolicy decides which attempt at a subject
+// counts towards the CGPA.
+type GradingScale struct {
+	ScaleID         uuid.UUID      `json:"scale_id" db:"scale_id"`
+	ScaleName       string         `json:"scale_name" db:"scale_name"`
+	MaxGradePoints  float64        `json:"max_grade_points" db:"max_grade_points"`
+	PassGradePoints float64        `json:"pass_grade_points" db:"pass_grade_points"`
+	RepeatPolicy    string         `json:"repeat_policy" db:"repeat_policy"`
+	IsDefault       bool           `json:"is_default" db:"is_default"`
+	Grades          []GradeMapping `json:"grades"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// GradeMapping is a letter grade of a grading scale
+type GradeMapping struct {
+	Grade       string  `json:"grade" db:"grade"`
+	GradePoints float64 `json:"grade_points" db:"grade_points"`
+}
+
+// Repeat policies
+const (
+	// RepeatPolicyBest counts the best attempt at a subject
+	RepeatPolicyBest = "best"
+	// RepeatPolicyLatest counts the latest attempt at a subject
+	RepeatPolicyLatest = "latest"
+)
+
+// Curriculum is a version of the degree requirements of a program. It applies
+// to the students of the program admitted from BatchYear on, until a version
+// with a later batch year takes over. TotalCredits is the minimum of credits
+// earned to graduate.
+type Curriculum struct {
+	CurriculumID    uuid.UUID        `json:"curriculum_id" db:"curriculum_id"`
+	ProgramID       uuid.UUID        `json:"program_id" db:"program_id"`
+	BatchYear       int              `json:"batch_year" db:"batch_year"`
+	VersionName     string           `json:"version_name" db:"version_name"`
+	TotalCredits    int              `json:"total_credits" db:"total_credits"`
+	CoreSubjects    []CoreSubject    `json:"core_subjects"`
+	ElectiveBuckets []ElectiveBucket `json:"elective_buckets"`
+	CreatedAt       time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// CoreSubject is a subject required by a curriculum, planned for the
+// semester SemesterNumber of the program
+type CoreSubject struct {
+	SubjectBasic
+	SemesterNumber int `json:"semester_number" db:"semester_number"`
+}
+
+// ElectiveBucket is a pool of subjects of a curriculum from which at least
+// MinCredits have to be earned
+type ElectiveBucket struct {
+	BucketID   uuid.UUID      `json:"bucket_id" db:"bucket_id"`
+	BucketName string         `json:"bucket_name" db:"bucket_name"`
+	MinCredits int            `json:"min_credits" db:"min_credits"`
+	Subjects   []SubjectBasic `json:"subjects"`
 }
 
 // ProgramWithDepartment includes department info
@@ -71,18 +134,47 @@ type SubjectWithDetails struct {
 	Corequisites  []SubjectBasic        `json:"corequisites,omitempty"`
 }
 
-// SubjectPrerequisite represents a prerequisite relationship
+// SubjectPrerequisite represents a prerequisite relationship. A prerequisite
+// with MinGrade or MinGradePoints is only met by a completion with at least
+// that grade.
 type SubjectPrerequisite struct {
 	PrerequisiteID        uuid.UUID `json:"prerequisite_id" db:"prerequisite_id"`
 	SubjectID             uuid.UUID `json:"subject_id" db:"subject_id"`
 	PrerequisiteSubjectID uuid.UUID `json:"prerequisite_subject_id" db:"prerequisite_subject_id"`
 	IsMandatory           bool      `json:"is_mandatory" db:"is_mandatory"`
+	MinGrade              *string   `json:"min_grade,omitempty" db:"min_grade"`
+	MinGradePoints        *float64  `json:"min_grade_points,omitempty" db:"min_grade_points"`
 	CreatedAt             time.Time `json:"created_at" db:"created_at"`
 	// Joined fields
 	SubjectCode string `json:"subject_code,omitempty" db:"prereq_subject_code"`
 	SubjectName string `json:"subject_name,omitempty" db:"prereq_subject_name"`
 }
 
+// PrerequisiteGraph is a set of subjects and the prerequisite relationships
+// between them
+type PrerequisiteGraph struct {
+	Subjects []SubjectBasic        `json:"subjects"`
+	Edges    []SubjectPrerequisite `json:"edges"`
+}
+
+// PrerequisiteTree lists the subjects a subject transitively requires, and
+// the subjects it transitively unlocks
+type PrerequisiteTree struct {
+	Subject       SubjectBasic            `json:"subject"`
+	Prerequisites []*PrerequisiteTreeNode `json:"prerequisites"`
+	Unlocks       []*PrerequisiteTreeNode `json:"unlocks"`
+}
+
+// PrerequisiteTreeNode is a subject in a prerequisite tree, together with the
+// rule linking it to its parent
+type PrerequisiteTreeNode struct {
+	Subject        SubjectBasic            `json:"subject"`
+	IsMandatory    bool                    `json:"is_mandatory"`
+	MinGrade       *string                 `json:"min_grade,omitempty"`
+	MinGradePoints *float64                `json:"min_grade_points,omitempty"`
+	Children       []*PrerequisiteTreeNode `json:"children"`
+}
+
 // SubjectCorequisite represents a corequisite relationship
 type SubjectCorequisite struct {
 	CorequisiteID        uuid.UUID `json:"corequisite_id" db:"corequisite_id"`
@@ -91,19 +183,23 @@ type SubjectCorequisite struct {
 	CreatedAt            time.Time `json:"created_at" db:"created_at"`
 }
 
-// Semester represents an academic semester
+// Semester represents an academic semester. Students add and drop courses
+// until AddDropEnd (RegistrationEnd if unset), and withdraw from them with a W
+// until WithdrawalDeadline (EndDate if unset).
 type Semester struct {
-	SemesterID        uuid.UUID  `json:"semester_id" db:"semester_id"`
-	SemesterName      string     `json:"semester_name" db:"semester_name"`
-	SemesterCode      string     `json:"semester_code" db:"semester_code"`
-	AcademicYear      int        `json:"academic_year" db:"academic_year"`
-	StartDate         time.Time  `json:"start_date" db:"start_date"`
-	EndDate           time.Time  `json:"end_date" db:"end_date"`
-	RegistrationStart *time.Time `json:"registration_start,omitempty" db:"registration_start"`
-	RegistrationEnd   *time.Time `json:"registration_end,omitempty" db:"registration_end"`
-	IsCurrent         bool       `json:"is_current" db:"is_current"`
-	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+	SemesterID         uuid.UUID  `json:"semester_id" db:"semester_id"`
+	SemesterName       string     `json:"semester_name" db:"semester_name"`
+	SemesterCode       string     `json:"semester_code" db:"semester_code"`
+	AcademicYear       int        `json:"academic_year" db:"academic_year"`
+	StartDate          time.Time  `json:"start_date" db:"start_date"`
+	EndDate            time.Time  `json:"end_date" db:"end_date"`
+	RegistrationStart  *time.Time `json:"registration_start,omitempty" db:"registration_start"`
+	RegistrationEnd    *time.Time `json:"registration_end,omitempty" db:"registration_end"`
+	AddDropEnd         *time.Time `json:"add_drop_end,omitempty" db:"add_drop_end"`
+	WithdrawalDeadline *time.Time `json:"withdrawal_deadline,omitempty" db:"withdrawal_deadline"`
+	IsCurrent          bool       `json:"is_current" db:"is_current"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // Faculty represents a faculty member
@@ -231,6 +327,362 @@ type CourseEnrollment struct {
 	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
 }
 
+// EnrollmentOverride records an admin enrolling or dropping a student outside
+// the registration windows of the semester
+type EnrollmentOverride struct {
+	OverrideID   uuid.UUID `json:"override_id" db:"override_id"`
+	EnrollmentID uuid.UUID `json:"enrollment_id" db:"enrollment_id"`
+	StudentID    uuid.UUID `json:"student_id" db:"student_id"`
+	CourseID     uuid.UUID `json:"course_id" db:"course_id"`
+	Action       string    `json:"action" db:"action"`
+	Reason       string    `json:"reason" db:"reason"`
+	OverriddenBy uuid.UUID `json:"overridden_by" db:"overridden_by"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// PrerequisiteWaiver lets a student enroll in a course without meeting one of
+// its prerequisites or corequisites
+type PrerequisiteWaiver struct {
+	WaiverID        uuid.UUID `json:"waiver_id" db:"waiver_id"`
+	StudentID       uuid.UUID `json:"student_id" db:"student_id"`
+	CourseID        uuid.UUID `json:"course_id" db:"course_id"`
+	WaivedSubjectID uuid.UUID `json:"waived_subject_id" db:"waived_subject_id"`
+	RequirementType string    `json:"requirement_type" db:"requirement_type"`
+	Reason          string    `json:"reason" db:"reason"`
+	GrantedBy       uuid.UUID `json:"granted_by" db:"granted_by"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// Gradebook statuses
+const (
+	GradebookSubmitted = "submitted"
+	GradebookReturned  = "returned"
+	GradebookApproved  = "approved"
+)
+
+// Gradebook holds the grades the primary faculty of a course submitted for
+// its roster. The grades are released to the enrollments when the head of the
+// department approves them, and can only be changed by amendments after that.
+type Gradebook struct {
+	CourseID      uuid.UUID        `json:"course_id" db:"course_id"`
+	Status        string           `json:"status" db:"status"`
+	SubmittedBy   uuid.UUID        `json:"submitted_by" db:"submitted_by"`
+	SubmittedAt   time.Time        `json:"submitted_at" db:"submitted_at"`
+	ReviewedBy    *uuid.UUID       `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewComment *string          `json:"review_comment,omitempty" db:"review_comment"`
+	Entries       []GradebookEntry `json:"entries"`
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// GradebookEntry is the grade submitted for one enrollment of the roster
+type GradebookEntry struct {
+	EnrollmentID uuid.UUID `json:"enrollment_id" db:"enrollment_id"`
+	StudentID    uuid.UUID `json:"student_id" db:"student_id"`
+	Grade        string    `json:"grade" db:"grade"`
+	GradePoints  float64   `json:"grade_points" db:"grade_points"`
+}
+
+// Grade amendment statuses
+const (
+	AmendmentPending  = "pending"
+	AmendmentApproved = "approved"
+	AmendmentRejected = "rejected"
+)
+
+// GradeAmendment is a request to change an approved grade. It keeps the
+// grade it replaces and who requested and reviewed the change.
+type GradeAmendment struct {
+	AmendmentID         uuid.UUID  `json:"amendment_id" db:"amendment_id"`
+	EnrollmentID        uuid.UUID  `json:"enrollment_id" db:"enrollment_id"`
+	CourseID            uuid.UUID  `json:"course_id" db:"course_id"`
+	StudentID           uuid.UUID  `json:"student_id" db:"student_id"`
+	PreviousGrade       *string    `json:"previous_grade,omitempty" db:"previous_grade"`
+	PreviousGradePoints *float64   `json:"previous_grade_points,omitempty" db:"previous_grade_points"`
+	NewGrade            string     `json:"new_grade" db:"new_grade"`
+	NewGradePoints      float64    `json:"new_grade_points" db:"new_grade_points"`
+	Reason              string     `json:"reason" db:"reason"`
+	Status              string     `json:"status" db:"status"`
+	RequestedBy         uuid.UUID  `json:"requested_by" db:"requested_by"`
+	RequestedAt         time.Time  `json:"requested_at" db:"requested_at"`
+	ReviewedBy          *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt          *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewComment       *string    `json:"review_comment,omitempty" db:"review_comment"`
+}
+
+// UnmetRequirement is a prerequisite or corequisite of a course that a student
+// does not meet, together with the student's best completed attempt at it
+type UnmetRequirement struct {
+	StudentID   uuid.UUID
+	Type        string
+	Subject     SubjectBasic
+	IsMandatory bool
+	// MinGradePoints is the higher of the minimum grade and minimum grade
+	// points of a prerequisite, if it has either
+	MinGradePoints *float64
+	// PassGradePoints of the grading scale of the student
+	PassGradePoints float64
+	Completed       bool
+	// GradePoints of the best completed attempt, nil if no grade was recorded
+	GradePoints *float64
+	Waived      bool
+}
+
+// GradedAttempt is a completed enrollment with grade points, as counted
+// towards a GPA
+type GradedAttempt struct {
+	EnrollmentID  uuid.UUID
+	SubjectID     uuid.UUID
+	SemesterID    uuid.UUID
+	SemesterStart time.Time
+	Credits       int
+	GradePoints   float64
+}
+
+// SemesterGPA is the GPA of a student in a semester. GPA is nil if none of
+// the graded subjects carry credits.
+type SemesterGPA struct {
+	StudentID        uuid.UUID `json:"student_id" db:"student_id"`
+	SemesterID       uuid.UUID `json:"semester_id" db:"semester_id"`
+	SemesterCode     string    `json:"semester_code,omitempty" db:"semester_code"`
+	SemesterName     string    `json:"semester_name,omitempty" db:"semester_name"`
+	GPA              *float64  `json:"gpa" db:"gpa"`
+	CreditsAttempted int       `json:"credits_attempted" db:"credits_attempted"`
+	CreditsEarned    int       `json:"credits_earned" db:"credits_earned"`
+	ComputedAt       time.Time `json:"computed_at" db:"computed_at"`
+}
+
+// AcademicRecord is the cumulative GPA and earned credits of a student,
+// together with their semester GPAs
+type AcademicRecord struct {
+	StudentID          uuid.UUID     `json:"student_id"`
+	CGPA               *float64      `json:"cgpa"`
+	TotalCreditsEarned int           `json:"total_credits_earned"`
+	Semesters          []SemesterGPA `json:"semesters"`
+}
+
+// Academic standings of a student
+const (
+	StandingGood      = "good_standing"
+	StandingProbation = "probation"
+	StandingInactive  = "inactive"
+)
+
+// TranscriptRecord is the academic record of a student shown on a transcript.
+// The verification hash of an issued transcript covers its JSON encoding.
+type TranscriptRecord struct {
+	StudentID          uuid.UUID            `json:"student_id"`
+	StudentName        string               `json:"student_name,omitempty"`
+	RegistrationNumber string               `json:"registration_number"`
+	Department         DepartmentBasic      `json:"department"`
+	Program            ProgramBasic         `json:"program"`
+	BatchYear          int                  `json:"batch_year"`
+	CurrentSemester    int                  `json:"current_semester"`
+	Standing           string               `json:"standing"`
+	GradingScale       string               `json:"grading_scale"`
+	MaxGradePoints     float64              `json:"max_grade_points"`
+	Semesters          []TranscriptSemester `json:"semesters"`
+	CGPA               *float64             `json:"cgpa"`
+	TotalCreditsEarned int                  `json:"total_credits_earned"`
+}
+
+// TranscriptSemester groups the graded courses of a transcript by semester
+type TranscriptSemester struct {
+	SemesterID       uuid.UUID          `json:"semester_id"`
+	SemesterCode     string             `json:"semester_code"`
+	SemesterName     string             `json:"semester_name"`
+	AcademicYear     int                `json:"academic_year"`
+	Courses          []TranscriptCourse `json:"courses"`
+	GPA              *float64           `json:"gpa"`
+	CreditsAttempted int                `json:"credits_attempted"`
+	CreditsEarned    int                `json:"credits_earned"`
+}
+
+// TranscriptCourse is a graded course on a transcript
+type TranscriptCourse struct {
+	EnrollmentID uuid.UUID `json:"enrollment_id"`
+	CourseCode   string    `json:"course_code"`
+	SubjectID    uuid.UUID `json:"subject_id"`
+	SubjectCode  string    `json:"subject_code"`
+	SubjectName  string    `json:"subject_name"`
+	Credits      int       `json:"credits"`
+	Grade        string    `json:"grade"`
+	GradePoints  float64   `json:"grade_points"`
+	Passed       bool      `json:"passed"`
+}
+
+// GradedCourse is a completed enrollment of a student with its course,
+// subject and semester, as listed on a transcript
+type GradedCourse struct {
+	TranscriptCourse
+	SemesterID    uuid.UUID
+	SemesterCode  string
+	SemesterName  string
+	AcademicYear  int
+	SemesterStart time.Time
+}
+
+// Transcript is an issued transcript. Anyone holding its verification code
+// can check it against the signed hash of its record.
+type Transcript struct {
+	TranscriptRecord
+	VerificationCode string    `json:"verification_code"`
+	ContentHash      string    `json:"content_hash"`
+	IssuedAt         time.Time `json:"issued_at"`
+}
+
+// IssuedTranscript is the stored copy of an issued transcript. Contents is
+// the JSON encoding of its record, ContentHash its SHA-256 digest and
+// Signature an HMAC of the digest.
+type IssuedTranscript struct {
+	VerificationCode string
+	StudentID        uuid.UUID
+	Contents         []byte
+	ContentHash      string
+	Signature        string
+	IssuedBy         uuid.UUID
+	IssuedAt         time.Time
+}
+
+// TranscriptVerification is the result of checking a verification code.
+// Valid is false if the stored transcript no longer matches its signed hash.
+type TranscriptVerification struct {
+	VerificationCode string           `json:"verification_code"`
+	Valid            bool             `json:"valid"`
+	ContentHash      string           `json:"content_hash"`
+	IssuedAt         time.Time        `json:"issued_at"`
+	Transcript       TranscriptRecord `json:"transcript"`
+}
+
+// Degree requirement statuses
+const (
+	RequirementSatisfied  = "satisfied"
+	RequirementInProgress = "in_progress"
+	RequirementMissing    = "missing"
+)
+
+// DegreeAudit evaluates the enrollments of a student against their
+// curriculum. Eligible is true once every requirement is satisfied.
+type DegreeAudit struct {
+	StudentID       uuid.UUID              `json:"student_id"`
+	CurriculumID    uuid.UUID              `json:"curriculum_id"`
+	VersionName     string                 `json:"version_name"`
+	CoreSubjects    []CoreSubjectAudit     `json:"core_subjects"`
+	ElectiveBuckets []ElectiveBucketAudit  `json:"elective_buckets"`
+	TotalCredits    CreditRequirementAudit `json:"total_credits"`
+	Eligible        bool                   `json:"eligible"`
+}
+
+// CoreSubjectAudit is the status of a core subject of a curriculum
+type CoreSubjectAudit struct {
+	CoreSubject
+	Status string `json:"status"`
+}
+
+// ElectiveBucketAudit is the status of an elective bucket of a curriculum,
+// with the subjects of its pool the student passed or is taking
+type ElectiveBucketAudit struct {
+	CreditRequirementAudit
+	BucketID   uuid.UUID      `json:"bucket_id"`
+	BucketName string         `json:"bucket_name"`
+	Completed  []SubjectBasic `json:"completed"`
+	InProgress []SubjectBasic `json:"in_progress"`
+}
+
+// CreditRequirementAudit is the status of a minimum of credits. A requirement
+// is in progress if the credits being taken would satisfy it.
+type CreditRequirementAudit struct {
+	Status            string `json:"status"`
+	RequiredCredits   int    `json:"required_credits"`
+	EarnedCredits     int    `json:"earned_credits"`
+	InProgressCredits int    `json:"in_progress_credits"`
+}
+
+// Semester rollover statuses
+const (
+	RolloverPending   = "pending"
+	RolloverRunning   = "running"
+	RolloverCompleted = "completed"
+	RolloverFailed    = "failed"
+)
+
+// Reasons a semester rollover flags a student instead of promoting them
+const (
+	RolloverFlagInactive      = "inactive"
+	RolloverFlagProbation     = "probation"
+	RolloverFlagFinalSemester = "final_semester"
+)
+
+// SemesterRollover is a background job ending a semester. It completes the
+// active courses of the semester, drops their waitlisted enrollments, promotes
+// or flags each of its students and makes the next semester current. A
+// rollover left running by a stopped instance is resumed once LockedUntil
+// has passed.
+type SemesterRollover struct {
+	RolloverID         uuid.UUID         `json:"rollover_id" db:"rollover_id"`
+	SemesterID         uuid.UUID         `json:"semester_id" db:"semester_id"`
+	NextSemesterID     uuid.UUID         `json:"next_semester_id" db:"next_semester_id"`
+	Status             string            `json:"status" db:"status"`
+	CoursesCompleted   int               `json:"courses_completed" db:"courses_completed"`
+	EnrollmentsDropped int               `json:"enrollments_dropped" db:"enrollments_dropped"`
+	StudentsPromoted   int               `json:"students_promoted" db:"students_promoted"`
+	StudentsFlagged    int               `json:"students_flagged" db:"students_flagged"`
+	Error              *string           `json:"error,omitempty" db:"error"`
+	RequestedBy        uuid.UUID         `json:"requested_by" db:"requested_by"`
+	LockedUntil        *time.Time        `json:"-" db:"locked_until"`
+	StartedAt          *time.Time        `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt        *time.Time        `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt          time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at" db:"updated_at"`
+	FlaggedStudents    []RolloverStudent `json:"flagged_students,omitempty"`
+}
+
+// RolloverStudent is the outcome of a rollover for a student: promoted to
+// ToSemester, or flagged with FlagReason and left in their semester
+type RolloverStudent struct {
+	StudentID          uuid.UUID `json:"student_id" db:"student_id"`
+	RegistrationNumber string    `json:"registration_number" db:"registration_number"`
+	FromSemester       int       `json:"from_semester" db:"from_semester"`
+	ToSemester         *int      `json:"to_semester,omitempty" db:"to_semester"`
+	FlagReason         string    `json:"flag_reason,omitempty" db:"flag_reason"`
+}
+
+// RolloverCandidate is a student with graded enrollments in a semester, with
+// the number of semesters of their program and the pass grade points of
+// their grading scale
+type RolloverCandidate struct {
+	StudentID          uuid.UUID
+	RegistrationNumber string
+	CurrentSemester    int
+	CGPA               *float64
+	IsActive           bool
+	ProgramSemesters   int
+	PassGradePoints    float64
+}
+
+// UngradedCourse is a course of a semester with students still enrolled in
+// it without a grade
+type UngradedCourse struct {
+	CourseID         uuid.UUID `json:"course_id" db:"course_id"`
+	CourseCode       string    `json:"course_code" db:"course_code"`
+	CourseName       string    `json:"course_name" db:"course_name"`
+	UngradedStudents int       `json:"ungraded_students" db:"ungraded_students"`
+}
+
+// RolloverPreview is what a rollover of a semester would do if started now.
+// Ready is false while any of its courses has ungraded students.
+type RolloverPreview struct {
+	SemesterID        uuid.UUID         `json:"semester_id"`
+	NextSemesterID    uuid.UUID         `json:"next_semester_id"`
+	Ready             bool              `json:"ready"`
+	UngradedCourses   []UngradedCourse  `json:"ungraded_courses"`
+	CoursesToComplete int               `json:"courses_to_complete"`
+	EnrollmentsToDrop int               `json:"enrollments_to_drop"`
+	PromotedStudents  []RolloverStudent `json:"promoted_students"`
+	FlaggedStudents   []RolloverStudent `json:"flagged_students"`
+}
+
 // EnrollmentWithDetails includes student and course info
 type EnrollmentWithDetails struct {
 	CourseEnrollment
@@ -260,6 +712,19 @@ type AcademicCalendarEventWithDetails struct {
 	Semester SemesterBasic `json:"semester"`
 }
 
+// UserDirectoryEntry is the local copy of a User Service user, kept up to date
+// from user.events
+type UserDirectoryEntry struct {
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	Email          string    `json:"email" db:"email"`
+	FirstName      string    `json:"first_name" db:"first_name"`
+	LastName       string    `json:"last_name" db:"last_name"`
+	Phone          *string   `json:"phone,omitempty" db:"phone"`
+	Status         string    `json:"status" db:"status"`
+	EventTimestamp time.Time `json:"event_timestamp" db:"event_timestamp"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
 // ========== Basic/Summary Types for Embedding ==========
 
 // DepartmentBasic is a minimal department representation
@@ -363,6 +828,12 @@ type SubjectFilter struct {
 	IsActive     *bool
 }
 
+// PrerequisiteGraphFilter selects the subjects of a prerequisite graph
+type PrerequisiteGraphFilter struct {
+	DepartmentID *uuid.UUID
+	ProgramID    *uuid.UUID
+}
+
 // SemesterFilter for filtering semesters
 type SemesterFilter struct {
 	AcademicYear *int
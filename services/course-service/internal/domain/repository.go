@@ -2,10 +2,17 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Transactor runs fn in a database transaction. Repository calls and published
+// events made with the context passed to fn join the transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // DepartmentRepository defines the interface for department data access
 type DepartmentRepository interface {
 	Create(ctx context.Context, department *Department) error
@@ -28,6 +35,30 @@ type ProgramRepository interface {
 	GetWithDepartment(ctx context.Context, id uuid.UUID) (*ProgramWithDepartment, error)
 }
 
+// GradingScaleRepository defines the interface for grading scale data access
+type GradingScaleRepository interface {
+	Create(ctx context.Context, scale *GradingScale) error
+	GetByID(ctx context.Context, id uuid.UUID) (*GradingScale, error)
+	// GetForStudent returns the grading scale of the student's program, or
+	// the default scale if the program has none
+	GetForStudent(ctx context.Context, studentID uuid.UUID) (*GradingScale, error)
+	Update(ctx context.Context, scale *GradingScale) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]*GradingScale, error)
+}
+
+// CurriculumRepository defines the interface for curriculum data access
+type CurriculumRepository interface {
+	Create(ctx context.Context, curriculum *Curriculum) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Curriculum, error)
+	// GetForBatch returns the latest curriculum of a program that applies to
+	// the students admitted in batchYear
+	GetForBatch(ctx context.Context, programID uuid.UUID, batchYear int) (*Curriculum, error)
+	Update(ctx context.Context, curriculum *Curriculum) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByProgram(ctx context.Context, programID uuid.UUID) ([]*Curriculum, error)
+}
+
 // SubjectRepository defines the interface for subject data access
 type SubjectRepository interface {
 	Create(ctx context.Context, subject *Subject) error
@@ -37,12 +68,14 @@ type SubjectRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filter SubjectFilter, limit, offset int) ([]*Subject, int64, error)
 	GetWithDetails(ctx context.Context, id uuid.UUID) (*SubjectWithDetails, error)
-	AddPrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID, isMandatory bool) error
+	AddPrerequisite(ctx context.Context, prerequisite *SubjectPrerequisite) error
 	RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error
 	GetPrerequisites(ctx context.Context, subjectID uuid.UUID) ([]SubjectPrerequisite, error)
 	AddCorequisite(ctx context.Context, subjectID, corequisiteID uuid.UUID) error
 	RemoveCorequisite(ctx context.Context, subjectID, corequisiteID uuid.UUID) error
 	GetCorequisites(ctx context.Context, subjectID uuid.UUID) ([]SubjectBasic, error)
+	GetPrerequisiteChain(ctx context.Context, subjectID uuid.UUID, downstream bool) (*PrerequisiteGraph, error)
+	GetPrerequisiteGraph(ctx context.Context, filter PrerequisiteGraphFilter) (*PrerequisiteGraph, error)
 }
 
 // SemesterRepository defines the interface for semester data access
@@ -57,6 +90,34 @@ type SemesterRepository interface {
 	SetCurrent(ctx context.Context, id uuid.UUID) error
 }
 
+// SemesterRolloverRepository defines the interface for semester rollover
+// data access
+type SemesterRolloverRepository interface {
+	Create(ctx context.Context, rollover *SemesterRollover) error
+	// GetLatest returns the last rollover started for a semester, with the
+	// students it flagged
+	GetLatest(ctx context.Context, semesterID uuid.UUID) (*SemesterRollover, error)
+	// Claim marks the oldest pending rollover, or a running one whose lock has
+	// expired, as running and locks it for lease. ErrRolloverNotFound is
+	// returned if there is none.
+	Claim(ctx context.Context, lease time.Duration) (*SemesterRollover, error)
+	Update(ctx context.Context, rollover *SemesterRollover) error
+	ListUngradedCourses(ctx context.Context, semesterID uuid.UUID) ([]UngradedCourse, error)
+	// CountClosable returns the active courses of a semester and their
+	// waitlisted enrollments
+	CountClosable(ctx context.Context, semesterID uuid.UUID) (courses, waitlisted int, err error)
+	// CloseCourses completes the active courses of a semester and drops their
+	// waitlisted enrollments
+	CloseCourses(ctx context.Context, semesterID uuid.UUID) (courses, dropped int, err error)
+	// ListCandidates returns the students with graded enrollments in a
+	// semester that no rollover of it has promoted or flagged yet
+	ListCandidates(ctx context.Context, semesterID uuid.UUID) ([]*RolloverCandidate, error)
+	// RecordStudent adds the outcome for a student to the ledger of a
+	// rollover. It returns false if the student already has an outcome for
+	// the semester.
+	RecordStudent(ctx context.Context, rollover *SemesterRollover, student *RolloverStudent) (bool, error)
+}
+
 // CourseRepository defines the interface for course data access
 type CourseRepository interface {
 	Create(ctx context.Context, course *Course) error
@@ -67,8 +128,13 @@ type CourseRepository interface {
 	List(ctx context.Context, filter CourseFilter, limit, offset int) ([]*CourseWithDetails, int64, error)
 	GetWithDetails(ctx context.Context, id uuid.UUID) (*CourseWithDetails, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
-	IncrementEnrollment(ctx context.Context, id uuid.UUID) error
-	DecrementEnrollment(ctx context.Context, id uuid.UUID) error
+	// GetByIDForUpdate gets a course and locks it until the transaction of ctx
+	// ends. Enrollment changes of a course are serialized on this lock.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Course, error)
+	// ReconcileEnrollmentCounts resets current_enrollment to the number of
+	// enrolled students wherever they differ, and returns the number of courses
+	// that were corrected
+	ReconcileEnrollmentCounts(ctx context.Context) (int64, error)
 }
 
 // FacultyRepository defines the interface for faculty data access
@@ -81,19 +147,25 @@ type FacultyRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filter FacultyFilter, limit, offset int) ([]*FacultyWithDetails, int64, error)
 	GetWithDetails(ctx context.Context, id uuid.UUID) (*FacultyWithDetails, error)
+	DeactivateByUserID(ctx context.Context, userID uuid.UUID) error
 }
 
 // StudentRepository defines the interface for student data access
 type StudentRepository interface {
 	Create(ctx context.Context, student *Student) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Student, error)
+	// ListExistingIDs returns which of the given students exist
+	ListExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*Student, error)
 	GetByRegistrationNumber(ctx context.Context, regNo string) (*Student, error)
 	Update(ctx context.Context, student *Student) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filter StudentFilter, limit, offset int) ([]*StudentWithDetails, int64, error)
 	GetWithDetails(ctx context.Context, id uuid.UUID) (*StudentWithDetails, error)
-	UpdateSemester(ctx context.Context, id uuid.UUID, semester int, cgpa *float64, credits int) error
+	UpdateSemester(ctx context.Context, id uuid.UUID, semester int) error
+	UpdateAcademicRecord(ctx context.Context, record *AcademicRecord) error
+	ListSemesterGPAs(ctx context.Context, studentID uuid.UUID) ([]SemesterGPA, error)
+	DeactivateByUserID(ctx context.Context, userID uuid.UUID) error
 }
 
 // FacultyCourseRepository defines the interface for faculty-course assignments
@@ -111,8 +183,13 @@ type FacultyCourseRepository interface {
 // EnrollmentRepository defines the interface for enrollment data access
 type EnrollmentRepository interface {
 	Create(ctx context.Context, enrollment *CourseEnrollment) error
+	// CreateBatch inserts the enrollments with a single statement
+	CreateBatch(ctx context.Context, enrollments []*CourseEnrollment) error
 	GetByID(ctx context.Context, id uuid.UUID) (*CourseEnrollment, error)
 	GetByStudentAndCourse(ctx context.Context, studentID, courseID uuid.UUID) (*CourseEnrollment, error)
+	// ListEnrolledStudentIDs returns which of the given students have an
+	// enrollment in the course, in any status
+	ListEnrolledStudentIDs(ctx context.Context, courseID uuid.UUID, studentIDs []uuid.UUID) (map[uuid.UUID]bool, error)
 	Update(ctx context.Context, enrollment *CourseEnrollment) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	ListByStudent(ctx context.Context, filter EnrollmentFilter, limit, offset int) ([]*EnrollmentWithDetails, int64, error)
@@ -120,6 +197,36 @@ type EnrollmentRepository interface {
 	GetEnrollmentSummary(ctx context.Context, courseID uuid.UUID) (enrolled, waitlisted, dropped, completed int, err error)
 	GetNextWaitlistPosition(ctx context.Context, courseID uuid.UUID) (int, error)
 	PromoteFromWaitlist(ctx context.Context, courseID uuid.UUID) (*CourseEnrollment, error)
+	RecordOverride(ctx context.Context, override *EnrollmentOverride) error
+	CreateWaiver(ctx context.Context, waiver *PrerequisiteWaiver) error
+	ListWaivers(ctx context.Context, studentID, courseID uuid.UUID) ([]*PrerequisiteWaiver, error)
+	GetUnmetRequirements(ctx context.Context, studentID, courseID uuid.UUID) ([]*UnmetRequirement, error)
+	GetUnmetRequirementsForStudents(ctx context.Context, studentIDs []uuid.UUID, courseID uuid.UUID) ([]*UnmetRequirement, error)
+	ListGradedAttempts(ctx context.Context, studentID uuid.UUID) ([]*GradedAttempt, error)
+	ListRoster(ctx context.Context, courseID uuid.UUID) ([]*CourseEnrollment, error)
+	ListGradedCourses(ctx context.Context, studentID uuid.UUID) ([]*GradedCourse, error)
+	// ListEnrolledSubjects returns the subjects a student is currently enrolled in
+	ListEnrolledSubjects(ctx context.Context, studentID uuid.UUID) ([]*SubjectBasic, error)
+}
+
+// GradebookRepository defines the interface for gradebook and grade amendment data access
+type GradebookRepository interface {
+	// Save creates or replaces the gradebook of a course together with its entries
+	Save(ctx context.Context, gradebook *Gradebook) error
+	GetByCourse(ctx context.Context, courseID uuid.UUID) (*Gradebook, error)
+	UpdateStatus(ctx context.Context, gradebook *Gradebook) error
+	CreateAmendment(ctx context.Context, amendment *GradeAmendment) error
+	GetAmendment(ctx context.Context, id uuid.UUID) (*GradeAmendment, error)
+	UpdateAmendment(ctx context.Context, amendment *GradeAmendment) error
+	ListAmendments(ctx context.Context, courseID uuid.UUID) ([]*GradeAmendment, error)
+}
+
+// TranscriptRepository defines the interface for issued transcript data access
+type TranscriptRepository interface {
+	Create(ctx context.Context, transcript *IssuedTranscript) error
+	GetByCode(ctx context.Context, code string) (*IssuedTranscript, error)
+	// GetByContentHash finds a transcript of a student issued with the same contents
+	GetByContentHash(ctx context.Context, studentID uuid.UUID, contentHash string) (*IssuedTranscript, error)
 }
 
 // CalendarRepository defines the interface for academic calendar data access
@@ -130,3 +237,12 @@ type CalendarRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filter CalendarFilter, limit, offset int) ([]*AcademicCalendarEventWithDetails, int64, error)
 }
+
+// UserDirectoryRepository defines the interface for the local user directory.
+// Changes carry the timestamp of the event they come from, and are ignored if
+// the entry already reflects a later event.
+type UserDirectoryRepository interface {
+	Upsert(ctx context.Context, entry *UserDirectoryEntry) error
+	UpdateStatus(ctx context.Context, userID uuid.UUID, status string, eventTimestamp time.Time) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*UserDirectoryEntry, error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
@@ -20,6 +21,16 @@ var (
 	ErrEnrollmentNotFound    = errors.New("enrollment not found")
 	ErrCalendarEventNotFound = errors.New("calendar event not found")
 	ErrAssignmentNotFound    = errors.New("faculty assignment not found")
+	ErrPrerequisiteNotFound  = errors.New("prerequisite not found")
+	ErrCorequisiteNotFound   = errors.New("corequisite not found")
+	ErrUserNotFound          = errors.New("user not found in directory")
+	ErrRequirementNotFound   = errors.New("subject is not a prerequisite or corequisite of the course")
+	ErrGradingScaleNotFound  = errors.New("grading scale not found")
+	ErrGradebookNotFound     = errors.New("no grades have been submitted for this course")
+	ErrAmendmentNotFound     = errors.New("grade amendment not found")
+	ErrTranscriptNotFound    = errors.New("transcript not found")
+	ErrCurriculumNotFound    = errors.New("curriculum not found")
+	ErrRolloverNotFound      = errors.New("semester has not been rolled over")
 
 	// Duplicate errors
 	ErrDepartmentCodeExists     = errors.New("department code already exists")
@@ -31,10 +42,18 @@ var (
 	ErrRegistrationNumberExists = errors.New("registration number already exists")
 	ErrAlreadyEnrolled          = errors.New("student already enrolled in this course")
 	ErrFacultyAlreadyAssigned   = errors.New("faculty already assigned to this course")
+	ErrPrerequisiteExists       = errors.New("prerequisite already exists")
+	ErrCorequisiteExists        = errors.New("corequisite already exists")
+	ErrWaiverExists             = errors.New("requirement already waived for this student")
+	ErrGradingScaleNameExists   = errors.New("grading scale name already exists")
+	ErrAmendmentPending         = errors.New("a grade amendment is already pending for this enrollment")
+	ErrCurriculumExists         = errors.New("program already has a curriculum for this batch year")
+	ErrRolloverInProgress       = errors.New("a rollover of this semester is already in progress")
 
 	// Business logic errors
 	ErrCourseFull                  = errors.New("course has reached maximum enrollment")
 	ErrRegistrationClosed          = errors.New("course registration is closed")
+	ErrWithdrawalDeadlinePassed    = errors.New("course withdrawal deadline has passed")
 	ErrPrerequisitesNotMet         = errors.New("course prerequisites not met")
 	ErrCannotDropCompletedCourse   = errors.New("cannot drop a completed course")
 	ErrCannotModifyCompletedCourse = errors.New("cannot modify a completed course")
@@ -42,6 +61,21 @@ var (
 	ErrInvalidCourseStatus         = errors.New("invalid course status transition")
 	ErrNoCurrentSemester           = errors.New("no current semester is set")
 	ErrSelfPrerequisite            = errors.New("subject cannot be its own prerequisite")
+	ErrSelfCorequisite             = errors.New("subject cannot be its own corequisite")
+	ErrInvalidMinimumGrade         = errors.New("invalid minimum grade")
+	ErrPrerequisiteCycle           = errors.New("prerequisite would create a cycle")
+	ErrInvalidGradingScale         = errors.New("invalid grading scale")
+	ErrGradingScaleInUse           = errors.New("grading scale is in use")
+	ErrInvalidGrade                = errors.New("grade is not on the grading scale of the student's program")
+	ErrIncompleteGradebook         = errors.New("grades must be submitted for every student of the roster")
+	ErrGradesLocked                = errors.New("grades are approved and locked, request an amendment instead")
+	ErrGradesNotSubmitted          = errors.New("grades are not awaiting approval")
+	ErrGradesNotApproved           = errors.New("grades of the course are not approved yet")
+	ErrAmendmentReviewed           = errors.New("grade amendment has already been reviewed")
+	ErrInvalidCurriculum           = errors.New("invalid curriculum")
+	ErrInvalidRollover             = errors.New("invalid semester rollover")
+	ErrUngradedEnrollments         = errors.New("students are still enrolled in courses of the semester without a grade")
+	ErrSemesterRolledOver          = errors.New("semester has already been rolled over")
 
 	// Permission errors
 	ErrUnauthorized = errors.New("unauthorized access")
@@ -73,10 +107,12 @@ type SubjectService interface {
 	UpdateSubject(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	DeleteSubject(ctx context.Context, id uuid.UUID) error
 	ListSubjects(ctx context.Context, filter SubjectFilter, page, limit int) ([]*Subject, int64, error)
-	AddPrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID, isMandatory bool) error
+	AddPrerequisite(ctx context.Context, prerequisite *SubjectPrerequisite) error
 	RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error
 	AddCorequisite(ctx context.Context, subjectID, corequisiteID uuid.UUID) error
 	RemoveCorequisite(ctx context.Context, subjectID, corequisiteID uuid.UUID) error
+	GetPrerequisiteTree(ctx context.Context, subjectID uuid.UUID) (*PrerequisiteTree, error)
+	GetPrerequisiteGraph(ctx context.Context, filter PrerequisiteGraphFilter) (*PrerequisiteGraph, error)
 }
 
 // SemesterService defines the interface for semester business logic
@@ -121,7 +157,35 @@ type StudentService interface {
 	UpdateStudent(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	DeleteStudent(ctx context.Context, id uuid.UUID) error
 	ListStudents(ctx context.Context, filter StudentFilter, page, limit int) ([]*StudentWithDetails, int64, error)
-	PromoteStudent(ctx context.Context, id uuid.UUID, newSemester int, cgpa *float64, creditsEarned int) error
+	PromoteStudent(ctx context.Context, id uuid.UUID, newSemester int) error
+	GetAcademicRecord(ctx context.Context, id uuid.UUID) (*AcademicRecord, error)
+}
+
+// GradingScaleService defines the interface for grading scale business logic
+type GradingScaleService interface {
+	CreateGradingScale(ctx context.Context, scale *GradingScale) error
+	GetGradingScale(ctx context.Context, id uuid.UUID) (*GradingScale, error)
+	UpdateGradingScale(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteGradingScale(ctx context.Context, id uuid.UUID) error
+	ListGradingScales(ctx context.Context) ([]*GradingScale, error)
+}
+
+// CurriculumService defines the interface for curriculum and degree audit business logic
+type CurriculumService interface {
+	CreateCurriculum(ctx context.Context, curriculum *Curriculum) error
+	GetCurriculum(ctx context.Context, id uuid.UUID) (*Curriculum, error)
+	UpdateCurriculum(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteCurriculum(ctx context.Context, id uuid.UUID) error
+	ListCurricula(ctx context.Context, programID uuid.UUID) ([]*Curriculum, error)
+	AuditDegree(ctx context.Context, studentID uuid.UUID) (*DegreeAudit, error)
+}
+
+// SemesterRolloverService defines the interface for ending a semester. A
+// started rollover is run in the background by the rollover runner.
+type SemesterRolloverService interface {
+	PreviewRollover(ctx context.Context, semesterID, nextSemesterID uuid.UUID) (*RolloverPreview, error)
+	StartRollover(ctx context.Context, semesterID, nextSemesterID, requestedBy uuid.UUID) (*SemesterRollover, error)
+	GetRollover(ctx context.Context, semesterID uuid.UUID) (*SemesterRollover, error)
 }
 
 // FacultyAssignmentService defines the interface for faculty-course assignment business logic
@@ -134,14 +198,48 @@ type FacultyAssignmentService interface {
 
 // EnrollmentService defines the interface for enrollment business logic
 type EnrollmentService interface {
-	EnrollStudent(ctx context.Context, courseID, studentID uuid.UUID, enrolledBy string) (*CourseEnrollment, error)
-	DropCourse(ctx context.Context, courseID, studentID uuid.UUID, reason string) error
-	UpdateEnrollment(ctx context.Context, enrollmentID uuid.UUID, status string, grade *string, gradePoints *float64) error
+	EnrollStudent(ctx context.Context, courseID, studentID uuid.UUID, enrolledBy string, override *DeadlineOverride) (*CourseEnrollment, error)
+	DropCourse(ctx context.Context, courseID, studentID uuid.UUID, reason string, override *DeadlineOverride) error
+	GetEnrollment(ctx context.Context, enrollmentID uuid.UUID) (*CourseEnrollment, error)
+	UpdateEnrollment(ctx context.Context, enrollmentID uuid.UUID, status string) error
 	GetStudentEnrollments(ctx context.Context, studentID uuid.UUID, filter EnrollmentFilter, page, limit int) ([]*EnrollmentWithDetails, int64, error)
 	BulkEnroll(ctx context.Context, courseID uuid.UUID, studentIDs []uuid.UUID, skipPrerequisites bool) ([]BulkEnrollResult, error)
-	CheckPrerequisites(ctx context.Context, studentID, courseID uuid.UUID) (bool, []SubjectBasic, error)
+	CheckPrerequisites(ctx context.Context, studentID, courseID uuid.UUID) (*RequirementCheck, error)
+	GrantWaiver(ctx context.Context, waiver *PrerequisiteWaiver) error
+	ListWaivers(ctx context.Context, studentID, courseID uuid.UUID) ([]*PrerequisiteWaiver, error)
 }
 
+// DeadlineOverride lets an admin enroll or drop a student outside the
+// registration windows of the semester. The override is recorded with its
+// reason when a window is actually closed.
+type DeadlineOverride struct {
+	Reason       string
+	OverriddenBy uuid.UUID
+}
+
+// RequirementCheck explains whether a student meets the prerequisites and
+// corequisites of a course. Only Unmet requirements block enrollment.
+type RequirementCheck struct {
+	Met      bool                `json:"met"`
+	Unmet    []RequirementResult `json:"unmet"`
+	Warnings []RequirementResult `json:"warnings"`
+	Waived   []RequirementResult `json:"waived"`
+}
+
+// RequirementResult explains a single prerequisite or corequisite that the
+// student does not meet
+type RequirementResult struct {
+	Type    string       `json:"type"`
+	Subject SubjectBasic `json:"subject"`
+	Reason  string       `json:"reason"`
+}
+
+// Requirement types
+const (
+	RequirementPrerequisite = "prerequisite"
+	RequirementCorequisite  = "corequisite"
+)
+
 // BulkEnrollResult represents the result of a bulk enrollment operation
 type BulkEnrollResult struct {
 	StudentID    uuid.UUID  `json:"student_id"`
@@ -159,8 +257,59 @@ type CalendarService interface {
 	ListEvents(ctx context.Context, filter CalendarFilter, page, limit int) ([]*AcademicCalendarEventWithDetails, int64, error)
 }
 
-// EventProducer defines the interface for publishing events to Kafka
+// GradebookService defines the interface for submitting, approving and
+// amending the grades of a course roster
+type GradebookService interface {
+	SubmitGrades(ctx context.Context, courseID uuid.UUID, grades []GradeSubmission, actor GradeActor) (*Gradebook, error)
+	GetGradebook(ctx context.Context, courseID uuid.UUID, actor GradeActor) (*Gradebook, error)
+	ApproveGrades(ctx context.Context, courseID uuid.UUID, comment *string, actor GradeActor) error
+	ReturnGrades(ctx context.Context, courseID uuid.UUID, comment string, actor GradeActor) error
+	RequestAmendment(ctx context.Context, enrollmentID uuid.UUID, grade, reason string, actor GradeActor) (*GradeAmendment, error)
+	ApproveAmendment(ctx context.Context, amendmentID uuid.UUID, comment *string, actor GradeActor) error
+	RejectAmendment(ctx context.Context, amendmentID uuid.UUID, comment string, actor GradeActor) error
+	ListAmendments(ctx context.Context, courseID uuid.UUID, actor GradeActor) ([]*GradeAmendment, error)
+}
+
+// GradeSubmission is the grade of one student of a course roster
+type GradeSubmission struct {
+	StudentID uuid.UUID
+	Grade     string
+}
+
+// GradeActor is the user acting on a gradebook or grade amendment. Faculty
+// may only act in their role on the course: the primary faculty submits
+// grades and requests amendments, and the head of the course's department
+// reviews them. Faculty assigned to the course and the head of department may
+// view its grades. Admins may act on any course.
+type GradeActor struct {
+	UserID    uuid.UUID
+	FacultyID *uuid.UUID
+	IsAdmin   bool
+}
+
+// TranscriptService defines the interface for issuing and verifying official
+// transcripts
+type TranscriptService interface {
+	IssueTranscript(ctx context.Context, studentID, issuedBy uuid.UUID) (*Transcript, error)
+	VerifyTranscript(ctx context.Context, code string) (*TranscriptVerification, error)
+}
+
+// CourseEventsTopic is the topic all course service events are published to.
+// Events are keyed by the entity they describe, and events about enrollments
+// and faculty assignments by their course, so a consumer sees the changes of
+// one entity or course roster in order.
+const CourseEventsTopic = "course.events"
+
+// EventProducer defines the interface for publishing events to Kafka. Events are written to
+// the outbox, so an event published within Transactor.WithinTransaction is only
+// delivered if the transaction commits.
 type EventProducer interface {
-	PublishEvent(topic string, key string, event interface{}) error
-	Close() error
+	PublishEvent(ctx context.Context, topic string, key string, event interface{}) error
+}
+
+// UserDirectoryService keeps the local user directory in sync with the User
+// Service and deactivates the faculty and student records of users that are
+// suspended or deleted
+type UserDirectoryService interface {
+	HandleUserEvent(ctx context.Context, event *models.UserEvent) error
 }
@@ -26,22 +26,78 @@ type UpdateDepartmentRequest struct {
 // ==================== Program Requests ====================
 
 type CreateProgramRequest struct {
-	ProgramName   string    `json:"program_name" binding:"required,max=100"`
-	ProgramCode   string    `json:"program_code" binding:"required,max=20"`
-	DepartmentID  uuid.UUID `json:"department_id" binding:"required"`
-	DegreeType    *string   `json:"degree_type" binding:"omitempty,oneof=Bachelor Master PhD"`
-	DurationYears int       `json:"duration_years" binding:"required,min=1,max=10"`
-	TotalCredits  *int      `json:"total_credits" binding:"omitempty,min=1"`
-	Description   *string   `json:"description"`
+	ProgramName    string     `json:"program_name" binding:"required,max=100"`
+	ProgramCode    string     `json:"program_code" binding:"required,max=20"`
+	DepartmentID   uuid.UUID  `json:"department_id" binding:"required"`
+	DegreeType     *string    `json:"degree_type" binding:"omitempty,oneof=Bachelor Master PhD"`
+	DurationYears  int        `json:"duration_years" binding:"required,min=1,max=10"`
+	TotalCredits   *int       `json:"total_credits" binding:"omitempty,min=1"`
+	Description    *string    `json:"description"`
+	GradingScaleID *uuid.UUID `json:"grading_scale_id"`
 }
 
 type UpdateProgramRequest struct {
-	ProgramName   *string `json:"program_name" binding:"omitempty,max=100"`
-	DegreeType    *string `json:"degree_type" binding:"omitempty,oneof=Bachelor Master PhD"`
-	DurationYears *int    `json:"duration_years" binding:"omitempty,min=1,max=10"`
-	TotalCredits  *int    `json:"total_credits" binding:"omitempty,min=1"`
-	Description   *string `json:"description"`
-	IsActive      *bool   `json:"is_active"`
+	ProgramName    *string    `json:"program_name" binding:"omitempty,max=100"`
+	DegreeType     *string    `json:"degree_type" binding:"omitempty,oneof=Bachelor Master PhD"`
+	DurationYears  *int       `json:"duration_years" binding:"omitempty,min=1,max=10"`
+	TotalCredits   *int       `json:"total_credits" binding:"omitempty,min=1"`
+	Description    *string    `json:"description"`
+	IsActive       *bool      `json:"is_active"`
+	GradingScaleID *uuid.UUID `json:"grading_scale_id"`
+}
+
+// ==================== Grading Scale Requests ====================
+
+type GradeMappingRequest struct {
+	Grade       string  `json:"grade" binding:"required,max=5"`
+	GradePoints float64 `json:"grade_points" binding:"min=0,max=10"`
+}
+
+type CreateGradingScaleRequest struct {
+	ScaleName       string                `json:"scale_name" binding:"required,max=100"`
+	MaxGradePoints  float64               `json:"max_grade_points" binding:"required,gt=0,max=10"`
+	PassGradePoints float64               `json:"pass_grade_points" binding:"min=0,max=10"`
+	RepeatPolicy    string                `json:"repeat_policy" binding:"omitempty,oneof=best latest"`
+	IsDefault       bool                  `json:"is_default"`
+	Grades          []GradeMappingRequest `json:"grades" binding:"required,min=1,dive"`
+}
+
+type UpdateGradingScaleRequest struct {
+	ScaleName       *string               `json:"scale_name" binding:"omitempty,max=100"`
+	MaxGradePoints  *float64              `json:"max_grade_points" binding:"omitempty,gt=0,max=10"`
+	PassGradePoints *float64              `json:"pass_grade_points" binding:"omitempty,min=0,max=10"`
+	RepeatPolicy    *string               `json:"repeat_policy" binding:"omitempty,oneof=best latest"`
+	IsDefault       *bool                 `json:"is_default"`
+	Grades          []GradeMappingRequest `json:"grades" binding:"omitempty,min=1,dive"`
+}
+
+// ==================== Curriculum Requests ====================
+
+type CoreSubjectRequest struct {
+	SubjectID      uuid.UUID `json:"subject_id" binding:"required"`
+	SemesterNumber int       `json:"semester_number" binding:"required,min=1"`
+}
+
+type ElectiveBucketRequest struct {
+	BucketName string      `json:"bucket_name" binding:"required,max=100"`
+	MinCredits int         `json:"min_credits" binding:"required,min=1"`
+	SubjectIDs []uuid.UUID `json:"subject_ids" binding:"required,min=1,dive,required"`
+}
+
+type CreateCurriculumRequest struct {
+	BatchYear       int                     `json:"batch_year" binding:"required,min=2000,max=2100"`
+	VersionName     string                  `json:"version_name" binding:"required,max=100"`
+	TotalCredits    int                     `json:"total_credits" binding:"omitempty,min=1"`
+	CoreSubjects    []CoreSubjectRequest    `json:"core_subjects" binding:"dive"`
+	ElectiveBuckets []ElectiveBucketRequest `json:"elective_buckets" binding:"dive"`
+}
+
+type UpdateCurriculumRequest struct {
+	BatchYear       *int                    `json:"batch_year" binding:"omitempty,min=2000,max=2100"`
+	VersionName     *string                 `json:"version_name" binding:"omitempty,max=100"`
+	TotalCredits    *int                    `json:"total_credits" binding:"omitempty,min=1"`
+	CoreSubjects    []CoreSubjectRequest    `json:"core_subjects" binding:"omitempty,dive"`
+	ElectiveBuckets []ElectiveBucketRequest `json:"elective_buckets" binding:"omitempty,dive"`
 }
 
 // ==================== Subject Requests ====================
@@ -59,8 +115,19 @@ type CreateSubjectRequest struct {
 }
 
 type PrerequisiteRequest struct {
-	SubjectID   uuid.UUID `json:"subject_id" binding:"required"`
-	IsMandatory bool      `json:"is_mandatory"`
+	SubjectID      uuid.UUID `json:"subject_id" binding:"required"`
+	IsMandatory    bool      `json:"is_mandatory"`
+	MinGrade       *string   `json:"min_grade" binding:"omitempty,max=5"`
+	MinGradePoints *float64  `json:"min_grade_points" binding:"omitempty,min=0,max=10"`
+}
+
+type CorequisiteRequest struct {
+	SubjectID uuid.UUID `json:"subject_id" binding:"required"`
+}
+
+type GrantWaiverRequest struct {
+	SubjectID uuid.UUID `json:"subject_id" binding:"required"`
+	Reason    string    `json:"reason" binding:"required"`
 }
 
 type UpdateSubjectRequest struct {
@@ -75,21 +142,31 @@ type UpdateSubjectRequest struct {
 // ==================== Semester Requests ====================
 
 type CreateSemesterRequest struct {
-	SemesterName      string     `json:"semester_name" binding:"required,max=50"`
-	SemesterCode      string     `json:"semester_code" binding:"required,max=20"`
-	AcademicYear      int        `json:"academic_year" binding:"required,min=2000,max=2100"`
-	StartDate         time.Time  `json:"start_date" binding:"required"`
-	EndDate           time.Time  `json:"end_date" binding:"required,gtfield=StartDate"`
-	RegistrationStart *time.Time `json:"registration_start"`
-	RegistrationEnd   *time.Time `json:"registration_end"`
+	SemesterName       string     `json:"semester_name" binding:"required,max=50"`
+	SemesterCode       string     `json:"semester_code" binding:"required,max=20"`
+	AcademicYear       int        `json:"academic_year" binding:"required,min=2000,max=2100"`
+	StartDate          time.Time  `json:"start_date" binding:"required"`
+	EndDate            time.Time  `json:"end_date" binding:"required,gtfield=StartDate"`
+	RegistrationStart  *time.Time `json:"registration_start"`
+	RegistrationEnd    *time.Time `json:"registration_end"`
+	AddDropEnd         *time.Time `json:"add_drop_end"`
+	WithdrawalDeadline *time.Time `json:"withdrawal_deadline"`
 }
 
 type UpdateSemesterRequest struct {
-	SemesterName      *string    `json:"semester_name" binding:"omitempty,max=50"`
-	StartDate         *time.Time `json:"start_date"`
-	EndDate           *time.Time `json:"end_date"`
-	RegistrationStart *time.Time `json:"registration_start"`
-	RegistrationEnd   *time.Time `json:"registration_end"`
+	SemesterName       *string    `json:"semester_name" binding:"omitempty,max=50"`
+	StartDate          *time.Time `json:"start_date"`
+	EndDate            *time.Time `json:"end_date"`
+	RegistrationStart  *time.Time `json:"registration_start"`
+	RegistrationEnd    *time.Time `json:"registration_end"`
+	AddDropEnd         *time.Time `json:"add_drop_end"`
+	WithdrawalDeadline *time.Time `json:"withdrawal_deadline"`
+}
+
+// RolloverSemesterRequest starts or previews the rollover of a semester into
+// the semester that becomes current after it
+type RolloverSemesterRequest struct {
+	NextSemesterID uuid.UUID `json:"next_semester_id" binding:"required"`
 }
 
 // ==================== Course Requests ====================
@@ -134,14 +211,15 @@ type EnrollRequest struct {
 }
 
 type DropCourseRequest struct {
-	StudentID *uuid.UUID `json:"student_id"`
-	Reason    string     `json:"reason"`
+	StudentID      *uuid.UUID `json:"student_id"`
+	Reason         string     `json:"reason"`
+	OverrideReason string     `json:"override_reason"`
 }
 
+// UpdateEnrollmentRequest changes the status of an enrollment. Grades are
+// submitted through the gradebook of the course.
 type UpdateEnrollmentRequest struct {
-	EnrollmentStatus string   `json:"enrollment_status" binding:"required,oneof=enrolled waitlisted dropped completed failed"`
-	Grade            *string  `json:"grade" binding:"omitempty,max=5"`
-	GradePoints      *float64 `json:"grade_points" binding:"omitempty,min=0,max=10"`
+	EnrollmentStatus string `json:"enrollment_status" binding:"required,oneof=enrolled waitlisted"`
 }
 
 type BulkEnrollRequest struct {
@@ -149,6 +227,30 @@ type BulkEnrollRequest struct {
 	SkipPrerequisites bool        `json:"skip_prerequisites"`
 }
 
+// ==================== Gradebook Requests ====================
+
+type StudentGradeRequest struct {
+	StudentID uuid.UUID `json:"student_id" binding:"required"`
+	Grade     string    `json:"grade" binding:"required,max=5"`
+}
+
+type SubmitGradesRequest struct {
+	Grades []StudentGradeRequest `json:"grades" binding:"required,min=1,dive"`
+}
+
+type ApproveRequest struct {
+	Comment *string `json:"comment"`
+}
+
+type ReturnRequest struct {
+	Comment string `json:"comment" binding:"required"`
+}
+
+type RequestAmendmentRequest struct {
+	Grade  string `json:"grade" binding:"required,max=5"`
+	Reason string `json:"reason" binding:"required"`
+}
+
 // ==================== Faculty Requests ====================
 
 type CreateFacultyRequest struct {
@@ -191,9 +293,7 @@ type UpdateStudentRequest struct {
 }
 
 type PromoteStudentRequest struct {
-	NewSemester   int      `json:"new_semester" binding:"required,min=1,max=8"`
-	UpdateCGPA    *float64 `json:"update_cgpa" binding:"omitempty,min=0,max=10"`
-	CreditsEarned int      `json:"credits_earned" binding:"min=0"`
+	NewSemester int `json:"new_semester" binding:"required,min=1,max=8"`
 }
 
 // ==================== Calendar Requests ====================
@@ -301,7 +401,8 @@ type CalendarQueryParams struct {
 // ==================== Enroll Student Request ====================
 
 type EnrollStudentRequest struct {
-	StudentID uuid.UUID `json:"student_id" validate:"required"`
+	StudentID      uuid.UUID `json:"student_id" validate:"required"`
+	OverrideReason string    `json:"override_reason"`
 }
 
 // ==================== ToDomain Methods ====================
@@ -335,14 +436,15 @@ func (r *UpdateDepartmentRequest) ToUpdates() map[string]interface{} {
 
 func (r *CreateProgramRequest) ToDomain() *domain.Program {
 	return &domain.Program{
-		ProgramName:   r.ProgramName,
-		ProgramCode:   r.ProgramCode,
-		DepartmentID:  r.DepartmentID,
-		DegreeType:    r.DegreeType,
-		DurationYears: r.DurationYears,
-		TotalCredits:  r.TotalCredits,
-		Description:   r.Description,
-		IsActive:      true,
+		ProgramName:    r.ProgramName,
+		ProgramCode:    r.ProgramCode,
+		DepartmentID:   r.DepartmentID,
+		DegreeType:     r.DegreeType,
+		DurationYears:  r.DurationYears,
+		TotalCredits:   r.TotalCredits,
+		Description:    r.Description,
+		IsActive:       true,
+		GradingScaleID: r.GradingScaleID,
 	}
 }
 
@@ -366,9 +468,113 @@ func (r *UpdateProgramRequest) ToUpdates() map[string]interface{} {
 	if r.IsActive != nil {
 		updates["is_active"] = *r.IsActive
 	}
+	if r.GradingScaleID != nil {
+		updates["grading_scale_id"] = *r.GradingScaleID
+	}
+	return updates
+}
+
+func (r *CreateGradingScaleRequest) ToDomain() *domain.GradingScale {
+	return &domain.GradingScale{
+		ScaleName:       r.ScaleName,
+		MaxGradePoints:  r.MaxGradePoints,
+		PassGradePoints: r.PassGradePoints,
+		RepeatPolicy:    r.RepeatPolicy,
+		IsDefault:       r.IsDefault,
+		Grades:          gradeMappings(r.Grades),
+	}
+}
+
+func (r *UpdateGradingScaleRequest) ToUpdates() map[string]interface{} {
+	updates := make(map[string]interface{})
+	if r.ScaleName != nil {
+		updates["scale_name"] = *r.ScaleName
+	}
+	if r.MaxGradePoints != nil {
+		updates["max_grade_points"] = *r.MaxGradePoints
+	}
+	if r.PassGradePoints != nil {
+		updates["pass_grade_points"] = *r.PassGradePoints
+	}
+	if r.RepeatPolicy != nil {
+		updates["repeat_policy"] = *r.RepeatPolicy
+	}
+	if r.IsDefault != nil {
+		updates["is_default"] = *r.IsDefault
+	}
+	if r.Grades != nil {
+		updates["grades"] = gradeMappings(r.Grades)
+	}
+	return updates
+}
+
+func gradeMappings(grades []GradeMappingRequest) []domain.GradeMapping {
+	mappings := make([]domain.GradeMapping, len(grades))
+	for i, g := range grades {
+		mappings[i] = domain.GradeMapping{Grade: g.Grade, GradePoints: g.GradePoints}
+	}
+	return mappings
+}
+
+func (r *CreateCurriculumRequest) ToDomain(programID uuid.UUID) *domain.Curriculum {
+	return &domain.Curriculum{
+		ProgramID:       programID,
+		BatchYear:       r.BatchYear,
+		VersionName:     r.VersionName,
+		TotalCredits:    r.TotalCredits,
+		CoreSubjects:    coreSubjects(r.CoreSubjects),
+		ElectiveBuckets: electiveBuckets(r.ElectiveBuckets),
+	}
+}
+
+func (r *UpdateCurriculumRequest) ToUpdates() map[string]interface{} {
+	updates := make(map[string]interface{})
+	if r.BatchYear != nil {
+		updates["batch_year"] = *r.BatchYear
+	}
+	if r.VersionName != nil {
+		updates["version_name"] = *r.VersionName
+	}
+	if r.TotalCredits != nil {
+		updates["total_credits"] = *r.TotalCredits
+	}
+	if r.CoreSubjects != nil {
+		updates["core_subjects"] = coreSubjects(r.CoreSubjects)
+	}
+	if r.ElectiveBuckets != nil {
+		updates["elective_buckets"] = electiveBuckets(r.ElectiveBuckets)
+	}
 	return updates
 }
 
+func coreSubjects(subjects []CoreSubjectRequest) []domain.CoreSubject {
+	core := make([]domain.CoreSubject, len(subjects))
+	for i, s := range subjects {
+		core[i] = domain.CoreSubject{SubjectBasic: domain.SubjectBasic{SubjectID: s.SubjectID}, SemesterNumber: s.SemesterNumber}
+	}
+	return core
+}
+
+func electiveBuckets(buckets []ElectiveBucketRequest) []domain.ElectiveBucket {
+	electives := make([]domain.ElectiveBucket, len(buckets))
+	for i, b := range buckets {
+		subjects := make([]domain.SubjectBasic, len(b.SubjectIDs))
+		for j, id := range b.SubjectIDs {
+			subjects[j] = domain.SubjectBasic{SubjectID: id}
+		}
+		electives[i] = domain.ElectiveBucket{BucketName: b.BucketName, MinCredits: b.MinCredits, Subjects: subjects}
+	}
+	return electives
+}
+
+func (r *SubmitGradesRequest) ToDomain() []domain.GradeSubmission {
+	grades := make([]domain.GradeSubmission, len(r.Grades))
+	for i, g := range r.Grades {
+		grades[i] = domain.GradeSubmission{StudentID: g.StudentID, Grade: g.Grade}
+	}
+	return grades
+}
+
 func (r *CreateSubjectRequest) ToDomain() *domain.Subject {
 	return &domain.Subject{
 		SubjectName:  r.SubjectName,
@@ -382,6 +588,24 @@ func (r *CreateSubjectRequest) ToDomain() *domain.Subject {
 	}
 }
 
+func (r *CreateSubjectRequest) ToPrerequisites() []domain.SubjectPrerequisite {
+	prereqs := make([]domain.SubjectPrerequisite, 0, len(r.Prerequisites))
+	for _, p := range r.Prerequisites {
+		prereqs = append(prereqs, *p.ToDomain(uuid.Nil))
+	}
+	return prereqs
+}
+
+func (r *PrerequisiteRequest) ToDomain(subjectID uuid.UUID) *domain.SubjectPrerequisite {
+	return &domain.SubjectPrerequisite{
+		SubjectID:             subjectID,
+		PrerequisiteSubjectID: r.SubjectID,
+		IsMandatory:           r.IsMandatory,
+		MinGrade:              r.MinGrade,
+		MinGradePoints:        r.MinGradePoints,
+	}
+}
+
 func (r *UpdateSubjectRequest) ToUpdates() map[string]interface{} {
 	updates := make(map[string]interface{})
 	if r.SubjectName != nil {
@@ -407,14 +631,16 @@ func (r *UpdateSubjectRequest) ToUpdates() map[string]interface{} {
 
 func (r *CreateSemesterRequest) ToDomain() *domain.Semester {
 	return &domain.Semester{
-		SemesterName:      r.SemesterName,
-		SemesterCode:      r.SemesterCode,
-		AcademicYear:      r.AcademicYear,
-		StartDate:         r.StartDate,
-		EndDate:           r.EndDate,
-		RegistrationStart: r.RegistrationStart,
-		RegistrationEnd:   r.RegistrationEnd,
-		IsCurrent:         false,
+		SemesterName:       r.SemesterName,
+		SemesterCode:       r.SemesterCode,
+		AcademicYear:       r.AcademicYear,
+		StartDate:          r.StartDate,
+		EndDate:            r.EndDate,
+		RegistrationStart:  r.RegistrationStart,
+		RegistrationEnd:    r.RegistrationEnd,
+		AddDropEnd:         r.AddDropEnd,
+		WithdrawalDeadline: r.WithdrawalDeadline,
+		IsCurrent:          false,
 	}
 }
 
@@ -435,6 +661,12 @@ func (r *UpdateSemesterRequest) ToUpdates() map[string]interface{} {
 	if r.RegistrationEnd != nil {
 		updates["registration_end"] = *r.RegistrationEnd
 	}
+	if r.AddDropEnd != nil {
+		updates["add_drop_end"] = *r.AddDropEnd
+	}
+	if r.WithdrawalDeadline != nil {
+		updates["withdrawal_deadline"] = *r.WithdrawalDeadline
+	}
 	return updates
 }
 
@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
@@ -65,21 +68,24 @@ type ProgramResponse struct {
 	TotalCredits  *int                    `json:"total_credits,omitempty"`
 	Description   *string                 `json:"description,omitempty"`
 	IsActive      bool                    `json:"is_active"`
-	CreatedAt     time.Time               `json:"created_at"`
+	// GradingScaleID is omitted for programs graded on the default scale
+	GradingScaleID *uuid.UUID `json:"grading_scale_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
 }
 
 func ToProgramResponse(p *domain.ProgramWithDepartment) ProgramResponse {
 	return ProgramResponse{
-		ProgramID:     p.ProgramID,
-		ProgramName:   p.ProgramName,
-		ProgramCode:   p.ProgramCode,
-		Department:    ToDepartmentBasicResponse(&p.Department),
-		DegreeType:    p.DegreeType,
-		DurationYears: p.DurationYears,
-		TotalCredits:  p.TotalCredits,
-		Description:   p.Description,
-		IsActive:      p.IsActive,
-		CreatedAt:     p.CreatedAt,
+		ProgramID:      p.ProgramID,
+		ProgramName:    p.ProgramName,
+		ProgramCode:    p.ProgramCode,
+		Department:     ToDepartmentBasicResponse(&p.Department),
+		DegreeType:     p.DegreeType,
+		DurationYears:  p.DurationYears,
+		TotalCredits:   p.TotalCredits,
+		Description:    p.Description,
+		IsActive:       p.IsActive,
+		GradingScaleID: p.GradingScaleID,
+		CreatedAt:      p.CreatedAt,
 	}
 }
 
@@ -105,20 +111,24 @@ type SubjectDetailResponse struct {
 }
 
 type PrerequisiteResponse struct {
-	SubjectID   uuid.UUID `json:"subject_id"`
-	SubjectCode string    `json:"subject_code"`
-	SubjectName string    `json:"subject_name"`
-	IsMandatory bool      `json:"is_mandatory"`
+	SubjectID      uuid.UUID `json:"subject_id"`
+	SubjectCode    string    `json:"subject_code"`
+	SubjectName    string    `json:"subject_name"`
+	IsMandatory    bool      `json:"is_mandatory"`
+	MinGrade       *string   `json:"min_grade,omitempty"`
+	MinGradePoints *float64  `json:"min_grade_points,omitempty"`
 }
 
 func ToSubjectDetailResponse(s *domain.SubjectWithDetails) SubjectDetailResponse {
 	prereqs := make([]PrerequisiteResponse, len(s.Prerequisites))
 	for i, p := range s.Prerequisites {
 		prereqs[i] = PrerequisiteResponse{
-			SubjectID:   p.PrerequisiteSubjectID,
-			SubjectCode: p.SubjectCode,
-			SubjectName: p.SubjectName,
-			IsMandatory: p.IsMandatory,
+			SubjectID:      p.PrerequisiteSubjectID,
+			SubjectCode:    p.SubjectCode,
+			SubjectName:    p.SubjectName,
+			IsMandatory:    p.IsMandatory,
+			MinGrade:       p.MinGrade,
+			MinGradePoints: p.MinGradePoints,
 		}
 	}
 
@@ -145,18 +155,65 @@ func ToSubjectDetailResponse(s *domain.SubjectWithDetails) SubjectDetailResponse
 	}
 }
 
+// PrerequisiteGraphToDOT renders a prerequisite graph in the Graphviz DOT
+// language. Edges point from a prerequisite to the subject it unlocks, and
+// optional prerequisites are dashed.
+func PrerequisiteGraphToDOT(graph *domain.PrerequisiteGraph) string {
+	var b strings.Builder
+	b.WriteString("digraph prerequisites {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	for _, s := range graph.Subjects {
+		fmt.Fprintf(&b, "\t\"%s\" [label=\"%s\\n%s\"];\n", s.SubjectID, dotEscape(s.SubjectCode), dotEscape(s.SubjectName))
+	}
+
+	for _, e := range graph.Edges {
+		var attrs []string
+		if !e.IsMandatory {
+			attrs = append(attrs, "style=dashed")
+		}
+		var minimums []string
+		if e.MinGrade != nil {
+			minimums = append(minimums, *e.MinGrade)
+		}
+		if e.MinGradePoints != nil {
+			minimums = append(minimums, strconv.FormatFloat(*e.MinGradePoints, 'f', -1, 64))
+		}
+		if len(minimums) > 0 {
+			attrs = append(attrs, fmt.Sprintf("label=\"min %s\"", dotEscape(strings.Join(minimums, ", "))))
+		}
+
+		fmt.Fprintf(&b, "\t\"%s\" -> \"%s\"", e.PrerequisiteSubjectID, e.SubjectID)
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, " "))
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotEscape escapes s for use in a quoted DOT string
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
 // ==================== Semester Responses ====================
 
 type SemesterResponse struct {
-	SemesterID        uuid.UUID  `json:"semester_id"`
-	SemesterName      string     `json:"semester_name"`
-	SemesterCode      string     `json:"semester_code"`
-	AcademicYear      int        `json:"academic_year"`
-	StartDate         time.Time  `json:"start_date"`
-	EndDate           time.Time  `json:"end_date"`
-	RegistrationStart *time.Time `json:"registration_start,omitempty"`
-	RegistrationEnd   *time.Time `json:"registration_end,omitempty"`
-	IsCurrent         bool       `json:"is_current"`
+	SemesterID         uuid.UUID  `json:"semester_id"`
+	SemesterName       string     `json:"semester_name"`
+	SemesterCode       string     `json:"semester_code"`
+	AcademicYear       int        `json:"academic_year"`
+	StartDate          time.Time  `json:"start_date"`
+	EndDate            time.Time  `json:"end_date"`
+	RegistrationStart  *time.Time `json:"registration_start,omitempty"`
+	RegistrationEnd    *time.Time `json:"registration_end,omitempty"`
+	AddDropEnd         *time.Time `json:"add_drop_end,omitempty"`
+	WithdrawalDeadline *time.Time `json:"withdrawal_deadline,omitempty"`
+	IsCurrent          bool       `json:"is_current"`
 }
 
 type CurrentSemesterResponse struct {
@@ -167,15 +224,17 @@ type CurrentSemesterResponse struct {
 
 func ToSemesterResponse(s *domain.Semester) SemesterResponse {
 	return SemesterResponse{
-		SemesterID:        s.SemesterID,
-		SemesterName:      s.SemesterName,
-		SemesterCode:      s.SemesterCode,
-		AcademicYear:      s.AcademicYear,
-		StartDate:         s.StartDate,
-		EndDate:           s.EndDate,
-		RegistrationStart: s.RegistrationStart,
-		RegistrationEnd:   s.RegistrationEnd,
-		IsCurrent:         s.IsCurrent,
+		SemesterID:         s.SemesterID,
+		SemesterName:       s.SemesterName,
+		SemesterCode:       s.SemesterCode,
+		AcademicYear:       s.AcademicYear,
+		StartDate:          s.StartDate,
+		EndDate:            s.EndDate,
+		RegistrationStart:  s.RegistrationStart,
+		RegistrationEnd:    s.RegistrationEnd,
+		AddDropEnd:         s.AddDropEnd,
+		WithdrawalDeadline: s.WithdrawalDeadline,
+		IsCurrent:          s.IsCurrent,
 	}
 }
 
@@ -766,6 +825,119 @@ func FacultyCourseWithDetailsToResponse(fc *domain.FacultyCourseWithDetails) *Fa
 	}
 }
 
+func ProgramToResponse(p *domain.Program) *ProgramResponse {
+	return &ProgramResponse{
+		ProgramID:      p.ProgramID,
+		ProgramName:    p.ProgramName,
+		ProgramCode:    p.ProgramCode,
+		Department:     DepartmentBasicResponse{DepartmentID: p.DepartmentID},
+		DegreeType:     p.DegreeType,
+		DurationYears:  p.DurationYears,
+		TotalCredits:   p.TotalCredits,
+		Description:    p.Description,
+		IsActive:       p.IsActive,
+		GradingScaleID: p.GradingScaleID,
+		CreatedAt:      p.CreatedAt,
+	}
+}
+
+func ProgramWithDepartmentToResponse(p *domain.ProgramWithDepartment) *ProgramResponse {
+	resp := ToProgramResponse(p)
+	return &resp
+}
+
+func SubjectToResponse(s *domain.Subject) *SubjectResponse {
+	return &SubjectResponse{
+		SubjectID:   s.SubjectID,
+		SubjectName: s.SubjectName,
+		SubjectCode: s.SubjectCode,
+		Department:  DepartmentBasicResponse{DepartmentID: s.DepartmentID},
+		Credits:     s.Credits,
+		SubjectType: s.SubjectType,
+		IsActive:    s.IsActive,
+	}
+}
+
+func SubjectWithDetailsToResponse(s *domain.SubjectWithDetails) *SubjectDetailResponse {
+	resp := ToSubjectDetailResponse(s)
+	return &resp
+}
+
+func SemesterToResponse(s *domain.Semester) *SemesterResponse {
+	resp := ToSemesterResponse(s)
+	return &resp
+}
+
+func CurrentSemesterToResponse(s *domain.Semester, now time.Time) *CurrentSemesterResponse {
+	daysRemaining := int(s.EndDate.Sub(now).Hours() / 24)
+	if daysRemaining < 0 {
+		daysRemaining = 0
+	}
+
+	registrationOpen := s.RegistrationStart != nil && s.RegistrationEnd != nil &&
+		!now.Before(*s.RegistrationStart) && !now.After(*s.RegistrationEnd)
+
+	return &CurrentSemesterResponse{
+		SemesterResponse: ToSemesterResponse(s),
+		DaysRemaining:    daysRemaining,
+		RegistrationOpen: registrationOpen,
+	}
+}
+
+func FacultyToResponse(f *domain.Faculty) *FacultyResponse {
+	return &FacultyResponse{
+		FacultyID:      f.FacultyID,
+		UserID:         f.UserID,
+		EmployeeID:     f.EmployeeID,
+		Department:     DepartmentBasicResponse{DepartmentID: f.DepartmentID},
+		Designation:    f.Designation,
+		Specialization: f.Specialization,
+		IsActive:       f.IsActive,
+	}
+}
+
+func FacultyWithDetailsToResponse(f *domain.FacultyWithDetails) *FacultyDetailResponse {
+	resp := ToFacultyDetailResponse(f)
+	return &resp
+}
+
+func StudentToResponse(s *domain.Student) *StudentResponse {
+	return &StudentResponse{
+		StudentID:          s.StudentID,
+		UserID:             s.UserID,
+		RegistrationNumber: s.RegistrationNumber,
+		Department:         DepartmentBasicResponse{DepartmentID: s.DepartmentID},
+		Program:            ProgramBasicResponse{ProgramID: s.ProgramID},
+		CurrentSemester:    s.CurrentSemester,
+		BatchYear:          s.BatchYear,
+		CurrentCGPA:        s.CurrentCGPA,
+		IsActive:           s.IsActive,
+	}
+}
+
+func StudentWithDetailsToResponse(s *domain.StudentWithDetails) *StudentDetailResponse {
+	resp := ToStudentDetailResponse(s)
+	return &resp
+}
+
+func CalendarEventToResponse(e *domain.AcademicCalendarEvent) *CalendarEventResponse {
+	return &CalendarEventResponse{
+		EventID:     e.EventID,
+		Semester:    SemesterBasicResponse{SemesterID: e.SemesterID},
+		EventName:   e.EventName,
+		EventType:   e.EventType,
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+		Description: e.Description,
+		IsHoliday:   e.IsHoliday,
+	}
+}
+
+func CalendarEventWithDetailsToResponse(e *domain.AcademicCalendarEventWithDetails) *CalendarEventResponse {
+	resp := ToCalendarEventResponse(e)
+	return &resp
+}
+
 // Additional response types needed by handlers
 
 type CourseWithDetailsResponse struct {
@@ -0,0 +1,230 @@
+package dto
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+)
+
+// A4 page layout of rendered PDFs, in points
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+	pdfFooterY    = 30.0
+)
+
+// TranscriptToPDF renders a transcript as a PDF document. Every page carries
+// the verification code, and the last one the content hash.
+func TranscriptToPDF(t *domain.Transcript) []byte {
+	p := &pdfWriter{}
+	p.newPage()
+
+	p.space(16)
+	p.text(pdfMargin, true, 16, "Official Transcript")
+	p.space(8)
+	p.rule()
+
+	name := t.StudentName
+	if name == "" {
+		name = "-"
+	}
+	details := [][2]string{
+		{"Student", name},
+		{"Registration number", t.RegistrationNumber},
+		{"Program", t.Program.ProgramCode + " - " + t.Program.ProgramName},
+		{"Department", t.Department.DepartmentName},
+		{"Batch", strconv.Itoa(t.BatchYear)},
+		{"Current semester", strconv.Itoa(t.CurrentSemester)},
+		{"Standing", standingLabel(t.Standing)},
+		{"Grading scale", fmt.Sprintf("%s (out of %s)", t.GradingScale, formatPoints(&t.MaxGradePoints))},
+	}
+	for _, d := range details {
+		p.space(14)
+		p.text(pdfMargin, true, 10, d[0])
+		p.text(170, false, 10, d[1])
+	}
+
+	for _, sem := range t.Semesters {
+		// Keep the semester heading together with its first courses
+		p.keep(60)
+		p.space(26)
+		p.text(pdfMargin, true, 11, fmt.Sprintf("%s (%s)", sem.SemesterName, sem.SemesterCode))
+		p.space(14)
+		p.transcriptColumns(true, "Code", "Subject", "Credits", "Grade", "Points", "Result")
+		p.space(4)
+		p.rule()
+		for _, c := range sem.Courses {
+			result := "Pass"
+			if !c.Passed {
+				result = "Fail"
+			}
+			p.space(13)
+			p.transcriptColumns(false, c.SubjectCode, truncate(c.SubjectName, 42), strconv.Itoa(c.Credits), c.Grade, formatPoints(&c.GradePoints), result)
+		}
+		p.space(15)
+		p.text(pdfMargin, true, 9, fmt.Sprintf("Semester GPA: %s    Credits attempted: %d    Credits earned: %d",
+			formatPoints(sem.GPA), sem.CreditsAttempted, sem.CreditsEarned))
+	}
+	if len(t.Semesters) == 0 {
+		p.space(26)
+		p.text(pdfMargin, false, 10, "No graded courses.")
+	}
+
+	p.keep(60)
+	p.space(20)
+	p.rule()
+	p.space(16)
+	p.text(pdfMargin, true, 11, fmt.Sprintf("CGPA: %s    Total credits earned: %d", formatPoints(t.CGPA), t.TotalCreditsEarned))
+	p.space(20)
+	p.text(pdfMargin, false, 8, "Content hash (SHA-256): "+t.ContentHash)
+	p.space(11)
+	p.text(pdfMargin, false, 8, "Verify this transcript at /api/v1/transcripts/verify/"+t.VerificationCode)
+
+	issued := t.IssuedAt.Format("2006-01-02")
+	return p.bytes(func(page, pages int) string {
+		return fmt.Sprintf("Verification code %s    Issued %s    Page %d of %d", t.VerificationCode, issued, page, pages)
+	})
+}
+
+// transcriptColumns writes a row of the course table of a transcript
+func (p *pdfWriter) transcriptColumns(bold bool, code, subject, credits, grade, points, result string) {
+	p.text(pdfMargin, bold, 9, code)
+	p.text(125, bold, 9, subject)
+	p.text(360, bold, 9, credits)
+	p.text(420, bold, 9, grade)
+	p.text(470, bold, 9, points)
+	p.text(515, bold, 9, result)
+}
+
+func standingLabel(standing string) string {
+	switch standing {
+	case domain.StandingGood:
+		return "Good standing"
+	case domain.StandingProbation:
+		return "Academic probation"
+	case domain.StandingInactive:
+		return "Inactive"
+	default:
+		return standing
+	}
+}
+
+// formatPoints formats grade points with two decimals, or a dash if there are none
+func formatPoints(points *float64) string {
+	if points == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*points, 'f', 2, 64)
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-3]) + "..."
+}
+
+// pdfWriter lays out text from the top of A4 pages downwards, using the
+// standard Helvetica fonts so that no font has to be embedded
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func (p *pdfWriter) newPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+	p.y = pdfPageHeight - pdfMargin
+}
+
+// keep starts a new page unless height fits on the current one
+func (p *pdfWriter) keep(height float64) {
+	if p.y-height < pdfMargin {
+		p.newPage()
+	}
+}
+
+// space moves down by height, to a new page if needed
+func (p *pdfWriter) space(height float64) {
+	p.keep(height)
+	p.y -= height
+}
+
+func (p *pdfWriter) text(x float64, bold bool, size float64, s string) {
+	p.textAt(p.pages[len(p.pages)-1], x, p.y, bold, size, s)
+}
+
+func (p *pdfWriter) textAt(page *bytes.Buffer, x, y float64, bold bool, size float64, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(page, "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+// rule draws a horizontal line across the page
+func (p *pdfWriter) rule() {
+	fmt.Fprintf(p.pages[len(p.pages)-1], "0.5 w %g %g m %g %g l S\n", pdfMargin, p.y, pdfPageWidth-pdfMargin, p.y)
+}
+
+// bytes assembles the document, adding the footer returned for every page
+func (p *pdfWriter) bytes(footer func(page, pages int) string) []byte {
+	var b bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1 to 4 are the catalog, page tree and fonts, followed by a page
+	// and its content stream for every page
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+
+	b.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range p.pages {
+		p.textAt(page, pdfMargin, pdfFooterY, false, 8, footer(i+1, len(p.pages)))
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return b.Bytes()
+}
+
+// pdfEscape escapes s for use in a PDF string. Latin-1 letters are written as
+// octal escapes, which WinAnsiEncoding maps to the same characters, and any
+// other character the standard fonts cannot show is replaced.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r >= 32 && r <= 126:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,272 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// AccessControl scopes faculty and student callers to the resources they own.
+// Role gating is done by RoleMiddleware; these checks only narrow access for
+// callers whose role is allowed but who must be tied to the specific course
+// or student in the URL.
+type AccessControl struct {
+	studentService    domain.StudentService
+	facultyService    domain.FacultyService
+	subjectService    domain.SubjectService
+	assignmentService domain.FacultyAssignmentService
+	enrollmentService domain.EnrollmentService
+}
+
+func NewAccessControl(
+	studentService domain.StudentService,
+	facultyService domain.FacultyService,
+	subjectService domain.SubjectService,
+	assignmentService domain.FacultyAssignmentService,
+	enrollmentService domain.EnrollmentService,
+) *AccessControl {
+	return &AccessControl{
+		studentService:    studentService,
+		facultyService:    facultyService,
+		subjectService:    subjectService,
+		assignmentService: assignmentService,
+		enrollmentService: enrollmentService,
+	}
+}
+
+// GetStudentID retrieves the caller's student ID, set by LoadStudent
+func GetStudentID(r *http.Request) (uuid.UUID, bool) {
+	id, ok := r.Context().Value("student_id").(uuid.UUID)
+	return id, ok
+}
+
+// GetFacultyID retrieves the caller's faculty ID, set by LoadFaculty
+func GetFacultyID(r *http.Request) (uuid.UUID, bool) {
+	id, ok := r.Context().Value("faculty_id").(uuid.UUID)
+	return id, ok
+}
+
+// GetFacultyDepartmentID retrieves the caller's faculty department, set by LoadFaculty
+func GetFacultyDepartmentID(r *http.Request) (uuid.UUID, bool) {
+	id, ok := r.Context().Value("faculty_department_id").(uuid.UUID)
+	return id, ok
+}
+
+// LoadStudent resolves the student record of a student caller and stores its
+// ID in the request context. Callers with other roles pass through untouched.
+func (a *AccessControl) LoadStudent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if role, _ := GetRoleName(r); role != "student" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, ok := GetUserID(r)
+		if !ok {
+			ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+			return
+		}
+
+		student, err := a.studentService.GetStudentByUserID(r.Context(), userID)
+		if err != nil {
+			if err == domain.ErrStudentNotFound {
+				ErrorResponse(w, http.StatusForbidden, "no student profile for this user", err)
+				return
+			}
+			ErrorResponse(w, http.StatusInternalServerError, "failed to resolve student", err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "student_id", student.StudentID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// LoadFaculty resolves the faculty record of a faculty caller and stores its
+// ID and department in the request context. Callers with other roles pass
+// through untouched.
+func (a *AccessControl) LoadFaculty(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if role, _ := GetRoleName(r); role != "faculty" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, ok := GetUserID(r)
+		if !ok {
+			ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+			return
+		}
+
+		faculty, err := a.facultyService.GetFacultyByUserID(r.Context(), userID)
+		if err != nil {
+			if err == domain.ErrFacultyNotFound {
+				ErrorResponse(w, http.StatusForbidden, "no faculty profile for this user", err)
+				return
+			}
+			ErrorResponse(w, http.StatusInternalServerError, "failed to resolve faculty", err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "faculty_id", faculty.FacultyID)
+		ctx = context.WithValue(ctx, "faculty_department_id", faculty.DepartmentID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// StudentSelf restricts student callers to the student ID in the given URL
+// parameter. It must run after LoadStudent.
+func (a *AccessControl) StudentSelf(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if role, _ := GetRoleName(r); role != "student" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			studentID, ok := GetStudentID(r)
+			if !ok || chi.URLParam(r, param) != studentID.String() {
+				ErrorResponse(w, http.StatusForbidden, "students may only access their own records", domain.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// FacultySelf restricts faculty callers to the faculty ID in the given URL
+// parameter. It must run after LoadFaculty.
+func (a *AccessControl) FacultySelf(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if role, _ := GetRoleName(r); role != "faculty" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			facultyID, ok := GetFacultyID(r)
+			if !ok || chi.URLParam(r, param) != facultyID.String() {
+				ErrorResponse(w, http.StatusForbidden, "faculty may only update their own profile", domain.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SubjectDepartment restricts faculty callers to subjects of their own
+// department. It must run after LoadFaculty.
+func (a *AccessControl) SubjectDepartment(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if role, _ := GetRoleName(r); role != "faculty" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			subjectID, err := uuid.Parse(chi.URLParam(r, param))
+			if err != nil {
+				ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+				return
+			}
+
+			subject, err := a.subjectService.GetSubject(r.Context(), subjectID)
+			if err != nil {
+				if err == domain.ErrSubjectNotFound {
+					ErrorResponse(w, http.StatusNotFound, "subject not found", err)
+					return
+				}
+				ErrorResponse(w, http.StatusInternalServerError, "failed to get subject", err)
+				return
+			}
+
+			deptID, ok := GetFacultyDepartmentID(r)
+			if !ok || subject.DepartmentID != deptID {
+				ErrorResponse(w, http.StatusForbidden, "faculty may only manage subjects of their own department", domain.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CourseFaculty restricts faculty callers to courses they are assigned to.
+// When primaryOnly is set, only the primary faculty of the course passes.
+func (a *AccessControl) CourseFaculty(param string, primaryOnly bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if role, _ := GetRoleName(r); role != "faculty" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			courseID, err := uuid.Parse(chi.URLParam(r, param))
+			if err != nil {
+				ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+				return
+			}
+
+			a.requireAssignment(w, r, next, courseID, primaryOnly)
+		})
+	}
+}
+
+// EnrollmentFaculty restricts faculty callers to enrollments in courses they
+// are assigned to.
+func (a *AccessControl) EnrollmentFaculty(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if role, _ := GetRoleName(r); role != "faculty" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			enrollmentID, err := uuid.Parse(chi.URLParam(r, param))
+			if err != nil {
+				ErrorResponse(w, http.StatusBadRequest, "invalid enrollment ID", err)
+				return
+			}
+
+			enrollment, err := a.enrollmentService.GetEnrollment(r.Context(), enrollmentID)
+			if err != nil {
+				if err == domain.ErrEnrollmentNotFound {
+					ErrorResponse(w, http.StatusNotFound, "enrollment not found", err)
+					return
+				}
+				ErrorResponse(w, http.StatusInternalServerError, "failed to get enrollment", err)
+				return
+			}
+
+			a.requireAssignment(w, r, next, enrollment.CourseID, false)
+		})
+	}
+}
+
+func (a *AccessControl) requireAssignment(w http.ResponseWriter, r *http.Request, next http.Handler, courseID uuid.UUID, primaryOnly bool) {
+	userID, ok := GetUserID(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	assignments, err := a.assignmentService.ListCourseFaculty(r.Context(), courseID)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get course faculty", err)
+		return
+	}
+
+	for _, assignment := range assignments {
+		if assignment.Faculty.UserID == userID && (!primaryOnly || assignment.IsPrimary) {
+			next.ServeHTTP(w, r)
+			return
+		}
+	}
+
+	ErrorResponse(w, http.StatusForbidden, "faculty may only manage their own courses", domain.ErrForbidden)
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CalendarHandler struct {
+	service   domain.CalendarService
+	validator *validator.Validate
+}
+
+func NewCalendarHandler(service domain.CalendarService) *CalendarHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &CalendarHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *CalendarHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateCalendarEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := GetUserID(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	event := req.ToDomain()
+	event.CreatedBy = userID
+
+	if err := h.service.CreateEvent(r.Context(), event); err != nil {
+		if err == domain.ErrSemesterNotFound {
+			ErrorResponse(w, http.StatusBadRequest, "semester not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to create calendar event", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "calendar event created", dto.CalendarEventToResponse(event))
+}
+
+func (h *CalendarHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid event ID", err)
+		return
+	}
+
+	event, err := h.service.GetEvent(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrCalendarEventNotFound {
+			ErrorResponse(w, http.StatusNotFound, "calendar event not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get calendar event", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "calendar event retrieved", dto.CalendarEventWithDetailsToResponse(event))
+}
+
+func (h *CalendarHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid event ID", err)
+		return
+	}
+
+	var req dto.UpdateCalendarEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	updates := req.ToUpdates()
+	if err := h.service.UpdateEvent(r.Context(), id, updates); err != nil {
+		if err == domain.ErrCalendarEventNotFound {
+			ErrorResponse(w, http.StatusNotFound, "calendar event not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to update calendar event", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "calendar event updated", nil)
+}
+
+func (h *CalendarHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid event ID", err)
+		return
+	}
+
+	if err := h.service.DeleteEvent(r.Context(), id); err != nil {
+		if err == domain.ErrCalendarEventNotFound {
+			ErrorResponse(w, http.StatusNotFound, "calendar event not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to delete calendar event", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "calendar event deleted", nil)
+}
+
+func (h *CalendarHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filter domain.CalendarFilter
+	if semIDStr := r.URL.Query().Get("semester_id"); semIDStr != "" {
+		if semID, err := uuid.Parse(semIDStr); err == nil {
+			filter.SemesterID = &semID
+		}
+	}
+	if eventType := r.URL.Query().Get("event_type"); eventType != "" {
+		filter.EventType = &eventType
+	}
+	if startStr := r.URL.Query().Get("start_date"); startStr != "" {
+		if start, err := time.Parse("2006-01-02", startStr); err == nil {
+			filter.StartDate = &start
+		}
+	}
+	if endStr := r.URL.Query().Get("end_date"); endStr != "" {
+		if end, err := time.Parse("2006-01-02", endStr); err == nil {
+			filter.EndDate = &end
+		}
+	}
+	if isHolidayStr := r.URL.Query().Get("is_holiday"); isHolidayStr != "" {
+		isHoliday := isHolidayStr == "true"
+		filter.IsHoliday = &isHoliday
+	}
+
+	events, total, err := h.service.ListEvents(r.Context(), filter, page, limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list calendar events", err)
+		return
+	}
+
+	response := make([]*dto.CalendarEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, dto.CalendarEventWithDetailsToResponse(event))
+	}
+
+	PaginatedResponse(w, http.StatusOK, "calendar events retrieved", response, page, limit, total)
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalendarHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCalendarService(ctrl)
+	handler := NewCalendarHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/calendar", handler.Create)
+
+	t.Run("Success", func(t *testing.T) {
+		userID := uuid.New()
+		req := dto.CreateCalendarEventRequest{
+			SemesterID: uuid.New(),
+			EventName:  "Diwali Holiday",
+			EventType:  "holiday",
+			StartDate:  time.Now(),
+			IsHoliday:  true,
+		}
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx interface{}, event *domain.AcademicCalendarEvent) error {
+			assert.Equal(t, req.EventName, event.EventName)
+			assert.Equal(t, userID, event.CreatedBy)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/calendar", bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "user_id", userID)
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Invalid Event Type", func(t *testing.T) {
+		req := dto.CreateCalendarEventRequest{
+			SemesterID: uuid.New(),
+			EventName:  "Something",
+			EventType:  "party",
+			StartDate:  time.Now(),
+		}
+		body, _ := json.Marshal(req)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/calendar", bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "user_id", uuid.New())
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCalendarHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCalendarService(ctrl)
+	handler := NewCalendarHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/calendar", handler.List)
+
+	t.Run("Filters", func(t *testing.T) {
+		semesterID := uuid.New()
+		mockService.EXPECT().ListEvents(gomock.Any(), gomock.Any(), 1, 20).DoAndReturn(
+			func(ctx interface{}, filter domain.CalendarFilter, page, limit int) ([]*domain.AcademicCalendarEventWithDetails, int64, error) {
+				assert.Equal(t, semesterID, *filter.SemesterID)
+				assert.Equal(t, "2024-11-01", filter.StartDate.Format("2006-01-02"))
+				assert.Equal(t, "2024-11-30", filter.EndDate.Format("2006-01-02"))
+				assert.True(t, *filter.IsHoliday)
+				return []*domain.AcademicCalendarEventWithDetails{}, 0, nil
+			})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet,
+			"/calendar?start_date=2024-11-01&end_date=2024-11-30&is_holiday=true&semester_id="+semesterID.String(), nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -47,14 +48,14 @@ func (h *CourseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID := r.Context().Value("user_id")
-	if userID == nil {
+	userID, ok := GetUserID(r)
+	if !ok {
 		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
 		return
 	}
 
 	course := req.ToDomain()
-	course.CreatedBy = userID.(uuid.UUID)
+	course.CreatedBy = userID
 
 	if err := h.service.CreateCourse(r.Context(), course); err != nil {
 		switch err {
@@ -292,13 +293,13 @@ func (h *CourseHandler) AssignFaculty(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get user ID from context
-	userID := r.Context().Value("user_id")
-	if userID == nil {
+	userID, ok := GetUserID(r)
+	if !ok {
 		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
 		return
 	}
 
-	fc, err := h.assignmentService.AssignFaculty(r.Context(), courseID, req.FacultyID, userID.(uuid.UUID), req.Role, req.IsPrimary)
+	fc, err := h.assignmentService.AssignFaculty(r.Context(), courseID, req.FacultyID, userID, req.Role, req.IsPrimary)
 	if err != nil {
 		switch err {
 		case domain.ErrFacultyNotFound:
@@ -384,8 +385,31 @@ func (h *CourseHandler) EnrollStudent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	enrollment, err := h.enrollmentService.EnrollStudent(r.Context(), courseID, req.StudentID, "admin")
+	// Students enroll themselves; anyone else enrolls on the student's behalf
+	enrolledBy := "admin"
+	if studentID, ok := GetStudentID(r); ok {
+		if req.StudentID == uuid.Nil {
+			req.StudentID = studentID
+		}
+		if req.StudentID != studentID {
+			ErrorResponse(w, http.StatusForbidden, "students may only enroll themselves", domain.ErrForbidden)
+			return
+		}
+		enrolledBy = "self"
+	}
+
+	override, err := deadlineOverride(r, req.OverrideReason)
 	if err != nil {
+		ErrorResponse(w, http.StatusForbidden, "only admins may override registration deadlines", err)
+		return
+	}
+
+	enrollment, err := h.enrollmentService.EnrollStudent(r.Context(), courseID, req.StudentID, enrolledBy, override)
+	if err != nil {
+		if errors.Is(err, domain.ErrPrerequisitesNotMet) {
+			ErrorResponse(w, http.StatusBadRequest, "prerequisites not met", err)
+			return
+		}
 		switch err {
 		case domain.ErrStudentNotFound:
 			ErrorResponse(w, http.StatusBadRequest, "student not found", err)
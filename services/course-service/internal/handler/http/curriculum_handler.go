@@ -0,0 +1,178 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CurriculumHandler struct {
+	service   domain.CurriculumService
+	validator *validator.Validate
+}
+
+func NewCurriculumHandler(service domain.CurriculumService) *CurriculumHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &CurriculumHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *CurriculumHandler) Create(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	programID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid program ID", err)
+		return
+	}
+
+	var req dto.CreateCurriculumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	curriculum := req.ToDomain(programID)
+	if err := h.service.CreateCurriculum(r.Context(), curriculum); err != nil {
+		curriculumError(w, err, "failed to create curriculum")
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "curriculum created", curriculum)
+}
+
+func (h *CurriculumHandler) List(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	programID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid program ID", err)
+		return
+	}
+
+	curricula, err := h.service.ListCurricula(r.Context(), programID)
+	if err != nil {
+		curriculumError(w, err, "failed to list curricula")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "curricula retrieved", curricula)
+}
+
+func (h *CurriculumHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid curriculum ID", err)
+		return
+	}
+
+	curriculum, err := h.service.GetCurriculum(r.Context(), id)
+	if err != nil {
+		curriculumError(w, err, "failed to get curriculum")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "curriculum retrieved", curriculum)
+}
+
+func (h *CurriculumHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid curriculum ID", err)
+		return
+	}
+
+	var req dto.UpdateCurriculumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if err := h.service.UpdateCurriculum(r.Context(), id, req.ToUpdates()); err != nil {
+		curriculumError(w, err, "failed to update curriculum")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "curriculum updated", nil)
+}
+
+func (h *CurriculumHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid curriculum ID", err)
+		return
+	}
+
+	if err := h.service.DeleteCurriculum(r.Context(), id); err != nil {
+		curriculumError(w, err, "failed to delete curriculum")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "curriculum deleted", nil)
+}
+
+func (h *CurriculumHandler) AuditDegree(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	studentID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	audit, err := h.service.AuditDegree(r.Context(), studentID)
+	if err != nil {
+		switch err {
+		case domain.ErrStudentNotFound:
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+		case domain.ErrCurriculumNotFound:
+			ErrorResponse(w, http.StatusNotFound, "no curriculum applies to the student's program and batch", err)
+		case domain.ErrGradingScaleNotFound:
+			ErrorResponse(w, http.StatusNotFound, "grading scale not found", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to audit degree", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "degree audit completed", audit)
+}
+
+// curriculumError writes the response of a failed curriculum change or lookup
+func curriculumError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, domain.ErrInvalidCurriculum) {
+		ErrorResponse(w, http.StatusBadRequest, "invalid curriculum", err)
+		return
+	}
+	switch err {
+	case domain.ErrCurriculumNotFound:
+		ErrorResponse(w, http.StatusNotFound, "curriculum not found", err)
+	case domain.ErrProgramNotFound:
+		ErrorResponse(w, http.StatusNotFound, "program not found", err)
+	case domain.ErrSubjectNotFound:
+		ErrorResponse(w, http.StatusBadRequest, "subject not found", err)
+	case domain.ErrCurriculumExists:
+		ErrorResponse(w, http.StatusConflict, "program already has a curriculum for this batch year", err)
+	default:
+		ErrorResponse(w, http.StatusInternalServerError, message, err)
+	}
+}
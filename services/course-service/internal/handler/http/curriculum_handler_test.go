@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurriculumHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCurriculumService(ctrl)
+	handler := NewCurriculumHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/programs/{id}/curricula", handler.Create)
+
+	validRequest := func() dto.CreateCurriculumRequest {
+		return dto.CreateCurriculumRequest{
+			BatchYear:    2024,
+			VersionName:  "2024 scheme",
+			CoreSubjects: []dto.CoreSubjectRequest{{SubjectID: uuid.New(), SemesterNumber: 1}},
+			ElectiveBuckets: []dto.ElectiveBucketRequest{
+				{BucketName: "Open electives", MinCredits: 6, SubjectIDs: []uuid.UUID{uuid.New(), uuid.New()}},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		programID := uuid.New()
+		body, _ := json.Marshal(validRequest())
+
+		mockService.EXPECT().CreateCurriculum(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx interface{}, curriculum *domain.Curriculum) error {
+				assert.Equal(t, programID, curriculum.ProgramID)
+				assert.Len(t, curriculum.CoreSubjects, 1)
+				assert.Len(t, curriculum.ElectiveBuckets[0].Subjects, 2)
+				return nil
+			})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/programs/"+programID.String()+"/curricula", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Empty Elective Bucket", func(t *testing.T) {
+		req := validRequest()
+		req.ElectiveBuckets[0].SubjectIDs = nil
+		body, _ := json.Marshal(req)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/programs/"+uuid.New().String()+"/curricula", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid Curriculum", func(t *testing.T) {
+		body, _ := json.Marshal(validRequest())
+
+		mockService.EXPECT().CreateCurriculum(gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("%w: core subjects must be planned for semesters 1 to 8", domain.ErrInvalidCurriculum))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/programs/"+uuid.New().String()+"/curricula", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Batch Year Taken", func(t *testing.T) {
+		body, _ := json.Marshal(validRequest())
+
+		mockService.EXPECT().CreateCurriculum(gomock.Any(), gomock.Any()).Return(domain.ErrCurriculumExists)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/programs/"+uuid.New().String()+"/curricula", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestCurriculumHandler_AuditDegree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCurriculumService(ctrl)
+	handler := NewCurriculumHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/students/{id}/degree-audit", handler.AuditDegree)
+
+	t.Run("Success", func(t *testing.T) {
+		studentID := uuid.New()
+		mockService.EXPECT().AuditDegree(gomock.Any(), studentID).
+			Return(&domain.DegreeAudit{StudentID: studentID, Eligible: true}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/degree-audit", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"eligible":true`)
+	})
+
+	t.Run("No Curriculum", func(t *testing.T) {
+		studentID := uuid.New()
+		mockService.EXPECT().AuditDegree(gomock.Any(), studentID).Return(nil, domain.ErrCurriculumNotFound)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/degree-audit", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -34,20 +34,23 @@ func (h *EnrollmentHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// For now, just return basic enrollment info
-	filter := domain.EnrollmentFilter{}
-	enrollments, _, err := h.service.GetStudentEnrollments(r.Context(), id, filter, 1, 1)
+	enrollment, err := h.service.GetEnrollment(r.Context(), id)
 	if err != nil {
+		if err == domain.ErrEnrollmentNotFound {
+			ErrorResponse(w, http.StatusNotFound, "enrollment not found", err)
+			return
+		}
 		ErrorResponse(w, http.StatusInternalServerError, "failed to get enrollment", err)
 		return
 	}
 
-	if len(enrollments) == 0 {
-		ErrorResponse(w, http.StatusNotFound, "enrollment not found", nil)
+	// Students may only view their own enrollments
+	if studentID, ok := GetStudentID(r); ok && enrollment.StudentID != studentID {
+		ErrorResponse(w, http.StatusForbidden, "students may only access their own records", domain.ErrForbidden)
 		return
 	}
 
-	SuccessResponse(w, http.StatusOK, "enrollment retrieved", dto.EnrollmentWithDetailsToResponse(enrollments[0]))
+	SuccessResponse(w, http.StatusOK, "enrollment retrieved", dto.EnrollmentToResponse(enrollment))
 }
 
 func (h *EnrollmentHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -69,7 +72,7 @@ func (h *EnrollmentHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.UpdateEnrollment(r.Context(), id, req.EnrollmentStatus, req.Grade, req.GradePoints); err != nil {
+	if err := h.service.UpdateEnrollment(r.Context(), id, req.EnrollmentStatus); err != nil {
 		switch err {
 		case domain.ErrEnrollmentNotFound:
 			ErrorResponse(w, http.StatusNotFound, "enrollment not found", err)
@@ -105,12 +108,24 @@ func (h *EnrollmentHandler) Drop(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.DropCourse(r.Context(), courseID, studentID, req.Reason); err != nil {
+	override, err := deadlineOverride(r, req.OverrideReason)
+	if err != nil {
+		ErrorResponse(w, http.StatusForbidden, "only admins may override registration deadlines", err)
+		return
+	}
+
+	if err := h.service.DropCourse(r.Context(), courseID, studentID, req.Reason, override); err != nil {
 		switch err {
 		case domain.ErrEnrollmentNotFound:
 			ErrorResponse(w, http.StatusNotFound, "enrollment not found", err)
+		case domain.ErrCourseNotFound:
+			ErrorResponse(w, http.StatusNotFound, "course not found", err)
 		case domain.ErrCannotDropCompletedCourse:
 			ErrorResponse(w, http.StatusBadRequest, "cannot drop completed course", err)
+		case domain.ErrInvalidEnrollmentStatus:
+			ErrorResponse(w, http.StatusBadRequest, "only enrolled or waitlisted students can drop a course", err)
+		case domain.ErrWithdrawalDeadlinePassed:
+			ErrorResponse(w, http.StatusBadRequest, "withdrawal deadline has passed", err)
 		default:
 			ErrorResponse(w, http.StatusInternalServerError, "failed to drop course", err)
 		}
@@ -182,7 +197,14 @@ func (h *EnrollmentHandler) BulkEnroll(w http.ResponseWriter, r *http.Request) {
 
 	results, err := h.service.BulkEnroll(r.Context(), courseID, req.StudentIDs, req.SkipPrerequisites)
 	if err != nil {
-		ErrorResponse(w, http.StatusInternalServerError, "failed to bulk enroll", err)
+		switch err {
+		case domain.ErrCourseNotFound:
+			ErrorResponse(w, http.StatusNotFound, "course not found", err)
+		case domain.ErrRegistrationClosed:
+			ErrorResponse(w, http.StatusBadRequest, "registration is closed", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to bulk enroll", err)
+		}
 		return
 	}
 
@@ -204,14 +226,107 @@ func (h *EnrollmentHandler) CheckPrerequisites(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	met, missing, err := h.service.CheckPrerequisites(r.Context(), studentID, courseID)
+	check, err := h.service.CheckPrerequisites(r.Context(), studentID, courseID)
 	if err != nil {
+		if err == domain.ErrCourseNotFound {
+			ErrorResponse(w, http.StatusNotFound, "course not found", err)
+			return
+		}
 		ErrorResponse(w, http.StatusInternalServerError, "failed to check prerequisites", err)
 		return
 	}
 
-	SuccessResponse(w, http.StatusOK, "prerequisites checked", map[string]interface{}{
-		"met":                   met,
-		"missing_prerequisites": missing,
-	})
+	SuccessResponse(w, http.StatusOK, "prerequisites checked", check)
+}
+
+func (h *EnrollmentHandler) GrantWaiver(w http.ResponseWriter, r *http.Request) {
+	courseID, err := uuid.Parse(chi.URLParam(r, "courseId"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+		return
+	}
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	var req dto.GrantWaiverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	grantedBy, ok := GetUserID(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	waiver := &domain.PrerequisiteWaiver{
+		StudentID:       studentID,
+		CourseID:        courseID,
+		WaivedSubjectID: req.SubjectID,
+		Reason:          req.Reason,
+		GrantedBy:       grantedBy,
+	}
+	if err := h.service.GrantWaiver(r.Context(), waiver); err != nil {
+		switch err {
+		case domain.ErrCourseNotFound:
+			ErrorResponse(w, http.StatusNotFound, "course not found", err)
+		case domain.ErrStudentNotFound:
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+		case domain.ErrRequirementNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "subject is not a requirement of the course", err)
+		case domain.ErrWaiverExists:
+			ErrorResponse(w, http.StatusConflict, "requirement already waived", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to grant waiver", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "waiver granted", waiver)
+}
+
+func (h *EnrollmentHandler) ListWaivers(w http.ResponseWriter, r *http.Request) {
+	courseID, err := uuid.Parse(chi.URLParam(r, "courseId"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+		return
+	}
+
+	studentID, err := uuid.Parse(chi.URLParam(r, "studentId"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	waivers, err := h.service.ListWaivers(r.Context(), studentID, courseID)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list waivers", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "waivers retrieved", waivers)
+}
+
+// deadlineOverride returns the override of the semester registration windows
+// requested with reason, or nil if no reason was given. Only admins may
+// override them.
+func deadlineOverride(r *http.Request, reason string) (*domain.DeadlineOverride, error) {
+	if reason == "" {
+		return nil, nil
+	}
+	if role, _ := GetRoleName(r); role != "admin" {
+		return nil, domain.ErrForbidden
+	}
+	userID, _ := GetUserID(r)
+	return &domain.DeadlineOverride{Reason: reason, OverriddenBy: userID}, nil
 }
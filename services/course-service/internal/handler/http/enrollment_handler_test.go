@@ -2,11 +2,13 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
 	"github.com/go-chi/chi/v5"
@@ -27,16 +29,12 @@ func TestEnrollmentHandler_Update(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		enrollmentID := uuid.New()
-		grade := "A"
-		gradePoints := 4.0
 		req := dto.UpdateEnrollmentRequest{
-			EnrollmentStatus: "completed",
-			Grade:            &grade,
-			GradePoints:      &gradePoints,
+			EnrollmentStatus: "enrolled",
 		}
 		body, _ := json.Marshal(req)
 
-		mockService.EXPECT().UpdateEnrollment(gomock.Any(), enrollmentID, "completed", &grade, &gradePoints).Return(nil)
+		mockService.EXPECT().UpdateEnrollment(gomock.Any(), enrollmentID, "enrolled").Return(nil)
 
 		w := httptest.NewRecorder()
 		reqHttp := httptest.NewRequest(http.MethodPut, "/enrollments/"+enrollmentID.String(), bytes.NewBuffer(body))
@@ -48,7 +46,7 @@ func TestEnrollmentHandler_Update(t *testing.T) {
 	t.Run("Invalid Status", func(t *testing.T) {
 		enrollmentID := uuid.New()
 		req := dto.UpdateEnrollmentRequest{
-			EnrollmentStatus: "invalid_status",
+			EnrollmentStatus: "dropped",
 		}
 		body, _ := json.Marshal(req)
 
@@ -79,7 +77,7 @@ func TestEnrollmentHandler_Drop(t *testing.T) {
 		}
 		body, _ := json.Marshal(req)
 
-		mockService.EXPECT().DropCourse(gomock.Any(), courseID, studentID, "Too hard").Return(nil)
+		mockService.EXPECT().DropCourse(gomock.Any(), courseID, studentID, "Too hard", nil).Return(nil)
 
 		w := httptest.NewRecorder()
 		reqHttp := httptest.NewRequest(http.MethodDelete, "/enrollments/courses/"+courseID.String()+"/students/"+studentID.String(), bytes.NewBuffer(body))
@@ -87,4 +85,106 @@ func TestEnrollmentHandler_Drop(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("Admin Override", func(t *testing.T) {
+		courseID := uuid.New()
+		studentID := uuid.New()
+		adminID := uuid.New()
+		req := dto.DropCourseRequest{
+			Reason:         "Medical leave",
+			OverrideReason: "Approved by dean",
+		}
+		body, _ := json.Marshal(req)
+
+		override := &domain.DeadlineOverride{Reason: "Approved by dean", OverriddenBy: adminID}
+		mockService.EXPECT().DropCourse(gomock.Any(), courseID, studentID, "Medical leave", override).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodDelete, "/enrollments/courses/"+courseID.String()+"/students/"+studentID.String(), bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "role_name", "admin")
+		ctx = context.WithValue(ctx, "user_id", adminID)
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Student Cannot Override", func(t *testing.T) {
+		courseID := uuid.New()
+		studentID := uuid.New()
+		req := dto.DropCourseRequest{
+			Reason:         "Too hard",
+			OverrideReason: "Please",
+		}
+		body, _ := json.Marshal(req)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodDelete, "/enrollments/courses/"+courseID.String()+"/students/"+studentID.String(), bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "role_name", "student")
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Withdrawal Deadline Passed", func(t *testing.T) {
+		courseID := uuid.New()
+		studentID := uuid.New()
+		body, _ := json.Marshal(dto.DropCourseRequest{Reason: "Too hard"})
+
+		mockService.EXPECT().DropCourse(gomock.Any(), courseID, studentID, "Too hard", nil).Return(domain.ErrWithdrawalDeadlinePassed)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodDelete, "/enrollments/courses/"+courseID.String()+"/students/"+studentID.String(), bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestEnrollmentHandler_GrantWaiver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockEnrollmentService(ctrl)
+	handler := NewEnrollmentHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/enrollments/courses/{courseId}/students/{studentId}/waivers", handler.GrantWaiver)
+
+	t.Run("Success", func(t *testing.T) {
+		courseID := uuid.New()
+		studentID := uuid.New()
+		facultyID := uuid.New()
+		subjectID := uuid.New()
+		body, _ := json.Marshal(dto.GrantWaiverRequest{SubjectID: subjectID, Reason: "Equivalent course at previous university"})
+
+		mockService.EXPECT().GrantWaiver(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, w *domain.PrerequisiteWaiver) error {
+			assert.Equal(t, courseID, w.CourseID)
+			assert.Equal(t, studentID, w.StudentID)
+			assert.Equal(t, subjectID, w.WaivedSubjectID)
+			assert.Equal(t, facultyID, w.GrantedBy)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/enrollments/courses/"+courseID.String()+"/students/"+studentID.String()+"/waivers", bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "user_id", facultyID)
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Not A Requirement", func(t *testing.T) {
+		courseID := uuid.New()
+		studentID := uuid.New()
+		body, _ := json.Marshal(dto.GrantWaiverRequest{SubjectID: uuid.New(), Reason: "No reason"})
+
+		mockService.EXPECT().GrantWaiver(gomock.Any(), gomock.Any()).Return(domain.ErrRequirementNotFound)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/enrollments/courses/"+courseID.String()+"/students/"+studentID.String()+"/waivers", bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "user_id", uuid.New())
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
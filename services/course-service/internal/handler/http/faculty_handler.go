@@ -0,0 +1,214 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type FacultyHandler struct {
+	service   domain.FacultyService
+	validator *validator.Validate
+}
+
+func NewFacultyHandler(service domain.FacultyService) *FacultyHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &FacultyHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *FacultyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateFacultyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	faculty := req.ToDomain()
+	if err := h.service.CreateFaculty(r.Context(), faculty); err != nil {
+		switch err {
+		case domain.ErrDepartmentNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "department not found", err)
+		case domain.ErrEmployeeIDExists:
+			ErrorResponse(w, http.StatusConflict, "employee ID already exists", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to create faculty", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "faculty created", dto.FacultyToResponse(faculty))
+}
+
+func (h *FacultyHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid faculty ID", err)
+		return
+	}
+
+	faculty, err := h.service.GetFaculty(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrFacultyNotFound {
+			ErrorResponse(w, http.StatusNotFound, "faculty not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get faculty", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "faculty retrieved", dto.FacultyWithDetailsToResponse(faculty))
+}
+
+func (h *FacultyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid faculty ID", err)
+		return
+	}
+
+	var req dto.UpdateFacultyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	// Only admins may (de)activate faculty profiles
+	if role, _ := GetRoleName(r); role != "admin" && req.IsActive != nil {
+		ErrorResponse(w, http.StatusForbidden, "only admins may change the active status", domain.ErrForbidden)
+		return
+	}
+
+	updates := req.ToUpdates()
+	if err := h.service.UpdateFaculty(r.Context(), id, updates); err != nil {
+		if err == domain.ErrFacultyNotFound {
+			ErrorResponse(w, http.StatusNotFound, "faculty not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to update faculty", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "faculty updated", nil)
+}
+
+func (h *FacultyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid faculty ID", err)
+		return
+	}
+
+	if err := h.service.DeleteFaculty(r.Context(), id); err != nil {
+		if err == domain.ErrFacultyNotFound {
+			ErrorResponse(w, http.StatusNotFound, "faculty not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to delete faculty", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "faculty deleted", nil)
+}
+
+func (h *FacultyHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filter domain.FacultyFilter
+	if deptIDStr := r.URL.Query().Get("department_id"); deptIDStr != "" {
+		if deptID, err := uuid.Parse(deptIDStr); err == nil {
+			filter.DepartmentID = &deptID
+		}
+	}
+	if designation := r.URL.Query().Get("designation"); designation != "" {
+		filter.Designation = &designation
+	}
+	if search := r.URL.Query().Get("search"); search != "" {
+		filter.Search = &search
+	}
+	if isActiveStr := r.URL.Query().Get("is_active"); isActiveStr != "" {
+		isActive := isActiveStr == "true"
+		filter.IsActive = &isActive
+	}
+
+	faculty, total, err := h.service.ListFaculty(r.Context(), filter, page, limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list faculty", err)
+		return
+	}
+
+	response := make([]*dto.FacultyResponse, 0, len(faculty))
+	for _, f := range faculty {
+		resp := dto.ToFacultyResponse(f)
+		response = append(response, &resp)
+	}
+
+	PaginatedResponse(w, http.StatusOK, "faculty retrieved", response, page, limit, total)
+}
+
+func (h *FacultyHandler) GetCourses(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	facultyID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid faculty ID", err)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var semesterID *uuid.UUID
+	if semIDStr := r.URL.Query().Get("semester_id"); semIDStr != "" {
+		if semID, err := uuid.Parse(semIDStr); err == nil {
+			semesterID = &semID
+		}
+	}
+
+	courses, total, err := h.service.GetFacultyCourses(r.Context(), facultyID, semesterID, page, limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get faculty courses", err)
+		return
+	}
+
+	response := make([]*dto.FacultyCourseAssignmentResponse, 0, len(courses))
+	for _, fc := range courses {
+		response = append(response, dto.FacultyCourseToResponse(fc))
+	}
+
+	PaginatedResponse(w, http.StatusOK, "faculty courses retrieved", response, page, limit, total)
+}
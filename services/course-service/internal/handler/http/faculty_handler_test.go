@@ -0,0 +1,88 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFacultyHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockFacultyService(ctrl)
+	handler := NewFacultyHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/faculty/{id}", handler.Update)
+
+	t.Run("Success", func(t *testing.T) {
+		facultyID := uuid.New()
+		room := "CSE-301"
+		body, _ := json.Marshal(dto.UpdateFacultyRequest{OfficeRoom: &room})
+
+		mockService.EXPECT().UpdateFaculty(gomock.Any(), facultyID, map[string]interface{}{"office_room": room}).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPut, "/faculty/"+facultyID.String(), bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "role_name", "faculty")
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Faculty Cannot Deactivate", func(t *testing.T) {
+		isActive := false
+		body, _ := json.Marshal(dto.UpdateFacultyRequest{IsActive: &isActive})
+
+		// Service should NOT be called
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPut, "/faculty/"+uuid.New().String(), bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "role_name", "faculty")
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestFacultyHandler_GetCourses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockFacultyService(ctrl)
+	handler := NewFacultyHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/faculty/{id}/courses", handler.GetCourses)
+
+	t.Run("Success", func(t *testing.T) {
+		facultyID := uuid.New()
+		semesterID := uuid.New()
+		courses := []*domain.FacultyCourse{
+			{FacultyCourseID: uuid.New(), FacultyID: facultyID, CourseID: uuid.New(), Role: "instructor", IsPrimary: true},
+		}
+
+		mockService.EXPECT().GetFacultyCourses(gomock.Any(), facultyID, &semesterID, 1, 20).Return(courses, int64(1), nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet, "/faculty/"+facultyID.String()+"/courses?semester_id="+semesterID.String(), nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp PaginatedAPIResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, int64(1), resp.Pagination.TotalCount)
+	})
+}
@@ -0,0 +1,299 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type GradebookHandler struct {
+	service   domain.GradebookService
+	validator *validator.Validate
+}
+
+func NewGradebookHandler(service domain.GradebookService) *GradebookHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &GradebookHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *GradebookHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	courseID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	var req dto.SubmitGradesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	gradebook, err := h.service.SubmitGrades(r.Context(), courseID, req.ToDomain(), actor)
+	if err != nil {
+		gradebookError(w, err, "failed to submit grades")
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "grades submitted", gradebook)
+}
+
+func (h *GradebookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	courseID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	gradebook, err := h.service.GetGradebook(r.Context(), courseID, actor)
+	if err != nil {
+		gradebookError(w, err, "failed to get grades")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grades retrieved", gradebook)
+}
+
+func (h *GradebookHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	courseID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	var req dto.ApproveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.service.ApproveGrades(r.Context(), courseID, req.Comment, actor); err != nil {
+		gradebookError(w, err, "failed to approve grades")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grades approved", nil)
+}
+
+func (h *GradebookHandler) Return(w http.ResponseWriter, r *http.Request) {
+	courseID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	var req dto.ReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if err := h.service.ReturnGrades(r.Context(), courseID, req.Comment, actor); err != nil {
+		gradebookError(w, err, "failed to return grades")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grades returned", nil)
+}
+
+func (h *GradebookHandler) ListAmendments(w http.ResponseWriter, r *http.Request) {
+	courseID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid course ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	amendments, err := h.service.ListAmendments(r.Context(), courseID, actor)
+	if err != nil {
+		gradebookError(w, err, "failed to list grade amendments")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grade amendments retrieved", amendments)
+}
+
+func (h *GradebookHandler) RequestAmendment(w http.ResponseWriter, r *http.Request) {
+	enrollmentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid enrollment ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	var req dto.RequestAmendmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	amendment, err := h.service.RequestAmendment(r.Context(), enrollmentID, req.Grade, req.Reason, actor)
+	if err != nil {
+		gradebookError(w, err, "failed to request grade amendment")
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "grade amendment requested", amendment)
+}
+
+func (h *GradebookHandler) ApproveAmendment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid amendment ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	var req dto.ApproveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.service.ApproveAmendment(r.Context(), id, req.Comment, actor); err != nil {
+		gradebookError(w, err, "failed to approve grade amendment")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grade amendment approved", nil)
+}
+
+func (h *GradebookHandler) RejectAmendment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid amendment ID", err)
+		return
+	}
+
+	actor, ok := gradeActor(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	var req dto.ReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if err := h.service.RejectAmendment(r.Context(), id, req.Comment, actor); err != nil {
+		gradebookError(w, err, "failed to reject grade amendment")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grade amendment rejected", nil)
+}
+
+// gradeActor returns the caller acting on a gradebook. The service checks
+// whether they may act on the course.
+func gradeActor(r *http.Request) (domain.GradeActor, bool) {
+	userID, ok := GetUserID(r)
+	if !ok {
+		return domain.GradeActor{}, false
+	}
+	role, _ := GetRoleName(r)
+	actor := domain.GradeActor{UserID: userID, IsAdmin: role == "admin"}
+	if facultyID, ok := GetFacultyID(r); ok {
+		actor.FacultyID = &facultyID
+	}
+	return actor, true
+}
+
+// gradebookError writes the response for an error of the grading workflow
+func gradebookError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, domain.ErrIncompleteGradebook) {
+		ErrorResponse(w, http.StatusBadRequest, "grades must cover the whole roster", err)
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidGrade) {
+		ErrorResponse(w, http.StatusBadRequest, "grade is not on the grading scale", err)
+		return
+	}
+	switch err {
+	case domain.ErrForbidden:
+		ErrorResponse(w, http.StatusForbidden, "not allowed to act on the grades of this course", err)
+	case domain.ErrCourseNotFound:
+		ErrorResponse(w, http.StatusNotFound, "course not found", err)
+	case domain.ErrEnrollmentNotFound:
+		ErrorResponse(w, http.StatusNotFound, "enrollment not found", err)
+	case domain.ErrGradebookNotFound:
+		ErrorResponse(w, http.StatusNotFound, "no grades have been submitted for this course", err)
+	case domain.ErrAmendmentNotFound:
+		ErrorResponse(w, http.StatusNotFound, "grade amendment not found", err)
+	case domain.ErrGradesLocked, domain.ErrGradesNotSubmitted, domain.ErrGradesNotApproved,
+		domain.ErrAmendmentPending, domain.ErrAmendmentReviewed:
+		ErrorResponse(w, http.StatusConflict, err.Error(), err)
+	default:
+		ErrorResponse(w, http.StatusInternalServerError, message, err)
+	}
+}
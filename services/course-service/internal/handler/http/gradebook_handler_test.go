@@ -0,0 +1,178 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGradebookHandler_Submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradebookService(ctrl)
+	handler := NewGradebookHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/courses/{id}/gradebook", handler.Submit)
+
+	userID := uuid.New()
+	facultyID := uuid.New()
+	withFaculty := func(req *http.Request) *http.Request {
+		ctx := context.WithValue(req.Context(), "user_id", userID)
+		ctx = context.WithValue(ctx, "role_name", "faculty")
+		ctx = context.WithValue(ctx, "faculty_id", facultyID)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		courseID := uuid.New()
+		studentID := uuid.New()
+		req := dto.SubmitGradesRequest{Grades: []dto.StudentGradeRequest{{StudentID: studentID, Grade: "A"}}}
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().SubmitGrades(gomock.Any(), courseID, []domain.GradeSubmission{{StudentID: studentID, Grade: "A"}}, gomock.Any()).
+			DoAndReturn(func(ctx interface{}, id uuid.UUID, grades []domain.GradeSubmission, actor domain.GradeActor) (*domain.Gradebook, error) {
+				assert.Equal(t, userID, actor.UserID)
+				assert.Equal(t, facultyID, *actor.FacultyID)
+				assert.False(t, actor.IsAdmin)
+				return &domain.Gradebook{CourseID: id, Status: domain.GradebookSubmitted}, nil
+			})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/courses/"+courseID.String()+"/gradebook", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withFaculty(reqHttp))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Empty Grades", func(t *testing.T) {
+		courseID := uuid.New()
+		body, _ := json.Marshal(dto.SubmitGradesRequest{})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/courses/"+courseID.String()+"/gradebook", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withFaculty(reqHttp))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Incomplete Roster", func(t *testing.T) {
+		courseID := uuid.New()
+		req := dto.SubmitGradesRequest{Grades: []dto.StudentGradeRequest{{StudentID: uuid.New(), Grade: "A"}}}
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().SubmitGrades(gomock.Any(), courseID, gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("%w: student has no grade", domain.ErrIncompleteGradebook))
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/courses/"+courseID.String()+"/gradebook", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withFaculty(reqHttp))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		courseID := uuid.New()
+		req := dto.SubmitGradesRequest{Grades: []dto.StudentGradeRequest{{StudentID: uuid.New(), Grade: "A"}}}
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().SubmitGrades(gomock.Any(), courseID, gomock.Any(), gomock.Any()).Return(nil, domain.ErrGradesLocked)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/courses/"+courseID.String()+"/gradebook", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withFaculty(reqHttp))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		courseID := uuid.New()
+		body, _ := json.Marshal(dto.SubmitGradesRequest{})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/courses/"+courseID.String()+"/gradebook", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestGradebookHandler_RejectAmendment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradebookService(ctrl)
+	handler := NewGradebookHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/grade-amendments/{id}/reject", handler.RejectAmendment)
+
+	withAdmin := func(req *http.Request) *http.Request {
+		ctx := context.WithValue(req.Context(), "user_id", uuid.New())
+		ctx = context.WithValue(ctx, "role_name", "admin")
+		return req.WithContext(ctx)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		id := uuid.New()
+		body, _ := json.Marshal(dto.ReturnRequest{Comment: "Not justified"})
+
+		mockService.EXPECT().RejectAmendment(gomock.Any(), id, "Not justified", gomock.Any()).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/grade-amendments/"+id.String()+"/reject", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withAdmin(reqHttp))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Missing Comment", func(t *testing.T) {
+		id := uuid.New()
+		body, _ := json.Marshal(dto.ReturnRequest{})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/grade-amendments/"+id.String()+"/reject", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withAdmin(reqHttp))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Own Amendment", func(t *testing.T) {
+		id := uuid.New()
+		body, _ := json.Marshal(dto.ReturnRequest{Comment: "Not justified"})
+
+		mockService.EXPECT().RejectAmendment(gomock.Any(), id, "Not justified", gomock.Any()).Return(domain.ErrForbidden)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/grade-amendments/"+id.String()+"/reject", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withAdmin(reqHttp))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Already Reviewed", func(t *testing.T) {
+		id := uuid.New()
+		body, _ := json.Marshal(dto.ReturnRequest{Comment: "Not justified"})
+
+		mockService.EXPECT().RejectAmendment(gomock.Any(), id, "Not justified", gomock.Any()).Return(domain.ErrAmendmentReviewed)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/grade-amendments/"+id.String()+"/reject", bytes.NewBuffer(body))
+		r.ServeHTTP(w, withAdmin(reqHttp))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type GradingScaleHandler struct {
+	service   domain.GradingScaleService
+	validator *validator.Validate
+}
+
+func NewGradingScaleHandler(service domain.GradingScaleService) *GradingScaleHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &GradingScaleHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *GradingScaleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateGradingScaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	scale := req.ToDomain()
+	if err := h.service.CreateGradingScale(r.Context(), scale); err != nil {
+		if errors.Is(err, domain.ErrInvalidGradingScale) {
+			ErrorResponse(w, http.StatusBadRequest, "invalid grading scale", err)
+			return
+		}
+		switch err {
+		case domain.ErrGradingScaleNameExists:
+			ErrorResponse(w, http.StatusConflict, "grading scale name already exists", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to create grading scale", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "grading scale created", scale)
+}
+
+func (h *GradingScaleHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid grading scale ID", err)
+		return
+	}
+
+	scale, err := h.service.GetGradingScale(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrGradingScaleNotFound {
+			ErrorResponse(w, http.StatusNotFound, "grading scale not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get grading scale", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grading scale retrieved", scale)
+}
+
+func (h *GradingScaleHandler) List(w http.ResponseWriter, r *http.Request) {
+	scales, err := h.service.ListGradingScales(r.Context())
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list grading scales", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grading scales retrieved", scales)
+}
+
+func (h *GradingScaleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid grading scale ID", err)
+		return
+	}
+
+	var req dto.UpdateGradingScaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if err := h.service.UpdateGradingScale(r.Context(), id, req.ToUpdates()); err != nil {
+		if errors.Is(err, domain.ErrInvalidGradingScale) {
+			ErrorResponse(w, http.StatusBadRequest, "invalid grading scale", err)
+			return
+		}
+		switch err {
+		case domain.ErrGradingScaleNotFound:
+			ErrorResponse(w, http.StatusNotFound, "grading scale not found", err)
+		case domain.ErrGradingScaleNameExists:
+			ErrorResponse(w, http.StatusConflict, "grading scale name already exists", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to update grading scale", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grading scale updated", nil)
+}
+
+func (h *GradingScaleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid grading scale ID", err)
+		return
+	}
+
+	if err := h.service.DeleteGradingScale(r.Context(), id); err != nil {
+		switch err {
+		case domain.ErrGradingScaleNotFound:
+			ErrorResponse(w, http.StatusNotFound, "grading scale not found", err)
+		case domain.ErrGradingScaleInUse:
+			ErrorResponse(w, http.StatusConflict, "grading scale is the default or used by a program", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to delete grading scale", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "grading scale deleted", nil)
+}
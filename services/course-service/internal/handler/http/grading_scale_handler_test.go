@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGradingScaleHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradingScaleService(ctrl)
+	handler := NewGradingScaleHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/grading-scales", handler.Create)
+
+	req := dto.CreateGradingScaleRequest{
+		ScaleName:       "4-point",
+		MaxGradePoints:  4,
+		PassGradePoints: 1,
+		Grades: []dto.GradeMappingRequest{
+			{Grade: "A", GradePoints: 4},
+			{Grade: "F", GradePoints: 0},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().CreateGradingScale(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx interface{}, scale *domain.GradingScale) error {
+			assert.Equal(t, "4-point", scale.ScaleName)
+			assert.Len(t, scale.Grades, 2)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/grading-scales", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Invalid Scale", func(t *testing.T) {
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().CreateGradingScale(gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("%w: grade A is listed twice", domain.ErrInvalidGradingScale))
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/grading-scales", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "grade A is listed twice")
+	})
+
+	t.Run("Name Exists", func(t *testing.T) {
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().CreateGradingScale(gomock.Any(), gomock.Any()).Return(domain.ErrGradingScaleNameExists)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/grading-scales", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestGradingScaleHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGradingScaleService(ctrl)
+	handler := NewGradingScaleHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Delete("/grading-scales/{id}", handler.Delete)
+
+	t.Run("Success", func(t *testing.T) {
+		scaleID := uuid.New()
+		mockService.EXPECT().DeleteGradingScale(gomock.Any(), scaleID).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodDelete, "/grading-scales/"+scaleID.String(), nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("In Use", func(t *testing.T) {
+		scaleID := uuid.New()
+		mockService.EXPECT().DeleteGradingScale(gomock.Any(), scaleID).Return(domain.ErrGradingScaleInUse)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodDelete, "/grading-scales/"+scaleID.String(), nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
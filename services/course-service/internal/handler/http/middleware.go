@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/middleware"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/google/uuid"
+)
+
+// CorrelationMiddleware puts the correlation ID of the request, or a new one,
+// into the event context of the request so that events published while
+// handling it share it. The ID is echoed in the response.
+func CorrelationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID := r.Header.Get(middleware.CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = uuid.NewString()
+		}
+		w.Header().Set(middleware.CorrelationIDHeader, correlationID)
+
+		ec := models.EventContextFrom(r.Context())
+		ec.CorrelationID = correlationID
+		next.ServeHTTP(w, r.WithContext(models.WithEventContext(r.Context(), ec)))
+	})
+}
+
+// AuthMiddleware validates JWT tokens, rejects revoked ones and adds user info to the request context
+func AuthMiddleware(jwtManager *utils.JWTManager, revocations middleware.RevocationChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Get token from Authorization header
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				ErrorResponse(w, http.StatusUnauthorized, "authorization header required", nil)
+				return
+			}
+
+			// Check Bearer prefix
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || parts[0] != "Bearer" {
+				ErrorResponse(w, http.StatusUnauthorized, "invalid authorization header format", nil)
+				return
+			}
+
+			// Validate token
+			claims, err := jwtManager.ValidateAccessToken(parts[1])
+			if err != nil {
+				ErrorResponse(w, http.StatusUnauthorized, "invalid or expired token", err)
+				return
+			}
+
+			// Check revocation; if Redis fails, allow the token until it expires
+			if revoked, err := revocations.IsRevoked(r.Context(), claims); err == nil && revoked {
+				ErrorResponse(w, http.StatusUnauthorized, "token has been revoked", nil)
+				return
+			}
+
+			// Add user info to context
+			ctx := r.Context()
+			ctx = context.WithValue(ctx, "user_id", claims.UserID)
+			ctx = context.WithValue(ctx, "email", claims.Email)
+			ctx = context.WithValue(ctx, "role_id", claims.RoleID)
+			ctx = context.WithValue(ctx, "role_name", claims.RoleName)
+
+			// Events published for the request are attributed to the user
+			ec := models.EventContextFrom(ctx)
+			ec.ActorID = claims.UserID
+			ctx = models.WithEventContext(ctx, ec)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetUserID retrieves user ID from the request context
+func GetUserID(r *http.Request) (uuid.UUID, bool) {
+	id, ok := r.Context().Value("user_id").(uuid.UUID)
+	return id, ok
+}
+
+// GetRoleName retrieves role name from the request context
+func GetRoleName(r *http.Request) (string, bool) {
+	name, ok := r.Context().Value("role_name").(string)
+	return name, ok
+}
+
+// RoleMiddleware checks if user has required role
+func RoleMiddleware(allowedRoles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			roleName, exists := GetRoleName(r)
+			if !exists {
+				ErrorResponse(w, http.StatusUnauthorized, "user role not found", nil)
+				return
+			}
+
+			// Check if user's role is in allowed roles
+			for _, role := range allowedRoles {
+				if roleName == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			ErrorResponse(w, http.StatusForbidden, "insufficient permissions", nil)
+		})
+	}
+}
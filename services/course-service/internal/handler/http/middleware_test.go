@@ -0,0 +1,282 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func newTestJWTManager(t *testing.T) *utils.JWTManager {
+	key, err := utils.GenerateSigningKey(utils.AlgorithmEdDSA, time.Now())
+	assert.NoError(t, err)
+	return utils.NewJWTManager(utils.NewKeyRing(key), 3600, 7200)
+}
+
+// fakeRevocations revokes every token of the listed users
+type fakeRevocations struct {
+	revokedUsers map[uuid.UUID]bool
+}
+
+func (f *fakeRevocations) IsRevoked(ctx context.Context, claims *utils.Claims) (bool, error) {
+	return f.revokedUsers[claims.UserID], nil
+}
+
+var noRevocations = &fakeRevocations{}
+
+func bearer(t *testing.T, jwtManager *utils.JWTManager, userID uuid.UUID, role string) string {
+	token, err := jwtManager.GenerateAccessToken(userID, "user@nimbusu.edu", uuid.New(), role)
+	assert.NoError(t, err)
+	return "Bearer " + token
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	jwtManager := newTestJWTManager(t)
+	revocations := &fakeRevocations{revokedUsers: map[uuid.UUID]bool{}}
+
+	r := chi.NewRouter()
+	r.Use(AuthMiddleware(jwtManager, revocations))
+	r.Get("/me", func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := GetUserID(r)
+		assert.True(t, ok)
+		role, _ := GetRoleName(r)
+		w.Header().Set("X-User-ID", userID.String())
+		w.Header().Set("X-User-Role", role)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("Missing Header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Token From Other Key", func(t *testing.T) {
+		other := newTestJWTManager(t)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", bearer(t, other, uuid.New(), "admin"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Valid Token", func(t *testing.T) {
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, userID, "faculty"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, userID.String(), w.Header().Get("X-User-ID"))
+		assert.Equal(t, "faculty", w.Header().Get("X-User-Role"))
+	})
+
+	t.Run("Revoked Token", func(t *testing.T) {
+		userID := uuid.New()
+		revocations.revokedUsers[userID] = true
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, userID, "faculty"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestRoleMiddleware(t *testing.T) {
+	jwtManager := newTestJWTManager(t)
+
+	r := chi.NewRouter()
+	r.Use(AuthMiddleware(jwtManager, noRevocations))
+	r.With(RoleMiddleware("admin")).Post("/departments", okHandler)
+
+	t.Run("Allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/departments", nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, uuid.New(), "admin"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/departments", nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, uuid.New(), "student"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAccessControl_StudentSelf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jwtManager := newTestJWTManager(t)
+	mockStudentService := mocks.NewMockStudentService(ctrl)
+	access := NewAccessControl(mockStudentService, nil, nil, nil, nil)
+
+	r := chi.NewRouter()
+	r.Use(AuthMiddleware(jwtManager, noRevocations), access.LoadStudent)
+	r.With(access.StudentSelf("studentId")).Get("/enrollments/students/{studentId}", okHandler)
+
+	userID := uuid.New()
+	studentID := uuid.New()
+
+	t.Run("Own Record", func(t *testing.T) {
+		mockStudentService.EXPECT().GetStudentByUserID(gomock.Any(), userID).Return(&domain.StudentWithDetails{
+			Student: domain.Student{StudentID: studentID, UserID: userID},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/enrollments/students/"+studentID.String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, userID, "student"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Other Student", func(t *testing.T) {
+		mockStudentService.EXPECT().GetStudentByUserID(gomock.Any(), userID).Return(&domain.StudentWithDetails{
+			Student: domain.Student{StudentID: studentID, UserID: userID},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/enrollments/students/"+uuid.New().String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, userID, "student"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin Not Restricted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/enrollments/students/"+uuid.New().String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, uuid.New(), "admin"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestAccessControl_CourseFaculty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jwtManager := newTestJWTManager(t)
+	mockAssignService := mocks.NewMockFacultyAssignmentService(ctrl)
+	access := NewAccessControl(nil, nil, nil, mockAssignService, nil)
+
+	r := chi.NewRouter()
+	r.Use(AuthMiddleware(jwtManager, noRevocations))
+	r.With(access.CourseFaculty("id", false)).Put("/courses/{id}", okHandler)
+	r.With(access.CourseFaculty("id", true)).Post("/courses/{id}/activate", okHandler)
+
+	userID := uuid.New()
+	courseID := uuid.New()
+	assignments := []*domain.FacultyCourseWithDetails{
+		{
+			FacultyCourse: domain.FacultyCourse{CourseID: courseID, IsPrimary: false},
+			Faculty:       domain.FacultyBasic{UserID: userID},
+		},
+	}
+
+	t.Run("Assigned Faculty", func(t *testing.T) {
+		mockAssignService.EXPECT().ListCourseFaculty(gomock.Any(), courseID).Return(assignments, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/courses/"+courseID.String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, userID, "faculty"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unassigned Faculty", func(t *testing.T) {
+		mockAssignService.EXPECT().ListCourseFaculty(gomock.Any(), courseID).Return(assignments, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/courses/"+courseID.String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, uuid.New(), "faculty"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Non-Primary Cannot Activate", func(t *testing.T) {
+		mockAssignService.EXPECT().ListCourseFaculty(gomock.Any(), courseID).Return(assignments, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/courses/"+courseID.String()+"/activate", nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, userID, "faculty"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAccessControl_EnrollmentFaculty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jwtManager := newTestJWTManager(t)
+	mockAssignService := mocks.NewMockFacultyAssignmentService(ctrl)
+	mockEnrollService := mocks.NewMockEnrollmentService(ctrl)
+	access := NewAccessControl(nil, nil, nil, mockAssignService, mockEnrollService)
+
+	r := chi.NewRouter()
+	r.Use(AuthMiddleware(jwtManager, noRevocations))
+	r.With(access.EnrollmentFaculty("id")).Get("/enrollments/{id}", okHandler)
+
+	userID := uuid.New()
+	enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), CourseID: uuid.New()}
+	assignments := []*domain.FacultyCourseWithDetails{
+		{
+			FacultyCourse: domain.FacultyCourse{CourseID: enrollment.CourseID},
+			Faculty:       domain.FacultyBasic{UserID: userID},
+		},
+	}
+
+	t.Run("Assigned Faculty", func(t *testing.T) {
+		mockEnrollService.EXPECT().GetEnrollment(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+		mockAssignService.EXPECT().ListCourseFaculty(gomock.Any(), enrollment.CourseID).Return(assignments, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/enrollments/"+enrollment.EnrollmentID.String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, userID, "faculty"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unassigned Faculty", func(t *testing.T) {
+		mockEnrollService.EXPECT().GetEnrollment(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+		mockAssignService.EXPECT().ListCourseFaculty(gomock.Any(), enrollment.CourseID).Return(assignments, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/enrollments/"+enrollment.EnrollmentID.String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, uuid.New(), "faculty"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/enrollments/"+enrollment.EnrollmentID.String(), nil)
+		req.Header.Set("Authorization", bearer(t, jwtManager, uuid.New(), "admin"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ProgramHandler struct {
+	service   domain.ProgramService
+	validator *validator.Validate
+}
+
+func NewProgramHandler(service domain.ProgramService) *ProgramHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &ProgramHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *ProgramHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	program := req.ToDomain()
+	if err := h.service.CreateProgram(r.Context(), program); err != nil {
+		switch err {
+		case domain.ErrDepartmentNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "department not found", err)
+		case domain.ErrProgramCodeExists:
+			ErrorResponse(w, http.StatusConflict, "program code already exists", err)
+		case domain.ErrGradingScaleNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "grading scale not found", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to create program", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "program created", dto.ProgramToResponse(program))
+}
+
+func (h *ProgramHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid program ID", err)
+		return
+	}
+
+	program, err := h.service.GetProgram(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrProgramNotFound {
+			ErrorResponse(w, http.StatusNotFound, "program not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get program", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "program retrieved", dto.ProgramWithDepartmentToResponse(program))
+}
+
+func (h *ProgramHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid program ID", err)
+		return
+	}
+
+	var req dto.UpdateProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	updates := req.ToUpdates()
+	if err := h.service.UpdateProgram(r.Context(), id, updates); err != nil {
+		switch err {
+		case domain.ErrProgramNotFound:
+			ErrorResponse(w, http.StatusNotFound, "program not found", err)
+		case domain.ErrGradingScaleNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "grading scale not found", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to update program", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "program updated", nil)
+}
+
+func (h *ProgramHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid program ID", err)
+		return
+	}
+
+	if err := h.service.DeleteProgram(r.Context(), id); err != nil {
+		if err == domain.ErrProgramNotFound {
+			ErrorResponse(w, http.StatusNotFound, "program not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to delete program", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "program deleted", nil)
+}
+
+func (h *ProgramHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filter domain.ProgramFilter
+	if deptIDStr := r.URL.Query().Get("department_id"); deptIDStr != "" {
+		if deptID, err := uuid.Parse(deptIDStr); err == nil {
+			filter.DepartmentID = &deptID
+		}
+	}
+	if degreeType := r.URL.Query().Get("degree_type"); degreeType != "" {
+		filter.DegreeType = &degreeType
+	}
+	if isActiveStr := r.URL.Query().Get("is_active"); isActiveStr != "" {
+		isActive := isActiveStr == "true"
+		filter.IsActive = &isActive
+	}
+
+	programs, total, err := h.service.ListPrograms(r.Context(), filter, page, limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list programs", err)
+		return
+	}
+
+	response := make([]*dto.ProgramResponse, 0, len(programs))
+	for _, program := range programs {
+		response = append(response, dto.ProgramWithDepartmentToResponse(program))
+	}
+
+	PaginatedResponse(w, http.StatusOK, "programs retrieved", response, page, limit, total)
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProgramHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProgramService(ctrl)
+	handler := NewProgramHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/programs", handler.Create)
+
+	t.Run("Success", func(t *testing.T) {
+		req := dto.CreateProgramRequest{
+			ProgramName:   "B.Tech Computer Science",
+			ProgramCode:   "BTCS",
+			DepartmentID:  uuid.New(),
+			DurationYears: 4,
+		}
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().CreateProgram(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx interface{}, program *domain.Program) error {
+			assert.Equal(t, req.ProgramCode, program.ProgramCode)
+			assert.Equal(t, req.DepartmentID, program.DepartmentID)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/programs", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Duplicate Code", func(t *testing.T) {
+		req := dto.CreateProgramRequest{
+			ProgramName:   "B.Tech Computer Science",
+			ProgramCode:   "BTCS",
+			DepartmentID:  uuid.New(),
+			DurationYears: 4,
+		}
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().CreateProgram(gomock.Any(), gomock.Any()).Return(domain.ErrProgramCodeExists)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/programs", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestProgramHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProgramService(ctrl)
+	handler := NewProgramHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/programs", handler.List)
+
+	t.Run("Filters", func(t *testing.T) {
+		deptID := uuid.New()
+		mockService.EXPECT().ListPrograms(gomock.Any(), gomock.Any(), 2, 10).DoAndReturn(
+			func(ctx interface{}, filter domain.ProgramFilter, page, limit int) ([]*domain.ProgramWithDepartment, int64, error) {
+				assert.Equal(t, deptID, *filter.DepartmentID)
+				assert.Equal(t, "Bachelor", *filter.DegreeType)
+				assert.True(t, *filter.IsActive)
+				return []*domain.ProgramWithDepartment{}, 0, nil
+			})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet,
+			"/programs?page=2&limit=10&degree_type=Bachelor&is_active=true&department_id="+deptID.String(), nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
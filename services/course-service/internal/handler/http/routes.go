@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/middleware"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -15,9 +17,18 @@ func SetupRoutes(
 	subjService domain.SubjectService,
 	semService domain.SemesterService,
 	courseService domain.CourseService,
+	facultyService domain.FacultyService,
+	studentService domain.StudentService,
 	facultyAssignService domain.FacultyAssignmentService,
 	enrollService domain.EnrollmentService,
 	calendarService domain.CalendarService,
+	scaleService domain.GradingScaleService,
+	gradebookService domain.GradebookService,
+	transcriptService domain.TranscriptService,
+	curriculumService domain.CurriculumService,
+	rolloverService domain.SemesterRolloverService,
+	jwtManager *utils.JWTManager,
+	revocations middleware.RevocationChecker,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
@@ -25,10 +36,12 @@ func SetupRoutes(
 	r.Use(chiMiddleware.Logger)
 	r.Use(chiMiddleware.Recoverer)
 	r.Use(chiMiddleware.RequestID)
+	r.Use(CorrelationMiddleware)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Requested-With"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Requested-With", middleware.CorrelationIDHeader},
+		ExposedHeaders:   []string{middleware.CorrelationIDHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -39,50 +52,191 @@ func SetupRoutes(
 		w.Write([]byte(`{"status":"healthy"}`))
 	})
 
-	// Unused services - acknowledge for linter
-	_ = progService
-	_ = subjService
-	_ = semService
-	_ = calendarService
+	// Transcripts are verified without authentication by whoever they were
+	// handed to
+	transcriptHandler := NewTranscriptHandler(transcriptService)
+	r.Get("/api/v1/transcripts/verify/{code}", transcriptHandler.Verify)
+
+	// API routes (authentication required)
+	access := NewAccessControl(studentService, facultyService, subjService, facultyAssignService, enrollService)
+	adminOnly := RoleMiddleware("admin")
 
-	// API routes
 	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtManager, revocations))
+		r.Use(access.LoadStudent)
+		r.Use(access.LoadFaculty)
+
 		// Department routes
 		deptHandler := NewDepartmentHandler(deptService)
 		r.Route("/departments", func(r chi.Router) {
 			r.Get("/", deptHandler.List)
-			r.Post("/", deptHandler.Create)
 			r.Get("/{id}", deptHandler.GetByID)
-			r.Put("/{id}", deptHandler.Update)
-			r.Delete("/{id}", deptHandler.Delete)
+			r.With(adminOnly).Post("/", deptHandler.Create)
+			r.With(adminOnly).Put("/{id}", deptHandler.Update)
+			r.With(adminOnly).Delete("/{id}", deptHandler.Delete)
+		})
+
+		// Program routes
+		progHandler := NewProgramHandler(progService)
+		curriculumHandler := NewCurriculumHandler(curriculumService)
+		r.Route("/programs", func(r chi.Router) {
+			r.Get("/", progHandler.List)
+			r.Get("/{id}", progHandler.GetByID)
+			r.Get("/{id}/curricula", curriculumHandler.List)
+			r.With(adminOnly).Post("/", progHandler.Create)
+			r.With(adminOnly).Put("/{id}", progHandler.Update)
+			r.With(adminOnly).Delete("/{id}", progHandler.Delete)
+			r.With(adminOnly).Post("/{id}/curricula", curriculumHandler.Create)
+		})
+
+		// Curriculum routes
+		r.Route("/curricula", func(r chi.Router) {
+			r.Get("/{id}", curriculumHandler.GetByID)
+			r.With(adminOnly).Put("/{id}", curriculumHandler.Update)
+			r.With(adminOnly).Delete("/{id}", curriculumHandler.Delete)
+		})
+
+		// Grading scale routes
+		scaleHandler := NewGradingScaleHandler(scaleService)
+		r.Route("/grading-scales", func(r chi.Router) {
+			r.Get("/", scaleHandler.List)
+			r.Get("/{id}", scaleHandler.GetByID)
+			r.With(adminOnly).Post("/", scaleHandler.Create)
+			r.With(adminOnly).Put("/{id}", scaleHandler.Update)
+			r.With(adminOnly).Delete("/{id}", scaleHandler.Delete)
+		})
+
+		// Subject routes
+		subjHandler := NewSubjectHandler(subjService)
+		r.Route("/subjects", func(r chi.Router) {
+			r.Get("/", subjHandler.List)
+			r.Get("/prerequisite-graph", subjHandler.GetPrerequisiteGraph)
+			r.Get("/{id}", subjHandler.GetByID)
+			r.Get("/{id}/prerequisite-tree", subjHandler.GetPrerequisiteTree)
+			r.With(adminOnly).Delete("/{id}", subjHandler.Delete)
+
+			// Faculty manage subjects of their own department
+			r.With(RoleMiddleware("admin", "faculty")).Post("/", subjHandler.Create)
+			ownDept := r.With(RoleMiddleware("admin", "faculty"), access.SubjectDepartment("id"))
+			ownDept.Put("/{id}", subjHandler.Update)
+			ownDept.Post("/{id}/prerequisites", subjHandler.AddPrerequisite)
+			ownDept.Delete("/{id}/prerequisites/{prerequisiteId}", subjHandler.RemovePrerequisite)
+			ownDept.Post("/{id}/corequisites", subjHandler.AddCorequisite)
+			ownDept.Delete("/{id}/corequisites/{corequisiteId}", subjHandler.RemoveCorequisite)
+		})
+
+		// Semester routes
+		semHandler := NewSemesterHandler(semService)
+		rolloverHandler := NewSemesterRolloverHandler(rolloverService)
+		r.Route("/semesters", func(r chi.Router) {
+			r.Get("/", semHandler.List)
+			r.Get("/current", semHandler.GetCurrent)
+			r.Get("/{id}", semHandler.GetByID)
+			r.With(adminOnly).Post("/", semHandler.Create)
+			r.With(adminOnly).Put("/{id}", semHandler.Update)
+			r.With(adminOnly).Delete("/{id}", semHandler.Delete)
+			r.With(adminOnly).Post("/{id}/set-current", semHandler.SetCurrent)
+			r.With(adminOnly).Get("/{id}/rollover", rolloverHandler.Get)
+			r.With(adminOnly).Post("/{id}/rollover", rolloverHandler.Start)
+		})
+
+		// Academic calendar routes
+		calendarHandler := NewCalendarHandler(calendarService)
+		r.Route("/calendar", func(r chi.Router) {
+			r.Get("/", calendarHandler.List)
+			r.Get("/{id}", calendarHandler.GetByID)
+			r.With(adminOnly).Post("/", calendarHandler.Create)
+			r.With(adminOnly).Put("/{id}", calendarHandler.Update)
+			r.With(adminOnly).Delete("/{id}", calendarHandler.Delete)
+		})
+
+		// Faculty profile routes
+		facultyHandler := NewFacultyHandler(facultyService)
+		r.Route("/faculty", func(r chi.Router) {
+			r.Get("/", facultyHandler.List)
+			r.Get("/{id}", facultyHandler.GetByID)
+			r.Get("/{id}/courses", facultyHandler.GetCourses)
+			r.With(adminOnly).Post("/", facultyHandler.Create)
+			r.With(RoleMiddleware("admin", "faculty"), access.FacultySelf("id")).Put("/{id}", facultyHandler.Update)
+			r.With(adminOnly).Delete("/{id}", facultyHandler.Delete)
 		})
 
+		// Student profile routes
+		studentHandler := NewStudentHandler(studentService)
+		r.Route("/students", func(r chi.Router) {
+			r.With(RoleMiddleware("admin", "faculty")).Get("/", studentHandler.List)
+			r.With(access.StudentSelf("id")).Get("/{id}", studentHandler.GetByID)
+			r.With(access.StudentSelf("id")).Get("/{id}/academic-record", studentHandler.GetAcademicRecord)
+			r.With(RoleMiddleware("admin", "student"), access.StudentSelf("id")).Get("/{id}/transcript", transcriptHandler.Get)
+			r.With(access.StudentSelf("id")).Get("/{id}/degree-audit", curriculumHandler.AuditDegree)
+			r.With(adminOnly).Post("/", studentHandler.Create)
+			r.With(RoleMiddleware("admin", "student"), access.StudentSelf("id")).Put("/{id}", studentHandler.Update)
+			r.With(adminOnly).Delete("/{id}", studentHandler.Delete)
+			r.With(adminOnly).Post("/{id}/promote", studentHandler.Promote)
+		})
+
+		// Faculty submit the grades of their courses and heads of department
+		// review them. The service checks who may act on which course.
+		gradebookHandler := NewGradebookHandler(gradebookService)
+		staff := RoleMiddleware("admin", "faculty")
+
 		// Course routes
 		courseHandler := NewCourseHandler(courseService, facultyAssignService, enrollService)
 		r.Route("/courses", func(r chi.Router) {
 			r.Get("/", courseHandler.List)
-			r.Post("/", courseHandler.Create)
 			r.Get("/{id}", courseHandler.GetByID)
-			r.Put("/{id}", courseHandler.Update)
-			r.Delete("/{id}", courseHandler.Delete)
-			r.Post("/{id}/activate", courseHandler.Activate)
-			r.Post("/{id}/deactivate", courseHandler.Deactivate)
-			r.Get("/{id}/students", courseHandler.GetStudents)
 			r.Get("/{id}/faculty", courseHandler.GetFaculty)
-			r.Post("/{id}/faculty", courseHandler.AssignFaculty)
-			r.Delete("/{id}/faculty/{facultyId}", courseHandler.RemoveFaculty)
-			r.Post("/{id}/enroll", courseHandler.EnrollStudent)
+
+			// Catalog management is admin only
+			r.With(adminOnly).Post("/", courseHandler.Create)
+			r.With(adminOnly).Delete("/{id}", courseHandler.Delete)
+			r.With(adminOnly).Post("/{id}/faculty", courseHandler.AssignFaculty)
+			r.With(adminOnly).Delete("/{id}/faculty/{facultyId}", courseHandler.RemoveFaculty)
+
+			// Faculty manage the courses they are assigned to
+			assigned := r.With(RoleMiddleware("admin", "faculty"), access.CourseFaculty("id", false))
+			assigned.Put("/{id}", courseHandler.Update)
+			assigned.Get("/{id}/students", courseHandler.GetStudents)
+
+			primary := r.With(RoleMiddleware("admin", "faculty"), access.CourseFaculty("id", true))
+			primary.Post("/{id}/activate", courseHandler.Activate)
+			primary.Post("/{id}/deactivate", courseHandler.Deactivate)
+
+			// Grading workflow
+			r.With(staff).Get("/{id}/gradebook", gradebookHandler.Get)
+			r.With(staff).Post("/{id}/gradebook", gradebookHandler.Submit)
+			r.With(staff).Post("/{id}/gradebook/approve", gradebookHandler.Approve)
+			r.With(staff).Post("/{id}/gradebook/return", gradebookHandler.Return)
+			r.With(staff).Get("/{id}/grade-amendments", gradebookHandler.ListAmendments)
+
+			// Students enroll themselves, admins enroll anyone
+			r.With(RoleMiddleware("admin", "student")).Post("/{id}/enroll", courseHandler.EnrollStudent)
 		})
 
 		// Enrollment routes
 		enrollHandler := NewEnrollmentHandler(enrollService)
 		r.Route("/enrollments", func(r chi.Router) {
-			r.Get("/{id}", enrollHandler.GetByID)
-			r.Put("/{id}", enrollHandler.Update)
-			r.Post("/courses/{courseId}/bulk", enrollHandler.BulkEnroll)
-			r.Delete("/courses/{courseId}/students/{studentId}", enrollHandler.Drop)
-			r.Get("/students/{studentId}", enrollHandler.GetStudentEnrollments)
-			r.Get("/courses/{courseId}/students/{studentId}/prerequisites", enrollHandler.CheckPrerequisites)
+			r.With(access.EnrollmentFaculty("id")).Get("/{id}", enrollHandler.GetByID)
+			r.With(RoleMiddleware("admin", "faculty"), access.EnrollmentFaculty("id")).Put("/{id}", enrollHandler.Update)
+			r.With(staff).Post("/{id}/grade-amendments", gradebookHandler.RequestAmendment)
+			r.With(adminOnly).Post("/courses/{courseId}/bulk", enrollHandler.BulkEnroll)
+			r.With(RoleMiddleware("admin", "student"), access.StudentSelf("studentId")).
+				Delete("/courses/{courseId}/students/{studentId}", enrollHandler.Drop)
+			r.With(access.StudentSelf("studentId")).Get("/students/{studentId}", enrollHandler.GetStudentEnrollments)
+			r.With(access.StudentSelf("studentId")).
+				Get("/courses/{courseId}/students/{studentId}/prerequisites", enrollHandler.CheckPrerequisites)
+
+			// Faculty waive requirements for students of the courses they teach
+			waivers := r.With(RoleMiddleware("admin", "faculty"), access.CourseFaculty("courseId", false))
+			waivers.Get("/courses/{courseId}/students/{studentId}/waivers", enrollHandler.ListWaivers)
+			waivers.Post("/courses/{courseId}/students/{studentId}/waivers", enrollHandler.GrantWaiver)
+		})
+
+		// Grade amendment reviews
+		r.Route("/grade-amendments", func(r chi.Router) {
+			r.Use(staff)
+			r.Post("/{id}/approve", gradebookHandler.ApproveAmendment)
+			r.Post("/{id}/reject", gradebookHandler.RejectAmendment)
 		})
 	})
 
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type SemesterHandler struct {
+	service   domain.SemesterService
+	validator *validator.Validate
+}
+
+func NewSemesterHandler(service domain.SemesterService) *SemesterHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &SemesterHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *SemesterHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSemesterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	semester := req.ToDomain()
+	if err := h.service.CreateSemester(r.Context(), semester); err != nil {
+		if err == domain.ErrSemesterCodeExists {
+			ErrorResponse(w, http.StatusConflict, "semester code already exists", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to create semester", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "semester created", dto.SemesterToResponse(semester))
+}
+
+func (h *SemesterHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid semester ID", err)
+		return
+	}
+
+	semester, err := h.service.GetSemester(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrSemesterNotFound {
+			ErrorResponse(w, http.StatusNotFound, "semester not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get semester", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "semester retrieved", dto.SemesterToResponse(semester))
+}
+
+func (h *SemesterHandler) GetCurrent(w http.ResponseWriter, r *http.Request) {
+	semester, err := h.service.GetCurrentSemester(r.Context())
+	if err != nil {
+		if err == domain.ErrNoCurrentSemester {
+			ErrorResponse(w, http.StatusNotFound, "no current semester is set", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get current semester", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "current semester retrieved", dto.CurrentSemesterToResponse(semester, time.Now()))
+}
+
+func (h *SemesterHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid semester ID", err)
+		return
+	}
+
+	var req dto.UpdateSemesterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	updates := req.ToUpdates()
+	if err := h.service.UpdateSemester(r.Context(), id, updates); err != nil {
+		if err == domain.ErrSemesterNotFound {
+			ErrorResponse(w, http.StatusNotFound, "semester not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to update semester", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "semester updated", nil)
+}
+
+func (h *SemesterHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid semester ID", err)
+		return
+	}
+
+	if err := h.service.DeleteSemester(r.Context(), id); err != nil {
+		if err == domain.ErrSemesterNotFound {
+			ErrorResponse(w, http.StatusNotFound, "semester not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to delete semester", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "semester deleted", nil)
+}
+
+func (h *SemesterHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filter domain.SemesterFilter
+	if yearStr := r.URL.Query().Get("academic_year"); yearStr != "" {
+		if year, err := strconv.Atoi(yearStr); err == nil {
+			filter.AcademicYear = &year
+		}
+	}
+	if isCurrentStr := r.URL.Query().Get("is_current"); isCurrentStr != "" {
+		isCurrent := isCurrentStr == "true"
+		filter.IsCurrent = &isCurrent
+	}
+
+	semesters, total, err := h.service.ListSemesters(r.Context(), filter, page, limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list semesters", err)
+		return
+	}
+
+	response := make([]*dto.SemesterResponse, 0, len(semesters))
+	for _, semester := range semesters {
+		response = append(response, dto.SemesterToResponse(semester))
+	}
+
+	PaginatedResponse(w, http.StatusOK, "semesters retrieved", response, page, limit, total)
+}
+
+func (h *SemesterHandler) SetCurrent(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid semester ID", err)
+		return
+	}
+
+	if err := h.service.SetCurrentSemester(r.Context(), id); err != nil {
+		if err == domain.ErrSemesterNotFound {
+			ErrorResponse(w, http.StatusNotFound, "semester not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to set current semester", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "current semester set", nil)
+}
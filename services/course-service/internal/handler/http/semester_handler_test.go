@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSemesterHandler_GetCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSemesterService(ctrl)
+	handler := NewSemesterHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/semesters/current", handler.GetCurrent)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().GetCurrentSemester(gomock.Any()).Return(&domain.Semester{
+			SemesterID:   uuid.New(),
+			SemesterName: "Fall 2024",
+			StartDate:    time.Now().AddDate(0, -1, 0),
+			EndDate:      time.Now().AddDate(0, 3, 0),
+			IsCurrent:    true,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet, "/semesters/current", nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("None Set", func(t *testing.T) {
+		mockService.EXPECT().GetCurrentSemester(gomock.Any()).Return(nil, domain.ErrNoCurrentSemester)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet, "/semesters/current", nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSemesterHandler_SetCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSemesterService(ctrl)
+	handler := NewSemesterHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/semesters/{id}/set-current", handler.SetCurrent)
+
+	t.Run("Success", func(t *testing.T) {
+		semesterID := uuid.New()
+		mockService.EXPECT().SetCurrentSemester(gomock.Any(), semesterID).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/semesters/"+semesterID.String()+"/set-current", nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		semesterID := uuid.New()
+		mockService.EXPECT().SetCurrentSemester(gomock.Any(), semesterID).Return(domain.ErrSemesterNotFound)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/semesters/"+semesterID.String()+"/set-current", nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type SemesterRolloverHandler struct {
+	service   domain.SemesterRolloverService
+	validator *validator.Validate
+}
+
+func NewSemesterRolloverHandler(service domain.SemesterRolloverService) *SemesterRolloverHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &SemesterRolloverHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+// Start queues the rollover of a semester, or with ?dry_run=true reports what
+// the rollover would do
+func (h *SemesterRolloverHandler) Start(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	semesterID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid semester ID", err)
+		return
+	}
+
+	userID, ok := GetUserID(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	var req dto.RolloverSemesterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if r.URL.Query().Get("dry_run") == "true" {
+		preview, err := h.service.PreviewRollover(r.Context(), semesterID, req.NextSemesterID)
+		if err != nil {
+			rolloverError(w, err, "failed to preview semester rollover")
+			return
+		}
+		SuccessResponse(w, http.StatusOK, "semester rollover previewed", preview)
+		return
+	}
+
+	rollover, err := h.service.StartRollover(r.Context(), semesterID, req.NextSemesterID, userID)
+	if err != nil {
+		rolloverError(w, err, "failed to start semester rollover")
+		return
+	}
+
+	SuccessResponse(w, http.StatusAccepted, "semester rollover started", rollover)
+}
+
+func (h *SemesterRolloverHandler) Get(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	semesterID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid semester ID", err)
+		return
+	}
+
+	rollover, err := h.service.GetRollover(r.Context(), semesterID)
+	if err != nil {
+		rolloverError(w, err, "failed to get semester rollover")
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "semester rollover retrieved", rollover)
+}
+
+// rolloverError writes the response of a failed semester rollover request
+func rolloverError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRollover):
+		ErrorResponse(w, http.StatusBadRequest, "invalid semester rollover", err)
+		return
+	case errors.Is(err, domain.ErrUngradedEnrollments):
+		ErrorResponse(w, http.StatusConflict, "students are still enrolled without a grade", err)
+		return
+	}
+	switch err {
+	case domain.ErrSemesterNotFound:
+		ErrorResponse(w, http.StatusNotFound, "semester not found", err)
+	case domain.ErrRolloverNotFound:
+		ErrorResponse(w, http.StatusNotFound, "semester has not been rolled over", err)
+	case domain.ErrRolloverInProgress:
+		ErrorResponse(w, http.StatusConflict, "a rollover of this semester is already in progress", err)
+	case domain.ErrSemesterRolledOver:
+		ErrorResponse(w, http.StatusConflict, "semester has already been rolled over", err)
+	default:
+		ErrorResponse(w, http.StatusInternalServerError, message, err)
+	}
+}
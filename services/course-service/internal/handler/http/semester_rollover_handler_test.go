@@ -0,0 +1,110 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSemesterRolloverHandler_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSemesterRolloverService(ctrl)
+	handler := NewSemesterRolloverHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/semesters/{id}/rollover", handler.Start)
+
+	adminID := uuid.New()
+	newRequest := func(target string, nextSemesterID uuid.UUID) *http.Request {
+		body, _ := json.Marshal(dto.RolloverSemesterRequest{NextSemesterID: nextSemesterID})
+		req := httptest.NewRequest(http.MethodPost, target, bytes.NewBuffer(body))
+		return req.WithContext(context.WithValue(req.Context(), "user_id", adminID))
+	}
+
+	t.Run("Started", func(t *testing.T) {
+		semesterID, nextID := uuid.New(), uuid.New()
+		mockService.EXPECT().StartRollover(gomock.Any(), semesterID, nextID, adminID).
+			Return(&domain.SemesterRollover{RolloverID: uuid.New(), Status: domain.RolloverPending}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newRequest("/semesters/"+semesterID.String()+"/rollover", nextID))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		semesterID, nextID := uuid.New(), uuid.New()
+		mockService.EXPECT().PreviewRollover(gomock.Any(), semesterID, nextID).
+			Return(&domain.RolloverPreview{SemesterID: semesterID, Ready: true}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newRequest("/semesters/"+semesterID.String()+"/rollover?dry_run=true", nextID))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"ready":true`)
+	})
+
+	t.Run("Missing Next Semester", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newRequest("/semesters/"+uuid.New().String()+"/rollover", uuid.Nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Ungraded Enrollments", func(t *testing.T) {
+		mockService.EXPECT().StartRollover(gomock.Any(), gomock.Any(), gomock.Any(), adminID).
+			Return(nil, fmt.Errorf("%w: 3 students in 2 courses", domain.ErrUngradedEnrollments))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newRequest("/semesters/"+uuid.New().String()+"/rollover", uuid.New()))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Not The Current Semester", func(t *testing.T) {
+		mockService.EXPECT().StartRollover(gomock.Any(), gomock.Any(), gomock.Any(), adminID).
+			Return(nil, fmt.Errorf("%w: only the current semester can be rolled over", domain.ErrInvalidRollover))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newRequest("/semesters/"+uuid.New().String()+"/rollover", uuid.New()))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSemesterRolloverHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSemesterRolloverService(ctrl)
+	handler := NewSemesterRolloverHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/semesters/{id}/rollover", handler.Get)
+
+	t.Run("Not Rolled Over", func(t *testing.T) {
+		semesterID := uuid.New()
+		mockService.EXPECT().GetRollover(gomock.Any(), semesterID).Return(nil, domain.ErrRolloverNotFound)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/semesters/"+semesterID.String()+"/rollover", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
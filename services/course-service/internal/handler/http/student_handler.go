@@ -0,0 +1,242 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type StudentHandler struct {
+	service   domain.StudentService
+	validator *validator.Validate
+}
+
+func NewStudentHandler(service domain.StudentService) *StudentHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &StudentHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *StudentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateStudentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	student := req.ToDomain()
+	if err := h.service.CreateStudent(r.Context(), student); err != nil {
+		switch err {
+		case domain.ErrDepartmentNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "department not found", err)
+		case domain.ErrProgramNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "program not found", err)
+		case domain.ErrRegistrationNumberExists:
+			ErrorResponse(w, http.StatusConflict, "registration number already exists", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to create student", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "student created", dto.StudentToResponse(student))
+}
+
+func (h *StudentHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	student, err := h.service.GetStudent(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrStudentNotFound {
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get student", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "student retrieved", dto.StudentWithDetailsToResponse(student))
+}
+
+func (h *StudentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	var req dto.UpdateStudentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	// Students may only update the limited, non-administrative fields
+	if role, _ := GetRoleName(r); role != "admin" && req.IsActive != nil {
+		ErrorResponse(w, http.StatusForbidden, "only admins may change the active status", domain.ErrForbidden)
+		return
+	}
+
+	updates := req.ToUpdates()
+	if err := h.service.UpdateStudent(r.Context(), id, updates); err != nil {
+		if err == domain.ErrStudentNotFound {
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to update student", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "student updated", nil)
+}
+
+func (h *StudentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	if err := h.service.DeleteStudent(r.Context(), id); err != nil {
+		if err == domain.ErrStudentNotFound {
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to delete student", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "student deleted", nil)
+}
+
+func (h *StudentHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filter domain.StudentFilter
+	if deptIDStr := r.URL.Query().Get("department_id"); deptIDStr != "" {
+		if deptID, err := uuid.Parse(deptIDStr); err == nil {
+			filter.DepartmentID = &deptID
+		}
+	}
+	if progIDStr := r.URL.Query().Get("program_id"); progIDStr != "" {
+		if progID, err := uuid.Parse(progIDStr); err == nil {
+			filter.ProgramID = &progID
+		}
+	}
+	if semStr := r.URL.Query().Get("current_semester"); semStr != "" {
+		if sem, err := strconv.Atoi(semStr); err == nil {
+			filter.CurrentSemester = &sem
+		}
+	}
+	if batchStr := r.URL.Query().Get("batch_year"); batchStr != "" {
+		if batch, err := strconv.Atoi(batchStr); err == nil {
+			filter.BatchYear = &batch
+		}
+	}
+	if search := r.URL.Query().Get("search"); search != "" {
+		filter.Search = &search
+	}
+	if isActiveStr := r.URL.Query().Get("is_active"); isActiveStr != "" {
+		isActive := isActiveStr == "true"
+		filter.IsActive = &isActive
+	}
+
+	students, total, err := h.service.ListStudents(r.Context(), filter, page, limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list students", err)
+		return
+	}
+
+	response := make([]*dto.StudentResponse, 0, len(students))
+	for _, s := range students {
+		resp := dto.ToStudentResponse(s)
+		response = append(response, &resp)
+	}
+
+	PaginatedResponse(w, http.StatusOK, "students retrieved", response, page, limit, total)
+}
+
+func (h *StudentHandler) Promote(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	var req dto.PromoteStudentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if err := h.service.PromoteStudent(r.Context(), id, req.NewSemester); err != nil {
+		if err == domain.ErrStudentNotFound {
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to promote student", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "student promoted", nil)
+}
+
+func (h *StudentHandler) GetAcademicRecord(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	record, err := h.service.GetAcademicRecord(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrStudentNotFound {
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get academic record", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "academic record retrieved", record)
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStudentHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	handler := NewStudentHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/students/{id}", handler.Update)
+
+	t.Run("Student Limited Fields", func(t *testing.T) {
+		studentID := uuid.New()
+		rollNumber := "22CS01"
+		body, _ := json.Marshal(dto.UpdateStudentRequest{RollNumber: &rollNumber})
+
+		mockService.EXPECT().UpdateStudent(gomock.Any(), studentID, map[string]interface{}{"roll_number": rollNumber}).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPut, "/students/"+studentID.String(), bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "role_name", "student")
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Student Cannot Deactivate", func(t *testing.T) {
+		isActive := false
+		body, _ := json.Marshal(dto.UpdateStudentRequest{IsActive: &isActive})
+
+		// Service should NOT be called
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPut, "/students/"+uuid.New().String(), bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "role_name", "student")
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestStudentHandler_Promote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	handler := NewStudentHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/students/{id}/promote", handler.Promote)
+
+	t.Run("Success", func(t *testing.T) {
+		studentID := uuid.New()
+		body, _ := json.Marshal(dto.PromoteStudentRequest{NewSemester: 6})
+
+		mockService.EXPECT().PromoteStudent(gomock.Any(), studentID, 6).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/students/"+studentID.String()+"/promote", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		studentID := uuid.New()
+		body, _ := json.Marshal(dto.PromoteStudentRequest{NewSemester: 2})
+
+		mockService.EXPECT().PromoteStudent(gomock.Any(), studentID, 2).Return(domain.ErrStudentNotFound)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/students/"+studentID.String()+"/promote", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestStudentHandler_GetAcademicRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStudentService(ctrl)
+	handler := NewStudentHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/students/{id}/academic-record", handler.GetAcademicRecord)
+
+	t.Run("Success", func(t *testing.T) {
+		studentID := uuid.New()
+		cgpa := 8.25
+		record := &domain.AcademicRecord{StudentID: studentID, CGPA: &cgpa, TotalCreditsEarned: 40}
+
+		mockService.EXPECT().GetAcademicRecord(gomock.Any(), studentID).Return(record, nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/academic-record", nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"cgpa":8.25`)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		studentID := uuid.New()
+
+		mockService.EXPECT().GetAcademicRecord(gomock.Any(), studentID).Return(nil, domain.ErrStudentNotFound)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/academic-record", nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,377 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type SubjectHandler struct {
+	service   domain.SubjectService
+	validator *validator.Validate
+}
+
+func NewSubjectHandler(service domain.SubjectService) *SubjectHandler {
+	v := validator.New()
+	v.SetTagName("binding")
+	return &SubjectHandler{
+		service:   service,
+		validator: v,
+	}
+}
+
+func (h *SubjectHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	// Faculty may only create subjects in their own department
+	if deptID, ok := GetFacultyDepartmentID(r); ok && req.DepartmentID != deptID {
+		ErrorResponse(w, http.StatusForbidden, "faculty may only manage subjects of their own department", domain.ErrForbidden)
+		return
+	}
+
+	subject := req.ToDomain()
+	if err := h.service.CreateSubject(r.Context(), subject, req.ToPrerequisites(), req.Corequisites); err != nil {
+		switch err {
+		case domain.ErrDepartmentNotFound:
+			ErrorResponse(w, http.StatusBadRequest, "department not found", err)
+		case domain.ErrSubjectCodeExists:
+			ErrorResponse(w, http.StatusConflict, "subject code already exists", err)
+		case domain.ErrInvalidMinimumGrade:
+			ErrorResponse(w, http.StatusBadRequest, "invalid minimum grade", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to create subject", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "subject created", dto.SubjectToResponse(subject))
+}
+
+func (h *SubjectHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	subject, err := h.service.GetSubject(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrSubjectNotFound {
+			ErrorResponse(w, http.StatusNotFound, "subject not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get subject", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "subject retrieved", dto.SubjectWithDetailsToResponse(subject))
+}
+
+func (h *SubjectHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	var req dto.UpdateSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	updates := req.ToUpdates()
+	if err := h.service.UpdateSubject(r.Context(), id, updates); err != nil {
+		if err == domain.ErrSubjectNotFound {
+			ErrorResponse(w, http.StatusNotFound, "subject not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to update subject", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "subject updated", nil)
+}
+
+func (h *SubjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	if err := h.service.DeleteSubject(r.Context(), id); err != nil {
+		if err == domain.ErrSubjectNotFound {
+			ErrorResponse(w, http.StatusNotFound, "subject not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to delete subject", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "subject deleted", nil)
+}
+
+func (h *SubjectHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filter domain.SubjectFilter
+	if deptIDStr := r.URL.Query().Get("department_id"); deptIDStr != "" {
+		if deptID, err := uuid.Parse(deptIDStr); err == nil {
+			filter.DepartmentID = &deptID
+		}
+	}
+	if subjectType := r.URL.Query().Get("subject_type"); subjectType != "" {
+		filter.SubjectType = &subjectType
+	}
+	if creditsStr := r.URL.Query().Get("credits"); creditsStr != "" {
+		if credits, err := strconv.Atoi(creditsStr); err == nil {
+			filter.Credits = &credits
+		}
+	}
+	if search := r.URL.Query().Get("search"); search != "" {
+		filter.Search = &search
+	}
+	if isActiveStr := r.URL.Query().Get("is_active"); isActiveStr != "" {
+		isActive := isActiveStr == "true"
+		filter.IsActive = &isActive
+	}
+
+	subjects, total, err := h.service.ListSubjects(r.Context(), filter, page, limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to list subjects", err)
+		return
+	}
+
+	response := make([]*dto.SubjectResponse, 0, len(subjects))
+	for _, subject := range subjects {
+		response = append(response, dto.SubjectToResponse(subject))
+	}
+
+	PaginatedResponse(w, http.StatusOK, "subjects retrieved", response, page, limit, total)
+}
+
+func (h *SubjectHandler) AddPrerequisite(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	subjectID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	var req dto.PrerequisiteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if err := h.service.AddPrerequisite(r.Context(), req.ToDomain(subjectID)); err != nil {
+		if errors.Is(err, domain.ErrPrerequisiteCycle) {
+			ErrorResponse(w, http.StatusConflict, "prerequisite would create a cycle", err)
+			return
+		}
+		switch err {
+		case domain.ErrSubjectNotFound:
+			ErrorResponse(w, http.StatusNotFound, "subject not found", err)
+		case domain.ErrSelfPrerequisite:
+			ErrorResponse(w, http.StatusBadRequest, "subject cannot be its own prerequisite", err)
+		case domain.ErrInvalidMinimumGrade:
+			ErrorResponse(w, http.StatusBadRequest, "invalid minimum grade", err)
+		case domain.ErrPrerequisiteExists:
+			ErrorResponse(w, http.StatusConflict, "prerequisite already exists", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to add prerequisite", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "prerequisite added", nil)
+}
+
+func (h *SubjectHandler) GetPrerequisiteTree(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	subjectID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	tree, err := h.service.GetPrerequisiteTree(r.Context(), subjectID)
+	if err != nil {
+		if err == domain.ErrSubjectNotFound {
+			ErrorResponse(w, http.StatusNotFound, "subject not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get prerequisite tree", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "prerequisite tree retrieved", tree)
+}
+
+func (h *SubjectHandler) GetPrerequisiteGraph(w http.ResponseWriter, r *http.Request) {
+	var filter domain.PrerequisiteGraphFilter
+	if deptIDStr := r.URL.Query().Get("department_id"); deptIDStr != "" {
+		deptID, err := uuid.Parse(deptIDStr)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, "invalid department ID", err)
+			return
+		}
+		filter.DepartmentID = &deptID
+	}
+	if programIDStr := r.URL.Query().Get("program_id"); programIDStr != "" {
+		programID, err := uuid.Parse(programIDStr)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, "invalid program ID", err)
+			return
+		}
+		filter.ProgramID = &programID
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "dot" {
+		ErrorResponse(w, http.StatusBadRequest, "format must be json or dot", nil)
+		return
+	}
+
+	graph, err := h.service.GetPrerequisiteGraph(r.Context(), filter)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, "failed to get prerequisite graph", err)
+		return
+	}
+
+	if format == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(dto.PrerequisiteGraphToDOT(graph)))
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "prerequisite graph retrieved", graph)
+}
+
+func (h *SubjectHandler) RemovePrerequisite(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	subjectID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	prereqIDStr := chi.URLParam(r, "prerequisiteId")
+	prereqID, err := uuid.Parse(prereqIDStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid prerequisite ID", err)
+		return
+	}
+
+	if err := h.service.RemovePrerequisite(r.Context(), subjectID, prereqID); err != nil {
+		if err == domain.ErrPrerequisiteNotFound {
+			ErrorResponse(w, http.StatusNotFound, "prerequisite not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to remove prerequisite", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "prerequisite removed", nil)
+}
+
+func (h *SubjectHandler) AddCorequisite(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	subjectID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	var req dto.CorequisiteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "validation failed", err)
+		return
+	}
+
+	if err := h.service.AddCorequisite(r.Context(), subjectID, req.SubjectID); err != nil {
+		switch err {
+		case domain.ErrSubjectNotFound:
+			ErrorResponse(w, http.StatusNotFound, "subject not found", err)
+		case domain.ErrSelfCorequisite:
+			ErrorResponse(w, http.StatusBadRequest, "subject cannot be its own corequisite", err)
+		case domain.ErrCorequisiteExists:
+			ErrorResponse(w, http.StatusConflict, "corequisite already exists", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to add corequisite", err)
+		}
+		return
+	}
+
+	SuccessResponse(w, http.StatusCreated, "corequisite added", nil)
+}
+
+func (h *SubjectHandler) RemoveCorequisite(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	subjectID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid subject ID", err)
+		return
+	}
+
+	coreqIDStr := chi.URLParam(r, "corequisiteId")
+	coreqID, err := uuid.Parse(coreqIDStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid corequisite ID", err)
+		return
+	}
+
+	if err := h.service.RemoveCorequisite(r.Context(), subjectID, coreqID); err != nil {
+		if err == domain.ErrCorequisiteNotFound {
+			ErrorResponse(w, http.StatusNotFound, "corequisite not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to remove corequisite", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "corequisite removed", nil)
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubjectHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSubjectService(ctrl)
+	handler := NewSubjectHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/subjects", handler.Create)
+
+	deptID := uuid.New()
+	prereqID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		req := dto.CreateSubjectRequest{
+			SubjectName:  "Data Structures",
+			SubjectCode:  "CS201",
+			DepartmentID: deptID,
+			Credits:      4,
+			Prerequisites: []dto.PrerequisiteRequest{
+				{SubjectID: prereqID, IsMandatory: true},
+			},
+		}
+		body, _ := json.Marshal(req)
+
+		mockService.EXPECT().CreateSubject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, subject *domain.Subject, prereqs []domain.SubjectPrerequisite, coreqs []uuid.UUID) error {
+				assert.Equal(t, req.SubjectCode, subject.SubjectCode)
+				assert.Len(t, prereqs, 1)
+				assert.Equal(t, prereqID, prereqs[0].PrerequisiteSubjectID)
+				assert.True(t, prereqs[0].IsMandatory)
+				return nil
+			})
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/subjects", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Faculty Other Department", func(t *testing.T) {
+		req := dto.CreateSubjectRequest{
+			SubjectName:  "Data Structures",
+			SubjectCode:  "CS201",
+			DepartmentID: deptID,
+			Credits:      4,
+		}
+		body, _ := json.Marshal(req)
+
+		// Service should NOT be called
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/subjects", bytes.NewBuffer(body))
+		ctx := context.WithValue(reqHttp.Context(), "faculty_department_id", uuid.New())
+		r.ServeHTTP(w, reqHttp.WithContext(ctx))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestSubjectHandler_AddPrerequisite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSubjectService(ctrl)
+	handler := NewSubjectHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/subjects/{id}/prerequisites", handler.AddPrerequisite)
+
+	t.Run("Success", func(t *testing.T) {
+		subjectID := uuid.New()
+		prereqID := uuid.New()
+		body, _ := json.Marshal(dto.PrerequisiteRequest{SubjectID: prereqID, IsMandatory: true})
+
+		mockService.EXPECT().AddPrerequisite(gomock.Any(), &domain.SubjectPrerequisite{
+			SubjectID:             subjectID,
+			PrerequisiteSubjectID: prereqID,
+			IsMandatory:           true,
+		}).Return(nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/subjects/"+subjectID.String()+"/prerequisites", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		subjectID := uuid.New()
+		prereqID := uuid.New()
+		body, _ := json.Marshal(dto.PrerequisiteRequest{SubjectID: prereqID, IsMandatory: true})
+
+		mockService.EXPECT().AddPrerequisite(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: CS101 -> CS201 -> CS101", domain.ErrPrerequisiteCycle))
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/subjects/"+subjectID.String()+"/prerequisites", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "prerequisite would create a cycle: CS101")
+	})
+
+	t.Run("Self Prerequisite", func(t *testing.T) {
+		subjectID := uuid.New()
+		body, _ := json.Marshal(dto.PrerequisiteRequest{SubjectID: subjectID})
+
+		mockService.EXPECT().AddPrerequisite(gomock.Any(), &domain.SubjectPrerequisite{
+			SubjectID:             subjectID,
+			PrerequisiteSubjectID: subjectID,
+		}).Return(domain.ErrSelfPrerequisite)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodPost, "/subjects/"+subjectID.String()+"/prerequisites", bytes.NewBuffer(body))
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSubjectHandler_GetPrerequisiteGraph(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSubjectService(ctrl)
+	handler := NewSubjectHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/subjects/prerequisite-graph", handler.GetPrerequisiteGraph)
+
+	deptID := uuid.New()
+	cs101 := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "CS101", SubjectName: "Intro to \"Programming\""}
+	cs201 := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "CS201", SubjectName: "Data Structures"}
+	graph := &domain.PrerequisiteGraph{
+		Subjects: []domain.SubjectBasic{cs101, cs201},
+		Edges:    []domain.SubjectPrerequisite{{SubjectID: cs201.SubjectID, PrerequisiteSubjectID: cs101.SubjectID}},
+	}
+
+	t.Run("DOT", func(t *testing.T) {
+		mockService.EXPECT().GetPrerequisiteGraph(gomock.Any(), domain.PrerequisiteGraphFilter{DepartmentID: &deptID}).Return(graph, nil)
+
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet, "/subjects/prerequisite-graph?format=dot&department_id="+deptID.String(), nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/vnd.graphviz")
+		assert.Contains(t, w.Body.String(), `label="CS101\nIntro to \"Programming\""`)
+		assert.Contains(t, w.Body.String(), `"`+cs101.SubjectID.String()+`" -> "`+cs201.SubjectID.String()+`" [style=dashed];`)
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reqHttp := httptest.NewRequest(http.MethodGet, "/subjects/prerequisite-graph?format=svg", nil)
+		r.ServeHTTP(w, reqHttp)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
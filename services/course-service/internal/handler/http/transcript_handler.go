@@ -0,0 +1,80 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type TranscriptHandler struct {
+	service domain.TranscriptService
+}
+
+func NewTranscriptHandler(service domain.TranscriptService) *TranscriptHandler {
+	return &TranscriptHandler{service: service}
+}
+
+func (h *TranscriptHandler) Get(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	studentID, err := uuid.Parse(idStr)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, "invalid student ID", err)
+		return
+	}
+
+	userID, ok := GetUserID(r)
+	if !ok {
+		ErrorResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "pdf" {
+		ErrorResponse(w, http.StatusBadRequest, "format must be json or pdf", nil)
+		return
+	}
+
+	transcript, err := h.service.IssueTranscript(r.Context(), studentID, userID)
+	if err != nil {
+		switch err {
+		case domain.ErrStudentNotFound:
+			ErrorResponse(w, http.StatusNotFound, "student not found", err)
+		case domain.ErrGradingScaleNotFound:
+			ErrorResponse(w, http.StatusNotFound, "grading scale not found", err)
+		default:
+			ErrorResponse(w, http.StatusInternalServerError, "failed to generate transcript", err)
+		}
+		return
+	}
+
+	if format == "pdf" {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"transcript-%s.pdf\"", transcript.RegistrationNumber))
+		w.WriteHeader(http.StatusOK)
+		w.Write(dto.TranscriptToPDF(transcript))
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "transcript generated", transcript)
+}
+
+// Verify is public, so that anyone handed a transcript can check it
+func (h *TranscriptHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	verification, err := h.service.VerifyTranscript(r.Context(), code)
+	if err != nil {
+		if err == domain.ErrTranscriptNotFound {
+			ErrorResponse(w, http.StatusNotFound, "transcript not found", err)
+			return
+		}
+		ErrorResponse(w, http.StatusInternalServerError, "failed to verify transcript", err)
+		return
+	}
+
+	SuccessResponse(w, http.StatusOK, "transcript verified", verification)
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTranscriptHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTranscriptService(ctrl)
+	handler := NewTranscriptHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/students/{id}/transcript", handler.Get)
+
+	userID := uuid.New()
+	withUser := func(req *http.Request) *http.Request {
+		ctx := context.WithValue(req.Context(), "user_id", userID)
+		ctx = context.WithValue(ctx, "role_name", "student")
+		return req.WithContext(ctx)
+	}
+	transcript := func(studentID uuid.UUID) *domain.Transcript {
+		gpa := 8.5
+		return &domain.Transcript{
+			TranscriptRecord: domain.TranscriptRecord{
+				StudentID:          studentID,
+				RegistrationNumber: "REG001",
+				Standing:           domain.StandingGood,
+				Semesters: []domain.TranscriptSemester{{
+					SemesterCode: "2025-ODD",
+					SemesterName: "Odd Semester (2025)",
+					Courses:      []domain.TranscriptCourse{{SubjectCode: "CS101", SubjectName: "Programming", Credits: 4, Grade: "A", GradePoints: 8.5, Passed: true}},
+					GPA:          &gpa,
+				}},
+				CGPA: &gpa,
+			},
+			VerificationCode: "ABCD-EFGH-IJKL-MNOP",
+			IssuedAt:         time.Now(),
+		}
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		studentID := uuid.New()
+		mockService.EXPECT().IssueTranscript(gomock.Any(), studentID, userID).Return(transcript(studentID), nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/transcript", nil)
+		r.ServeHTTP(w, withUser(req))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "ABCD-EFGH-IJKL-MNOP")
+	})
+
+	t.Run("PDF", func(t *testing.T) {
+		studentID := uuid.New()
+		mockService.EXPECT().IssueTranscript(gomock.Any(), studentID, userID).Return(transcript(studentID), nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/transcript?format=pdf", nil)
+		r.ServeHTTP(w, withUser(req))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")))
+		assert.Contains(t, w.Body.String(), "ABCD-EFGH-IJKL-MNOP")
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		studentID := uuid.New()
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/transcript?format=docx", nil)
+		r.ServeHTTP(w, withUser(req))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Student Not Found", func(t *testing.T) {
+		studentID := uuid.New()
+		mockService.EXPECT().IssueTranscript(gomock.Any(), studentID, userID).Return(nil, domain.ErrStudentNotFound)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/transcript", nil)
+		r.ServeHTTP(w, withUser(req))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestTranscriptHandler_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTranscriptService(ctrl)
+	handler := NewTranscriptHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/transcripts/verify/{code}", handler.Verify)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().VerifyTranscript(gomock.Any(), "ABCD-EFGH-IJKL-MNOP").
+			Return(&domain.TranscriptVerification{VerificationCode: "ABCD-EFGH-IJKL-MNOP", Valid: true}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/transcripts/verify/ABCD-EFGH-IJKL-MNOP", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.EXPECT().VerifyTranscript(gomock.Any(), "ZZZZ-ZZZZ-ZZZZ-ZZZZ").Return(nil, domain.ErrTranscriptNotFound)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/transcripts/verify/ZZZZ-ZZZZ-ZZZZ-ZZZZ", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
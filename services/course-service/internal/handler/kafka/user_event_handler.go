@@ -0,0 +1,31 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+)
+
+// UserEventTopic is the topic the User Service publishes user events to
+const UserEventTopic = "user.events"
+
+type UserEventHandler struct {
+	service domain.UserDirectoryService
+}
+
+func NewUserEventHandler(service domain.UserDirectoryService) *UserEventHandler {
+	return &UserEventHandler{service: service}
+}
+
+// Handle decodes a message from user.events and applies it to the user
+// directory. Changes made while handling it are caused by the event.
+func (h *UserEventHandler) Handle(ctx context.Context, message []byte) error {
+	var event models.UserEvent
+	if err := kafka.UnmarshalEvent(message, &event); err != nil {
+		return err
+	}
+
+	return h.service.HandleUserEvent(models.WithCause(ctx, &event.BaseEvent), &event)
+}
@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserEventHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockUserDirectoryService(ctrl)
+	handler := NewUserEventHandler(mockService)
+
+	t.Run("Success", func(t *testing.T) {
+		event := models.NewUserEvent(models.EventUserSuspended, uuid.New(), "jane@example.com")
+		event.Status = "suspended"
+		message, err := kafka.EncodeEvent(context.Background(), event)
+		assert.NoError(t, err)
+
+		mockService.EXPECT().HandleUserEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *models.UserEvent) error {
+			assert.Equal(t, event.UserID, e.UserID)
+			assert.Equal(t, models.EventUserSuspended, e.EventType)
+			assert.Equal(t, event.EventID.String(), models.EventContextFrom(ctx).CausationID)
+			return nil
+		})
+
+		err = handler.Handle(context.Background(), message)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid Event", func(t *testing.T) {
+		message, _ := json.Marshal(map[string]interface{}{"event_type": "USER_CREATED", "email": "jane@example.com"})
+
+		err := handler.Handle(context.Background(), message)
+		assert.True(t, kafka.IsPermanent(err))
+	})
+}
@@ -12,12 +12,51 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
+
 // MockDepartmentRepository is a mock of DepartmentRepository interface.
 type MockDepartmentRepository struct {
 	ctrl     *gomock.Controller
@@ -272,6 +311,228 @@ func (mr *MockProgramRepositoryMockRecorder) Update(ctx, program any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProgramRepository)(nil).Update), ctx, program)
 }
 
+// MockGradingScaleRepository is a mock of GradingScaleRepository interface.
+type MockGradingScaleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGradingScaleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGradingScaleRepositoryMockRecorder is the mock recorder for MockGradingScaleRepository.
+type MockGradingScaleRepositoryMockRecorder struct {
+	mock *MockGradingScaleRepository
+}
+
+// NewMockGradingScaleRepository creates a new mock instance.
+func NewMockGradingScaleRepository(ctrl *gomock.Controller) *MockGradingScaleRepository {
+	mock := &MockGradingScaleRepository{ctrl: ctrl}
+	mock.recorder = &MockGradingScaleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGradingScaleRepository) EXPECT() *MockGradingScaleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGradingScaleRepository) Create(ctx context.Context, scale *domain.GradingScale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, scale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGradingScaleRepositoryMockRecorder) Create(ctx, scale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGradingScaleRepository)(nil).Create), ctx, scale)
+}
+
+// Delete mocks base method.
+func (m *MockGradingScaleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGradingScaleRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGradingScaleRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockGradingScaleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GradingScale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.GradingScale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGradingScaleRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGradingScaleRepository)(nil).GetByID), ctx, id)
+}
+
+// GetForStudent mocks base method.
+func (m *MockGradingScaleRepository) GetForStudent(ctx context.Context, studentID uuid.UUID) (*domain.GradingScale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForStudent", ctx, studentID)
+	ret0, _ := ret[0].(*domain.GradingScale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForStudent indicates an expected call of GetForStudent.
+func (mr *MockGradingScaleRepositoryMockRecorder) GetForStudent(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForStudent", reflect.TypeOf((*MockGradingScaleRepository)(nil).GetForStudent), ctx, studentID)
+}
+
+// List mocks base method.
+func (m *MockGradingScaleRepository) List(ctx context.Context) ([]*domain.GradingScale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.GradingScale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockGradingScaleRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGradingScaleRepository)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockGradingScaleRepository) Update(ctx context.Context, scale *domain.GradingScale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, scale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGradingScaleRepositoryMockRecorder) Update(ctx, scale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGradingScaleRepository)(nil).Update), ctx, scale)
+}
+
+// MockCurriculumRepository is a mock of CurriculumRepository interface.
+type MockCurriculumRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurriculumRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCurriculumRepositoryMockRecorder is the mock recorder for MockCurriculumRepository.
+type MockCurriculumRepositoryMockRecorder struct {
+	mock *MockCurriculumRepository
+}
+
+// NewMockCurriculumRepository creates a new mock instance.
+func NewMockCurriculumRepository(ctrl *gomock.Controller) *MockCurriculumRepository {
+	mock := &MockCurriculumRepository{ctrl: ctrl}
+	mock.recorder = &MockCurriculumRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurriculumRepository) EXPECT() *MockCurriculumRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCurriculumRepository) Create(ctx context.Context, curriculum *domain.Curriculum) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, curriculum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCurriculumRepositoryMockRecorder) Create(ctx, curriculum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCurriculumRepository)(nil).Create), ctx, curriculum)
+}
+
+// Delete mocks base method.
+func (m *MockCurriculumRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCurriculumRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCurriculumRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockCurriculumRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Curriculum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Curriculum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCurriculumRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCurriculumRepository)(nil).GetByID), ctx, id)
+}
+
+// GetForBatch mocks base method.
+func (m *MockCurriculumRepository) GetForBatch(ctx context.Context, programID uuid.UUID, batchYear int) (*domain.Curriculum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForBatch", ctx, programID, batchYear)
+	ret0, _ := ret[0].(*domain.Curriculum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForBatch indicates an expected call of GetForBatch.
+func (mr *MockCurriculumRepositoryMockRecorder) GetForBatch(ctx, programID, batchYear any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForBatch", reflect.TypeOf((*MockCurriculumRepository)(nil).GetForBatch), ctx, programID, batchYear)
+}
+
+// ListByProgram mocks base method.
+func (m *MockCurriculumRepository) ListByProgram(ctx context.Context, programID uuid.UUID) ([]*domain.Curriculum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProgram", ctx, programID)
+	ret0, _ := ret[0].([]*domain.Curriculum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProgram indicates an expected call of ListByProgram.
+func (mr *MockCurriculumRepositoryMockRecorder) ListByProgram(ctx, programID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProgram", reflect.TypeOf((*MockCurriculumRepository)(nil).ListByProgram), ctx, programID)
+}
+
+// Update mocks base method.
+func (m *MockCurriculumRepository) Update(ctx context.Context, curriculum *domain.Curriculum) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, curriculum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCurriculumRepositoryMockRecorder) Update(ctx, curriculum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCurriculumRepository)(nil).Update), ctx, curriculum)
+}
+
 // MockSubjectRepository is a mock of SubjectRepository interface.
 type MockSubjectRepository struct {
 	ctrl     *gomock.Controller
@@ -311,17 +572,17 @@ func (mr *MockSubjectRepositoryMockRecorder) AddCorequisite(ctx, subjectID, core
 }
 
 // AddPrerequisite mocks base method.
-func (m *MockSubjectRepository) AddPrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID, isMandatory bool) error {
+func (m *MockSubjectRepository) AddPrerequisite(ctx context.Context, prerequisite *domain.SubjectPrerequisite) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPrerequisite", ctx, subjectID, prerequisiteID, isMandatory)
+	ret := m.ctrl.Call(m, "AddPrerequisite", ctx, prerequisite)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddPrerequisite indicates an expected call of AddPrerequisite.
-func (mr *MockSubjectRepositoryMockRecorder) AddPrerequisite(ctx, subjectID, prerequisiteID, isMandatory any) *gomock.Call {
+func (mr *MockSubjectRepositoryMockRecorder) AddPrerequisite(ctx, prerequisite any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrerequisite", reflect.TypeOf((*MockSubjectRepository)(nil).AddPrerequisite), ctx, subjectID, prerequisiteID, isMandatory)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrerequisite", reflect.TypeOf((*MockSubjectRepository)(nil).AddPrerequisite), ctx, prerequisite)
 }
 
 // Create mocks base method.
@@ -397,6 +658,36 @@ func (mr *MockSubjectRepositoryMockRecorder) GetCorequisites(ctx, subjectID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCorequisites", reflect.TypeOf((*MockSubjectRepository)(nil).GetCorequisites), ctx, subjectID)
 }
 
+// GetPrerequisiteChain mocks base method.
+func (m *MockSubjectRepository) GetPrerequisiteChain(ctx context.Context, subjectID uuid.UUID, downstream bool) (*domain.PrerequisiteGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrerequisiteChain", ctx, subjectID, downstream)
+	ret0, _ := ret[0].(*domain.PrerequisiteGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrerequisiteChain indicates an expected call of GetPrerequisiteChain.
+func (mr *MockSubjectRepositoryMockRecorder) GetPrerequisiteChain(ctx, subjectID, downstream any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrerequisiteChain", reflect.TypeOf((*MockSubjectRepository)(nil).GetPrerequisiteChain), ctx, subjectID, downstream)
+}
+
+// GetPrerequisiteGraph mocks base method.
+func (m *MockSubjectRepository) GetPrerequisiteGraph(ctx context.Context, filter domain.PrerequisiteGraphFilter) (*domain.PrerequisiteGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrerequisiteGraph", ctx, filter)
+	ret0, _ := ret[0].(*domain.PrerequisiteGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrerequisiteGraph indicates an expected call of GetPrerequisiteGraph.
+func (mr *MockSubjectRepositoryMockRecorder) GetPrerequisiteGraph(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrerequisiteGraph", reflect.TypeOf((*MockSubjectRepository)(nil).GetPrerequisiteGraph), ctx, filter)
+}
+
 // GetPrerequisites mocks base method.
 func (m *MockSubjectRepository) GetPrerequisites(ctx context.Context, subjectID uuid.UUID) ([]domain.SubjectPrerequisite, error) {
 	m.ctrl.T.Helper()
@@ -626,135 +917,281 @@ func (mr *MockSemesterRepositoryMockRecorder) Update(ctx, semester any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSemesterRepository)(nil).Update), ctx, semester)
 }
 
-// MockCourseRepository is a mock of CourseRepository interface.
-type MockCourseRepository struct {
+// MockSemesterRolloverRepository is a mock of SemesterRolloverRepository interface.
+type MockSemesterRolloverRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockCourseRepositoryMockRecorder
+	recorder *MockSemesterRolloverRepositoryMockRecorder
 	isgomock struct{}
 }
 
-// MockCourseRepositoryMockRecorder is the mock recorder for MockCourseRepository.
-type MockCourseRepositoryMockRecorder struct {
-	mock *MockCourseRepository
+// MockSemesterRolloverRepositoryMockRecorder is the mock recorder for MockSemesterRolloverRepository.
+type MockSemesterRolloverRepositoryMockRecorder struct {
+	mock *MockSemesterRolloverRepository
 }
 
-// NewMockCourseRepository creates a new mock instance.
-func NewMockCourseRepository(ctrl *gomock.Controller) *MockCourseRepository {
-	mock := &MockCourseRepository{ctrl: ctrl}
-	mock.recorder = &MockCourseRepositoryMockRecorder{mock}
+// NewMockSemesterRolloverRepository creates a new mock instance.
+func NewMockSemesterRolloverRepository(ctrl *gomock.Controller) *MockSemesterRolloverRepository {
+	mock := &MockSemesterRolloverRepository{ctrl: ctrl}
+	mock.recorder = &MockSemesterRolloverRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCourseRepository) EXPECT() *MockCourseRepositoryMockRecorder {
+func (m *MockSemesterRolloverRepository) EXPECT() *MockSemesterRolloverRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockCourseRepository) Create(ctx context.Context, course *domain.Course) error {
+// Claim mocks base method.
+func (m *MockSemesterRolloverRepository) Claim(ctx context.Context, lease time.Duration) (*domain.SemesterRollover, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, course)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Claim", ctx, lease)
+	ret0, _ := ret[0].(*domain.SemesterRollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockCourseRepositoryMockRecorder) Create(ctx, course any) *gomock.Call {
+// Claim indicates an expected call of Claim.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) Claim(ctx, lease any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCourseRepository)(nil).Create), ctx, course)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).Claim), ctx, lease)
 }
 
-// DecrementEnrollment mocks base method.
-func (m *MockCourseRepository) DecrementEnrollment(ctx context.Context, id uuid.UUID) error {
+// CloseCourses mocks base method.
+func (m *MockSemesterRolloverRepository) CloseCourses(ctx context.Context, semesterID uuid.UUID) (int, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DecrementEnrollment", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CloseCourses", ctx, semesterID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// DecrementEnrollment indicates an expected call of DecrementEnrollment.
-func (mr *MockCourseRepositoryMockRecorder) DecrementEnrollment(ctx, id any) *gomock.Call {
+// CloseCourses indicates an expected call of CloseCourses.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) CloseCourses(ctx, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementEnrollment", reflect.TypeOf((*MockCourseRepository)(nil).DecrementEnrollment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseCourses", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).CloseCourses), ctx, semesterID)
 }
 
-// Delete mocks base method.
-func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// CountClosable mocks base method.
+func (m *MockSemesterRolloverRepository) CountClosable(ctx context.Context, semesterID uuid.UUID) (int, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "CountClosable", ctx, semesterID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountClosable indicates an expected call of CountClosable.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) CountClosable(ctx, semesterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClosable", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).CountClosable), ctx, semesterID)
+}
+
+// Create mocks base method.
+func (m *MockSemesterRolloverRepository) Create(ctx context.Context, rollover *domain.SemesterRollover) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rollover)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockCourseRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) Create(ctx, rollover any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourseRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).Create), ctx, rollover)
 }
 
-// GetByCode mocks base method.
-func (m *MockCourseRepository) GetByCode(ctx context.Context, code string) (*domain.Course, error) {
+// GetLatest mocks base method.
+func (m *MockSemesterRolloverRepository) GetLatest(ctx context.Context, semesterID uuid.UUID) (*domain.SemesterRollover, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
-	ret0, _ := ret[0].(*domain.Course)
+	ret := m.ctrl.Call(m, "GetLatest", ctx, semesterID)
+	ret0, _ := ret[0].(*domain.SemesterRollover)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByCode indicates an expected call of GetByCode.
-func (mr *MockCourseRepositoryMockRecorder) GetByCode(ctx, code any) *gomock.Call {
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) GetLatest(ctx, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockCourseRepository)(nil).GetByCode), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).GetLatest), ctx, semesterID)
 }
 
-// GetByID mocks base method.
-func (m *MockCourseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Course, error) {
+// ListCandidates mocks base method.
+func (m *MockSemesterRolloverRepository) ListCandidates(ctx context.Context, semesterID uuid.UUID) ([]*domain.RolloverCandidate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
-	ret0, _ := ret[0].(*domain.Course)
+	ret := m.ctrl.Call(m, "ListCandidates", ctx, semesterID)
+	ret0, _ := ret[0].([]*domain.RolloverCandidate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByID indicates an expected call of GetByID.
-func (mr *MockCourseRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+// ListCandidates indicates an expected call of ListCandidates.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) ListCandidates(ctx, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCourseRepository)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidates", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).ListCandidates), ctx, semesterID)
 }
 
-// GetWithDetails mocks base method.
-func (m *MockCourseRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*domain.CourseWithDetails, error) {
+// ListUngradedCourses mocks base method.
+func (m *MockSemesterRolloverRepository) ListUngradedCourses(ctx context.Context, semesterID uuid.UUID) ([]domain.UngradedCourse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWithDetails", ctx, id)
-	ret0, _ := ret[0].(*domain.CourseWithDetails)
+	ret := m.ctrl.Call(m, "ListUngradedCourses", ctx, semesterID)
+	ret0, _ := ret[0].([]domain.UngradedCourse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWithDetails indicates an expected call of GetWithDetails.
-func (mr *MockCourseRepositoryMockRecorder) GetWithDetails(ctx, id any) *gomock.Call {
+// ListUngradedCourses indicates an expected call of ListUngradedCourses.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) ListUngradedCourses(ctx, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithDetails", reflect.TypeOf((*MockCourseRepository)(nil).GetWithDetails), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUngradedCourses", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).ListUngradedCourses), ctx, semesterID)
 }
 
-// IncrementEnrollment mocks base method.
-func (m *MockCourseRepository) IncrementEnrollment(ctx context.Context, id uuid.UUID) error {
+// RecordStudent mocks base method.
+func (m *MockSemesterRolloverRepository) RecordStudent(ctx context.Context, rollover *domain.SemesterRollover, student *domain.RolloverStudent) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IncrementEnrollment", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RecordStudent", ctx, rollover, student)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// IncrementEnrollment indicates an expected call of IncrementEnrollment.
-func (mr *MockCourseRepositoryMockRecorder) IncrementEnrollment(ctx, id any) *gomock.Call {
+// RecordStudent indicates an expected call of RecordStudent.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) RecordStudent(ctx, rollover, student any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementEnrollment", reflect.TypeOf((*MockCourseRepository)(nil).IncrementEnrollment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordStudent", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).RecordStudent), ctx, rollover, student)
 }
 
-// List mocks base method.
-func (m *MockCourseRepository) List(ctx context.Context, filter domain.CourseFilter, limit, offset int) ([]*domain.CourseWithDetails, int64, error) {
+// Update mocks base method.
+func (m *MockSemesterRolloverRepository) Update(ctx context.Context, rollover *domain.SemesterRollover) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
+	ret := m.ctrl.Call(m, "Update", ctx, rollover)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSemesterRolloverRepositoryMockRecorder) Update(ctx, rollover any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSemesterRolloverRepository)(nil).Update), ctx, rollover)
+}
+
+// MockCourseRepository is a mock of CourseRepository interface.
+type MockCourseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCourseRepositoryMockRecorder is the mock recorder for MockCourseRepository.
+type MockCourseRepositoryMockRecorder struct {
+	mock *MockCourseRepository
+}
+
+// NewMockCourseRepository creates a new mock instance.
+func NewMockCourseRepository(ctrl *gomock.Controller) *MockCourseRepository {
+	mock := &MockCourseRepository{ctrl: ctrl}
+	mock.recorder = &MockCourseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseRepository) EXPECT() *MockCourseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCourseRepository) Create(ctx context.Context, course *domain.Course) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, course)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCourseRepositoryMockRecorder) Create(ctx, course any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCourseRepository)(nil).Create), ctx, course)
+}
+
+// Delete mocks base method.
+func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCourseRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourseRepository)(nil).Delete), ctx, id)
+}
+
+// GetByCode mocks base method.
+func (m *MockCourseRepository) GetByCode(ctx context.Context, code string) (*domain.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*domain.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockCourseRepositoryMockRecorder) GetByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockCourseRepository)(nil).GetByCode), ctx, code)
+}
+
+// GetByID mocks base method.
+func (m *MockCourseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCourseRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCourseRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockCourseRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domain.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockCourseRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockCourseRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// GetWithDetails mocks base method.
+func (m *MockCourseRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*domain.CourseWithDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithDetails", ctx, id)
+	ret0, _ := ret[0].(*domain.CourseWithDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithDetails indicates an expected call of GetWithDetails.
+func (mr *MockCourseRepositoryMockRecorder) GetWithDetails(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithDetails", reflect.TypeOf((*MockCourseRepository)(nil).GetWithDetails), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockCourseRepository) List(ctx context.Context, filter domain.CourseFilter, limit, offset int) ([]*domain.CourseWithDetails, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
 	ret0, _ := ret[0].([]*domain.CourseWithDetails)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -767,6 +1204,21 @@ func (mr *MockCourseRepositoryMockRecorder) List(ctx, filter, limit, offset any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCourseRepository)(nil).List), ctx, filter, limit, offset)
 }
 
+// ReconcileEnrollmentCounts mocks base method.
+func (m *MockCourseRepository) ReconcileEnrollmentCounts(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileEnrollmentCounts", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileEnrollmentCounts indicates an expected call of ReconcileEnrollmentCounts.
+func (mr *MockCourseRepositoryMockRecorder) ReconcileEnrollmentCounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileEnrollmentCounts", reflect.TypeOf((*MockCourseRepository)(nil).ReconcileEnrollmentCounts), ctx)
+}
+
 // Update mocks base method.
 func (m *MockCourseRepository) Update(ctx context.Context, course *domain.Course) error {
 	m.ctrl.T.Helper()
@@ -833,6 +1285,20 @@ func (mr *MockFacultyRepositoryMockRecorder) Create(ctx, faculty any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFacultyRepository)(nil).Create), ctx, faculty)
 }
 
+// DeactivateByUserID mocks base method.
+func (m *MockFacultyRepository) DeactivateByUserID(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateByUserID indicates an expected call of DeactivateByUserID.
+func (mr *MockFacultyRepositoryMockRecorder) DeactivateByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateByUserID", reflect.TypeOf((*MockFacultyRepository)(nil).DeactivateByUserID), ctx, userID)
+}
+
 // Delete mocks base method.
 func (m *MockFacultyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -975,6 +1441,20 @@ func (mr *MockStudentRepositoryMockRecorder) Create(ctx, student any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStudentRepository)(nil).Create), ctx, student)
 }
 
+// DeactivateByUserID mocks base method.
+func (m *MockStudentRepository) DeactivateByUserID(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateByUserID indicates an expected call of DeactivateByUserID.
+func (mr *MockStudentRepositoryMockRecorder) DeactivateByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateByUserID", reflect.TypeOf((*MockStudentRepository)(nil).DeactivateByUserID), ctx, userID)
+}
+
 // Delete mocks base method.
 func (m *MockStudentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -1065,6 +1545,36 @@ func (mr *MockStudentRepositoryMockRecorder) List(ctx, filter, limit, offset any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStudentRepository)(nil).List), ctx, filter, limit, offset)
 }
 
+// ListExistingIDs mocks base method.
+func (m *MockStudentRepository) ListExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExistingIDs", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExistingIDs indicates an expected call of ListExistingIDs.
+func (mr *MockStudentRepositoryMockRecorder) ListExistingIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExistingIDs", reflect.TypeOf((*MockStudentRepository)(nil).ListExistingIDs), ctx, ids)
+}
+
+// ListSemesterGPAs mocks base method.
+func (m *MockStudentRepository) ListSemesterGPAs(ctx context.Context, studentID uuid.UUID) ([]domain.SemesterGPA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSemesterGPAs", ctx, studentID)
+	ret0, _ := ret[0].([]domain.SemesterGPA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSemesterGPAs indicates an expected call of ListSemesterGPAs.
+func (mr *MockStudentRepositoryMockRecorder) ListSemesterGPAs(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSemesterGPAs", reflect.TypeOf((*MockStudentRepository)(nil).ListSemesterGPAs), ctx, studentID)
+}
+
 // Update mocks base method.
 func (m *MockStudentRepository) Update(ctx context.Context, student *domain.Student) error {
 	m.ctrl.T.Helper()
@@ -1079,18 +1589,32 @@ func (mr *MockStudentRepositoryMockRecorder) Update(ctx, student any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudentRepository)(nil).Update), ctx, student)
 }
 
+// UpdateAcademicRecord mocks base method.
+func (m *MockStudentRepository) UpdateAcademicRecord(ctx context.Context, record *domain.AcademicRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAcademicRecord", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAcademicRecord indicates an expected call of UpdateAcademicRecord.
+func (mr *MockStudentRepositoryMockRecorder) UpdateAcademicRecord(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAcademicRecord", reflect.TypeOf((*MockStudentRepository)(nil).UpdateAcademicRecord), ctx, record)
+}
+
 // UpdateSemester mocks base method.
-func (m *MockStudentRepository) UpdateSemester(ctx context.Context, id uuid.UUID, semester int, cgpa *float64, credits int) error {
+func (m *MockStudentRepository) UpdateSemester(ctx context.Context, id uuid.UUID, semester int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSemester", ctx, id, semester, cgpa, credits)
+	ret := m.ctrl.Call(m, "UpdateSemester", ctx, id, semester)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateSemester indicates an expected call of UpdateSemester.
-func (mr *MockStudentRepositoryMockRecorder) UpdateSemester(ctx, id, semester, cgpa, credits any) *gomock.Call {
+func (mr *MockStudentRepositoryMockRecorder) UpdateSemester(ctx, id, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSemester", reflect.TypeOf((*MockStudentRepository)(nil).UpdateSemester), ctx, id, semester, cgpa, credits)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSemester", reflect.TypeOf((*MockStudentRepository)(nil).UpdateSemester), ctx, id, semester)
 }
 
 // MockFacultyCourseRepository is a mock of FacultyCourseRepository interface.
@@ -1272,6 +1796,34 @@ func (mr *MockEnrollmentRepositoryMockRecorder) Create(ctx, enrollment any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEnrollmentRepository)(nil).Create), ctx, enrollment)
 }
 
+// CreateBatch mocks base method.
+func (m *MockEnrollmentRepository) CreateBatch(ctx context.Context, enrollments []*domain.CourseEnrollment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, enrollments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockEnrollmentRepositoryMockRecorder) CreateBatch(ctx, enrollments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockEnrollmentRepository)(nil).CreateBatch), ctx, enrollments)
+}
+
+// CreateWaiver mocks base method.
+func (m *MockEnrollmentRepository) CreateWaiver(ctx context.Context, waiver *domain.PrerequisiteWaiver) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWaiver", ctx, waiver)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWaiver indicates an expected call of CreateWaiver.
+func (mr *MockEnrollmentRepositoryMockRecorder) CreateWaiver(ctx, waiver any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWaiver", reflect.TypeOf((*MockEnrollmentRepository)(nil).CreateWaiver), ctx, waiver)
+}
+
 // Delete mocks base method.
 func (m *MockEnrollmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -1349,6 +1901,36 @@ func (mr *MockEnrollmentRepositoryMockRecorder) GetNextWaitlistPosition(ctx, cou
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextWaitlistPosition", reflect.TypeOf((*MockEnrollmentRepository)(nil).GetNextWaitlistPosition), ctx, courseID)
 }
 
+// GetUnmetRequirements mocks base method.
+func (m *MockEnrollmentRepository) GetUnmetRequirements(ctx context.Context, studentID, courseID uuid.UUID) ([]*domain.UnmetRequirement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnmetRequirements", ctx, studentID, courseID)
+	ret0, _ := ret[0].([]*domain.UnmetRequirement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnmetRequirements indicates an expected call of GetUnmetRequirements.
+func (mr *MockEnrollmentRepositoryMockRecorder) GetUnmetRequirements(ctx, studentID, courseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnmetRequirements", reflect.TypeOf((*MockEnrollmentRepository)(nil).GetUnmetRequirements), ctx, studentID, courseID)
+}
+
+// GetUnmetRequirementsForStudents mocks base method.
+func (m *MockEnrollmentRepository) GetUnmetRequirementsForStudents(ctx context.Context, studentIDs []uuid.UUID, courseID uuid.UUID) ([]*domain.UnmetRequirement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnmetRequirementsForStudents", ctx, studentIDs, courseID)
+	ret0, _ := ret[0].([]*domain.UnmetRequirement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnmetRequirementsForStudents indicates an expected call of GetUnmetRequirementsForStudents.
+func (mr *MockEnrollmentRepositoryMockRecorder) GetUnmetRequirementsForStudents(ctx, studentIDs, courseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnmetRequirementsForStudents", reflect.TypeOf((*MockEnrollmentRepository)(nil).GetUnmetRequirementsForStudents), ctx, studentIDs, courseID)
+}
+
 // ListByCourse mocks base method.
 func (m *MockEnrollmentRepository) ListByCourse(ctx context.Context, courseID uuid.UUID, status *string, limit, offset int) ([]*domain.EnrollmentWithDetails, int64, error) {
 	m.ctrl.T.Helper()
@@ -1381,6 +1963,96 @@ func (mr *MockEnrollmentRepositoryMockRecorder) ListByStudent(ctx, filter, limit
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStudent", reflect.TypeOf((*MockEnrollmentRepository)(nil).ListByStudent), ctx, filter, limit, offset)
 }
 
+// ListEnrolledStudentIDs mocks base method.
+func (m *MockEnrollmentRepository) ListEnrolledStudentIDs(ctx context.Context, courseID uuid.UUID, studentIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnrolledStudentIDs", ctx, courseID, studentIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnrolledStudentIDs indicates an expected call of ListEnrolledStudentIDs.
+func (mr *MockEnrollmentRepositoryMockRecorder) ListEnrolledStudentIDs(ctx, courseID, studentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnrolledStudentIDs", reflect.TypeOf((*MockEnrollmentRepository)(nil).ListEnrolledStudentIDs), ctx, courseID, studentIDs)
+}
+
+// ListEnrolledSubjects mocks base method.
+func (m *MockEnrollmentRepository) ListEnrolledSubjects(ctx context.Context, studentID uuid.UUID) ([]*domain.SubjectBasic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnrolledSubjects", ctx, studentID)
+	ret0, _ := ret[0].([]*domain.SubjectBasic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnrolledSubjects indicates an expected call of ListEnrolledSubjects.
+func (mr *MockEnrollmentRepositoryMockRecorder) ListEnrolledSubjects(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnrolledSubjects", reflect.TypeOf((*MockEnrollmentRepository)(nil).ListEnrolledSubjects), ctx, studentID)
+}
+
+// ListGradedAttempts mocks base method.
+func (m *MockEnrollmentRepository) ListGradedAttempts(ctx context.Context, studentID uuid.UUID) ([]*domain.GradedAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGradedAttempts", ctx, studentID)
+	ret0, _ := ret[0].([]*domain.GradedAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGradedAttempts indicates an expected call of ListGradedAttempts.
+func (mr *MockEnrollmentRepositoryMockRecorder) ListGradedAttempts(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGradedAttempts", reflect.TypeOf((*MockEnrollmentRepository)(nil).ListGradedAttempts), ctx, studentID)
+}
+
+// ListGradedCourses mocks base method.
+func (m *MockEnrollmentRepository) ListGradedCourses(ctx context.Context, studentID uuid.UUID) ([]*domain.GradedCourse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGradedCourses", ctx, studentID)
+	ret0, _ := ret[0].([]*domain.GradedCourse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGradedCourses indicates an expected call of ListGradedCourses.
+func (mr *MockEnrollmentRepositoryMockRecorder) ListGradedCourses(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGradedCourses", reflect.TypeOf((*MockEnrollmentRepository)(nil).ListGradedCourses), ctx, studentID)
+}
+
+// ListRoster mocks base method.
+func (m *MockEnrollmentRepository) ListRoster(ctx context.Context, courseID uuid.UUID) ([]*domain.CourseEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoster", ctx, courseID)
+	ret0, _ := ret[0].([]*domain.CourseEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoster indicates an expected call of ListRoster.
+func (mr *MockEnrollmentRepositoryMockRecorder) ListRoster(ctx, courseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoster", reflect.TypeOf((*MockEnrollmentRepository)(nil).ListRoster), ctx, courseID)
+}
+
+// ListWaivers mocks base method.
+func (m *MockEnrollmentRepository) ListWaivers(ctx context.Context, studentID, courseID uuid.UUID) ([]*domain.PrerequisiteWaiver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWaivers", ctx, studentID, courseID)
+	ret0, _ := ret[0].([]*domain.PrerequisiteWaiver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWaivers indicates an expected call of ListWaivers.
+func (mr *MockEnrollmentRepositoryMockRecorder) ListWaivers(ctx, studentID, courseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWaivers", reflect.TypeOf((*MockEnrollmentRepository)(nil).ListWaivers), ctx, studentID, courseID)
+}
+
 // PromoteFromWaitlist mocks base method.
 func (m *MockEnrollmentRepository) PromoteFromWaitlist(ctx context.Context, courseID uuid.UUID) (*domain.CourseEnrollment, error) {
 	m.ctrl.T.Helper()
@@ -1396,6 +2068,20 @@ func (mr *MockEnrollmentRepositoryMockRecorder) PromoteFromWaitlist(ctx, courseI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteFromWaitlist", reflect.TypeOf((*MockEnrollmentRepository)(nil).PromoteFromWaitlist), ctx, courseID)
 }
 
+// RecordOverride mocks base method.
+func (m *MockEnrollmentRepository) RecordOverride(ctx context.Context, override *domain.EnrollmentOverride) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOverride", ctx, override)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOverride indicates an expected call of RecordOverride.
+func (mr *MockEnrollmentRepositoryMockRecorder) RecordOverride(ctx, override any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOverride", reflect.TypeOf((*MockEnrollmentRepository)(nil).RecordOverride), ctx, override)
+}
+
 // Update mocks base method.
 func (m *MockEnrollmentRepository) Update(ctx context.Context, enrollment *domain.CourseEnrollment) error {
 	m.ctrl.T.Helper()
@@ -1410,6 +2096,199 @@ func (mr *MockEnrollmentRepositoryMockRecorder) Update(ctx, enrollment any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEnrollmentRepository)(nil).Update), ctx, enrollment)
 }
 
+// MockGradebookRepository is a mock of GradebookRepository interface.
+type MockGradebookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGradebookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGradebookRepositoryMockRecorder is the mock recorder for MockGradebookRepository.
+type MockGradebookRepositoryMockRecorder struct {
+	mock *MockGradebookRepository
+}
+
+// NewMockGradebookRepository creates a new mock instance.
+func NewMockGradebookRepository(ctrl *gomock.Controller) *MockGradebookRepository {
+	mock := &MockGradebookRepository{ctrl: ctrl}
+	mock.recorder = &MockGradebookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGradebookRepository) EXPECT() *MockGradebookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAmendment mocks base method.
+func (m *MockGradebookRepository) CreateAmendment(ctx context.Context, amendment *domain.GradeAmendment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAmendment", ctx, amendment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAmendment indicates an expected call of CreateAmendment.
+func (mr *MockGradebookRepositoryMockRecorder) CreateAmendment(ctx, amendment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAmendment", reflect.TypeOf((*MockGradebookRepository)(nil).CreateAmendment), ctx, amendment)
+}
+
+// GetAmendment mocks base method.
+func (m *MockGradebookRepository) GetAmendment(ctx context.Context, id uuid.UUID) (*domain.GradeAmendment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAmendment", ctx, id)
+	ret0, _ := ret[0].(*domain.GradeAmendment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAmendment indicates an expected call of GetAmendment.
+func (mr *MockGradebookRepositoryMockRecorder) GetAmendment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAmendment", reflect.TypeOf((*MockGradebookRepository)(nil).GetAmendment), ctx, id)
+}
+
+// GetByCourse mocks base method.
+func (m *MockGradebookRepository) GetByCourse(ctx context.Context, courseID uuid.UUID) (*domain.Gradebook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCourse", ctx, courseID)
+	ret0, _ := ret[0].(*domain.Gradebook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCourse indicates an expected call of GetByCourse.
+func (mr *MockGradebookRepositoryMockRecorder) GetByCourse(ctx, courseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockGradebookRepository)(nil).GetByCourse), ctx, courseID)
+}
+
+// ListAmendments mocks base method.
+func (m *MockGradebookRepository) ListAmendments(ctx context.Context, courseID uuid.UUID) ([]*domain.GradeAmendment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAmendments", ctx, courseID)
+	ret0, _ := ret[0].([]*domain.GradeAmendment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAmendments indicates an expected call of ListAmendments.
+func (mr *MockGradebookRepositoryMockRecorder) ListAmendments(ctx, courseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAmendments", reflect.TypeOf((*MockGradebookRepository)(nil).ListAmendments), ctx, courseID)
+}
+
+// Save mocks base method.
+func (m *MockGradebookRepository) Save(ctx context.Context, gradebook *domain.Gradebook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, gradebook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockGradebookRepositoryMockRecorder) Save(ctx, gradebook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockGradebookRepository)(nil).Save), ctx, gradebook)
+}
+
+// UpdateAmendment mocks base method.
+func (m *MockGradebookRepository) UpdateAmendment(ctx context.Context, amendment *domain.GradeAmendment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAmendment", ctx, amendment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAmendment indicates an expected call of UpdateAmendment.
+func (mr *MockGradebookRepositoryMockRecorder) UpdateAmendment(ctx, amendment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAmendment", reflect.TypeOf((*MockGradebookRepository)(nil).UpdateAmendment), ctx, amendment)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockGradebookRepository) UpdateStatus(ctx context.Context, gradebook *domain.Gradebook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, gradebook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockGradebookRepositoryMockRecorder) UpdateStatus(ctx, gradebook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockGradebookRepository)(nil).UpdateStatus), ctx, gradebook)
+}
+
+// MockTranscriptRepository is a mock of TranscriptRepository interface.
+type MockTranscriptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranscriptRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTranscriptRepositoryMockRecorder is the mock recorder for MockTranscriptRepository.
+type MockTranscriptRepositoryMockRecorder struct {
+	mock *MockTranscriptRepository
+}
+
+// NewMockTranscriptRepository creates a new mock instance.
+func NewMockTranscriptRepository(ctrl *gomock.Controller) *MockTranscriptRepository {
+	mock := &MockTranscriptRepository{ctrl: ctrl}
+	mock.recorder = &MockTranscriptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranscriptRepository) EXPECT() *MockTranscriptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTranscriptRepository) Create(ctx context.Context, transcript *domain.IssuedTranscript) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, transcript)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTranscriptRepositoryMockRecorder) Create(ctx, transcript any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTranscriptRepository)(nil).Create), ctx, transcript)
+}
+
+// GetByCode mocks base method.
+func (m *MockTranscriptRepository) GetByCode(ctx context.Context, code string) (*domain.IssuedTranscript, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*domain.IssuedTranscript)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockTranscriptRepositoryMockRecorder) GetByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockTranscriptRepository)(nil).GetByCode), ctx, code)
+}
+
+// GetByContentHash mocks base method.
+func (m *MockTranscriptRepository) GetByContentHash(ctx context.Context, studentID uuid.UUID, contentHash string) (*domain.IssuedTranscript, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByContentHash", ctx, studentID, contentHash)
+	ret0, _ := ret[0].(*domain.IssuedTranscript)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByContentHash indicates an expected call of GetByContentHash.
+func (mr *MockTranscriptRepositoryMockRecorder) GetByContentHash(ctx, studentID, contentHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByContentHash", reflect.TypeOf((*MockTranscriptRepository)(nil).GetByContentHash), ctx, studentID, contentHash)
+}
+
 // MockCalendarRepository is a mock of CalendarRepository interface.
 type MockCalendarRepository struct {
 	ctrl     *gomock.Controller
@@ -1506,3 +2385,70 @@ func (mr *MockCalendarRepositoryMockRecorder) Update(ctx, event any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCalendarRepository)(nil).Update), ctx, event)
 }
+
+// MockUserDirectoryRepository is a mock of UserDirectoryRepository interface.
+type MockUserDirectoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDirectoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserDirectoryRepositoryMockRecorder is the mock recorder for MockUserDirectoryRepository.
+type MockUserDirectoryRepositoryMockRecorder struct {
+	mock *MockUserDirectoryRepository
+}
+
+// NewMockUserDirectoryRepository creates a new mock instance.
+func NewMockUserDirectoryRepository(ctrl *gomock.Controller) *MockUserDirectoryRepository {
+	mock := &MockUserDirectoryRepository{ctrl: ctrl}
+	mock.recorder = &MockUserDirectoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDirectoryRepository) EXPECT() *MockUserDirectoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUserID mocks base method.
+func (m *MockUserDirectoryRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserDirectoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*domain.UserDirectoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockUserDirectoryRepositoryMockRecorder) GetByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockUserDirectoryRepository)(nil).GetByUserID), ctx, userID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockUserDirectoryRepository) UpdateStatus(ctx context.Context, userID uuid.UUID, status string, eventTimestamp time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, userID, status, eventTimestamp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockUserDirectoryRepositoryMockRecorder) UpdateStatus(ctx, userID, status, eventTimestamp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockUserDirectoryRepository)(nil).UpdateStatus), ctx, userID, status, eventTimestamp)
+}
+
+// Upsert mocks base method.
+func (m *MockUserDirectoryRepository) Upsert(ctx context.Context, entry *domain.UserDirectoryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockUserDirectoryRepositoryMockRecorder) Upsert(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserDirectoryRepository)(nil).Upsert), ctx, entry)
+}
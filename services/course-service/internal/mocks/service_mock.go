@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	domain "github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	models "github.com/SureshAmal/NimbusU-backend/shared/models"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -251,17 +252,17 @@ func (mr *MockSubjectServiceMockRecorder) AddCorequisite(ctx, subjectID, corequi
 }
 
 // AddPrerequisite mocks base method.
-func (m *MockSubjectService) AddPrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID, isMandatory bool) error {
+func (m *MockSubjectService) AddPrerequisite(ctx context.Context, prerequisite *domain.SubjectPrerequisite) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPrerequisite", ctx, subjectID, prerequisiteID, isMandatory)
+	ret := m.ctrl.Call(m, "AddPrerequisite", ctx, prerequisite)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddPrerequisite indicates an expected call of AddPrerequisite.
-func (mr *MockSubjectServiceMockRecorder) AddPrerequisite(ctx, subjectID, prerequisiteID, isMandatory any) *gomock.Call {
+func (mr *MockSubjectServiceMockRecorder) AddPrerequisite(ctx, prerequisite any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrerequisite", reflect.TypeOf((*MockSubjectService)(nil).AddPrerequisite), ctx, subjectID, prerequisiteID, isMandatory)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrerequisite", reflect.TypeOf((*MockSubjectService)(nil).AddPrerequisite), ctx, prerequisite)
 }
 
 // CreateSubject mocks base method.
@@ -292,6 +293,36 @@ func (mr *MockSubjectServiceMockRecorder) DeleteSubject(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubject", reflect.TypeOf((*MockSubjectService)(nil).DeleteSubject), ctx, id)
 }
 
+// GetPrerequisiteGraph mocks base method.
+func (m *MockSubjectService) GetPrerequisiteGraph(ctx context.Context, filter domain.PrerequisiteGraphFilter) (*domain.PrerequisiteGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrerequisiteGraph", ctx, filter)
+	ret0, _ := ret[0].(*domain.PrerequisiteGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrerequisiteGraph indicates an expected call of GetPrerequisiteGraph.
+func (mr *MockSubjectServiceMockRecorder) GetPrerequisiteGraph(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrerequisiteGraph", reflect.TypeOf((*MockSubjectService)(nil).GetPrerequisiteGraph), ctx, filter)
+}
+
+// GetPrerequisiteTree mocks base method.
+func (m *MockSubjectService) GetPrerequisiteTree(ctx context.Context, subjectID uuid.UUID) (*domain.PrerequisiteTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrerequisiteTree", ctx, subjectID)
+	ret0, _ := ret[0].(*domain.PrerequisiteTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrerequisiteTree indicates an expected call of GetPrerequisiteTree.
+func (mr *MockSubjectServiceMockRecorder) GetPrerequisiteTree(ctx, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrerequisiteTree", reflect.TypeOf((*MockSubjectService)(nil).GetPrerequisiteTree), ctx, subjectID)
+}
+
 // GetSubject mocks base method.
 func (m *MockSubjectService) GetSubject(ctx context.Context, id uuid.UUID) (*domain.SubjectWithDetails, error) {
 	m.ctrl.T.Helper()
@@ -812,6 +843,21 @@ func (mr *MockStudentServiceMockRecorder) DeleteStudent(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStudent", reflect.TypeOf((*MockStudentService)(nil).DeleteStudent), ctx, id)
 }
 
+// GetAcademicRecord mocks base method.
+func (m *MockStudentService) GetAcademicRecord(ctx context.Context, id uuid.UUID) (*domain.AcademicRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAcademicRecord", ctx, id)
+	ret0, _ := ret[0].(*domain.AcademicRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAcademicRecord indicates an expected call of GetAcademicRecord.
+func (mr *MockStudentServiceMockRecorder) GetAcademicRecord(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcademicRecord", reflect.TypeOf((*MockStudentService)(nil).GetAcademicRecord), ctx, id)
+}
+
 // GetStudent mocks base method.
 func (m *MockStudentService) GetStudent(ctx context.Context, id uuid.UUID) (*domain.StudentWithDetails, error) {
 	m.ctrl.T.Helper()
@@ -859,17 +905,17 @@ func (mr *MockStudentServiceMockRecorder) ListStudents(ctx, filter, page, limit
 }
 
 // PromoteStudent mocks base method.
-func (m *MockStudentService) PromoteStudent(ctx context.Context, id uuid.UUID, newSemester int, cgpa *float64, creditsEarned int) error {
+func (m *MockStudentService) PromoteStudent(ctx context.Context, id uuid.UUID, newSemester int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PromoteStudent", ctx, id, newSemester, cgpa, creditsEarned)
+	ret := m.ctrl.Call(m, "PromoteStudent", ctx, id, newSemester)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PromoteStudent indicates an expected call of PromoteStudent.
-func (mr *MockStudentServiceMockRecorder) PromoteStudent(ctx, id, newSemester, cgpa, creditsEarned any) *gomock.Call {
+func (mr *MockStudentServiceMockRecorder) PromoteStudent(ctx, id, newSemester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteStudent", reflect.TypeOf((*MockStudentService)(nil).PromoteStudent), ctx, id, newSemester, cgpa, creditsEarned)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteStudent", reflect.TypeOf((*MockStudentService)(nil).PromoteStudent), ctx, id, newSemester)
 }
 
 // UpdateStudent mocks base method.
@@ -886,6 +932,282 @@ func (mr *MockStudentServiceMockRecorder) UpdateStudent(ctx, id, updates any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStudent", reflect.TypeOf((*MockStudentService)(nil).UpdateStudent), ctx, id, updates)
 }
 
+// MockGradingScaleService is a mock of GradingScaleService interface.
+type MockGradingScaleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGradingScaleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGradingScaleServiceMockRecorder is the mock recorder for MockGradingScaleService.
+type MockGradingScaleServiceMockRecorder struct {
+	mock *MockGradingScaleService
+}
+
+// NewMockGradingScaleService creates a new mock instance.
+func NewMockGradingScaleService(ctrl *gomock.Controller) *MockGradingScaleService {
+	mock := &MockGradingScaleService{ctrl: ctrl}
+	mock.recorder = &MockGradingScaleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGradingScaleService) EXPECT() *MockGradingScaleServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateGradingScale mocks base method.
+func (m *MockGradingScaleService) CreateGradingScale(ctx context.Context, scale *domain.GradingScale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGradingScale", ctx, scale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGradingScale indicates an expected call of CreateGradingScale.
+func (mr *MockGradingScaleServiceMockRecorder) CreateGradingScale(ctx, scale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGradingScale", reflect.TypeOf((*MockGradingScaleService)(nil).CreateGradingScale), ctx, scale)
+}
+
+// DeleteGradingScale mocks base method.
+func (m *MockGradingScaleService) DeleteGradingScale(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGradingScale", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGradingScale indicates an expected call of DeleteGradingScale.
+func (mr *MockGradingScaleServiceMockRecorder) DeleteGradingScale(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGradingScale", reflect.TypeOf((*MockGradingScaleService)(nil).DeleteGradingScale), ctx, id)
+}
+
+// GetGradingScale mocks base method.
+func (m *MockGradingScaleService) GetGradingScale(ctx context.Context, id uuid.UUID) (*domain.GradingScale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGradingScale", ctx, id)
+	ret0, _ := ret[0].(*domain.GradingScale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGradingScale indicates an expected call of GetGradingScale.
+func (mr *MockGradingScaleServiceMockRecorder) GetGradingScale(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradingScale", reflect.TypeOf((*MockGradingScaleService)(nil).GetGradingScale), ctx, id)
+}
+
+// ListGradingScales mocks base method.
+func (m *MockGradingScaleService) ListGradingScales(ctx context.Context) ([]*domain.GradingScale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGradingScales", ctx)
+	ret0, _ := ret[0].([]*domain.GradingScale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGradingScales indicates an expected call of ListGradingScales.
+func (mr *MockGradingScaleServiceMockRecorder) ListGradingScales(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGradingScales", reflect.TypeOf((*MockGradingScaleService)(nil).ListGradingScales), ctx)
+}
+
+// UpdateGradingScale mocks base method.
+func (m *MockGradingScaleService) UpdateGradingScale(ctx context.Context, id uuid.UUID, updates map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGradingScale", ctx, id, updates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGradingScale indicates an expected call of UpdateGradingScale.
+func (mr *MockGradingScaleServiceMockRecorder) UpdateGradingScale(ctx, id, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGradingScale", reflect.TypeOf((*MockGradingScaleService)(nil).UpdateGradingScale), ctx, id, updates)
+}
+
+// MockCurriculumService is a mock of CurriculumService interface.
+type MockCurriculumService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurriculumServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCurriculumServiceMockRecorder is the mock recorder for MockCurriculumService.
+type MockCurriculumServiceMockRecorder struct {
+	mock *MockCurriculumService
+}
+
+// NewMockCurriculumService creates a new mock instance.
+func NewMockCurriculumService(ctrl *gomock.Controller) *MockCurriculumService {
+	mock := &MockCurriculumService{ctrl: ctrl}
+	mock.recorder = &MockCurriculumServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurriculumService) EXPECT() *MockCurriculumServiceMockRecorder {
+	return m.recorder
+}
+
+// AuditDegree mocks base method.
+func (m *MockCurriculumService) AuditDegree(ctx context.Context, studentID uuid.UUID) (*domain.DegreeAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditDegree", ctx, studentID)
+	ret0, _ := ret[0].(*domain.DegreeAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditDegree indicates an expected call of AuditDegree.
+func (mr *MockCurriculumServiceMockRecorder) AuditDegree(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditDegree", reflect.TypeOf((*MockCurriculumService)(nil).AuditDegree), ctx, studentID)
+}
+
+// CreateCurriculum mocks base method.
+func (m *MockCurriculumService) CreateCurriculum(ctx context.Context, curriculum *domain.Curriculum) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCurriculum", ctx, curriculum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCurriculum indicates an expected call of CreateCurriculum.
+func (mr *MockCurriculumServiceMockRecorder) CreateCurriculum(ctx, curriculum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCurriculum", reflect.TypeOf((*MockCurriculumService)(nil).CreateCurriculum), ctx, curriculum)
+}
+
+// DeleteCurriculum mocks base method.
+func (m *MockCurriculumService) DeleteCurriculum(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCurriculum", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCurriculum indicates an expected call of DeleteCurriculum.
+func (mr *MockCurriculumServiceMockRecorder) DeleteCurriculum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCurriculum", reflect.TypeOf((*MockCurriculumService)(nil).DeleteCurriculum), ctx, id)
+}
+
+// GetCurriculum mocks base method.
+func (m *MockCurriculumService) GetCurriculum(ctx context.Context, id uuid.UUID) (*domain.Curriculum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurriculum", ctx, id)
+	ret0, _ := ret[0].(*domain.Curriculum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurriculum indicates an expected call of GetCurriculum.
+func (mr *MockCurriculumServiceMockRecorder) GetCurriculum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurriculum", reflect.TypeOf((*MockCurriculumService)(nil).GetCurriculum), ctx, id)
+}
+
+// ListCurricula mocks base method.
+func (m *MockCurriculumService) ListCurricula(ctx context.Context, programID uuid.UUID) ([]*domain.Curriculum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurricula", ctx, programID)
+	ret0, _ := ret[0].([]*domain.Curriculum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurricula indicates an expected call of ListCurricula.
+func (mr *MockCurriculumServiceMockRecorder) ListCurricula(ctx, programID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurricula", reflect.TypeOf((*MockCurriculumService)(nil).ListCurricula), ctx, programID)
+}
+
+// UpdateCurriculum mocks base method.
+func (m *MockCurriculumService) UpdateCurriculum(ctx context.Context, id uuid.UUID, updates map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurriculum", ctx, id, updates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCurriculum indicates an expected call of UpdateCurriculum.
+func (mr *MockCurriculumServiceMockRecorder) UpdateCurriculum(ctx, id, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurriculum", reflect.TypeOf((*MockCurriculumService)(nil).UpdateCurriculum), ctx, id, updates)
+}
+
+// MockSemesterRolloverService is a mock of SemesterRolloverService interface.
+type MockSemesterRolloverService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSemesterRolloverServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSemesterRolloverServiceMockRecorder is the mock recorder for MockSemesterRolloverService.
+type MockSemesterRolloverServiceMockRecorder struct {
+	mock *MockSemesterRolloverService
+}
+
+// NewMockSemesterRolloverService creates a new mock instance.
+func NewMockSemesterRolloverService(ctrl *gomock.Controller) *MockSemesterRolloverService {
+	mock := &MockSemesterRolloverService{ctrl: ctrl}
+	mock.recorder = &MockSemesterRolloverServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSemesterRolloverService) EXPECT() *MockSemesterRolloverServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRollover mocks base method.
+func (m *MockSemesterRolloverService) GetRollover(ctx context.Context, semesterID uuid.UUID) (*domain.SemesterRollover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRollover", ctx, semesterID)
+	ret0, _ := ret[0].(*domain.SemesterRollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRollover indicates an expected call of GetRollover.
+func (mr *MockSemesterRolloverServiceMockRecorder) GetRollover(ctx, semesterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRollover", reflect.TypeOf((*MockSemesterRolloverService)(nil).GetRollover), ctx, semesterID)
+}
+
+// PreviewRollover mocks base method.
+func (m *MockSemesterRolloverService) PreviewRollover(ctx context.Context, semesterID, nextSemesterID uuid.UUID) (*domain.RolloverPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewRollover", ctx, semesterID, nextSemesterID)
+	ret0, _ := ret[0].(*domain.RolloverPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewRollover indicates an expected call of PreviewRollover.
+func (mr *MockSemesterRolloverServiceMockRecorder) PreviewRollover(ctx, semesterID, nextSemesterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewRollover", reflect.TypeOf((*MockSemesterRolloverService)(nil).PreviewRollover), ctx, semesterID, nextSemesterID)
+}
+
+// StartRollover mocks base method.
+func (m *MockSemesterRolloverService) StartRollover(ctx context.Context, semesterID, nextSemesterID, requestedBy uuid.UUID) (*domain.SemesterRollover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRollover", ctx, semesterID, nextSemesterID, requestedBy)
+	ret0, _ := ret[0].(*domain.SemesterRollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartRollover indicates an expected call of StartRollover.
+func (mr *MockSemesterRolloverServiceMockRecorder) StartRollover(ctx, semesterID, nextSemesterID, requestedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRollover", reflect.TypeOf((*MockSemesterRolloverService)(nil).StartRollover), ctx, semesterID, nextSemesterID, requestedBy)
+}
+
 // MockFacultyAssignmentService is a mock of FacultyAssignmentService interface.
 type MockFacultyAssignmentService struct {
 	ctrl     *gomock.Controller
@@ -1008,13 +1330,12 @@ func (mr *MockEnrollmentServiceMockRecorder) BulkEnroll(ctx, courseID, studentID
 }
 
 // CheckPrerequisites mocks base method.
-func (m *MockEnrollmentService) CheckPrerequisites(ctx context.Context, studentID, courseID uuid.UUID) (bool, []domain.SubjectBasic, error) {
+func (m *MockEnrollmentService) CheckPrerequisites(ctx context.Context, studentID, courseID uuid.UUID) (*domain.RequirementCheck, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CheckPrerequisites", ctx, studentID, courseID)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].([]domain.SubjectBasic)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(*domain.RequirementCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CheckPrerequisites indicates an expected call of CheckPrerequisites.
@@ -1024,32 +1345,47 @@ func (mr *MockEnrollmentServiceMockRecorder) CheckPrerequisites(ctx, studentID,
 }
 
 // DropCourse mocks base method.
-func (m *MockEnrollmentService) DropCourse(ctx context.Context, courseID, studentID uuid.UUID, reason string) error {
+func (m *MockEnrollmentService) DropCourse(ctx context.Context, courseID, studentID uuid.UUID, reason string, override *domain.DeadlineOverride) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DropCourse", ctx, courseID, studentID, reason)
+	ret := m.ctrl.Call(m, "DropCourse", ctx, courseID, studentID, reason, override)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DropCourse indicates an expected call of DropCourse.
-func (mr *MockEnrollmentServiceMockRecorder) DropCourse(ctx, courseID, studentID, reason any) *gomock.Call {
+func (mr *MockEnrollmentServiceMockRecorder) DropCourse(ctx, courseID, studentID, reason, override any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropCourse", reflect.TypeOf((*MockEnrollmentService)(nil).DropCourse), ctx, courseID, studentID, reason)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropCourse", reflect.TypeOf((*MockEnrollmentService)(nil).DropCourse), ctx, courseID, studentID, reason, override)
 }
 
 // EnrollStudent mocks base method.
-func (m *MockEnrollmentService) EnrollStudent(ctx context.Context, courseID, studentID uuid.UUID, enrolledBy string) (*domain.CourseEnrollment, error) {
+func (m *MockEnrollmentService) EnrollStudent(ctx context.Context, courseID, studentID uuid.UUID, enrolledBy string, override *domain.DeadlineOverride) (*domain.CourseEnrollment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnrollStudent", ctx, courseID, studentID, enrolledBy)
+	ret := m.ctrl.Call(m, "EnrollStudent", ctx, courseID, studentID, enrolledBy, override)
 	ret0, _ := ret[0].(*domain.CourseEnrollment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EnrollStudent indicates an expected call of EnrollStudent.
-func (mr *MockEnrollmentServiceMockRecorder) EnrollStudent(ctx, courseID, studentID, enrolledBy any) *gomock.Call {
+func (mr *MockEnrollmentServiceMockRecorder) EnrollStudent(ctx, courseID, studentID, enrolledBy, override any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollStudent", reflect.TypeOf((*MockEnrollmentService)(nil).EnrollStudent), ctx, courseID, studentID, enrolledBy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollStudent", reflect.TypeOf((*MockEnrollmentService)(nil).EnrollStudent), ctx, courseID, studentID, enrolledBy, override)
+}
+
+// GetEnrollment mocks base method.
+func (m *MockEnrollmentService) GetEnrollment(ctx context.Context, enrollmentID uuid.UUID) (*domain.CourseEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnrollment", ctx, enrollmentID)
+	ret0, _ := ret[0].(*domain.CourseEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnrollment indicates an expected call of GetEnrollment.
+func (mr *MockEnrollmentServiceMockRecorder) GetEnrollment(ctx, enrollmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnrollment", reflect.TypeOf((*MockEnrollmentService)(nil).GetEnrollment), ctx, enrollmentID)
 }
 
 // GetStudentEnrollments mocks base method.
@@ -1068,18 +1404,47 @@ func (mr *MockEnrollmentServiceMockRecorder) GetStudentEnrollments(ctx, studentI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentEnrollments", reflect.TypeOf((*MockEnrollmentService)(nil).GetStudentEnrollments), ctx, studentID, filter, page, limit)
 }
 
+// GrantWaiver mocks base method.
+func (m *MockEnrollmentService) GrantWaiver(ctx context.Context, waiver *domain.PrerequisiteWaiver) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantWaiver", ctx, waiver)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantWaiver indicates an expected call of GrantWaiver.
+func (mr *MockEnrollmentServiceMockRecorder) GrantWaiver(ctx, waiver any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantWaiver", reflect.TypeOf((*MockEnrollmentService)(nil).GrantWaiver), ctx, waiver)
+}
+
+// ListWaivers mocks base method.
+func (m *MockEnrollmentService) ListWaivers(ctx context.Context, studentID, courseID uuid.UUID) ([]*domain.PrerequisiteWaiver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWaivers", ctx, studentID, courseID)
+	ret0, _ := ret[0].([]*domain.PrerequisiteWaiver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWaivers indicates an expected call of ListWaivers.
+func (mr *MockEnrollmentServiceMockRecorder) ListWaivers(ctx, studentID, courseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWaivers", reflect.TypeOf((*MockEnrollmentService)(nil).ListWaivers), ctx, studentID, courseID)
+}
+
 // UpdateEnrollment mocks base method.
-func (m *MockEnrollmentService) UpdateEnrollment(ctx context.Context, enrollmentID uuid.UUID, status string, grade *string, gradePoints *float64) error {
+func (m *MockEnrollmentService) UpdateEnrollment(ctx context.Context, enrollmentID uuid.UUID, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEnrollment", ctx, enrollmentID, status, grade, gradePoints)
+	ret := m.ctrl.Call(m, "UpdateEnrollment", ctx, enrollmentID, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateEnrollment indicates an expected call of UpdateEnrollment.
-func (mr *MockEnrollmentServiceMockRecorder) UpdateEnrollment(ctx, enrollmentID, status, grade, gradePoints any) *gomock.Call {
+func (mr *MockEnrollmentServiceMockRecorder) UpdateEnrollment(ctx, enrollmentID, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnrollment", reflect.TypeOf((*MockEnrollmentService)(nil).UpdateEnrollment), ctx, enrollmentID, status, grade, gradePoints)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnrollment", reflect.TypeOf((*MockEnrollmentService)(nil).UpdateEnrollment), ctx, enrollmentID, status)
 }
 
 // MockCalendarService is a mock of CalendarService interface.
@@ -1179,6 +1544,200 @@ func (mr *MockCalendarServiceMockRecorder) UpdateEvent(ctx, id, updates any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockCalendarService)(nil).UpdateEvent), ctx, id, updates)
 }
 
+// MockGradebookService is a mock of GradebookService interface.
+type MockGradebookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGradebookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGradebookServiceMockRecorder is the mock recorder for MockGradebookService.
+type MockGradebookServiceMockRecorder struct {
+	mock *MockGradebookService
+}
+
+// NewMockGradebookService creates a new mock instance.
+func NewMockGradebookService(ctrl *gomock.Controller) *MockGradebookService {
+	mock := &MockGradebookService{ctrl: ctrl}
+	mock.recorder = &MockGradebookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGradebookService) EXPECT() *MockGradebookServiceMockRecorder {
+	return m.recorder
+}
+
+// ApproveAmendment mocks base method.
+func (m *MockGradebookService) ApproveAmendment(ctx context.Context, amendmentID uuid.UUID, comment *string, actor domain.GradeActor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveAmendment", ctx, amendmentID, comment, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveAmendment indicates an expected call of ApproveAmendment.
+func (mr *MockGradebookServiceMockRecorder) ApproveAmendment(ctx, amendmentID, comment, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveAmendment", reflect.TypeOf((*MockGradebookService)(nil).ApproveAmendment), ctx, amendmentID, comment, actor)
+}
+
+// ApproveGrades mocks base method.
+func (m *MockGradebookService) ApproveGrades(ctx context.Context, courseID uuid.UUID, comment *string, actor domain.GradeActor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveGrades", ctx, courseID, comment, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveGrades indicates an expected call of ApproveGrades.
+func (mr *MockGradebookServiceMockRecorder) ApproveGrades(ctx, courseID, comment, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveGrades", reflect.TypeOf((*MockGradebookService)(nil).ApproveGrades), ctx, courseID, comment, actor)
+}
+
+// GetGradebook mocks base method.
+func (m *MockGradebookService) GetGradebook(ctx context.Context, courseID uuid.UUID, actor domain.GradeActor) (*domain.Gradebook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGradebook", ctx, courseID, actor)
+	ret0, _ := ret[0].(*domain.Gradebook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGradebook indicates an expected call of GetGradebook.
+func (mr *MockGradebookServiceMockRecorder) GetGradebook(ctx, courseID, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradebook", reflect.TypeOf((*MockGradebookService)(nil).GetGradebook), ctx, courseID, actor)
+}
+
+// ListAmendments mocks base method.
+func (m *MockGradebookService) ListAmendments(ctx context.Context, courseID uuid.UUID, actor domain.GradeActor) ([]*domain.GradeAmendment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAmendments", ctx, courseID, actor)
+	ret0, _ := ret[0].([]*domain.GradeAmendment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAmendments indicates an expected call of ListAmendments.
+func (mr *MockGradebookServiceMockRecorder) ListAmendments(ctx, courseID, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAmendments", reflect.TypeOf((*MockGradebookService)(nil).ListAmendments), ctx, courseID, actor)
+}
+
+// RejectAmendment mocks base method.
+func (m *MockGradebookService) RejectAmendment(ctx context.Context, amendmentID uuid.UUID, comment string, actor domain.GradeActor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectAmendment", ctx, amendmentID, comment, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectAmendment indicates an expected call of RejectAmendment.
+func (mr *MockGradebookServiceMockRecorder) RejectAmendment(ctx, amendmentID, comment, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectAmendment", reflect.TypeOf((*MockGradebookService)(nil).RejectAmendment), ctx, amendmentID, comment, actor)
+}
+
+// RequestAmendment mocks base method.
+func (m *MockGradebookService) RequestAmendment(ctx context.Context, enrollmentID uuid.UUID, grade, reason string, actor domain.GradeActor) (*domain.GradeAmendment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAmendment", ctx, enrollmentID, grade, reason, actor)
+	ret0, _ := ret[0].(*domain.GradeAmendment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestAmendment indicates an expected call of RequestAmendment.
+func (mr *MockGradebookServiceMockRecorder) RequestAmendment(ctx, enrollmentID, grade, reason, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAmendment", reflect.TypeOf((*MockGradebookService)(nil).RequestAmendment), ctx, enrollmentID, grade, reason, actor)
+}
+
+// ReturnGrades mocks base method.
+func (m *MockGradebookService) ReturnGrades(ctx context.Context, courseID uuid.UUID, comment string, actor domain.GradeActor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnGrades", ctx, courseID, comment, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReturnGrades indicates an expected call of ReturnGrades.
+func (mr *MockGradebookServiceMockRecorder) ReturnGrades(ctx, courseID, comment, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnGrades", reflect.TypeOf((*MockGradebookService)(nil).ReturnGrades), ctx, courseID, comment, actor)
+}
+
+// SubmitGrades mocks base method.
+func (m *MockGradebookService) SubmitGrades(ctx context.Context, courseID uuid.UUID, grades []domain.GradeSubmission, actor domain.GradeActor) (*domain.Gradebook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitGrades", ctx, courseID, grades, actor)
+	ret0, _ := ret[0].(*domain.Gradebook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitGrades indicates an expected call of SubmitGrades.
+func (mr *MockGradebookServiceMockRecorder) SubmitGrades(ctx, courseID, grades, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitGrades", reflect.TypeOf((*MockGradebookService)(nil).SubmitGrades), ctx, courseID, grades, actor)
+}
+
+// MockTranscriptService is a mock of TranscriptService interface.
+type MockTranscriptService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranscriptServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTranscriptServiceMockRecorder is the mock recorder for MockTranscriptService.
+type MockTranscriptServiceMockRecorder struct {
+	mock *MockTranscriptService
+}
+
+// NewMockTranscriptService creates a new mock instance.
+func NewMockTranscriptService(ctrl *gomock.Controller) *MockTranscriptService {
+	mock := &MockTranscriptService{ctrl: ctrl}
+	mock.recorder = &MockTranscriptServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranscriptService) EXPECT() *MockTranscriptServiceMockRecorder {
+	return m.recorder
+}
+
+// IssueTranscript mocks base method.
+func (m *MockTranscriptService) IssueTranscript(ctx context.Context, studentID, issuedBy uuid.UUID) (*domain.Transcript, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueTranscript", ctx, studentID, issuedBy)
+	ret0, _ := ret[0].(*domain.Transcript)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueTranscript indicates an expected call of IssueTranscript.
+func (mr *MockTranscriptServiceMockRecorder) IssueTranscript(ctx, studentID, issuedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueTranscript", reflect.TypeOf((*MockTranscriptService)(nil).IssueTranscript), ctx, studentID, issuedBy)
+}
+
+// VerifyTranscript mocks base method.
+func (m *MockTranscriptService) VerifyTranscript(ctx context.Context, code string) (*domain.TranscriptVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTranscript", ctx, code)
+	ret0, _ := ret[0].(*domain.TranscriptVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyTranscript indicates an expected call of VerifyTranscript.
+func (mr *MockTranscriptServiceMockRecorder) VerifyTranscript(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTranscript", reflect.TypeOf((*MockTranscriptService)(nil).VerifyTranscript), ctx, code)
+}
+
 // MockEventProducer is a mock of EventProducer interface.
 type MockEventProducer struct {
 	ctrl     *gomock.Controller
@@ -1203,30 +1762,54 @@ func (m *MockEventProducer) EXPECT() *MockEventProducerMockRecorder {
 	return m.recorder
 }
 
-// Close mocks base method.
-func (m *MockEventProducer) Close() error {
+// PublishEvent mocks base method.
+func (m *MockEventProducer) PublishEvent(ctx context.Context, topic, key string, event any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Close")
+	ret := m.ctrl.Call(m, "PublishEvent", ctx, topic, key, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Close indicates an expected call of Close.
-func (mr *MockEventProducerMockRecorder) Close() *gomock.Call {
+// PublishEvent indicates an expected call of PublishEvent.
+func (mr *MockEventProducerMockRecorder) PublishEvent(ctx, topic, key, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEventProducer)(nil).Close))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvent", reflect.TypeOf((*MockEventProducer)(nil).PublishEvent), ctx, topic, key, event)
 }
 
-// PublishEvent mocks base method.
-func (m *MockEventProducer) PublishEvent(topic, key string, event any) error {
+// MockUserDirectoryService is a mock of UserDirectoryService interface.
+type MockUserDirectoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDirectoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserDirectoryServiceMockRecorder is the mock recorder for MockUserDirectoryService.
+type MockUserDirectoryServiceMockRecorder struct {
+	mock *MockUserDirectoryService
+}
+
+// NewMockUserDirectoryService creates a new mock instance.
+func NewMockUserDirectoryService(ctrl *gomock.Controller) *MockUserDirectoryService {
+	mock := &MockUserDirectoryService{ctrl: ctrl}
+	mock.recorder = &MockUserDirectoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDirectoryService) EXPECT() *MockUserDirectoryServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleUserEvent mocks base method.
+func (m *MockUserDirectoryService) HandleUserEvent(ctx context.Context, event *models.UserEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PublishEvent", topic, key, event)
+	ret := m.ctrl.Call(m, "HandleUserEvent", ctx, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PublishEvent indicates an expected call of PublishEvent.
-func (mr *MockEventProducerMockRecorder) PublishEvent(topic, key, event any) *gomock.Call {
+// HandleUserEvent indicates an expected call of HandleUserEvent.
+func (mr *MockUserDirectoryServiceMockRecorder) HandleUserEvent(ctx, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvent", reflect.TypeOf((*MockEventProducer)(nil).PublishEvent), topic, key, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUserEvent", reflect.TypeOf((*MockUserDirectoryService)(nil).HandleUserEvent), ctx, event)
 }
@@ -6,16 +6,16 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type calendarRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewCalendarRepository(db *pgxpool.Pool) domain.CalendarRepository {
+func NewCalendarRepository(db *database.DB) domain.CalendarRepository {
 	return &calendarRepository{db: db}
 }
 
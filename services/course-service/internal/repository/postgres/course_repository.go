@@ -6,16 +6,16 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type courseRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewCourseRepository(db *pgxpool.Pool) domain.CourseRepository {
+func NewCourseRepository(db *database.DB) domain.CourseRepository {
 	return &courseRepository{db: db}
 }
 
@@ -295,9 +295,10 @@ func (r *courseRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*d
 
 	// Get faculty assignments
 	facultyQuery := `
-		SELECT f.faculty_id, fc.role, fc.is_primary
+		SELECT f.faculty_id, ` + userNameColumn + `, f.designation, fc.role, fc.is_primary
 		FROM faculty_courses fc
 		JOIN faculties f ON fc.faculty_id = f.faculty_id
+		LEFT JOIN user_directory u ON u.user_id = f.user_id
 		WHERE fc.course_id = $1 AND fc.is_active = true
 	`
 	rows, err := r.db.Query(ctx, facultyQuery, id)
@@ -308,7 +309,7 @@ func (r *courseRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*d
 
 	for rows.Next() {
 		var fb domain.FacultyCourseBasic
-		if err := rows.Scan(&fb.FacultyID, &fb.Role, &fb.IsPrimary); err != nil {
+		if err := rows.Scan(&fb.FacultyID, &fb.Name, &fb.Designation, &fb.Role, &fb.IsPrimary); err != nil {
 			return nil, fmt.Errorf("failed to scan faculty: %w", err)
 		}
 		c.Faculty = append(c.Faculty, fb)
@@ -329,26 +330,70 @@ func (r *courseRepository) UpdateStatus(ctx context.Context, id uuid.UUID, statu
 	return nil
 }
 
-func (r *courseRepository) IncrementEnrollment(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE courses SET current_enrollment = current_enrollment + 1, updated_at = now() WHERE course_id = $1`
-	result, err := r.db.Exec(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to increment enrollment: %w", err)
+func (r *courseRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Course, error) {
+	query := `
+		SELECT course_id, course_code, course_name, subject_id, department_id, program_id,
+			   semester_id, semester_number, academic_year, max_students, current_enrollment, status,
+			   description, is_active, created_by, created_at, updated_at
+		FROM courses
+		WHERE course_id = $1
+		FOR UPDATE
+	`
+	var c domain.Course
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&c.CourseID, &c.CourseCode, &c.CourseName, &c.SubjectID, &c.DepartmentID, &c.ProgramID,
+		&c.SemesterID, &c.SemesterNumber, &c.AcademicYear, &c.MaxStudents, &c.CurrentEnrollment, &c.Status,
+		&c.Description, &c.IsActive, &c.CreatedBy, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrCourseNotFound
 	}
-	if result.RowsAffected() == 0 {
-		return domain.ErrCourseNotFound
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock course: %w", err)
 	}
-	return nil
+	return &c, nil
 }
 
-func (r *courseRepository) DecrementEnrollment(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE courses SET current_enrollment = GREATEST(current_enrollment - 1, 0), updated_at = now() WHERE course_id = $1`
-	result, err := r.db.Exec(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to decrement enrollment: %w", err)
-	}
-	if result.RowsAffected() == 0 {
-		return domain.ErrCourseNotFound
-	}
-	return nil
+func (r *courseRepository) ReconcileEnrollmentCounts(ctx context.Context) (int64, error) {
+	var corrected int64
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the drifted courses first, so the counts below are taken after
+		// concurrent enrollment changes of those courses have committed
+		lockQuery := `
+			SELECT c.course_id
+			FROM courses c
+			WHERE c.current_enrollment <> (
+				SELECT COUNT(*) FROM course_enrollments e
+				WHERE e.course_id = c.course_id AND e.enrollment_status = 'enrolled'
+			)
+			FOR UPDATE OF c
+		`
+		rows, err := r.db.Query(ctx, lockQuery)
+		if err != nil {
+			return fmt.Errorf("failed to find drifted enrollment counts: %w", err)
+		}
+		courseIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+		if err != nil {
+			return fmt.Errorf("failed to scan drifted course: %w", err)
+		}
+		if len(courseIDs) == 0 {
+			return nil
+		}
+
+		updateQuery := `
+			UPDATE courses c
+			SET current_enrollment = (
+				SELECT COUNT(*) FROM course_enrollments e
+				WHERE e.course_id = c.course_id AND e.enrollment_status = 'enrolled'
+			), updated_at = now()
+			WHERE c.course_id = ANY($1)
+		`
+		result, err := r.db.Exec(ctx, updateQuery, courseIDs)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile enrollment counts: %w", err)
+		}
+		corrected = result.RowsAffected()
+		return nil
+	})
+	return corrected, err
 }
@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type curriculumRepository struct {
+	db *database.DB
+}
+
+func NewCurriculumRepository(db *database.DB) domain.CurriculumRepository {
+	return &curriculumRepository{db: db}
+}
+
+func (r *curriculumRepository) Create(ctx context.Context, curriculum *domain.Curriculum) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO curricula (curriculum_id, program_id, batch_year, version_name, total_credits)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING created_at, updated_at
+		`
+		curriculum.CurriculumID = uuid.New()
+		err := r.db.QueryRow(ctx, query,
+			curriculum.CurriculumID,
+			curriculum.ProgramID,
+			curriculum.BatchYear,
+			curriculum.VersionName,
+			curriculum.TotalCredits,
+		).Scan(&curriculum.CreatedAt, &curriculum.UpdatedAt)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return domain.ErrCurriculumExists
+			}
+			if strings.Contains(err.Error(), "foreign key") {
+				return domain.ErrProgramNotFound
+			}
+			return fmt.Errorf("failed to create curriculum: %w", err)
+		}
+
+		if err := r.insertRequirements(ctx, curriculum); err != nil {
+			return err
+		}
+		return r.loadRequirements(ctx, []*domain.Curriculum{curriculum})
+	})
+}
+
+func (r *curriculumRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Curriculum, error) {
+	query := `
+		SELECT curriculum_id, program_id, batch_year, version_name, total_credits, created_at, updated_at
+		FROM curricula
+		WHERE curriculum_id = $1
+	`
+	return r.getCurriculum(ctx, query, id)
+}
+
+func (r *curriculumRepository) GetForBatch(ctx context.Context, programID uuid.UUID, batchYear int) (*domain.Curriculum, error) {
+	query := `
+		SELECT curriculum_id, program_id, batch_year, version_name, total_credits, created_at, updated_at
+		FROM curricula
+		WHERE program_id = $1 AND batch_year <= $2
+		ORDER BY batch_year DESC
+		LIMIT 1
+	`
+	return r.getCurriculum(ctx, query, programID, batchYear)
+}
+
+func (r *curriculumRepository) Update(ctx context.Context, curriculum *domain.Curriculum) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			UPDATE curricula
+			SET batch_year = $2, version_name = $3, total_credits = $4, updated_at = now()
+			WHERE curriculum_id = $1
+			RETURNING updated_at
+		`
+		err := r.db.QueryRow(ctx, query,
+			curriculum.CurriculumID,
+			curriculum.BatchYear,
+			curriculum.VersionName,
+			curriculum.TotalCredits,
+		).Scan(&curriculum.UpdatedAt)
+		if err == pgx.ErrNoRows {
+			return domain.ErrCurriculumNotFound
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return domain.ErrCurriculumExists
+			}
+			return fmt.Errorf("failed to update curriculum: %w", err)
+		}
+
+		// Subjects of the elective buckets are removed with their buckets
+		if _, err := r.db.Exec(ctx, `DELETE FROM curriculum_core_subjects WHERE curriculum_id = $1`, curriculum.CurriculumID); err != nil {
+			return fmt.Errorf("failed to replace core subjects: %w", err)
+		}
+		if _, err := r.db.Exec(ctx, `DELETE FROM curriculum_elective_buckets WHERE curriculum_id = $1`, curriculum.CurriculumID); err != nil {
+			return fmt.Errorf("failed to replace elective buckets: %w", err)
+		}
+		if err := r.insertRequirements(ctx, curriculum); err != nil {
+			return err
+		}
+		return r.loadRequirements(ctx, []*domain.Curriculum{curriculum})
+	})
+}
+
+func (r *curriculumRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM curricula WHERE curriculum_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete curriculum: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCurriculumNotFound
+	}
+	return nil
+}
+
+func (r *curriculumRepository) ListByProgram(ctx context.Context, programID uuid.UUID) ([]*domain.Curriculum, error) {
+	query := `
+		SELECT curriculum_id, program_id, batch_year, version_name, total_credits, created_at, updated_at
+		FROM curricula
+		WHERE program_id = $1
+		ORDER BY batch_year DESC
+	`
+	rows, err := r.db.Query(ctx, query, programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list curricula: %w", err)
+	}
+	defer rows.Close()
+
+	var curricula []*domain.Curriculum
+	for rows.Next() {
+		var c domain.Curriculum
+		if err := rows.Scan(
+			&c.CurriculumID, &c.ProgramID, &c.BatchYear, &c.VersionName, &c.TotalCredits, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan curriculum: %w", err)
+		}
+		curricula = append(curricula, &c)
+	}
+	rows.Close()
+
+	if err := r.loadRequirements(ctx, curricula); err != nil {
+		return nil, err
+	}
+	return curricula, nil
+}
+
+func (r *curriculumRepository) getCurriculum(ctx context.Context, query string, args ...interface{}) (*domain.Curriculum, error) {
+	var c domain.Curriculum
+	err := r.db.QueryRow(ctx, query, args...).Scan(
+		&c.CurriculumID, &c.ProgramID, &c.BatchYear, &c.VersionName, &c.TotalCredits, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrCurriculumNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get curriculum: %w", err)
+	}
+
+	if err := r.loadRequirements(ctx, []*domain.Curriculum{&c}); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *curriculumRepository) insertRequirements(ctx context.Context, curriculum *domain.Curriculum) error {
+	coreQuery := `INSERT INTO curriculum_core_subjects (curriculum_id, subject_id, semester_number) VALUES ($1, $2, $3)`
+	for _, s := range curriculum.CoreSubjects {
+		if _, err := r.db.Exec(ctx, coreQuery, curriculum.CurriculumID, s.SubjectID, s.SemesterNumber); err != nil {
+			if strings.Contains(err.Error(), "foreign key") {
+				return domain.ErrSubjectNotFound
+			}
+			return fmt.Errorf("failed to add core subject: %w", err)
+		}
+	}
+
+	bucketQuery := `INSERT INTO curriculum_elective_buckets (bucket_id, curriculum_id, bucket_name, min_credits) VALUES ($1, $2, $3, $4)`
+	subjectQuery := `INSERT INTO curriculum_elective_subjects (bucket_id, subject_id) VALUES ($1, $2)`
+	for i := range curriculum.ElectiveBuckets {
+		b := &curriculum.ElectiveBuckets[i]
+		b.BucketID = uuid.New()
+		if _, err := r.db.Exec(ctx, bucketQuery, b.BucketID, curriculum.CurriculumID, b.BucketName, b.MinCredits); err != nil {
+			return fmt.Errorf("failed to add elective bucket %s: %w", b.BucketName, err)
+		}
+		for _, s := range b.Subjects {
+			if _, err := r.db.Exec(ctx, subjectQuery, b.BucketID, s.SubjectID); err != nil {
+				if strings.Contains(err.Error(), "foreign key") {
+					return domain.ErrSubjectNotFound
+				}
+				return fmt.Errorf("failed to add elective subject: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// loadRequirements fills in the core subjects and elective buckets of each of
+// the curricula, with the details of their subjects
+func (r *curriculumRepository) loadRequirements(ctx context.Context, curricula []*domain.Curriculum) error {
+	byID := make(map[uuid.UUID]*domain.Curriculum, len(curricula))
+	ids := make([]uuid.UUID, 0, len(curricula))
+	for _, c := range curricula {
+		c.CoreSubjects = []domain.CoreSubject{}
+		c.ElectiveBuckets = []domain.ElectiveBucket{}
+		byID[c.CurriculumID] = c
+		ids = append(ids, c.CurriculumID)
+	}
+
+	coreQuery := `
+		SELECT cs.curriculum_id, s.subject_id, s.subject_code, s.subject_name, s.credits, s.subject_type, cs.semester_number
+		FROM curriculum_core_subjects cs
+		JOIN subjects s ON cs.subject_id = s.subject_id
+		WHERE cs.curriculum_id = ANY($1)
+		ORDER BY cs.semester_number, s.subject_code
+	`
+	rows, err := r.db.Query(ctx, coreQuery, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list core subjects: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var curriculumID uuid.UUID
+		var s domain.CoreSubject
+		if err := rows.Scan(&curriculumID, &s.SubjectID, &s.SubjectCode, &s.SubjectName, &s.Credits, &s.SubjectType, &s.SemesterNumber); err != nil {
+			return fmt.Errorf("failed to scan core subject: %w", err)
+		}
+		c := byID[curriculumID]
+		c.CoreSubjects = append(c.CoreSubjects, s)
+	}
+	rows.Close()
+
+	bucketQuery := `
+		SELECT b.curriculum_id, b.bucket_id, b.bucket_name, b.min_credits,
+			   s.subject_id, s.subject_code, s.subject_name, s.credits, s.subject_type
+		FROM curriculum_elective_buckets b
+		LEFT JOIN curriculum_elective_subjects es ON b.bucket_id = es.bucket_id
+		LEFT JOIN subjects s ON es.subject_id = s.subject_id
+		WHERE b.curriculum_id = ANY($1)
+		ORDER BY b.bucket_name, b.bucket_id, s.subject_code
+	`
+	rows, err = r.db.Query(ctx, bucketQuery, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list elective buckets: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var curriculumID uuid.UUID
+		var b domain.ElectiveBucket
+		var subjectID *uuid.UUID
+		var subjectCode, subjectName, subjectType *string
+		var credits *int
+		if err := rows.Scan(&curriculumID, &b.BucketID, &b.BucketName, &b.MinCredits,
+			&subjectID, &subjectCode, &subjectName, &credits, &subjectType); err != nil {
+			return fmt.Errorf("failed to scan elective bucket: %w", err)
+		}
+
+		c := byID[curriculumID]
+		if n := len(c.ElectiveBuckets); n == 0 || c.ElectiveBuckets[n-1].BucketID != b.BucketID {
+			b.Subjects = []domain.SubjectBasic{}
+			c.ElectiveBuckets = append(c.ElectiveBuckets, b)
+		}
+		if subjectID != nil {
+			bucket := &c.ElectiveBuckets[len(c.ElectiveBuckets)-1]
+			bucket.Subjects = append(bucket.Subjects, domain.SubjectBasic{
+				SubjectID:   *subjectID,
+				SubjectCode: *subjectCode,
+				SubjectName: *subjectName,
+				Credits:     *credits,
+				SubjectType: subjectType,
+			})
+		}
+	}
+	return nil
+}
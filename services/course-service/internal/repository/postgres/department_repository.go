@@ -6,16 +6,16 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type departmentRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewDepartmentRepository(db *pgxpool.Pool) domain.DepartmentRepository {
+func NewDepartmentRepository(db *database.DB) domain.DepartmentRepository {
 	return &departmentRepository{db: db}
 }
 
@@ -181,22 +181,24 @@ func (r *departmentRepository) GetWithDetails(ctx context.Context, id uuid.UUID)
 	query := `
 		SELECT d.department_id, d.department_name, d.department_code, d.head_of_department,
 			   d.description, d.is_active, d.created_at, d.updated_at,
-			   f.faculty_id, f.employee_id, f.designation,
+			   f.faculty_id, f.employee_id, f.designation, ` + userNameColumn + `,
 			   (SELECT COUNT(*) FROM programs p WHERE p.department_id = d.department_id AND p.is_active = true) as programs_count,
 			   (SELECT COUNT(*) FROM faculties fa WHERE fa.department_id = d.department_id AND fa.is_active = true) as faculty_count,
 			   (SELECT COUNT(*) FROM students s WHERE s.department_id = d.department_id AND s.is_active = true) as students_count
 		FROM departments d
 		LEFT JOIN faculties f ON d.head_of_department = f.faculty_id
+		LEFT JOIN user_directory u ON u.user_id = f.user_id
 		WHERE d.department_id = $1
 	`
 
 	var dd domain.DepartmentWithDetails
 	var facultyID, employeeID, designation *string
+	var headName string
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&dd.DepartmentID, &dd.DepartmentName, &dd.DepartmentCode, &dd.HeadOfDepartment,
 		&dd.Description, &dd.IsActive, &dd.CreatedAt, &dd.UpdatedAt,
-		&facultyID, &employeeID, &designation,
+		&facultyID, &employeeID, &designation, &headName,
 		&dd.ProgramsCount, &dd.FacultyCount, &dd.StudentsCount,
 	)
 
@@ -214,7 +216,7 @@ func (r *departmentRepository) GetWithDetails(ctx context.Context, id uuid.UUID)
 			FacultyID:   fid,
 			EmployeeID:  *employeeID,
 			Designation: designation,
-			Name:        "", // Would need to join with user service for name
+			Name:        headName,
 		}
 	}
 
@@ -7,16 +7,16 @@ import (
 	"time"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type enrollmentRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewEnrollmentRepository(db *pgxpool.Pool) domain.EnrollmentRepository {
+func NewEnrollmentRepository(db *database.DB) domain.EnrollmentRepository {
 	return &enrollmentRepository{db: db}
 }
 
@@ -45,6 +45,54 @@ func (r *enrollmentRepository) Create(ctx context.Context, enrollment *domain.Co
 	return nil
 }
 
+func (r *enrollmentRepository) CreateBatch(ctx context.Context, enrollments []*domain.CourseEnrollment) error {
+	query := `
+		INSERT INTO course_enrollments (enrollment_id, student_id, course_id, enrollment_status, enrolled_by, waitlist_position)
+		SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::uuid[], $4::varchar[], $5::varchar[], $6::int[])
+		RETURNING enrollment_id, enrollment_date, created_at, updated_at
+	`
+	byID := make(map[uuid.UUID]*domain.CourseEnrollment, len(enrollments))
+	ids := make([]uuid.UUID, len(enrollments))
+	studentIDs := make([]uuid.UUID, len(enrollments))
+	courseIDs := make([]uuid.UUID, len(enrollments))
+	statuses := make([]string, len(enrollments))
+	enrolledBy := make([]string, len(enrollments))
+	positions := make([]*int, len(enrollments))
+	for i, e := range enrollments {
+		e.EnrollmentID = uuid.New()
+		byID[e.EnrollmentID] = e
+		ids[i] = e.EnrollmentID
+		studentIDs[i] = e.StudentID
+		courseIDs[i] = e.CourseID
+		statuses[i] = e.EnrollmentStatus
+		enrolledBy[i] = e.EnrolledBy
+		positions[i] = e.WaitlistPosition
+	}
+
+	rows, err := r.db.Query(ctx, query, ids, studentIDs, courseIDs, statuses, enrolledBy, positions)
+	if err != nil {
+		return fmt.Errorf("failed to create enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var enrollmentDate, createdAt, updatedAt time.Time
+		if err := rows.Scan(&id, &enrollmentDate, &createdAt, &updatedAt); err != nil {
+			return fmt.Errorf("failed to scan enrollment: %w", err)
+		}
+		e := byID[id]
+		e.EnrollmentDate, e.CreatedAt, e.UpdatedAt = enrollmentDate, createdAt, updatedAt
+	}
+	if err := rows.Err(); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return domain.ErrAlreadyEnrolled
+		}
+		return fmt.Errorf("failed to create enrollments: %w", err)
+	}
+	return nil
+}
+
 func (r *enrollmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CourseEnrollment, error) {
 	query := `
 		SELECT enrollment_id, student_id, course_id, enrollment_status, enrolled_by, enrollment_date,
@@ -87,6 +135,25 @@ func (r *enrollmentRepository) GetByStudentAndCourse(ctx context.Context, studen
 	return &e, nil
 }
 
+func (r *enrollmentRepository) ListEnrolledStudentIDs(ctx context.Context, courseID uuid.UUID, studentIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	query := `SELECT student_id FROM course_enrollments WHERE course_id = $1 AND student_id = ANY($2)`
+	rows, err := r.db.Query(ctx, query, courseID, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enrolled students: %w", err)
+	}
+	defer rows.Close()
+
+	enrolled := make(map[uuid.UUID]bool, len(studentIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan student id: %w", err)
+		}
+		enrolled[id] = true
+	}
+	return enrolled, rows.Err()
+}
+
 func (r *enrollmentRepository) Update(ctx context.Context, enrollment *domain.CourseEnrollment) error {
 	query := `
 		UPDATE course_enrollments
@@ -170,17 +237,18 @@ func (r *enrollmentRepository) ListByStudent(ctx context.Context, filter domain.
 	listQuery := fmt.Sprintf(`
 		SELECT e.enrollment_id, e.student_id, e.course_id, e.enrollment_status, e.enrolled_by, e.enrollment_date,
 			   e.dropped_date, e.drop_reason, e.completion_date, e.grade, e.grade_points, e.waitlist_position, e.created_at, e.updated_at,
-			   s.student_id, s.registration_number,
+			   s.student_id, s.user_id, s.registration_number, %s, %s,
 			   c.course_id, c.course_code, c.course_name,
 			   sem.semester_id, sem.semester_name, sem.semester_code
 		FROM course_enrollments e
 		JOIN students s ON e.student_id = s.student_id
+		LEFT JOIN user_directory u ON u.user_id = s.user_id
 		JOIN courses c ON e.course_id = c.course_id
 		JOIN semesters sem ON c.semester_id = sem.semester_id
 		%s
 		ORDER BY e.enrollment_date DESC
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argNum, argNum+1)
+	`, userNameColumn, userEmailColumn, whereClause, argNum, argNum+1)
 
 	rows, err := r.db.Query(ctx, listQuery, args...)
 	if err != nil {
@@ -195,7 +263,7 @@ func (r *enrollmentRepository) ListByStudent(ctx context.Context, filter domain.
 		if err := rows.Scan(
 			&e.EnrollmentID, &e.StudentID, &e.CourseID, &e.EnrollmentStatus, &e.EnrolledBy, &e.EnrollmentDate,
 			&e.DroppedDate, &e.DropReason, &e.CompletionDate, &e.Grade, &e.GradePoints, &e.WaitlistPosition, &e.CreatedAt, &e.UpdatedAt,
-			&e.Student.StudentID, &e.Student.RegistrationNumber,
+			&e.Student.StudentID, &e.Student.UserID, &e.Student.RegistrationNumber, &e.Student.Name, &e.Student.Email,
 			&e.Course.CourseID, &e.Course.CourseCode, &e.Course.CourseName,
 			&sem.SemesterID, &sem.SemesterName, &sem.SemesterCode,
 		); err != nil {
@@ -235,15 +303,16 @@ func (r *enrollmentRepository) ListByCourse(ctx context.Context, courseID uuid.U
 	listQuery := fmt.Sprintf(`
 		SELECT e.enrollment_id, e.student_id, e.course_id, e.enrollment_status, e.enrolled_by, e.enrollment_date,
 			   e.dropped_date, e.drop_reason, e.completion_date, e.grade, e.grade_points, e.waitlist_position, e.created_at, e.updated_at,
-			   s.student_id, s.registration_number,
+			   s.student_id, s.user_id, s.registration_number, %s, %s,
 			   c.course_id, c.course_code, c.course_name
 		FROM course_enrollments e
 		JOIN students s ON e.student_id = s.student_id
+		LEFT JOIN user_directory u ON u.user_id = s.user_id
 		JOIN courses c ON e.course_id = c.course_id
 		%s
 		ORDER BY e.enrollment_date
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argNum, argNum+1)
+	`, userNameColumn, userEmailColumn, whereClause, argNum, argNum+1)
 
 	rows, err := r.db.Query(ctx, listQuery, args...)
 	if err != nil {
@@ -257,7 +326,7 @@ func (r *enrollmentRepository) ListByCourse(ctx context.Context, courseID uuid.U
 		if err := rows.Scan(
 			&e.EnrollmentID, &e.StudentID, &e.CourseID, &e.EnrollmentStatus, &e.EnrolledBy, &e.EnrollmentDate,
 			&e.DroppedDate, &e.DropReason, &e.CompletionDate, &e.Grade, &e.GradePoints, &e.WaitlistPosition, &e.CreatedAt, &e.UpdatedAt,
-			&e.Student.StudentID, &e.Student.RegistrationNumber,
+			&e.Student.StudentID, &e.Student.UserID, &e.Student.RegistrationNumber, &e.Student.Name, &e.Student.Email,
 			&e.Course.CourseID, &e.Course.CourseCode, &e.Course.CourseName,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan enrollment: %w", err)
@@ -362,3 +431,283 @@ func (r *enrollmentRepository) PromoteFromWaitlist(ctx context.Context, courseID
 
 	return &e, nil
 }
+
+func (r *enrollmentRepository) RecordOverride(ctx context.Context, override *domain.EnrollmentOverride) error {
+	query := `
+		INSERT INTO enrollment_overrides (override_id, enrollment_id, student_id, course_id, action, reason, overridden_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+	override.OverrideID = uuid.New()
+	err := r.db.QueryRow(ctx, query,
+		override.OverrideID,
+		override.EnrollmentID,
+		override.StudentID,
+		override.CourseID,
+		override.Action,
+		override.Reason,
+		override.OverriddenBy,
+	).Scan(&override.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record enrollment override: %w", err)
+	}
+	return nil
+}
+
+func (r *enrollmentRepository) CreateWaiver(ctx context.Context, waiver *domain.PrerequisiteWaiver) error {
+	query := `
+		INSERT INTO prerequisite_waivers (waiver_id, student_id, course_id, waived_subject_id, requirement_type, reason, granted_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+	waiver.WaiverID = uuid.New()
+	err := r.db.QueryRow(ctx, query,
+		waiver.WaiverID,
+		waiver.StudentID,
+		waiver.CourseID,
+		waiver.WaivedSubjectID,
+		waiver.RequirementType,
+		waiver.Reason,
+		waiver.GrantedBy,
+	).Scan(&waiver.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return domain.ErrWaiverExists
+		}
+		return fmt.Errorf("failed to create prerequisite waiver: %w", err)
+	}
+	return nil
+}
+
+func (r *enrollmentRepository) ListWaivers(ctx context.Context, studentID, courseID uuid.UUID) ([]*domain.PrerequisiteWaiver, error) {
+	query := `
+		SELECT waiver_id, student_id, course_id, waived_subject_id, requirement_type, reason, granted_by, created_at
+		FROM prerequisite_waivers
+		WHERE student_id = $1 AND course_id = $2
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query, studentID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prerequisite waivers: %w", err)
+	}
+	defer rows.Close()
+
+	var waivers []*domain.PrerequisiteWaiver
+	for rows.Next() {
+		var w domain.PrerequisiteWaiver
+		if err := rows.Scan(
+			&w.WaiverID, &w.StudentID, &w.CourseID, &w.WaivedSubjectID, &w.RequirementType, &w.Reason, &w.GrantedBy, &w.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan prerequisite waiver: %w", err)
+		}
+		waivers = append(waivers, &w)
+	}
+	return waivers, nil
+}
+
+func (r *enrollmentRepository) GetUnmetRequirements(ctx context.Context, studentID, courseID uuid.UUID) ([]*domain.UnmetRequirement, error) {
+	return r.GetUnmetRequirementsForStudents(ctx, []uuid.UUID{studentID}, courseID)
+}
+
+// GetUnmetRequirementsForStudents returns the prerequisites and corequisites
+// of a course that each of the students does not meet, in a single query.
+// Grades are read on the grading scale of each student's program, or the
+// default scale. Prerequisites are met by the best completed attempt at the
+// subject reaching the pass grade points of the scale and the minimum grade.
+// Corequisites are met by a passing completion or by an enrollment in the
+// same semester.
+func (r *enrollmentRepository) GetUnmetRequirementsForStudents(ctx context.Context, studentIDs []uuid.UUID, courseID uuid.UUID) ([]*domain.UnmetRequirement, error) {
+	query := `
+		WITH course AS (
+			SELECT subject_id, semester_id FROM courses WHERE course_id = $2
+		),
+		requirements AS (
+			SELECT 'prerequisite' AS requirement_type, sp.prerequisite_subject_id AS subject_id, sp.is_mandatory,
+				UPPER(sp.min_grade) AS min_grade, sp.min_grade_points
+			FROM subject_prerequisites sp
+			JOIN course ON sp.subject_id = course.subject_id
+			UNION ALL
+			SELECT 'corequisite', sc.corequisite_subject_id, true, NULL, NULL
+			FROM subject_corequisites sc
+			JOIN course ON sc.subject_id = course.subject_id
+		),
+		student_scales AS (
+			SELECT ids.student_id, gs.scale_id, COALESCE(gs.pass_grade_points, 0) AS pass_grade_points
+			FROM (SELECT DISTINCT unnest($1::uuid[]) AS student_id) ids
+			LEFT JOIN students s ON ids.student_id = s.student_id
+			LEFT JOIN programs p ON s.program_id = p.program_id
+			LEFT JOIN grading_scales gs
+				ON gs.scale_id = COALESCE(p.grading_scale_id, (SELECT scale_id FROM grading_scales WHERE is_default))
+		),
+		best_attempts AS (
+			SELECT DISTINCT ON (e.student_id, c.subject_id) e.student_id, c.subject_id, g.points
+			FROM course_enrollments e
+			JOIN courses c ON e.course_id = c.course_id
+			JOIN student_scales st ON e.student_id = st.student_id
+			LEFT JOIN grading_scale_grades sg ON sg.scale_id = st.scale_id AND sg.grade = UPPER(e.grade)
+			CROSS JOIN LATERAL (SELECT COALESCE(e.grade_points, sg.grade_points) AS points) g
+			WHERE e.enrollment_status IN ('completed', 'failed')
+			  AND c.subject_id IN (SELECT subject_id FROM requirements)
+			ORDER BY e.student_id, c.subject_id,
+				(g.points IS NULL OR g.points >= st.pass_grade_points) DESC, (g.points IS NOT NULL) DESC, g.points DESC
+		)
+		SELECT st.student_id, req.requirement_type, s.subject_id, s.subject_code, s.subject_name, s.credits, s.subject_type,
+			req.is_mandatory, m.min_grade_points, st.pass_grade_points, a.student_id IS NOT NULL, a.points, w.waiver_id IS NOT NULL
+		FROM student_scales st
+		CROSS JOIN requirements req
+		JOIN subjects s ON req.subject_id = s.subject_id
+		LEFT JOIN grading_scale_grades mg ON mg.scale_id = st.scale_id AND mg.grade = req.min_grade
+		CROSS JOIN LATERAL (SELECT GREATEST(req.min_grade_points, mg.grade_points) AS min_grade_points) m
+		LEFT JOIN best_attempts a ON a.student_id = st.student_id AND a.subject_id = req.subject_id
+		LEFT JOIN prerequisite_waivers w
+			ON w.student_id = st.student_id AND w.course_id = $2 AND w.waived_subject_id = req.subject_id
+		WHERE NOT (
+			a.student_id IS NOT NULL AND (a.points IS NULL OR a.points >= st.pass_grade_points)
+			AND (m.min_grade_points IS NULL OR COALESCE(a.points >= m.min_grade_points, false))
+		)
+		AND NOT (req.requirement_type = 'corequisite' AND EXISTS (
+			SELECT 1
+			FROM course_enrollments ce
+			JOIN courses cc ON ce.course_id = cc.course_id
+			JOIN course ON cc.semester_id = course.semester_id
+			WHERE ce.student_id = st.student_id AND cc.subject_id = req.subject_id AND ce.enrollment_status = 'enrolled'
+		))
+		ORDER BY st.student_id, req.requirement_type DESC, s.subject_code
+	`
+
+	rows, err := r.db.Query(ctx, query, studentIDs, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unmet requirements: %w", err)
+	}
+	defer rows.Close()
+
+	var unmet []*domain.UnmetRequirement
+	for rows.Next() {
+		var u domain.UnmetRequirement
+		if err := rows.Scan(
+			&u.StudentID, &u.Type, &u.Subject.SubjectID, &u.Subject.SubjectCode, &u.Subject.SubjectName, &u.Subject.Credits, &u.Subject.SubjectType,
+			&u.IsMandatory, &u.MinGradePoints, &u.PassGradePoints, &u.Completed, &u.GradePoints, &u.Waived,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan unmet requirement: %w", err)
+		}
+		unmet = append(unmet, &u)
+	}
+	return unmet, nil
+}
+
+// ListGradedAttempts returns the completed and failed enrollments of a student
+// that have grade points, oldest semester first
+func (r *enrollmentRepository) ListGradedAttempts(ctx context.Context, studentID uuid.UUID) ([]*domain.GradedAttempt, error) {
+	query := `
+		SELECT e.enrollment_id, c.subject_id, c.semester_id, sem.start_date, s.credits, e.grade_points
+		FROM course_enrollments e
+		JOIN courses c ON e.course_id = c.course_id
+		JOIN subjects s ON c.subject_id = s.subject_id
+		JOIN semesters sem ON c.semester_id = sem.semester_id
+		WHERE e.student_id = $1 AND e.enrollment_status IN ('completed', 'failed') AND e.grade_points IS NOT NULL
+		ORDER BY sem.start_date, e.completion_date, e.enrollment_id
+	`
+	rows, err := r.db.Query(ctx, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list graded attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*domain.GradedAttempt
+	for rows.Next() {
+		var a domain.GradedAttempt
+		if err := rows.Scan(&a.EnrollmentID, &a.SubjectID, &a.SemesterID, &a.SemesterStart, &a.Credits, &a.GradePoints); err != nil {
+			return nil, fmt.Errorf("failed to scan graded attempt: %w", err)
+		}
+		attempts = append(attempts, &a)
+	}
+	return attempts, nil
+}
+
+// ListRoster returns the enrollments of the students enrolled in a course
+func (r *enrollmentRepository) ListRoster(ctx context.Context, courseID uuid.UUID) ([]*domain.CourseEnrollment, error) {
+	query := `
+		SELECT enrollment_id, student_id, course_id, enrollment_status, enrolled_by, enrollment_date,
+			   dropped_date, drop_reason, completion_date, grade, grade_points, waitlist_position, created_at, updated_at
+		FROM course_enrollments
+		WHERE course_id = $1 AND enrollment_status = 'enrolled'
+		ORDER BY enrollment_date
+	`
+	rows, err := r.db.Query(ctx, query, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list course roster: %w", err)
+	}
+	defer rows.Close()
+
+	var roster []*domain.CourseEnrollment
+	for rows.Next() {
+		var e domain.CourseEnrollment
+		if err := rows.Scan(
+			&e.EnrollmentID, &e.StudentID, &e.CourseID, &e.EnrollmentStatus, &e.EnrolledBy, &e.EnrollmentDate,
+			&e.DroppedDate, &e.DropReason, &e.CompletionDate, &e.Grade, &e.GradePoints, &e.WaitlistPosition, &e.CreatedAt, &e.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan enrollment: %w", err)
+		}
+		roster = append(roster, &e)
+	}
+	return roster, nil
+}
+
+// ListGradedCourses returns the completed and failed enrollments of a student
+// with a grade, oldest semester first
+func (r *enrollmentRepository) ListGradedCourses(ctx context.Context, studentID uuid.UUID) ([]*domain.GradedCourse, error) {
+	query := `
+		SELECT e.enrollment_id, c.course_code, s.subject_id, s.subject_code, s.subject_name, s.credits, e.grade, e.grade_points,
+			   sem.semester_id, sem.semester_code, sem.semester_name, sem.academic_year, sem.start_date
+		FROM course_enrollments e
+		JOIN courses c ON e.course_id = c.course_id
+		JOIN subjects s ON c.subject_id = s.subject_id
+		JOIN semesters sem ON c.semester_id = sem.semester_id
+		WHERE e.student_id = $1 AND e.enrollment_status IN ('completed', 'failed') AND e.grade IS NOT NULL AND e.grade_points IS NOT NULL
+		ORDER BY sem.start_date, e.completion_date, e.enrollment_id
+	`
+	rows, err := r.db.Query(ctx, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list graded courses: %w", err)
+	}
+	defer rows.Close()
+
+	var courses []*domain.GradedCourse
+	for rows.Next() {
+		var g domain.GradedCourse
+		if err := rows.Scan(
+			&g.EnrollmentID, &g.CourseCode, &g.SubjectID, &g.SubjectCode, &g.SubjectName, &g.Credits, &g.Grade, &g.GradePoints,
+			&g.SemesterID, &g.SemesterCode, &g.SemesterName, &g.AcademicYear, &g.SemesterStart,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan graded course: %w", err)
+		}
+		courses = append(courses, &g)
+	}
+	return courses, nil
+}
+
+func (r *enrollmentRepository) ListEnrolledSubjects(ctx context.Context, studentID uuid.UUID) ([]*domain.SubjectBasic, error) {
+	query := `
+		SELECT DISTINCT s.subject_id, s.subject_code, s.subject_name, s.credits, s.subject_type
+		FROM course_enrollments e
+		JOIN courses c ON e.course_id = c.course_id
+		JOIN subjects s ON c.subject_id = s.subject_id
+		WHERE e.student_id = $1 AND e.enrollment_status = 'enrolled'
+		ORDER BY s.subject_code
+	`
+	rows, err := r.db.Query(ctx, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enrolled subjects: %w", err)
+	}
+	defer rows.Close()
+
+	var subjects []*domain.SubjectBasic
+	for rows.Next() {
+		var s domain.SubjectBasic
+		if err := rows.Scan(&s.SubjectID, &s.SubjectCode, &s.SubjectName, &s.Credits, &s.SubjectType); err != nil {
+			return nil, fmt.Errorf("failed to scan enrolled subject: %w", err)
+		}
+		subjects = append(subjects, &s)
+	}
+	return subjects, nil
+}
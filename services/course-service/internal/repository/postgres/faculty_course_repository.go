@@ -6,16 +6,16 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type facultyCourseRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewFacultyCourseRepository(db *pgxpool.Pool) domain.FacultyCourseRepository {
+func NewFacultyCourseRepository(db *database.DB) domain.FacultyCourseRepository {
 	return &facultyCourseRepository{db: db}
 }
 
@@ -104,9 +104,11 @@ func (r *facultyCourseRepository) ListByCourse(ctx context.Context, courseID uui
 	query := `
 		SELECT fc.faculty_course_id, fc.faculty_id, fc.course_id, fc.role, fc.is_primary,
 			   fc.assigned_by, fc.assigned_at, fc.removed_at, fc.is_active,
-			   f.faculty_id, f.user_id, f.employee_id, f.designation
+			   f.faculty_id, f.user_id, f.employee_id, f.designation,
+			   ` + userNameColumn + `, ` + userEmailColumn + `
 		FROM faculty_courses fc
 		JOIN faculties f ON fc.faculty_id = f.faculty_id
+		LEFT JOIN user_directory u ON u.user_id = f.user_id
 		WHERE fc.course_id = $1 AND fc.is_active = true
 		ORDER BY fc.is_primary DESC, fc.assigned_at
 	`
@@ -124,6 +126,7 @@ func (r *facultyCourseRepository) ListByCourse(ctx context.Context, courseID uui
 			&a.FacultyCourseID, &a.FacultyID, &a.CourseID, &a.Role, &a.IsPrimary,
 			&a.AssignedBy, &a.AssignedAt, &a.RemovedAt, &a.IsActive,
 			&a.Faculty.FacultyID, &a.Faculty.UserID, &a.Faculty.EmployeeID, &a.Faculty.Designation,
+			&a.Faculty.Name, &a.Faculty.Email,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan faculty assignment: %w", err)
 		}
@@ -6,16 +6,16 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type facultyRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewFacultyRepository(db *pgxpool.Pool) domain.FacultyRepository {
+func NewFacultyRepository(db *database.DB) domain.FacultyRepository {
 	return &facultyRepository{db: db}
 }
 
@@ -155,6 +155,14 @@ func (r *facultyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (r *facultyRepository) DeactivateByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE faculties SET is_active = false, updated_at = now() WHERE user_id = $1 AND is_active = true`
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to deactivate faculty: %w", err)
+	}
+	return nil
+}
+
 func (r *facultyRepository) List(ctx context.Context, filter domain.FacultyFilter, limit, offset int) ([]*domain.FacultyWithDetails, int64, error) {
 	var conditions []string
 	var args []interface{}
@@ -171,7 +179,7 @@ func (r *facultyRepository) List(ctx context.Context, filter domain.FacultyFilte
 		argNum++
 	}
 	if filter.Search != nil {
-		conditions = append(conditions, fmt.Sprintf("(f.employee_id ILIKE $%d)", argNum))
+		conditions = append(conditions, fmt.Sprintf("(f.employee_id ILIKE $%d OR u.email ILIKE $%d OR %s ILIKE $%d)", argNum, argNum, userNameColumn, argNum))
 		args = append(args, "%"+*filter.Search+"%")
 		argNum++
 	}
@@ -187,24 +195,26 @@ func (r *facultyRepository) List(ctx context.Context, filter domain.FacultyFilte
 	}
 
 	// Count query
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM faculties f %s", whereClause)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM faculties f LEFT JOIN user_directory u ON u.user_id = f.user_id %s", whereClause)
 	var total int64
 	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("failed to count faculty: %w", err)
 	}
 
-	// List query with department join
+	// List query with department and user directory joins
 	args = append(args, limit, offset)
 	listQuery := fmt.Sprintf(`
 		SELECT f.faculty_id, f.user_id, f.employee_id, f.department_id, f.designation, f.qualification,
 			   f.specialization, f.joining_date, f.office_room, f.office_hours, f.is_active, f.created_at, f.updated_at,
-			   d.department_id, d.department_name, d.department_code
+			   d.department_id, d.department_name, d.department_code,
+			   %s, %s, u.phone
 		FROM faculties f
 		JOIN departments d ON f.department_id = d.department_id
+		LEFT JOIN user_directory u ON u.user_id = f.user_id
 		%s
 		ORDER BY f.employee_id
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argNum, argNum+1)
+	`, userNameColumn, userEmailColumn, whereClause, argNum, argNum+1)
 
 	rows, err := r.db.Query(ctx, listQuery, args...)
 	if err != nil {
@@ -219,10 +229,10 @@ func (r *facultyRepository) List(ctx context.Context, filter domain.FacultyFilte
 			&f.FacultyID, &f.UserID, &f.EmployeeID, &f.DepartmentID, &f.Designation, &f.Qualification,
 			&f.Specialization, &f.JoiningDate, &f.OfficeRoom, &f.OfficeHours, &f.IsActive, &f.CreatedAt, &f.UpdatedAt,
 			&f.Department.DepartmentID, &f.Department.DepartmentName, &f.Department.DepartmentCode,
+			&f.Name, &f.Email, &f.Phone,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan faculty: %w", err)
 		}
-		// Note: Name and Email would come from user service
 		faculties = append(faculties, &f)
 	}
 
@@ -233,9 +243,11 @@ func (r *facultyRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*
 	query := `
 		SELECT f.faculty_id, f.user_id, f.employee_id, f.department_id, f.designation, f.qualification,
 			   f.specialization, f.joining_date, f.office_room, f.office_hours, f.is_active, f.created_at, f.updated_at,
-			   d.department_id, d.department_name, d.department_code
+			   d.department_id, d.department_name, d.department_code,
+			   ` + userNameColumn + `, ` + userEmailColumn + `, u.phone
 		FROM faculties f
 		JOIN departments d ON f.department_id = d.department_id
+		LEFT JOIN user_directory u ON u.user_id = f.user_id
 		WHERE f.faculty_id = $1
 	`
 
@@ -244,6 +256,7 @@ func (r *facultyRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*
 		&f.FacultyID, &f.UserID, &f.EmployeeID, &f.DepartmentID, &f.Designation, &f.Qualification,
 		&f.Specialization, &f.JoiningDate, &f.OfficeRoom, &f.OfficeHours, &f.IsActive, &f.CreatedAt, &f.UpdatedAt,
 		&f.Department.DepartmentID, &f.Department.DepartmentName, &f.Department.DepartmentCode,
+		&f.Name, &f.Email, &f.Phone,
 	)
 
 	if err == pgx.ErrNoRows {
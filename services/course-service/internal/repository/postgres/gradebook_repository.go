@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type gradebookRepository struct {
+	db *database.DB
+}
+
+func NewGradebookRepository(db *database.DB) domain.GradebookRepository {
+	return &gradebookRepository{db: db}
+}
+
+// Save creates the gradebook of a course, or replaces a gradebook that is
+// resubmitted together with its entries and review
+func (r *gradebookRepository) Save(ctx context.Context, gradebook *domain.Gradebook) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO course_gradebooks (course_id, status, submitted_by, submitted_at)
+			VALUES ($1, $2, $3, now())
+			ON CONFLICT (course_id) DO UPDATE
+			SET status = EXCLUDED.status, submitted_by = EXCLUDED.submitted_by, submitted_at = EXCLUDED.submitted_at,
+				reviewed_by = NULL, reviewed_at = NULL, review_comment = NULL, updated_at = now()
+			RETURNING submitted_at, created_at, updated_at
+		`
+		err := r.db.QueryRow(ctx, query,
+			gradebook.CourseID,
+			gradebook.Status,
+			gradebook.SubmittedBy,
+		).Scan(&gradebook.SubmittedAt, &gradebook.CreatedAt, &gradebook.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save gradebook: %w", err)
+		}
+		gradebook.ReviewedBy = nil
+		gradebook.ReviewedAt = nil
+		gradebook.ReviewComment = nil
+
+		if _, err := r.db.Exec(ctx, `DELETE FROM gradebook_entries WHERE course_id = $1`, gradebook.CourseID); err != nil {
+			return fmt.Errorf("failed to clear gradebook entries: %w", err)
+		}
+
+		insert := `
+			INSERT INTO gradebook_entries (enrollment_id, course_id, student_id, grade, grade_points)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		for _, e := range gradebook.Entries {
+			if _, err := r.db.Exec(ctx, insert, e.EnrollmentID, gradebook.CourseID, e.StudentID, e.Grade, e.GradePoints); err != nil {
+				return fmt.Errorf("failed to add gradebook entry: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gradebookRepository) GetByCourse(ctx context.Context, courseID uuid.UUID) (*domain.Gradebook, error) {
+	query := `
+		SELECT course_id, status, submitted_by, submitted_at, reviewed_by, reviewed_at, review_comment, created_at, updated_at
+		FROM course_gradebooks
+		WHERE course_id = $1
+	`
+	var g domain.Gradebook
+	err := r.db.QueryRow(ctx, query, courseID).Scan(
+		&g.CourseID, &g.Status, &g.SubmittedBy, &g.SubmittedAt, &g.ReviewedBy, &g.ReviewedAt, &g.ReviewComment, &g.CreatedAt, &g.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrGradebookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gradebook: %w", err)
+	}
+
+	entriesQuery := `
+		SELECT ge.enrollment_id, ge.student_id, ge.grade, ge.grade_points
+		FROM gradebook_entries ge
+		JOIN students s ON ge.student_id = s.student_id
+		WHERE ge.course_id = $1
+		ORDER BY s.registration_number
+	`
+	rows, err := r.db.Query(ctx, entriesQuery, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gradebook entries: %w", err)
+	}
+	defer rows.Close()
+
+	g.Entries = []domain.GradebookEntry{}
+	for rows.Next() {
+		var e domain.GradebookEntry
+		if err := rows.Scan(&e.EnrollmentID, &e.StudentID, &e.Grade, &e.GradePoints); err != nil {
+			return nil, fmt.Errorf("failed to scan gradebook entry: %w", err)
+		}
+		g.Entries = append(g.Entries, e)
+	}
+	return &g, nil
+}
+
+// UpdateStatus records the review of a gradebook
+func (r *gradebookRepository) UpdateStatus(ctx context.Context, gradebook *domain.Gradebook) error {
+	query := `
+		UPDATE course_gradebooks
+		SET status = $2, reviewed_by = $3, reviewed_at = $4, review_comment = $5, updated_at = now()
+		WHERE course_id = $1
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(ctx, query,
+		gradebook.CourseID,
+		gradebook.Status,
+		gradebook.ReviewedBy,
+		gradebook.ReviewedAt,
+		gradebook.ReviewComment,
+	).Scan(&gradebook.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return domain.ErrGradebookNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update gradebook: %w", err)
+	}
+	return nil
+}
+
+func (r *gradebookRepository) CreateAmendment(ctx context.Context, amendment *domain.GradeAmendment) error {
+	query := `
+		INSERT INTO grade_amendments (
+			amendment_id, enrollment_id, course_id, student_id, previous_grade, previous_grade_points,
+			new_grade, new_grade_points, reason, status, requested_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING requested_at
+	`
+	amendment.AmendmentID = uuid.New()
+	err := r.db.QueryRow(ctx, query,
+		amendment.AmendmentID,
+		amendment.EnrollmentID,
+		amendment.CourseID,
+		amendment.StudentID,
+		amendment.PreviousGrade,
+		amendment.PreviousGradePoints,
+		amendment.NewGrade,
+		amendment.NewGradePoints,
+		amendment.Reason,
+		amendment.Status,
+		amendment.RequestedBy,
+	).Scan(&amendment.RequestedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return domain.ErrAmendmentPending
+		}
+		return fmt.Errorf("failed to create grade amendment: %w", err)
+	}
+	return nil
+}
+
+func (r *gradebookRepository) GetAmendment(ctx context.Context, id uuid.UUID) (*domain.GradeAmendment, error) {
+	query := `
+		SELECT amendment_id, enrollment_id, course_id, student_id, previous_grade, previous_grade_points,
+			   new_grade, new_grade_points, reason, status, requested_by, requested_at, reviewed_by, reviewed_at, review_comment
+		FROM grade_amendments
+		WHERE amendment_id = $1
+	`
+	var a domain.GradeAmendment
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&a.AmendmentID, &a.EnrollmentID, &a.CourseID, &a.StudentID, &a.PreviousGrade, &a.PreviousGradePoints,
+		&a.NewGrade, &a.NewGradePoints, &a.Reason, &a.Status, &a.RequestedBy, &a.RequestedAt, &a.ReviewedBy, &a.ReviewedAt, &a.ReviewComment,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAmendmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grade amendment: %w", err)
+	}
+	return &a, nil
+}
+
+// UpdateAmendment records the review of a pending amendment. It returns
+// ErrAmendmentReviewed if the amendment was reviewed in the meantime.
+func (r *gradebookRepository) UpdateAmendment(ctx context.Context, amendment *domain.GradeAmendment) error {
+	query := `
+		UPDATE grade_amendments
+		SET status = $2, reviewed_by = $3, reviewed_at = $4, review_comment = $5
+		WHERE amendment_id = $1 AND status = 'pending'
+	`
+	result, err := r.db.Exec(ctx, query,
+		amendment.AmendmentID,
+		amendment.Status,
+		amendment.ReviewedBy,
+		amendment.ReviewedAt,
+		amendment.ReviewComment,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update grade amendment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAmendmentReviewed
+	}
+	return nil
+}
+
+func (r *gradebookRepository) ListAmendments(ctx context.Context, courseID uuid.UUID) ([]*domain.GradeAmendment, error) {
+	query := `
+		SELECT amendment_id, enrollment_id, course_id, student_id, previous_grade, previous_grade_points,
+			   new_grade, new_grade_points, reason, status, requested_by, requested_at, reviewed_by, reviewed_at, review_comment
+		FROM grade_amendments
+		WHERE course_id = $1
+		ORDER BY requested_at
+	`
+	rows, err := r.db.Query(ctx, query, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grade amendments: %w", err)
+	}
+	defer rows.Close()
+
+	var amendments []*domain.GradeAmendment
+	for rows.Next() {
+		var a domain.GradeAmendment
+		if err := rows.Scan(
+			&a.AmendmentID, &a.EnrollmentID, &a.CourseID, &a.StudentID, &a.PreviousGrade, &a.PreviousGradePoints,
+			&a.NewGrade, &a.NewGradePoints, &a.Reason, &a.Status, &a.RequestedBy, &a.RequestedAt, &a.ReviewedBy, &a.ReviewedAt, &a.ReviewComment,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan grade amendment: %w", err)
+		}
+		amendments = append(amendments, &a)
+	}
+	return amendments, nil
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type gradingScaleRepository struct {
+	db *database.DB
+}
+
+func NewGradingScaleRepository(db *database.DB) domain.GradingScaleRepository {
+	return &gradingScaleRepository{db: db}
+}
+
+func (r *gradingScaleRepository) Create(ctx context.Context, scale *domain.GradingScale) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if scale.IsDefault {
+			if err := r.clearDefault(ctx); err != nil {
+				return err
+			}
+		}
+
+		query := `
+			INSERT INTO grading_scales (scale_id, scale_name, max_grade_points, pass_grade_points, repeat_policy, is_default)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING created_at, updated_at
+		`
+		scale.ScaleID = uuid.New()
+		err := r.db.QueryRow(ctx, query,
+			scale.ScaleID,
+			scale.ScaleName,
+			scale.MaxGradePoints,
+			scale.PassGradePoints,
+			scale.RepeatPolicy,
+			scale.IsDefault,
+		).Scan(&scale.CreatedAt, &scale.UpdatedAt)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return domain.ErrGradingScaleNameExists
+			}
+			return fmt.Errorf("failed to create grading scale: %w", err)
+		}
+
+		return r.insertGrades(ctx, scale)
+	})
+}
+
+func (r *gradingScaleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GradingScale, error) {
+	query := `
+		SELECT scale_id, scale_name, max_grade_points, pass_grade_points, repeat_policy, is_default, created_at, updated_at
+		FROM grading_scales
+		WHERE scale_id = $1
+	`
+	var s domain.GradingScale
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&s.ScaleID, &s.ScaleName, &s.MaxGradePoints, &s.PassGradePoints, &s.RepeatPolicy, &s.IsDefault, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrGradingScaleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grading scale: %w", err)
+	}
+
+	grades, err := r.listGrades(ctx, []uuid.UUID{s.ScaleID})
+	if err != nil {
+		return nil, err
+	}
+	s.Grades = grades[s.ScaleID]
+	return &s, nil
+}
+
+func (r *gradingScaleRepository) GetForStudent(ctx context.Context, studentID uuid.UUID) (*domain.GradingScale, error) {
+	query := `
+		SELECT COALESCE(
+			(SELECT p.grading_scale_id FROM students s JOIN programs p ON s.program_id = p.program_id WHERE s.student_id = $1),
+			(SELECT scale_id FROM grading_scales WHERE is_default)
+		)
+	`
+	var scaleID *uuid.UUID
+	if err := r.db.QueryRow(ctx, query, studentID).Scan(&scaleID); err != nil {
+		return nil, fmt.Errorf("failed to get grading scale of student: %w", err)
+	}
+	if scaleID == nil {
+		return nil, domain.ErrGradingScaleNotFound
+	}
+	return r.GetByID(ctx, *scaleID)
+}
+
+func (r *gradingScaleRepository) Update(ctx context.Context, scale *domain.GradingScale) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if scale.IsDefault {
+			if err := r.clearDefault(ctx); err != nil {
+				return err
+			}
+		}
+
+		query := `
+			UPDATE grading_scales
+			SET scale_name = $2, max_grade_points = $3, pass_grade_points = $4, repeat_policy = $5, is_default = $6, updated_at = now()
+			WHERE scale_id = $1
+			RETURNING updated_at
+		`
+		err := r.db.QueryRow(ctx, query,
+			scale.ScaleID,
+			scale.ScaleName,
+			scale.MaxGradePoints,
+			scale.PassGradePoints,
+			scale.RepeatPolicy,
+			scale.IsDefault,
+		).Scan(&scale.UpdatedAt)
+		if err == pgx.ErrNoRows {
+			return domain.ErrGradingScaleNotFound
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return domain.ErrGradingScaleNameExists
+			}
+			return fmt.Errorf("failed to update grading scale: %w", err)
+		}
+
+		if _, err := r.db.Exec(ctx, `DELETE FROM grading_scale_grades WHERE scale_id = $1`, scale.ScaleID); err != nil {
+			return fmt.Errorf("failed to replace grading scale grades: %w", err)
+		}
+		return r.insertGrades(ctx, scale)
+	})
+}
+
+func (r *gradingScaleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM grading_scales WHERE scale_id = $1`, id)
+	if err != nil {
+		// Programs graded on the scale keep it from being deleted
+		if strings.Contains(err.Error(), "foreign key") {
+			return domain.ErrGradingScaleInUse
+		}
+		return fmt.Errorf("failed to delete grading scale: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrGradingScaleNotFound
+	}
+	return nil
+}
+
+func (r *gradingScaleRepository) List(ctx context.Context) ([]*domain.GradingScale, error) {
+	query := `
+		SELECT scale_id, scale_name, max_grade_points, pass_grade_points, repeat_policy, is_default, created_at, updated_at
+		FROM grading_scales
+		ORDER BY is_default DESC, scale_name
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grading scales: %w", err)
+	}
+	defer rows.Close()
+
+	var scales []*domain.GradingScale
+	var ids []uuid.UUID
+	for rows.Next() {
+		var s domain.GradingScale
+		if err := rows.Scan(
+			&s.ScaleID, &s.ScaleName, &s.MaxGradePoints, &s.PassGradePoints, &s.RepeatPolicy, &s.IsDefault, &s.CreatedAt, &s.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan grading scale: %w", err)
+		}
+		scales = append(scales, &s)
+		ids = append(ids, s.ScaleID)
+	}
+	rows.Close()
+
+	grades, err := r.listGrades(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range scales {
+		s.Grades = grades[s.ScaleID]
+	}
+	return scales, nil
+}
+
+// clearDefault unsets the current default scale, so that another scale can
+// become the default
+func (r *gradingScaleRepository) clearDefault(ctx context.Context) error {
+	if _, err := r.db.Exec(ctx, `UPDATE grading_scales SET is_default = false WHERE is_default`); err != nil {
+		return fmt.Errorf("failed to clear default grading scale: %w", err)
+	}
+	return nil
+}
+
+func (r *gradingScaleRepository) insertGrades(ctx context.Context, scale *domain.GradingScale) error {
+	query := `INSERT INTO grading_scale_grades (scale_id, grade, grade_points) VALUES ($1, $2, $3)`
+	for _, g := range scale.Grades {
+		if _, err := r.db.Exec(ctx, query, scale.ScaleID, g.Grade, g.GradePoints); err != nil {
+			return fmt.Errorf("failed to add grade %s: %w", g.Grade, err)
+		}
+	}
+	return nil
+}
+
+// listGrades returns the grades of each of the scales, from the highest
+// grade points down
+func (r *gradingScaleRepository) listGrades(ctx context.Context, scaleIDs []uuid.UUID) (map[uuid.UUID][]domain.GradeMapping, error) {
+	query := `
+		SELECT scale_id, grade, grade_points
+		FROM grading_scale_grades
+		WHERE scale_id = ANY($1)
+		ORDER BY scale_id, grade_points DESC, grade
+	`
+	rows, err := r.db.Query(ctx, query, scaleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grading scale grades: %w", err)
+	}
+	defer rows.Close()
+
+	grades := make(map[uuid.UUID][]domain.GradeMapping)
+	for rows.Next() {
+		var scaleID uuid.UUID
+		var g domain.GradeMapping
+		if err := rows.Scan(&scaleID, &g.Grade, &g.GradePoints); err != nil {
+			return nil, fmt.Errorf("failed to scan grade: %w", err)
+		}
+		grades[scaleID] = append(grades[scaleID], g)
+	}
+	return grades, nil
+}
@@ -6,23 +6,23 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type programRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewProgramRepository(db *pgxpool.Pool) domain.ProgramRepository {
+func NewProgramRepository(db *database.DB) domain.ProgramRepository {
 	return &programRepository{db: db}
 }
 
 func (r *programRepository) Create(ctx context.Context, program *domain.Program) error {
 	query := `
-		INSERT INTO programs (program_id, program_name, program_code, department_id, degree_type, duration_years, total_credits, description, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO programs (program_id, program_name, program_code, department_id, degree_type, duration_years, total_credits, description, is_active, grading_scale_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING created_at, updated_at
 	`
 	program.ProgramID = uuid.New()
@@ -36,6 +36,7 @@ func (r *programRepository) Create(ctx context.Context, program *domain.Program)
 		program.TotalCredits,
 		program.Description,
 		program.IsActive,
+		program.GradingScaleID,
 	).Scan(&program.CreatedAt, &program.UpdatedAt)
 
 	if err != nil {
@@ -50,14 +51,14 @@ func (r *programRepository) Create(ctx context.Context, program *domain.Program)
 func (r *programRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Program, error) {
 	query := `
 		SELECT program_id, program_name, program_code, department_id, degree_type,
-			   duration_years, total_credits, description, is_active, created_at, updated_at
+			   duration_years, total_credits, description, is_active, grading_scale_id, created_at, updated_at
 		FROM programs
 		WHERE program_id = $1
 	`
 	var p domain.Program
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&p.ProgramID, &p.ProgramName, &p.ProgramCode, &p.DepartmentID, &p.DegreeType,
-		&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.CreatedAt, &p.UpdatedAt,
+		&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.GradingScaleID, &p.CreatedAt, &p.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrProgramNotFound
@@ -71,14 +72,14 @@ func (r *programRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 func (r *programRepository) GetByCode(ctx context.Context, code string) (*domain.Program, error) {
 	query := `
 		SELECT program_id, program_name, program_code, department_id, degree_type,
-			   duration_years, total_credits, description, is_active, created_at, updated_at
+			   duration_years, total_credits, description, is_active, grading_scale_id, created_at, updated_at
 		FROM programs
 		WHERE program_code = $1
 	`
 	var p domain.Program
 	err := r.db.QueryRow(ctx, query, code).Scan(
 		&p.ProgramID, &p.ProgramName, &p.ProgramCode, &p.DepartmentID, &p.DegreeType,
-		&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.CreatedAt, &p.UpdatedAt,
+		&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.GradingScaleID, &p.CreatedAt, &p.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrProgramNotFound
@@ -92,7 +93,8 @@ func (r *programRepository) GetByCode(ctx context.Context, code string) (*domain
 func (r *programRepository) Update(ctx context.Context, program *domain.Program) error {
 	query := `
 		UPDATE programs
-		SET program_name = $2, degree_type = $3, duration_years = $4, total_credits = $5, description = $6, is_active = $7, updated_at = now()
+		SET program_name = $2, degree_type = $3, duration_years = $4, total_credits = $5, description = $6, is_active = $7,
+			grading_scale_id = $8, updated_at = now()
 		WHERE program_id = $1
 		RETURNING updated_at
 	`
@@ -104,6 +106,7 @@ func (r *programRepository) Update(ctx context.Context, program *domain.Program)
 		program.TotalCredits,
 		program.Description,
 		program.IsActive,
+		program.GradingScaleID,
 	).Scan(&program.UpdatedAt)
 
 	if err == pgx.ErrNoRows {
@@ -164,7 +167,7 @@ func (r *programRepository) List(ctx context.Context, filter domain.ProgramFilte
 	args = append(args, limit, offset)
 	listQuery := fmt.Sprintf(`
 		SELECT p.program_id, p.program_name, p.program_code, p.department_id, p.degree_type,
-			   p.duration_years, p.total_credits, p.description, p.is_active, p.created_at, p.updated_at,
+			   p.duration_years, p.total_credits, p.description, p.is_active, p.grading_scale_id, p.created_at, p.updated_at,
 			   d.department_id, d.department_name, d.department_code
 		FROM programs p
 		JOIN departments d ON p.department_id = d.department_id
@@ -184,7 +187,7 @@ func (r *programRepository) List(ctx context.Context, filter domain.ProgramFilte
 		var p domain.ProgramWithDepartment
 		if err := rows.Scan(
 			&p.ProgramID, &p.ProgramName, &p.ProgramCode, &p.DepartmentID, &p.DegreeType,
-			&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.CreatedAt, &p.UpdatedAt,
+			&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.GradingScaleID, &p.CreatedAt, &p.UpdatedAt,
 			&p.Department.DepartmentID, &p.Department.DepartmentName, &p.Department.DepartmentCode,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan program: %w", err)
@@ -198,7 +201,7 @@ func (r *programRepository) List(ctx context.Context, filter domain.ProgramFilte
 func (r *programRepository) GetWithDepartment(ctx context.Context, id uuid.UUID) (*domain.ProgramWithDepartment, error) {
 	query := `
 		SELECT p.program_id, p.program_name, p.program_code, p.department_id, p.degree_type,
-			   p.duration_years, p.total_credits, p.description, p.is_active, p.created_at, p.updated_at,
+			   p.duration_years, p.total_credits, p.description, p.is_active, p.grading_scale_id, p.created_at, p.updated_at,
 			   d.department_id, d.department_name, d.department_code
 		FROM programs p
 		JOIN departments d ON p.department_id = d.department_id
@@ -208,7 +211,7 @@ func (r *programRepository) GetWithDepartment(ctx context.Context, id uuid.UUID)
 	var p domain.ProgramWithDepartment
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&p.ProgramID, &p.ProgramName, &p.ProgramCode, &p.DepartmentID, &p.DegreeType,
-		&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.CreatedAt, &p.UpdatedAt,
+		&p.DurationYears, &p.TotalCredits, &p.Description, &p.IsActive, &p.GradingScaleID, &p.CreatedAt, &p.UpdatedAt,
 		&p.Department.DepartmentID, &p.Department.DepartmentName, &p.Department.DepartmentCode,
 	)
 
@@ -6,23 +6,24 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type semesterRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewSemesterRepository(db *pgxpool.Pool) domain.SemesterRepository {
+func NewSemesterRepository(db *database.DB) domain.SemesterRepository {
 	return &semesterRepository{db: db}
 }
 
 func (r *semesterRepository) Create(ctx context.Context, semester *domain.Semester) error {
 	query := `
-		INSERT INTO semesters (semester_id, semester_name, semester_code, academic_year, start_date, end_date, registration_start, registration_end, is_current)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO semesters (semester_id, semester_name, semester_code, academic_year, start_date, end_date, registration_start, registration_end,
+			add_drop_end, withdrawal_deadline, is_current)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at
 	`
 	semester.SemesterID = uuid.New()
@@ -35,6 +36,8 @@ func (r *semesterRepository) Create(ctx context.Context, semester *domain.Semest
 		semester.EndDate,
 		semester.RegistrationStart,
 		semester.RegistrationEnd,
+		semester.AddDropEnd,
+		semester.WithdrawalDeadline,
 		semester.IsCurrent,
 	).Scan(&semester.CreatedAt, &semester.UpdatedAt)
 
@@ -50,14 +53,14 @@ func (r *semesterRepository) Create(ctx context.Context, semester *domain.Semest
 func (r *semesterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Semester, error) {
 	query := `
 		SELECT semester_id, semester_name, semester_code, academic_year, start_date, end_date,
-			   registration_start, registration_end, is_current, created_at, updated_at
+			   registration_start, registration_end, add_drop_end, withdrawal_deadline, is_current, created_at, updated_at
 		FROM semesters
 		WHERE semester_id = $1
 	`
 	var s domain.Semester
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&s.SemesterID, &s.SemesterName, &s.SemesterCode, &s.AcademicYear, &s.StartDate, &s.EndDate,
-		&s.RegistrationStart, &s.RegistrationEnd, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
+		&s.RegistrationStart, &s.RegistrationEnd, &s.AddDropEnd, &s.WithdrawalDeadline, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrSemesterNotFound
@@ -71,14 +74,14 @@ func (r *semesterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 func (r *semesterRepository) GetByCode(ctx context.Context, code string) (*domain.Semester, error) {
 	query := `
 		SELECT semester_id, semester_name, semester_code, academic_year, start_date, end_date,
-			   registration_start, registration_end, is_current, created_at, updated_at
+			   registration_start, registration_end, add_drop_end, withdrawal_deadline, is_current, created_at, updated_at
 		FROM semesters
 		WHERE semester_code = $1
 	`
 	var s domain.Semester
 	err := r.db.QueryRow(ctx, query, code).Scan(
 		&s.SemesterID, &s.SemesterName, &s.SemesterCode, &s.AcademicYear, &s.StartDate, &s.EndDate,
-		&s.RegistrationStart, &s.RegistrationEnd, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
+		&s.RegistrationStart, &s.RegistrationEnd, &s.AddDropEnd, &s.WithdrawalDeadline, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrSemesterNotFound
@@ -92,7 +95,7 @@ func (r *semesterRepository) GetByCode(ctx context.Context, code string) (*domai
 func (r *semesterRepository) GetCurrent(ctx context.Context) (*domain.Semester, error) {
 	query := `
 		SELECT semester_id, semester_name, semester_code, academic_year, start_date, end_date,
-			   registration_start, registration_end, is_current, created_at, updated_at
+			   registration_start, registration_end, add_drop_end, withdrawal_deadline, is_current, created_at, updated_at
 		FROM semesters
 		WHERE is_current = true
 		LIMIT 1
@@ -100,7 +103,7 @@ func (r *semesterRepository) GetCurrent(ctx context.Context) (*domain.Semester,
 	var s domain.Semester
 	err := r.db.QueryRow(ctx, query).Scan(
 		&s.SemesterID, &s.SemesterName, &s.SemesterCode, &s.AcademicYear, &s.StartDate, &s.EndDate,
-		&s.RegistrationStart, &s.RegistrationEnd, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
+		&s.RegistrationStart, &s.RegistrationEnd, &s.AddDropEnd, &s.WithdrawalDeadline, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrNoCurrentSemester
@@ -115,7 +118,7 @@ func (r *semesterRepository) Update(ctx context.Context, semester *domain.Semest
 	query := `
 		UPDATE semesters
 		SET semester_name = $2, academic_year = $3, start_date = $4, end_date = $5,
-			registration_start = $6, registration_end = $7, updated_at = now()
+			registration_start = $6, registration_end = $7, add_drop_end = $8, withdrawal_deadline = $9, updated_at = now()
 		WHERE semester_id = $1
 		RETURNING updated_at
 	`
@@ -127,6 +130,8 @@ func (r *semesterRepository) Update(ctx context.Context, semester *domain.Semest
 		semester.EndDate,
 		semester.RegistrationStart,
 		semester.RegistrationEnd,
+		semester.AddDropEnd,
+		semester.WithdrawalDeadline,
 	).Scan(&semester.UpdatedAt)
 
 	if err == pgx.ErrNoRows {
@@ -182,7 +187,7 @@ func (r *semesterRepository) List(ctx context.Context, filter domain.SemesterFil
 	args = append(args, limit, offset)
 	listQuery := fmt.Sprintf(`
 		SELECT semester_id, semester_name, semester_code, academic_year, start_date, end_date,
-			   registration_start, registration_end, is_current, created_at, updated_at
+			   registration_start, registration_end, add_drop_end, withdrawal_deadline, is_current, created_at, updated_at
 		FROM semesters
 		%s
 		ORDER BY academic_year DESC, start_date DESC
@@ -200,7 +205,7 @@ func (r *semesterRepository) List(ctx context.Context, filter domain.SemesterFil
 		var s domain.Semester
 		if err := rows.Scan(
 			&s.SemesterID, &s.SemesterName, &s.SemesterCode, &s.AcademicYear, &s.StartDate, &s.EndDate,
-			&s.RegistrationStart, &s.RegistrationEnd, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
+			&s.RegistrationStart, &s.RegistrationEnd, &s.AddDropEnd, &s.WithdrawalDeadline, &s.IsCurrent, &s.CreatedAt, &s.UpdatedAt,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan semester: %w", err)
 		}
@@ -0,0 +1,281 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const rolloverColumns = `
+	rollover_id, semester_id, next_semester_id, status, courses_completed, enrollments_dropped,
+	students_promoted, students_flagged, error, requested_by, locked_until, started_at, completed_at,
+	created_at, updated_at
+`
+
+type semesterRolloverRepository struct {
+	db *database.DB
+}
+
+func NewSemesterRolloverRepository(db *database.DB) domain.SemesterRolloverRepository {
+	return &semesterRolloverRepository{db: db}
+}
+
+func (r *semesterRolloverRepository) Create(ctx context.Context, rollover *domain.SemesterRollover) error {
+	query := `
+		INSERT INTO semester_rollovers (rollover_id, semester_id, next_semester_id, status, requested_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at
+	`
+	rollover.RolloverID = uuid.New()
+	err := r.db.QueryRow(ctx, query,
+		rollover.RolloverID,
+		rollover.SemesterID,
+		rollover.NextSemesterID,
+		rollover.Status,
+		rollover.RequestedBy,
+	).Scan(&rollover.CreatedAt, &rollover.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return domain.ErrRolloverInProgress
+		}
+		if strings.Contains(err.Error(), "foreign key") {
+			return domain.ErrSemesterNotFound
+		}
+		return fmt.Errorf("failed to create semester rollover: %w", err)
+	}
+	return nil
+}
+
+func (r *semesterRolloverRepository) GetLatest(ctx context.Context, semesterID uuid.UUID) (*domain.SemesterRollover, error) {
+	query := `SELECT ` + rolloverColumns + `
+		FROM semester_rollovers
+		WHERE semester_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	rollover, err := r.scanRollover(r.db.QueryRow(ctx, query, semesterID))
+	if err != nil {
+		return nil, err
+	}
+
+	// Students flagged by earlier, failed rollovers of the semester keep their flag
+	flaggedQuery := `
+		SELECT rs.student_id, s.registration_number, rs.from_semester, rs.to_semester, rs.flag_reason
+		FROM semester_rollover_students rs
+		JOIN students s ON rs.student_id = s.student_id
+		WHERE rs.semester_id = $1 AND rs.flag_reason IS NOT NULL
+		ORDER BY s.registration_number
+	`
+	rows, err := r.db.Query(ctx, flaggedQuery, semesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flagged students: %w", err)
+	}
+	defer rows.Close()
+
+	rollover.FlaggedStudents = []domain.RolloverStudent{}
+	for rows.Next() {
+		var s domain.RolloverStudent
+		var reason *string
+		if err := rows.Scan(&s.StudentID, &s.RegistrationNumber, &s.FromSemester, &s.ToSemester, &reason); err != nil {
+			return nil, fmt.Errorf("failed to scan flagged student: %w", err)
+		}
+		if reason != nil {
+			s.FlagReason = *reason
+		}
+		rollover.FlaggedStudents = append(rollover.FlaggedStudents, s)
+	}
+	return rollover, nil
+}
+
+func (r *semesterRolloverRepository) Claim(ctx context.Context, lease time.Duration) (*domain.SemesterRollover, error) {
+	query := `
+		UPDATE semester_rollovers
+		SET status = 'running', started_at = COALESCE(started_at, now()),
+			locked_until = now() + make_interval(secs => $1), updated_at = now()
+		WHERE rollover_id = (
+			SELECT rollover_id
+			FROM semester_rollovers
+			WHERE status IN ('pending', 'running') AND (locked_until IS NULL OR locked_until < now())
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + rolloverColumns
+	return r.scanRollover(r.db.QueryRow(ctx, query, lease.Seconds()))
+}
+
+func (r *semesterRolloverRepository) Update(ctx context.Context, rollover *domain.SemesterRollover) error {
+	query := `
+		UPDATE semester_rollovers
+		SET status = $2, courses_completed = $3, enrollments_dropped = $4, students_promoted = $5,
+			students_flagged = $6, error = $7, locked_until = $8, completed_at = $9, updated_at = now()
+		WHERE rollover_id = $1
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(ctx, query,
+		rollover.RolloverID,
+		rollover.Status,
+		rollover.CoursesCompleted,
+		rollover.EnrollmentsDropped,
+		rollover.StudentsPromoted,
+		rollover.StudentsFlagged,
+		rollover.Error,
+		rollover.LockedUntil,
+		rollover.CompletedAt,
+	).Scan(&rollover.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return domain.ErrRolloverNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update semester rollover: %w", err)
+	}
+	return nil
+}
+
+func (r *semesterRolloverRepository) ListUngradedCourses(ctx context.Context, semesterID uuid.UUID) ([]domain.UngradedCourse, error) {
+	query := `
+		SELECT c.course_id, c.course_code, c.course_name, COUNT(*)
+		FROM courses c
+		JOIN course_enrollments e ON c.course_id = e.course_id
+		WHERE c.semester_id = $1 AND c.status <> 'cancelled' AND e.enrollment_status = 'enrolled'
+		GROUP BY c.course_id, c.course_code, c.course_name
+		ORDER BY c.course_code
+	`
+	rows, err := r.db.Query(ctx, query, semesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ungraded courses: %w", err)
+	}
+	defer rows.Close()
+
+	courses := []domain.UngradedCourse{}
+	for rows.Next() {
+		var c domain.UngradedCourse
+		if err := rows.Scan(&c.CourseID, &c.CourseCode, &c.CourseName, &c.UngradedStudents); err != nil {
+			return nil, fmt.Errorf("failed to scan ungraded course: %w", err)
+		}
+		courses = append(courses, c)
+	}
+	return courses, nil
+}
+
+func (r *semesterRolloverRepository) CountClosable(ctx context.Context, semesterID uuid.UUID) (courses, waitlisted int, err error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM courses WHERE semester_id = $1 AND status = 'active'),
+			(SELECT COUNT(*)
+			 FROM course_enrollments e
+			 JOIN courses c ON e.course_id = c.course_id
+			 WHERE c.semester_id = $1 AND e.enrollment_status = 'waitlisted')
+	`
+	if err := r.db.QueryRow(ctx, query, semesterID).Scan(&courses, &waitlisted); err != nil {
+		return 0, 0, fmt.Errorf("failed to count closable courses: %w", err)
+	}
+	return courses, waitlisted, nil
+}
+
+func (r *semesterRolloverRepository) CloseCourses(ctx context.Context, semesterID uuid.UUID) (courses, dropped int, err error) {
+	query := `
+		WITH completed AS (
+			UPDATE courses
+			SET status = 'completed', updated_at = now()
+			WHERE semester_id = $1 AND status = 'active'
+			RETURNING course_id
+		), dropped AS (
+			UPDATE course_enrollments
+			SET enrollment_status = 'dropped', dropped_date = now(), drop_reason = 'Semester ended',
+				waitlist_position = NULL, updated_at = now()
+			WHERE enrollment_status = 'waitlisted'
+			  AND course_id IN (SELECT course_id FROM courses WHERE semester_id = $1)
+			RETURNING enrollment_id
+		)
+		SELECT (SELECT COUNT(*) FROM completed), (SELECT COUNT(*) FROM dropped)
+	`
+	if err := r.db.QueryRow(ctx, query, semesterID).Scan(&courses, &dropped); err != nil {
+		return 0, 0, fmt.Errorf("failed to close courses: %w", err)
+	}
+	return courses, dropped, nil
+}
+
+func (r *semesterRolloverRepository) ListCandidates(ctx context.Context, semesterID uuid.UUID) ([]*domain.RolloverCandidate, error) {
+	// Students have at most 8 semesters
+	query := `
+		SELECT s.student_id, s.registration_number, s.current_semester, s.current_cgpa, s.is_active,
+			   LEAST(p.duration_years * 2, 8), COALESCE(ps.pass_grade_points, ds.pass_grade_points, 0)
+		FROM students s
+		JOIN programs p ON s.program_id = p.program_id
+		LEFT JOIN grading_scales ps ON p.grading_scale_id = ps.scale_id
+		LEFT JOIN grading_scales ds ON ds.is_default
+		WHERE EXISTS (
+			SELECT 1
+			FROM course_enrollments e
+			JOIN courses c ON e.course_id = c.course_id
+			WHERE e.student_id = s.student_id AND c.semester_id = $1
+			  AND e.enrollment_status IN ('completed', 'failed')
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM semester_rollover_students rs
+			WHERE rs.semester_id = $1 AND rs.student_id = s.student_id
+		)
+		ORDER BY s.registration_number
+	`
+	rows, err := r.db.Query(ctx, query, semesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rollover candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*domain.RolloverCandidate
+	for rows.Next() {
+		var c domain.RolloverCandidate
+		if err := rows.Scan(
+			&c.StudentID, &c.RegistrationNumber, &c.CurrentSemester, &c.CGPA, &c.IsActive,
+			&c.ProgramSemesters, &c.PassGradePoints,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan rollover candidate: %w", err)
+		}
+		candidates = append(candidates, &c)
+	}
+	return candidates, nil
+}
+
+func (r *semesterRolloverRepository) RecordStudent(ctx context.Context, rollover *domain.SemesterRollover, student *domain.RolloverStudent) (bool, error) {
+	query := `
+		INSERT INTO semester_rollover_students (semester_id, student_id, rollover_id, from_semester, to_semester, flag_reason)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		ON CONFLICT (semester_id, student_id) DO NOTHING
+	`
+	result, err := r.db.Exec(ctx, query,
+		rollover.SemesterID,
+		student.StudentID,
+		rollover.RolloverID,
+		student.FromSemester,
+		student.ToSemester,
+		student.FlagReason,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record rollover student: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *semesterRolloverRepository) scanRollover(row pgx.Row) (*domain.SemesterRollover, error) {
+	var ro domain.SemesterRollover
+	err := row.Scan(
+		&ro.RolloverID, &ro.SemesterID, &ro.NextSemesterID, &ro.Status, &ro.CoursesCompleted, &ro.EnrollmentsDropped,
+		&ro.StudentsPromoted, &ro.StudentsFlagged, &ro.Error, &ro.RequestedBy, &ro.LockedUntil, &ro.StartedAt, &ro.CompletedAt,
+		&ro.CreatedAt, &ro.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrRolloverNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get semester rollover: %w", err)
+	}
+	return &ro, nil
+}
@@ -6,16 +6,16 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type studentRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewStudentRepository(db *pgxpool.Pool) domain.StudentRepository {
+func NewStudentRepository(db *database.DB) domain.StudentRepository {
 	return &studentRepository{db: db}
 }
 
@@ -74,6 +74,25 @@ func (r *studentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &s, nil
 }
 
+func (r *studentRepository) ListExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	query := `SELECT student_id FROM students WHERE student_id = ANY($1)`
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing students: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[uuid.UUID]bool, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan student id: %w", err)
+		}
+		existing[id] = true
+	}
+	return existing, rows.Err()
+}
+
 func (r *studentRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Student, error) {
 	query := `
 		SELECT student_id, user_id, registration_number, roll_number, department_id, program_id,
@@ -153,6 +172,14 @@ func (r *studentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (r *studentRepository) DeactivateByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE students SET is_active = false, updated_at = now() WHERE user_id = $1 AND is_active = true`
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to deactivate student: %w", err)
+	}
+	return nil
+}
+
 func (r *studentRepository) List(ctx context.Context, filter domain.StudentFilter, limit, offset int) ([]*domain.StudentWithDetails, int64, error) {
 	var conditions []string
 	var args []interface{}
@@ -179,7 +206,7 @@ func (r *studentRepository) List(ctx context.Context, filter domain.StudentFilte
 		argNum++
 	}
 	if filter.Search != nil {
-		conditions = append(conditions, fmt.Sprintf("(s.registration_number ILIKE $%d OR s.roll_number ILIKE $%d)", argNum, argNum))
+		conditions = append(conditions, fmt.Sprintf("(s.registration_number ILIKE $%d OR s.roll_number ILIKE $%d OR u.email ILIKE $%d OR %s ILIKE $%d)", argNum, argNum, argNum, userNameColumn, argNum))
 		args = append(args, "%"+*filter.Search+"%")
 		argNum++
 	}
@@ -195,7 +222,7 @@ func (r *studentRepository) List(ctx context.Context, filter domain.StudentFilte
 	}
 
 	// Count query
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM students s %s", whereClause)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM students s LEFT JOIN user_directory u ON u.user_id = s.user_id %s", whereClause)
 	var total int64
 	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("failed to count students: %w", err)
@@ -207,14 +234,16 @@ func (r *studentRepository) List(ctx context.Context, filter domain.StudentFilte
 		SELECT s.student_id, s.user_id, s.registration_number, s.roll_number, s.department_id, s.program_id,
 			   s.current_semester, s.batch_year, s.admission_date, s.current_cgpa, s.total_credits_earned, s.is_active, s.created_at, s.updated_at,
 			   d.department_id, d.department_name, d.department_code,
-			   p.program_id, p.program_name, p.program_code, p.duration_years
+			   p.program_id, p.program_name, p.program_code, p.duration_years,
+			   %s, %s, u.phone
 		FROM students s
 		JOIN departments d ON s.department_id = d.department_id
 		JOIN programs p ON s.program_id = p.program_id
+		LEFT JOIN user_directory u ON u.user_id = s.user_id
 		%s
 		ORDER BY s.registration_number
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argNum, argNum+1)
+	`, userNameColumn, userEmailColumn, whereClause, argNum, argNum+1)
 
 	rows, err := r.db.Query(ctx, listQuery, args...)
 	if err != nil {
@@ -230,10 +259,10 @@ func (r *studentRepository) List(ctx context.Context, filter domain.StudentFilte
 			&s.CurrentSemester, &s.BatchYear, &s.AdmissionDate, &s.CurrentCGPA, &s.TotalCreditsEarned, &s.IsActive, &s.CreatedAt, &s.UpdatedAt,
 			&s.Department.DepartmentID, &s.Department.DepartmentName, &s.Department.DepartmentCode,
 			&s.Program.ProgramID, &s.Program.ProgramName, &s.Program.ProgramCode, &s.Program.DurationYears,
+			&s.Name, &s.Email, &s.Phone,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan student: %w", err)
 		}
-		// Note: Name and Email would come from user service
 		students = append(students, &s)
 	}
 
@@ -245,10 +274,12 @@ func (r *studentRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*
 		SELECT s.student_id, s.user_id, s.registration_number, s.roll_number, s.department_id, s.program_id,
 			   s.current_semester, s.batch_year, s.admission_date, s.current_cgpa, s.total_credits_earned, s.is_active, s.created_at, s.updated_at,
 			   d.department_id, d.department_name, d.department_code,
-			   p.program_id, p.program_name, p.program_code, p.duration_years
+			   p.program_id, p.program_name, p.program_code, p.duration_years,
+			   ` + userNameColumn + `, ` + userEmailColumn + `, u.phone
 		FROM students s
 		JOIN departments d ON s.department_id = d.department_id
 		JOIN programs p ON s.program_id = p.program_id
+		LEFT JOIN user_directory u ON u.user_id = s.user_id
 		WHERE s.student_id = $1
 	`
 
@@ -258,6 +289,7 @@ func (r *studentRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*
 		&st.CurrentSemester, &st.BatchYear, &st.AdmissionDate, &st.CurrentCGPA, &st.TotalCreditsEarned, &st.IsActive, &st.CreatedAt, &st.UpdatedAt,
 		&st.Department.DepartmentID, &st.Department.DepartmentName, &st.Department.DepartmentCode,
 		&st.Program.ProgramID, &st.Program.ProgramName, &st.Program.ProgramCode, &st.Program.DurationYears,
+		&st.Name, &st.Email, &st.Phone,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -293,13 +325,13 @@ func (r *studentRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*
 	return &st, nil
 }
 
-func (r *studentRepository) UpdateSemester(ctx context.Context, id uuid.UUID, semester int, cgpa *float64, credits int) error {
+func (r *studentRepository) UpdateSemester(ctx context.Context, id uuid.UUID, semester int) error {
 	query := `
 		UPDATE students 
-		SET current_semester = $2, current_cgpa = $3, total_credits_earned = total_credits_earned + $4, updated_at = now()
+		SET current_semester = $2, updated_at = now()
 		WHERE student_id = $1
 	`
-	result, err := r.db.Exec(ctx, query, id, semester, cgpa, credits)
+	result, err := r.db.Exec(ctx, query, id, semester)
 	if err != nil {
 		return fmt.Errorf("failed to update student semester: %w", err)
 	}
@@ -308,3 +340,69 @@ func (r *studentRepository) UpdateSemester(ctx context.Context, id uuid.UUID, se
 	}
 	return nil
 }
+
+// UpdateAcademicRecord stores the CGPA and earned credits of a student, and
+// replaces their semester GPAs with those of record
+func (r *studentRepository) UpdateAcademicRecord(ctx context.Context, record *domain.AcademicRecord) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			UPDATE students
+			SET current_cgpa = $2, total_credits_earned = $3, updated_at = now()
+			WHERE student_id = $1
+		`
+		result, err := r.db.Exec(ctx, query, record.StudentID, record.CGPA, record.TotalCreditsEarned)
+		if err != nil {
+			return fmt.Errorf("failed to update academic record: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return domain.ErrStudentNotFound
+		}
+
+		if _, err := r.db.Exec(ctx, `DELETE FROM student_semester_gpas WHERE student_id = $1`, record.StudentID); err != nil {
+			return fmt.Errorf("failed to replace semester GPAs: %w", err)
+		}
+		insertQuery := `
+			INSERT INTO student_semester_gpas (student_id, semester_id, gpa, credits_attempted, credits_earned)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING computed_at
+		`
+		for i := range record.Semesters {
+			sem := &record.Semesters[i]
+			if err := r.db.QueryRow(ctx, insertQuery,
+				record.StudentID, sem.SemesterID, sem.GPA, sem.CreditsAttempted, sem.CreditsEarned,
+			).Scan(&sem.ComputedAt); err != nil {
+				return fmt.Errorf("failed to store semester GPA: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *studentRepository) ListSemesterGPAs(ctx context.Context, studentID uuid.UUID) ([]domain.SemesterGPA, error) {
+	query := `
+		SELECT g.student_id, g.semester_id, sem.semester_code, sem.semester_name,
+			   g.gpa, g.credits_attempted, g.credits_earned, g.computed_at
+		FROM student_semester_gpas g
+		JOIN semesters sem ON g.semester_id = sem.semester_id
+		WHERE g.student_id = $1
+		ORDER BY sem.start_date
+	`
+	rows, err := r.db.Query(ctx, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list semester GPAs: %w", err)
+	}
+	defer rows.Close()
+
+	var gpas []domain.SemesterGPA
+	for rows.Next() {
+		var g domain.SemesterGPA
+		if err := rows.Scan(
+			&g.StudentID, &g.SemesterID, &g.SemesterCode, &g.SemesterName,
+			&g.GPA, &g.CreditsAttempted, &g.CreditsEarned, &g.ComputedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan semester GPA: %w", err)
+		}
+		gpas = append(gpas, g)
+	}
+	return gpas, nil
+}
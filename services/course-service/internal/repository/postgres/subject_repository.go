@@ -6,16 +6,16 @@ import (
 	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type subjectRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
-func NewSubjectRepository(db *pgxpool.Pool) domain.SubjectRepository {
+func NewSubjectRepository(db *database.DB) domain.SubjectRepository {
 	return &subjectRepository{db: db}
 }
 
@@ -243,23 +243,79 @@ func (r *subjectRepository) GetWithDetails(ctx context.Context, id uuid.UUID) (*
 	return &sd, nil
 }
 
-func (r *subjectRepository) AddPrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID, isMandatory bool) error {
-	if subjectID == prerequisiteID {
+func (r *subjectRepository) AddPrerequisite(ctx context.Context, prerequisite *domain.SubjectPrerequisite) error {
+	if prerequisite.SubjectID == prerequisite.PrerequisiteSubjectID {
 		return domain.ErrSelfPrerequisite
 	}
 
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Serialize prerequisite changes, so that two edges added concurrently
+		// cannot close a cycle that neither sees on its own
+		if _, err := r.db.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('subject_prerequisites'))"); err != nil {
+			return fmt.Errorf("failed to lock prerequisites: %w", err)
+		}
+
+		cycle, err := r.prerequisitePath(ctx, prerequisite.PrerequisiteSubjectID, prerequisite.SubjectID)
+		if err != nil {
+			return err
+		}
+		if cycle != nil {
+			// The new edge leads from the subject back to itself
+			return fmt.Errorf("%w: %s", domain.ErrPrerequisiteCycle, strings.Join(cycle, " -> "))
+		}
+
+		query := `
+			INSERT INTO subject_prerequisites (prerequisite_id, subject_id, prerequisite_subject_id, is_mandatory, min_grade, min_grade_points)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING created_at
+		`
+		prerequisite.PrerequisiteID = uuid.New()
+		err = r.db.QueryRow(ctx, query,
+			prerequisite.PrerequisiteID, prerequisite.SubjectID, prerequisite.PrerequisiteSubjectID,
+			prerequisite.IsMandatory, prerequisite.MinGrade, prerequisite.MinGradePoints,
+		).Scan(&prerequisite.CreatedAt)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return domain.ErrPrerequisiteExists
+			}
+			return fmt.Errorf("failed to add prerequisite: %w", err)
+		}
+		return nil
+	})
+}
+
+// prerequisitePath returns the subject codes along a chain of prerequisites
+// leading from one subject to a subject it transitively requires, starting
+// and ending with to. It returns nil if from does not require to.
+func (r *subjectRepository) prerequisitePath(ctx context.Context, from, to uuid.UUID) ([]string, error) {
 	query := `
-		INSERT INTO subject_prerequisites (prerequisite_id, subject_id, prerequisite_subject_id, is_mandatory)
-		VALUES ($1, $2, $3, $4)
+		WITH RECURSIVE chain (subject_id, path) AS (
+			SELECT $1::uuid, ARRAY[$1::uuid]
+			UNION ALL
+			SELECT sp.prerequisite_subject_id, c.path || sp.prerequisite_subject_id
+			FROM subject_prerequisites sp
+			JOIN chain c ON sp.subject_id = c.subject_id
+			WHERE NOT sp.prerequisite_subject_id = ANY(c.path)
+		)
+		SELECT ARRAY(
+			SELECT s.subject_code
+			FROM unnest($2::uuid || c.path) WITH ORDINALITY AS p(subject_id, n)
+			JOIN subjects s ON s.subject_id = p.subject_id
+			ORDER BY p.n
+		)
+		FROM chain c
+		WHERE c.subject_id = $2
+		LIMIT 1
 	`
-	_, err := r.db.Exec(ctx, query, uuid.New(), subjectID, prerequisiteID, isMandatory)
+	var path []string
+	err := r.db.QueryRow(ctx, query, from, to).Scan(&path)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") {
-			return fmt.Errorf("prerequisite already exists")
-		}
-		return fmt.Errorf("failed to add prerequisite: %w", err)
+		return nil, fmt.Errorf("failed to check prerequisite cycle: %w", err)
 	}
-	return nil
+	return path, nil
 }
 
 func (r *subjectRepository) RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error {
@@ -269,14 +325,15 @@ func (r *subjectRepository) RemovePrerequisite(ctx context.Context, subjectID, p
 		return fmt.Errorf("failed to remove prerequisite: %w", err)
 	}
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("prerequisite not found")
+		return domain.ErrPrerequisiteNotFound
 	}
 	return nil
 }
 
 func (r *subjectRepository) GetPrerequisites(ctx context.Context, subjectID uuid.UUID) ([]domain.SubjectPrerequisite, error) {
 	query := `
-		SELECT sp.prerequisite_id, sp.subject_id, sp.prerequisite_subject_id, sp.is_mandatory, sp.created_at,
+		SELECT sp.prerequisite_id, sp.subject_id, sp.prerequisite_subject_id, sp.is_mandatory,
+			   sp.min_grade, sp.min_grade_points, sp.created_at,
 			   s.subject_code, s.subject_name
 		FROM subject_prerequisites sp
 		JOIN subjects s ON sp.prerequisite_subject_id = s.subject_id
@@ -292,7 +349,8 @@ func (r *subjectRepository) GetPrerequisites(ctx context.Context, subjectID uuid
 	for rows.Next() {
 		var p domain.SubjectPrerequisite
 		if err := rows.Scan(
-			&p.PrerequisiteID, &p.SubjectID, &p.PrerequisiteSubjectID, &p.IsMandatory, &p.CreatedAt,
+			&p.PrerequisiteID, &p.SubjectID, &p.PrerequisiteSubjectID, &p.IsMandatory,
+			&p.MinGrade, &p.MinGradePoints, &p.CreatedAt,
 			&p.SubjectCode, &p.SubjectName,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan prerequisite: %w", err)
@@ -304,7 +362,7 @@ func (r *subjectRepository) GetPrerequisites(ctx context.Context, subjectID uuid
 
 func (r *subjectRepository) AddCorequisite(ctx context.Context, subjectID, corequisiteID uuid.UUID) error {
 	if subjectID == corequisiteID {
-		return fmt.Errorf("subject cannot be its own corequisite")
+		return domain.ErrSelfCorequisite
 	}
 
 	query := `
@@ -314,7 +372,7 @@ func (r *subjectRepository) AddCorequisite(ctx context.Context, subjectID, coreq
 	_, err := r.db.Exec(ctx, query, uuid.New(), subjectID, corequisiteID)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
-			return fmt.Errorf("corequisite already exists")
+			return domain.ErrCorequisiteExists
 		}
 		return fmt.Errorf("failed to add corequisite: %w", err)
 	}
@@ -328,7 +386,7 @@ func (r *subjectRepository) RemoveCorequisite(ctx context.Context, subjectID, co
 		return fmt.Errorf("failed to remove corequisite: %w", err)
 	}
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("corequisite not found")
+		return domain.ErrCorequisiteNotFound
 	}
 	return nil
 }
@@ -356,3 +414,130 @@ func (r *subjectRepository) GetCorequisites(ctx context.Context, subjectID uuid.
 	}
 	return coreqs, nil
 }
+
+func (r *subjectRepository) GetPrerequisiteChain(ctx context.Context, subjectID uuid.UUID, downstream bool) (*domain.PrerequisiteGraph, error) {
+	// Upstream follows the prerequisites of each subject, downstream follows
+	// the subjects requiring it
+	from, to := "subject_id", "prerequisite_subject_id"
+	if downstream {
+		from, to = to, from
+	}
+
+	// UNION drops repeated edges, so the recursion ends even on a cycle
+	query := fmt.Sprintf(`
+		WITH RECURSIVE chain AS (
+			SELECT prerequisite_id, subject_id, prerequisite_subject_id, is_mandatory, min_grade, min_grade_points, created_at
+			FROM subject_prerequisites
+			WHERE %[1]s = $1
+			UNION
+			SELECT sp.prerequisite_id, sp.subject_id, sp.prerequisite_subject_id, sp.is_mandatory, sp.min_grade, sp.min_grade_points, sp.created_at
+			FROM subject_prerequisites sp
+			JOIN chain c ON sp.%[1]s = c.%[2]s
+		)
+		SELECT prerequisite_id, subject_id, prerequisite_subject_id, is_mandatory, min_grade, min_grade_points, created_at
+		FROM chain
+	`, from, to)
+	edges, err := r.queryPrerequisiteEdges(ctx, query, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	subjects, err := r.graphSubjects(ctx, "s.subject_id = $1", []interface{}{subjectID}, edges)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PrerequisiteGraph{Subjects: subjects, Edges: edges}, nil
+}
+
+func (r *subjectRepository) GetPrerequisiteGraph(ctx context.Context, filter domain.PrerequisiteGraphFilter) (*domain.PrerequisiteGraph, error) {
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if filter.DepartmentID != nil {
+		conditions = append(conditions, fmt.Sprintf("s.department_id = $%d", argNum))
+		args = append(args, *filter.DepartmentID)
+		argNum++
+	}
+	if filter.ProgramID != nil {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM courses c WHERE c.subject_id = s.subject_id AND c.program_id = $%d)", argNum))
+		args = append(args, *filter.ProgramID)
+		argNum++
+	}
+
+	scope := "true"
+	if len(conditions) > 0 {
+		scope = strings.Join(conditions, " AND ")
+	}
+
+	// Edges of the subjects in scope, whichever subjects they require
+	query := fmt.Sprintf(`
+		SELECT sp.prerequisite_id, sp.subject_id, sp.prerequisite_subject_id, sp.is_mandatory, sp.min_grade, sp.min_grade_points, sp.created_at
+		FROM subject_prerequisites sp
+		JOIN subjects s ON sp.subject_id = s.subject_id
+		WHERE %s
+	`, scope)
+	edges, err := r.queryPrerequisiteEdges(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	subjects, err := r.graphSubjects(ctx, scope, args, edges)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PrerequisiteGraph{Subjects: subjects, Edges: edges}, nil
+}
+
+func (r *subjectRepository) queryPrerequisiteEdges(ctx context.Context, query string, args ...interface{}) ([]domain.SubjectPrerequisite, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prerequisite graph: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []domain.SubjectPrerequisite
+	for rows.Next() {
+		var p domain.SubjectPrerequisite
+		if err := rows.Scan(
+			&p.PrerequisiteID, &p.SubjectID, &p.PrerequisiteSubjectID, &p.IsMandatory,
+			&p.MinGrade, &p.MinGradePoints, &p.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan prerequisite: %w", err)
+		}
+		edges = append(edges, p)
+	}
+	return edges, nil
+}
+
+// graphSubjects returns the subjects matching scope together with the
+// subjects at either end of edges
+func (r *subjectRepository) graphSubjects(ctx context.Context, scope string, args []interface{}, edges []domain.SubjectPrerequisite) ([]domain.SubjectBasic, error) {
+	ids := make([]uuid.UUID, 0, 2*len(edges))
+	for _, e := range edges {
+		ids = append(ids, e.SubjectID, e.PrerequisiteSubjectID)
+	}
+	args = append(args, ids)
+
+	query := fmt.Sprintf(`
+		SELECT s.subject_id, s.subject_code, s.subject_name, s.credits, s.subject_type
+		FROM subjects s
+		WHERE (%s) OR s.subject_id = ANY($%d)
+		ORDER BY s.subject_code
+	`, scope, len(args))
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prerequisite graph subjects: %w", err)
+	}
+	defer rows.Close()
+
+	var subjects []domain.SubjectBasic
+	for rows.Next() {
+		var s domain.SubjectBasic
+		if err := rows.Scan(&s.SubjectID, &s.SubjectCode, &s.SubjectName, &s.Credits, &s.SubjectType); err != nil {
+			return nil, fmt.Errorf("failed to scan subject: %w", err)
+		}
+		subjects = append(subjects, s)
+	}
+	return subjects, nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type transcriptRepository struct {
+	db *database.DB
+}
+
+func NewTranscriptRepository(db *database.DB) domain.TranscriptRepository {
+	return &transcriptRepository{db: db}
+}
+
+func (r *transcriptRepository) Create(ctx context.Context, transcript *domain.IssuedTranscript) error {
+	query := `
+		INSERT INTO transcripts (verification_code, student_id, contents, content_hash, signature, issued_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING issued_at
+	`
+	err := r.db.QueryRow(ctx, query,
+		transcript.VerificationCode,
+		transcript.StudentID,
+		string(transcript.Contents),
+		transcript.ContentHash,
+		transcript.Signature,
+		transcript.IssuedBy,
+	).Scan(&transcript.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create transcript: %w", err)
+	}
+	return nil
+}
+
+func (r *transcriptRepository) GetByCode(ctx context.Context, code string) (*domain.IssuedTranscript, error) {
+	query := `
+		SELECT verification_code, student_id, contents, content_hash, signature, issued_by, issued_at
+		FROM transcripts
+		WHERE verification_code = $1
+	`
+	return r.scanTranscript(r.db.QueryRow(ctx, query, code))
+}
+
+func (r *transcriptRepository) GetByContentHash(ctx context.Context, studentID uuid.UUID, contentHash string) (*domain.IssuedTranscript, error) {
+	query := `
+		SELECT verification_code, student_id, contents, content_hash, signature, issued_by, issued_at
+		FROM transcripts
+		WHERE student_id = $1 AND content_hash = $2
+		ORDER BY issued_at
+		LIMIT 1
+	`
+	return r.scanTranscript(r.db.QueryRow(ctx, query, studentID, contentHash))
+}
+
+func (r *transcriptRepository) scanTranscript(row pgx.Row) (*domain.IssuedTranscript, error) {
+	var t domain.IssuedTranscript
+	var contents string
+	err := row.Scan(&t.VerificationCode, &t.StudentID, &contents, &t.ContentHash, &t.Signature, &t.IssuedBy, &t.IssuedAt)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrTranscriptNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcript: %w", err)
+	}
+	t.Contents = []byte(contents)
+	return &t, nil
+}
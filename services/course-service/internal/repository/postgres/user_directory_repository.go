@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Columns with the name and email of the user_directory row joined as u. They
+// are empty for users the directory has not heard of yet.
+const (
+	userNameColumn  = `TRIM(CONCAT_WS(' ', u.first_name, u.last_name))`
+	userEmailColumn = `COALESCE(u.email, '')`
+)
+
+type userDirectoryRepository struct {
+	db *database.DB
+}
+
+func NewUserDirectoryRepository(db *database.DB) domain.UserDirectoryRepository {
+	return &userDirectoryRepository{db: db}
+}
+
+func (r *userDirectoryRepository) Upsert(ctx context.Context, entry *domain.UserDirectoryEntry) error {
+	query := `
+		INSERT INTO user_directory (user_id, email, first_name, last_name, phone, status, event_timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET email = EXCLUDED.email, first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name,
+			phone = EXCLUDED.phone, status = EXCLUDED.status, event_timestamp = EXCLUDED.event_timestamp
+		WHERE user_directory.event_timestamp <= EXCLUDED.event_timestamp
+	`
+	_, err := r.db.Exec(ctx, query,
+		entry.UserID,
+		entry.Email,
+		entry.FirstName,
+		entry.LastName,
+		entry.Phone,
+		entry.Status,
+		entry.EventTimestamp,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert user directory entry: %w", err)
+	}
+	return nil
+}
+
+func (r *userDirectoryRepository) UpdateStatus(ctx context.Context, userID uuid.UUID, status string, eventTimestamp time.Time) error {
+	query := `
+		UPDATE user_directory
+		SET status = $2, event_timestamp = $3
+		WHERE user_id = $1 AND event_timestamp <= $3
+	`
+	if _, err := r.db.Exec(ctx, query, userID, status, eventTimestamp); err != nil {
+		return fmt.Errorf("failed to update user directory status: %w", err)
+	}
+	return nil
+}
+
+func (r *userDirectoryRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserDirectoryEntry, error) {
+	query := `
+		SELECT user_id, email, COALESCE(first_name, ''), COALESCE(last_name, ''), phone, status, event_timestamp, updated_at
+		FROM user_directory
+		WHERE user_id = $1
+	`
+	var u domain.UserDirectoryEntry
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&u.UserID, &u.Email, &u.FirstName, &u.LastName, &u.Phone, &u.Status, &u.EventTimestamp, &u.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user directory entry: %w", err)
+	}
+	return &u, nil
+}
@@ -5,17 +5,19 @@ import (
 	"time"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type calendarService struct {
 	repo         domain.CalendarRepository
 	semesterRepo domain.SemesterRepository
+	transactor   domain.Transactor
 	producer     domain.EventProducer
 }
 
-func NewCalendarService(repo domain.CalendarRepository, semesterRepo domain.SemesterRepository, producer domain.EventProducer) domain.CalendarService {
-	return &calendarService{repo: repo, semesterRepo: semesterRepo, producer: producer}
+func NewCalendarService(repo domain.CalendarRepository, semesterRepo domain.SemesterRepository, transactor domain.Transactor, producer domain.EventProducer) domain.CalendarService {
+	return &calendarService{repo: repo, semesterRepo: semesterRepo, transactor: transactor, producer: producer}
 }
 
 func (s *calendarService) CreateEvent(ctx context.Context, event *domain.AcademicCalendarEvent) error {
@@ -25,22 +27,22 @@ func (s *calendarService) CreateEvent(ctx context.Context, event *domain.Academi
 		return err
 	}
 
-	if err := s.repo.Create(ctx, event); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.calendar.event_created", event.EventID.String(), map[string]interface{}{
-			"event_id":    event.EventID,
-			"event_name":  event.EventName,
-			"event_type":  event.EventType,
-			"semester_id": event.SemesterID,
-			"start_date":  event.StartDate,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, event); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		calendarEvent := models.NewCalendarEvent(models.EventCalendarEventCreated, event.EventID)
+		calendarEvent.SemesterID = event.SemesterID
+		calendarEvent.EventName = event.EventName
+		calendarEvent.CalendarEventType = event.EventType
+		calendarEvent.StartDate = &event.StartDate
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, event.EventID.String(), calendarEvent)
+	})
 }
 
 func (s *calendarService) GetEvent(ctx context.Context, id uuid.UUID) (*domain.AcademicCalendarEventWithDetails, error) {
@@ -98,34 +100,36 @@ func (s *calendarService) UpdateEvent(ctx context.Context, id uuid.UUID, updates
 		event.IsHoliday = isHoliday
 	}
 
-	if err := s.repo.Update(ctx, event); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.calendar.event_updated", event.EventID.String(), map[string]interface{}{
-			"event_id":   event.EventID,
-			"event_name": event.EventName,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, event); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		calendarEvent := models.NewCalendarEvent(models.EventCalendarEventUpdated, event.EventID)
+		calendarEvent.SemesterID = event.SemesterID
+		calendarEvent.EventName = event.EventName
+		calendarEvent.CalendarEventType = event.EventType
+		calendarEvent.StartDate = &event.StartDate
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, event.EventID.String(), calendarEvent)
+	})
 }
 
 func (s *calendarService) DeleteEvent(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.calendar.event_deleted", id.String(), map[string]interface{}{
-			"event_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewCalendarEvent(models.EventCalendarEventDeleted, id))
+	})
 }
 
 func (s *calendarService) ListEvents(ctx context.Context, filter domain.CalendarFilter, page, limit int) ([]*domain.AcademicCalendarEventWithDetails, int64, error) {
@@ -21,7 +21,7 @@ func TestCalendarService_CreateEvent(t *testing.T) {
 	mockSemesterRepo := mocks.NewMockSemesterRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewCalendarService(mockRepo, mockSemesterRepo, mockProducer)
+	service := NewCalendarService(mockRepo, mockSemesterRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		eventID := uuid.New()
@@ -42,7 +42,7 @@ func TestCalendarService_CreateEvent(t *testing.T) {
 
 		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), semesterID).Return(semester, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), event).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.calendar.event_created", eventID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, eventID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateEvent(context.Background(), event)
 		assert.NoError(t, err)
@@ -88,7 +88,7 @@ func TestCalendarService_GetEvent(t *testing.T) {
 	mockRepo := mocks.NewMockCalendarRepository(ctrl)
 	mockSemesterRepo := mocks.NewMockSemesterRepository(ctrl)
 
-	service := NewCalendarService(mockRepo, mockSemesterRepo, nil)
+	service := NewCalendarService(mockRepo, mockSemesterRepo, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		eventID := uuid.New()
@@ -136,7 +136,7 @@ func TestCalendarService_UpdateEvent(t *testing.T) {
 	mockSemesterRepo := mocks.NewMockSemesterRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewCalendarService(mockRepo, mockSemesterRepo, mockProducer)
+	service := NewCalendarService(mockRepo, mockSemesterRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		eventID := uuid.New()
@@ -157,7 +157,7 @@ func TestCalendarService_UpdateEvent(t *testing.T) {
 			assert.True(t, e.IsHoliday)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.calendar.event_updated", eventID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, eventID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateEvent(context.Background(), eventID, updates)
 		assert.NoError(t, err)
@@ -181,13 +181,13 @@ func TestCalendarService_DeleteEvent(t *testing.T) {
 	mockRepo := mocks.NewMockCalendarRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewCalendarService(mockRepo, nil, mockProducer)
+	service := NewCalendarService(mockRepo, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		eventID := uuid.New()
 
 		mockRepo.EXPECT().Delete(gomock.Any(), eventID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.calendar.event_deleted", eventID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, eventID.String(), gomock.Any()).Return(nil)
 
 		err := service.DeleteEvent(context.Background(), eventID)
 		assert.NoError(t, err)
@@ -209,7 +209,7 @@ func TestCalendarService_ListEvents(t *testing.T) {
 
 	mockRepo := mocks.NewMockCalendarRepository(ctrl)
 
-	service := NewCalendarService(mockRepo, nil, nil)
+	service := NewCalendarService(mockRepo, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		semesterID := uuid.New()
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
@@ -12,6 +13,7 @@ type courseService struct {
 	subjectRepo    domain.SubjectRepository
 	semesterRepo   domain.SemesterRepository
 	enrollmentRepo domain.EnrollmentRepository
+	transactor     domain.Transactor
 	producer       domain.EventProducer
 }
 
@@ -20,6 +22,7 @@ func NewCourseService(
 	subjectRepo domain.SubjectRepository,
 	semesterRepo domain.SemesterRepository,
 	enrollmentRepo domain.EnrollmentRepository,
+	transactor domain.Transactor,
 	producer domain.EventProducer,
 ) domain.CourseService {
 	return &courseService{
@@ -27,6 +30,7 @@ func NewCourseService(
 		subjectRepo:    subjectRepo,
 		semesterRepo:   semesterRepo,
 		enrollmentRepo: enrollmentRepo,
+		transactor:     transactor,
 		producer:       producer,
 	}
 }
@@ -56,23 +60,17 @@ func (s *courseService) CreateCourse(ctx context.Context, course *domain.Course)
 		course.CourseName = subject.SubjectName
 	}
 
-	if err := s.repo.Create(ctx, course); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.course.created", course.CourseID.String(), map[string]interface{}{
-			"course_id":     course.CourseID,
-			"course_code":   course.CourseCode,
-			"course_name":   course.CourseName,
-			"subject_id":    course.SubjectID,
-			"semester_id":   course.SemesterID,
-			"department_id": course.DepartmentID,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, course); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, course.CourseID.String(), courseEvent(models.EventCourseCreated, course))
+	})
 }
 
 func (s *courseService) GetCourse(ctx context.Context, id uuid.UUID) (*domain.CourseWithDetails, error) {
@@ -112,34 +110,31 @@ func (s *courseService) UpdateCourse(ctx context.Context, id uuid.UUID, updates
 		course.IsActive = isActive
 	}
 
-	if err := s.repo.Update(ctx, course); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.course.updated", course.CourseID.String(), map[string]interface{}{
-			"course_id":   course.CourseID,
-			"course_name": course.CourseName,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, course); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, course.CourseID.String(), courseEvent(models.EventCourseUpdated, course))
+	})
 }
 
 func (s *courseService) DeleteCourse(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.course.deleted", id.String(), map[string]interface{}{
-			"course_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewCourseEvent(models.EventCourseDeleted, id))
+	})
 }
 
 func (s *courseService) ListCourses(ctx context.Context, filter domain.CourseFilter, page, limit int) ([]*domain.CourseWithDetails, int64, error) {
@@ -157,18 +152,17 @@ func (s *courseService) ActivateCourse(ctx context.Context, id uuid.UUID) error
 		return domain.ErrCannotModifyCompletedCourse
 	}
 
-	if err := s.repo.UpdateStatus(ctx, id, "active"); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.course.activated", id.String(), map[string]interface{}{
-			"course_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateStatus(ctx, id, "active"); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), courseEvent(models.EventCourseActivated, course))
+	})
 }
 
 func (s *courseService) DeactivateCourse(ctx context.Context, id uuid.UUID) error {
@@ -181,21 +175,31 @@ func (s *courseService) DeactivateCourse(ctx context.Context, id uuid.UUID) erro
 		return domain.ErrCannotModifyCompletedCourse
 	}
 
-	if err := s.repo.UpdateStatus(ctx, id, "cancelled"); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.course.deactivated", id.String(), map[string]interface{}{
-			"course_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateStatus(ctx, id, "cancelled"); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), courseEvent(models.EventCourseDeactivated, course))
+	})
 }
 
 func (s *courseService) GetCourseStudents(ctx context.Context, courseID uuid.UUID, status *string, page, limit int) ([]*domain.EnrollmentWithDetails, int64, error) {
 	offset := (page - 1) * limit
 	return s.enrollmentRepo.ListByCourse(ctx, courseID, status, limit, offset)
 }
+
+// courseEvent creates a course event describing course
+func courseEvent(eventType models.EventType, course *domain.Course) *models.CourseEvent {
+	event := models.NewCourseEvent(eventType, course.CourseID)
+	event.CourseCode = course.CourseCode
+	event.CourseName = course.CourseName
+	event.SubjectID = course.SubjectID
+	event.SemesterID = course.SemesterID
+	event.DepartmentID = course.DepartmentID
+	return event
+}
@@ -21,7 +21,7 @@ func TestCourseService_CreateCourse(t *testing.T) {
 	mockEnrollmentRepo := mocks.NewMockEnrollmentRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewCourseService(mockRepo, mockSubjectRepo, mockSemesterRepo, mockEnrollmentRepo, mockProducer)
+	service := NewCourseService(mockRepo, mockSubjectRepo, mockSemesterRepo, mockEnrollmentRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		courseID := uuid.New()
@@ -47,7 +47,7 @@ func TestCourseService_CreateCourse(t *testing.T) {
 		mockSubjectRepo.EXPECT().GetByID(gomock.Any(), subjectID).Return(subject, nil)
 		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), semesterID).Return(semester, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), course).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.course.created", courseID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateCourse(context.Background(), course)
 		assert.NoError(t, err)
@@ -76,7 +76,7 @@ func TestCourseService_UpdateCourse(t *testing.T) {
 	mockEnrollmentRepo := mocks.NewMockEnrollmentRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewCourseService(mockRepo, mockSubjectRepo, mockSemesterRepo, mockEnrollmentRepo, mockProducer)
+	service := NewCourseService(mockRepo, mockSubjectRepo, mockSemesterRepo, mockEnrollmentRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		courseID := uuid.New()
@@ -95,7 +95,7 @@ func TestCourseService_UpdateCourse(t *testing.T) {
 			assert.Equal(t, "New Name", c.CourseName)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.course.updated", courseID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateCourse(context.Background(), courseID, updates)
 		assert.NoError(t, err)
@@ -123,7 +123,7 @@ func TestCourseService_ActivateCourse(t *testing.T) {
 
 	mockRepo := mocks.NewMockCourseRepository(ctrl)
 	// other mocks unused
-	service := NewCourseService(mockRepo, nil, nil, nil, mocks.NewMockEventProducer(ctrl))
+	service := NewCourseService(mockRepo, nil, nil, nil, newTestTransactor(ctrl), mocks.NewMockEventProducer(ctrl))
 
 	t.Run("Success", func(t *testing.T) {
 		courseID := uuid.New()
@@ -136,9 +136,18 @@ func TestCourseService_ActivateCourse(t *testing.T) {
 		mockRepo.EXPECT().UpdateStatus(gomock.Any(), courseID, "active").Return(nil)
 		// We need the producer here because ActivateCourse calls PublishEvent
 		service.(*courseService).producer.(*mocks.MockEventProducer).EXPECT().
-			PublishEvent("course.course.activated", courseID.String(), gomock.Any()).Return(nil)
+			PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
 
 		err := service.ActivateCourse(context.Background(), courseID)
 		assert.NoError(t, err)
 	})
 }
+
+// newTestTransactor returns a transactor that runs every transaction inline
+func newTestTransactor(ctrl *gomock.Controller) *mocks.MockTransactor {
+	transactor := mocks.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+	return transactor
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type curriculumService struct {
+	repo        domain.CurriculumRepository
+	progRepo    domain.ProgramRepository
+	studentRepo domain.StudentRepository
+	enrollRepo  domain.EnrollmentRepository
+	scaleRepo   domain.GradingScaleRepository
+}
+
+func NewCurriculumService(
+	repo domain.CurriculumRepository,
+	progRepo domain.ProgramRepository,
+	studentRepo domain.StudentRepository,
+	enrollRepo domain.EnrollmentRepository,
+	scaleRepo domain.GradingScaleRepository,
+) domain.CurriculumService {
+	return &curriculumService{
+		repo:        repo,
+		progRepo:    progRepo,
+		studentRepo: studentRepo,
+		enrollRepo:  enrollRepo,
+		scaleRepo:   scaleRepo,
+	}
+}
+
+// CreateCurriculum adds a curriculum version to a program. Without total
+// credits, the total credits of the program are required.
+func (s *curriculumService) CreateCurriculum(ctx context.Context, curriculum *domain.Curriculum) error {
+	program, err := s.progRepo.GetByID(ctx, curriculum.ProgramID)
+	if err != nil {
+		return err
+	}
+	if curriculum.TotalCredits == 0 && program.TotalCredits != nil {
+		curriculum.TotalCredits = *program.TotalCredits
+	}
+
+	if err := validateCurriculum(curriculum, program); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, curriculum)
+}
+
+func (s *curriculumService) GetCurriculum(ctx context.Context, id uuid.UUID) (*domain.Curriculum, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// UpdateCurriculum changes a curriculum. Core subjects and elective buckets
+// given in updates replace the existing ones.
+func (s *curriculumService) UpdateCurriculum(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	curriculum, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	program, err := s.progRepo.GetByID(ctx, curriculum.ProgramID)
+	if err != nil {
+		return err
+	}
+
+	// Apply updates
+	if batchYear, ok := updates["batch_year"].(int); ok {
+		curriculum.BatchYear = batchYear
+	}
+	if name, ok := updates["version_name"].(string); ok {
+		curriculum.VersionName = name
+	}
+	if totalCredits, ok := updates["total_credits"].(int); ok {
+		curriculum.TotalCredits = totalCredits
+	}
+	if core, ok := updates["core_subjects"].([]domain.CoreSubject); ok {
+		curriculum.CoreSubjects = core
+	}
+	if buckets, ok := updates["elective_buckets"].([]domain.ElectiveBucket); ok {
+		curriculum.ElectiveBuckets = buckets
+	}
+
+	if err := validateCurriculum(curriculum, program); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, curriculum)
+}
+
+func (s *curriculumService) DeleteCurriculum(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *curriculumService) ListCurricula(ctx context.Context, programID uuid.UUID) ([]*domain.Curriculum, error) {
+	if _, err := s.progRepo.GetByID(ctx, programID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByProgram(ctx, programID)
+}
+
+// AuditDegree evaluates the passed and current enrollments of a student
+// against the curriculum of their program and batch
+func (s *curriculumService) AuditDegree(ctx context.Context, studentID uuid.UUID) (*domain.DegreeAudit, error) {
+	student, err := s.studentRepo.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	curriculum, err := s.repo.GetForBatch(ctx, student.ProgramID, student.BatchYear)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := s.scaleRepo.GetForStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.enrollRepo.ListGradedAttempts(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	enrolled, err := s.enrollRepo.ListEnrolledSubjects(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return degreeAudit(studentID, curriculum, scale, attempts, enrolled), nil
+}
+
+// degreeAudit checks the requirements of a curriculum. A subject counts once,
+// as passed if any attempt at it reached the pass grade points of scale, or
+// else as in progress while the student is enrolled in it.
+func degreeAudit(studentID uuid.UUID, curriculum *domain.Curriculum, scale *domain.GradingScale, attempts []*domain.GradedAttempt, enrolled []*domain.SubjectBasic) *domain.DegreeAudit {
+	passed := make(map[uuid.UUID]int)
+	for _, a := range attempts {
+		if a.GradePoints >= scale.PassGradePoints {
+			passed[a.SubjectID] = a.Credits
+		}
+	}
+	taking := make(map[uuid.UUID]int)
+	for _, s := range enrolled {
+		if _, ok := passed[s.SubjectID]; !ok {
+			taking[s.SubjectID] = s.Credits
+		}
+	}
+
+	audit := &domain.DegreeAudit{
+		StudentID:       studentID,
+		CurriculumID:    curriculum.CurriculumID,
+		VersionName:     curriculum.VersionName,
+		CoreSubjects:    make([]domain.CoreSubjectAudit, 0, len(curriculum.CoreSubjects)),
+		ElectiveBuckets: make([]domain.ElectiveBucketAudit, 0, len(curriculum.ElectiveBuckets)),
+		Eligible:        true,
+	}
+
+	for _, core := range curriculum.CoreSubjects {
+		status := domain.RequirementMissing
+		if _, ok := passed[core.SubjectID]; ok {
+			status = domain.RequirementSatisfied
+		} else if _, ok := taking[core.SubjectID]; ok {
+			status = domain.RequirementInProgress
+		}
+		audit.CoreSubjects = append(audit.CoreSubjects, domain.CoreSubjectAudit{CoreSubject: core, Status: status})
+		audit.Eligible = audit.Eligible && status == domain.RequirementSatisfied
+	}
+
+	for _, bucket := range curriculum.ElectiveBuckets {
+		b := domain.ElectiveBucketAudit{
+			BucketID:   bucket.BucketID,
+			BucketName: bucket.BucketName,
+			Completed:  []domain.SubjectBasic{},
+			InProgress: []domain.SubjectBasic{},
+		}
+		var earned, inProgress int
+		for _, subject := range bucket.Subjects {
+			if _, ok := passed[subject.SubjectID]; ok {
+				b.Completed = append(b.Completed, subject)
+				earned += subject.Credits
+			} else if _, ok := taking[subject.SubjectID]; ok {
+				b.InProgress = append(b.InProgress, subject)
+				inProgress += subject.Credits
+			}
+		}
+		b.CreditRequirementAudit = creditRequirement(bucket.MinCredits, earned, inProgress)
+		audit.ElectiveBuckets = append(audit.ElectiveBuckets, b)
+		audit.Eligible = audit.Eligible && b.Status == domain.RequirementSatisfied
+	}
+
+	var earned, inProgress int
+	for _, credits := range passed {
+		earned += credits
+	}
+	for _, credits := range taking {
+		inProgress += credits
+	}
+	audit.TotalCredits = creditRequirement(curriculum.TotalCredits, earned, inProgress)
+	audit.Eligible = audit.Eligible && audit.TotalCredits.Status == domain.RequirementSatisfied
+
+	return audit
+}
+
+func creditRequirement(required, earned, inProgress int) domain.CreditRequirementAudit {
+	status := domain.RequirementMissing
+	if earned >= required {
+		status = domain.RequirementSatisfied
+	} else if earned+inProgress >= required {
+		status = domain.RequirementInProgress
+	}
+	return domain.CreditRequirementAudit{
+		Status:            status,
+		RequiredCredits:   required,
+		EarnedCredits:     earned,
+		InProgressCredits: inProgress,
+	}
+}
+
+// validateCurriculum checks that core subjects are planned within the
+// duration of program, and that no subject is required twice
+func validateCurriculum(curriculum *domain.Curriculum, program *domain.Program) error {
+	if curriculum.TotalCredits <= 0 {
+		return fmt.Errorf("%w: total credits are required as the program has none", domain.ErrInvalidCurriculum)
+	}
+	if curriculum.BatchYear < 2000 || curriculum.BatchYear > 2100 {
+		return fmt.Errorf("%w: batch year must be between 2000 and 2100", domain.ErrInvalidCurriculum)
+	}
+
+	// Programs have two semesters a year
+	semesters := program.DurationYears * 2
+	seen := make(map[uuid.UUID]bool)
+	for _, core := range curriculum.CoreSubjects {
+		if core.SemesterNumber < 1 || core.SemesterNumber > semesters {
+			return fmt.Errorf("%w: core subjects must be planned for semesters 1 to %d", domain.ErrInvalidCurriculum, semesters)
+		}
+		if seen[core.SubjectID] {
+			return fmt.Errorf("%w: subject %s is listed twice", domain.ErrInvalidCurriculum, core.SubjectID)
+		}
+		seen[core.SubjectID] = true
+	}
+
+	names := make(map[string]bool)
+	for i := range curriculum.ElectiveBuckets {
+		b := &curriculum.ElectiveBuckets[i]
+		b.BucketName = strings.TrimSpace(b.BucketName)
+		if b.BucketName == "" || names[strings.ToLower(b.BucketName)] {
+			return fmt.Errorf("%w: elective buckets need unique names", domain.ErrInvalidCurriculum)
+		}
+		names[strings.ToLower(b.BucketName)] = true
+		if b.MinCredits <= 0 {
+			return fmt.Errorf("%w: elective bucket %s must require credits", domain.ErrInvalidCurriculum, b.BucketName)
+		}
+		if len(b.Subjects) == 0 {
+			return fmt.Errorf("%w: elective bucket %s has no subjects", domain.ErrInvalidCurriculum, b.BucketName)
+		}
+		for _, subject := range b.Subjects {
+			if seen[subject.SubjectID] {
+				return fmt.Errorf("%w: subject %s is listed twice", domain.ErrInvalidCurriculum, subject.SubjectID)
+			}
+			seen[subject.SubjectID] = true
+		}
+	}
+	return nil
+}
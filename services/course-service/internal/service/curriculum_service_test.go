@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurriculumService_CreateCurriculum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCurriculumRepository(ctrl)
+	mockProgRepo := mocks.NewMockProgramRepository(ctrl)
+	service := NewCurriculumService(mockRepo, mockProgRepo, nil, nil, nil)
+
+	totalCredits := 160
+	program := &domain.Program{ProgramID: uuid.New(), DurationYears: 4, TotalCredits: &totalCredits}
+	core := func(semester int) domain.CoreSubject {
+		return domain.CoreSubject{SubjectBasic: domain.SubjectBasic{SubjectID: uuid.New()}, SemesterNumber: semester}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		curriculum := &domain.Curriculum{
+			ProgramID:    program.ProgramID,
+			BatchYear:    2024,
+			VersionName:  "2024 scheme",
+			CoreSubjects: []domain.CoreSubject{core(1), core(8)},
+			ElectiveBuckets: []domain.ElectiveBucket{
+				{BucketName: " Open electives ", MinCredits: 6, Subjects: []domain.SubjectBasic{{SubjectID: uuid.New()}}},
+			},
+		}
+
+		mockProgRepo.EXPECT().GetByID(gomock.Any(), program.ProgramID).Return(program, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), curriculum).Return(nil)
+
+		err := service.CreateCurriculum(context.Background(), curriculum)
+		assert.NoError(t, err)
+		assert.Equal(t, 160, curriculum.TotalCredits)
+		assert.Equal(t, "Open electives", curriculum.ElectiveBuckets[0].BucketName)
+	})
+
+	t.Run("Semester Beyond Program", func(t *testing.T) {
+		curriculum := &domain.Curriculum{ProgramID: program.ProgramID, BatchYear: 2024, CoreSubjects: []domain.CoreSubject{core(9)}}
+
+		mockProgRepo.EXPECT().GetByID(gomock.Any(), program.ProgramID).Return(program, nil)
+
+		err := service.CreateCurriculum(context.Background(), curriculum)
+		assert.True(t, errors.Is(err, domain.ErrInvalidCurriculum))
+	})
+
+	t.Run("Subject Listed Twice", func(t *testing.T) {
+		subject := core(1)
+		curriculum := &domain.Curriculum{
+			ProgramID:    program.ProgramID,
+			BatchYear:    2024,
+			CoreSubjects: []domain.CoreSubject{subject},
+			ElectiveBuckets: []domain.ElectiveBucket{
+				{BucketName: "Electives", MinCredits: 3, Subjects: []domain.SubjectBasic{subject.SubjectBasic}},
+			},
+		}
+
+		mockProgRepo.EXPECT().GetByID(gomock.Any(), program.ProgramID).Return(program, nil)
+
+		err := service.CreateCurriculum(context.Background(), curriculum)
+		assert.True(t, errors.Is(err, domain.ErrInvalidCurriculum))
+	})
+
+	t.Run("No Total Credits", func(t *testing.T) {
+		noCredits := &domain.Program{ProgramID: uuid.New(), DurationYears: 4}
+		curriculum := &domain.Curriculum{ProgramID: noCredits.ProgramID, BatchYear: 2024}
+
+		mockProgRepo.EXPECT().GetByID(gomock.Any(), noCredits.ProgramID).Return(noCredits, nil)
+
+		err := service.CreateCurriculum(context.Background(), curriculum)
+		assert.True(t, errors.Is(err, domain.ErrInvalidCurriculum))
+	})
+
+	t.Run("Program Not Found", func(t *testing.T) {
+		curriculum := &domain.Curriculum{ProgramID: uuid.New(), BatchYear: 2024}
+
+		mockProgRepo.EXPECT().GetByID(gomock.Any(), curriculum.ProgramID).Return(nil, domain.ErrProgramNotFound)
+
+		err := service.CreateCurriculum(context.Background(), curriculum)
+		assert.Equal(t, domain.ErrProgramNotFound, err)
+	})
+}
+
+func TestCurriculumService_AuditDegree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCurriculumRepository(ctrl)
+	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
+	mockEnrollRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockScaleRepo := mocks.NewMockGradingScaleRepository(ctrl)
+	service := NewCurriculumService(mockRepo, nil, mockStudentRepo, mockEnrollRepo, mockScaleRepo)
+
+	subject := func(credits int) domain.SubjectBasic {
+		return domain.SubjectBasic{SubjectID: uuid.New(), Credits: credits}
+	}
+	passedCore, failedCore, takingCore := subject(4), subject(4), subject(3)
+	passedElective, takingElective, otherElective := subject(3), subject(3), subject(3)
+	freeElective := subject(3)
+
+	curriculum := &domain.Curriculum{
+		CurriculumID: uuid.New(),
+		VersionName:  "2024 scheme",
+		TotalCredits: 20,
+		CoreSubjects: []domain.CoreSubject{
+			{SubjectBasic: passedCore, SemesterNumber: 1},
+			{SubjectBasic: failedCore, SemesterNumber: 1},
+			{SubjectBasic: takingCore, SemesterNumber: 2},
+		},
+		ElectiveBuckets: []domain.ElectiveBucket{
+			{BucketID: uuid.New(), BucketName: "Electives", MinCredits: 6, Subjects: []domain.SubjectBasic{passedElective, takingElective, otherElective}},
+		},
+	}
+	attempt := func(s domain.SubjectBasic, points float64) *domain.GradedAttempt {
+		return &domain.GradedAttempt{EnrollmentID: uuid.New(), SubjectID: s.SubjectID, Credits: s.Credits, GradePoints: points}
+	}
+
+	t.Run("In Progress", func(t *testing.T) {
+		student := &domain.Student{StudentID: uuid.New(), ProgramID: uuid.New(), BatchYear: 2025}
+		attempts := []*domain.GradedAttempt{
+			attempt(passedCore, 3), // failed before the retake below
+			attempt(passedCore, 9),
+			attempt(failedCore, 0),
+			attempt(passedElective, 7),
+			attempt(freeElective, 8),
+		}
+		enrolled := []*domain.SubjectBasic{&takingCore, &takingElective}
+
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), student.StudentID).Return(student, nil)
+		mockRepo.EXPECT().GetForBatch(gomock.Any(), student.ProgramID, 2025).Return(curriculum, nil)
+		mockScaleRepo.EXPECT().GetForStudent(gomock.Any(), student.StudentID).Return(testGradingScale, nil)
+		mockEnrollRepo.EXPECT().ListGradedAttempts(gomock.Any(), student.StudentID).Return(attempts, nil)
+		mockEnrollRepo.EXPECT().ListEnrolledSubjects(gomock.Any(), student.StudentID).Return(enrolled, nil)
+
+		audit, err := service.AuditDegree(context.Background(), student.StudentID)
+		assert.NoError(t, err)
+		assert.Equal(t, curriculum.CurriculumID, audit.CurriculumID)
+		assert.Equal(t, domain.RequirementSatisfied, audit.CoreSubjects[0].Status)
+		assert.Equal(t, domain.RequirementMissing, audit.CoreSubjects[1].Status)
+		assert.Equal(t, domain.RequirementInProgress, audit.CoreSubjects[2].Status)
+
+		bucket := audit.ElectiveBuckets[0]
+		assert.Equal(t, domain.RequirementInProgress, bucket.Status)
+		assert.Equal(t, 3, bucket.EarnedCredits)
+		assert.Equal(t, 3, bucket.InProgressCredits)
+		assert.Len(t, bucket.Completed, 1)
+		assert.Len(t, bucket.InProgress, 1)
+
+		// Credits outside the curriculum count towards the total
+		assert.Equal(t, 10, audit.TotalCredits.EarnedCredits)
+		assert.Equal(t, 6, audit.TotalCredits.InProgressCredits)
+		assert.Equal(t, domain.RequirementMissing, audit.TotalCredits.Status)
+		assert.False(t, audit.Eligible)
+	})
+
+	t.Run("Eligible", func(t *testing.T) {
+		student := &domain.Student{StudentID: uuid.New(), ProgramID: uuid.New(), BatchYear: 2024}
+		attempts := []*domain.GradedAttempt{
+			attempt(passedCore, 9),
+			attempt(failedCore, 5),
+			attempt(takingCore, 6),
+			attempt(passedElective, 7),
+			attempt(takingElective, 7),
+			attempt(freeElective, 8),
+		}
+
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), student.StudentID).Return(student, nil)
+		mockRepo.EXPECT().GetForBatch(gomock.Any(), student.ProgramID, 2024).Return(curriculum, nil)
+		mockScaleRepo.EXPECT().GetForStudent(gomock.Any(), student.StudentID).Return(testGradingScale, nil)
+		mockEnrollRepo.EXPECT().ListGradedAttempts(gomock.Any(), student.StudentID).Return(attempts, nil)
+		mockEnrollRepo.EXPECT().ListEnrolledSubjects(gomock.Any(), student.StudentID).Return(nil, nil)
+
+		audit, err := service.AuditDegree(context.Background(), student.StudentID)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, audit.ElectiveBuckets[0].EarnedCredits)
+		assert.Equal(t, 20, audit.TotalCredits.EarnedCredits)
+		assert.True(t, audit.Eligible)
+	})
+
+	t.Run("No Curriculum", func(t *testing.T) {
+		student := &domain.Student{StudentID: uuid.New(), ProgramID: uuid.New(), BatchYear: 2020}
+
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), student.StudentID).Return(student, nil)
+		mockRepo.EXPECT().GetForBatch(gomock.Any(), student.ProgramID, 2020).Return(nil, domain.ErrCurriculumNotFound)
+
+		_, err := service.AuditDegree(context.Background(), student.StudentID)
+		assert.Equal(t, domain.ErrCurriculumNotFound, err)
+	})
+}
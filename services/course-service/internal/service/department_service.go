@@ -4,34 +4,33 @@ import (
 	"context"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type departmentService struct {
-	repo     domain.DepartmentRepository
-	producer domain.EventProducer
+	repo       domain.DepartmentRepository
+	transactor domain.Transactor
+	producer   domain.EventProducer
 }
 
-func NewDepartmentService(repo domain.DepartmentRepository, producer domain.EventProducer) domain.DepartmentService {
-	return &departmentService{repo: repo, producer: producer}
+func NewDepartmentService(repo domain.DepartmentRepository, transactor domain.Transactor, producer domain.EventProducer) domain.DepartmentService {
+	return &departmentService{repo: repo, transactor: transactor, producer: producer}
 }
 
 func (s *departmentService) CreateDepartment(ctx context.Context, department *domain.Department) error {
 	department.IsActive = true
-	if err := s.repo.Create(ctx, department); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.department.created", department.DepartmentID.String(), map[string]interface{}{
-			"department_id":   department.DepartmentID,
-			"department_name": department.DepartmentName,
-			"department_code": department.DepartmentCode,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, department); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, department.DepartmentID.String(), departmentEvent(models.EventDepartmentCreated, department))
+	})
 }
 
 func (s *departmentService) GetDepartment(ctx context.Context, id uuid.UUID) (*domain.DepartmentWithDetails, error) {
@@ -69,37 +68,42 @@ func (s *departmentService) UpdateDepartment(ctx context.Context, id uuid.UUID,
 		dept.IsActive = isActive
 	}
 
-	if err := s.repo.Update(ctx, dept); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.department.updated", dept.DepartmentID.String(), map[string]interface{}{
-			"department_id":   dept.DepartmentID,
-			"department_name": dept.DepartmentName,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, dept); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, dept.DepartmentID.String(), departmentEvent(models.EventDepartmentUpdated, dept))
+	})
 }
 
 func (s *departmentService) DeleteDepartment(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.department.deleted", id.String(), map[string]interface{}{
-			"department_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewDepartmentEvent(models.EventDepartmentDeleted, id))
+	})
 }
 
 func (s *departmentService) ListDepartments(ctx context.Context, filter domain.DepartmentFilter, page, limit int) ([]*domain.Department, int64, error) {
 	offset := (page - 1) * limit
 	return s.repo.List(ctx, filter, limit, offset)
 }
+
+// departmentEvent creates a department event describing department
+func departmentEvent(eventType models.EventType, department *domain.Department) *models.DepartmentEvent {
+	event := models.NewDepartmentEvent(eventType, department.DepartmentID)
+	event.DepartmentName = department.DepartmentName
+	event.DepartmentCode = department.DepartmentCode
+	return event
+}
@@ -21,7 +21,7 @@ func TestDepartmentService_CreateDepartment(t *testing.T) {
 	// We need to check if EventProducer mock is generated. It should be.
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewDepartmentService(mockRepo, mockProducer)
+	service := NewDepartmentService(mockRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		deptID := uuid.New()
@@ -32,7 +32,7 @@ func TestDepartmentService_CreateDepartment(t *testing.T) {
 		}
 
 		mockRepo.EXPECT().Create(gomock.Any(), dept).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.department.created", deptID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, deptID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateDepartment(context.Background(), dept)
 		assert.NoError(t, err)
@@ -58,7 +58,7 @@ func TestDepartmentService_GetDepartment(t *testing.T) {
 	mockRepo := mocks.NewMockDepartmentRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewDepartmentService(mockRepo, mockProducer)
+	service := NewDepartmentService(mockRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		deptID := uuid.New()
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"go.uber.org/zap"
+)
+
+// EnrollmentReconciler repairs courses whose current_enrollment has drifted
+// from the number of students enrolled in them. The count is kept by the
+// enrollment count trigger, so drift only comes from changes made around it,
+// such as manual fixes to the enrollments table.
+type EnrollmentReconciler struct {
+	courseRepo domain.CourseRepository
+}
+
+func NewEnrollmentReconciler(courseRepo domain.CourseRepository) *EnrollmentReconciler {
+	return &EnrollmentReconciler{courseRepo: courseRepo}
+}
+
+// Start reconciles the enrollment counts every interval until ctx is done
+func (r *EnrollmentReconciler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reconcile(ctx); err != nil {
+				logger.Error("Failed to reconcile enrollment counts", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Reconcile corrects the enrollment counts that have drifted
+func (r *EnrollmentReconciler) Reconcile(ctx context.Context) error {
+	corrected, err := r.courseRepo.ReconcileEnrollmentCounts(ctx)
+	if err != nil {
+		return err
+	}
+	if corrected > 0 {
+		logger.Warn("Corrected drifted enrollment counts", zap.Int64("courses", corrected))
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnrollmentReconciler_Reconcile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
+	reconciler := NewEnrollmentReconciler(mockCourseRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		mockCourseRepo.EXPECT().ReconcileEnrollmentCounts(gomock.Any()).Return(int64(2), nil)
+
+		err := reconciler.Reconcile(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		dbErr := errors.New("db error")
+		mockCourseRepo.EXPECT().ReconcileEnrollmentCounts(gomock.Any()).Return(int64(0), dbErr)
+
+		err := reconciler.Reconcile(context.Background())
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
@@ -14,6 +17,7 @@ type enrollmentService struct {
 	studentRepo  domain.StudentRepository
 	subjectRepo  domain.SubjectRepository
 	semesterRepo domain.SemesterRepository
+	transactor   domain.Transactor
 	producer     domain.EventProducer
 }
 
@@ -23,6 +27,7 @@ func NewEnrollmentService(
 	studentRepo domain.StudentRepository,
 	subjectRepo domain.SubjectRepository,
 	semesterRepo domain.SemesterRepository,
+	transactor domain.Transactor,
 	producer domain.EventProducer,
 ) domain.EnrollmentService {
 	return &enrollmentService{
@@ -31,138 +36,221 @@ func NewEnrollmentService(
 		studentRepo:  studentRepo,
 		subjectRepo:  subjectRepo,
 		semesterRepo: semesterRepo,
+		transactor:   transactor,
 		producer:     producer,
 	}
 }
 
-func (s *enrollmentService) EnrollStudent(ctx context.Context, courseID, studentID uuid.UUID, enrolledBy string) (*domain.CourseEnrollment, error) {
+func (s *enrollmentService) EnrollStudent(ctx context.Context, courseID, studentID uuid.UUID, enrolledBy string, override *domain.DeadlineOverride) (*domain.CourseEnrollment, error) {
 	// Check if student exists
-	_, err := s.studentRepo.GetByID(ctx, studentID)
-	if err != nil {
+	if _, err := s.studentRepo.GetByID(ctx, studentID); err != nil {
 		return nil, err
 	}
 
-	// Check if course exists and is active
-	course, err := s.courseRepo.GetByID(ctx, courseID)
+	check, err := s.CheckPrerequisites(ctx, studentID, courseID)
 	if err != nil {
 		return nil, err
 	}
+	return s.enroll(ctx, courseID, studentID, enrolledBy, override, check)
+}
 
-	if course.Status != "active" {
-		return nil, domain.ErrRegistrationClosed
+// enroll enrolls a student in a course, or adds them to its waitlist if it is
+// full. Students are refused if their requirement check failed, and a nil
+// check skips the course requirements.
+func (s *enrollmentService) enroll(ctx context.Context, courseID, studentID uuid.UUID, enrolledBy string, override *domain.DeadlineOverride, check *domain.RequirementCheck) (*domain.CourseEnrollment, error) {
+	if check != nil && !check.Met {
+		return nil, requirementsNotMet(check)
 	}
 
-	// Check if already enrolled
-	_, err = s.repo.GetByStudentAndCourse(ctx, studentID, courseID)
-	if err == nil {
-		return nil, domain.ErrAlreadyEnrolled
-	}
-	if err != domain.ErrEnrollmentNotFound {
-		return nil, err
-	}
+	var enrollment *domain.CourseEnrollment
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the course so that concurrent enrollments cannot take the same seat
+		course, err := s.courseRepo.GetByIDForUpdate(ctx, courseID)
+		if err != nil {
+			return err
+		}
+
+		if course.Status != "active" {
+			return domain.ErrRegistrationClosed
+		}
 
-	// Determine enrollment status (enrolled or waitlisted)
-	status := "enrolled"
-	var waitlistPosition *int
-	if course.MaxStudents != nil && course.CurrentEnrollment >= *course.MaxStudents {
-		status = "waitlisted"
-		pos, err := s.repo.GetNextWaitlistPosition(ctx, courseID)
+		// Courses can only be added during registration and the add/drop period
+		semester, err := s.semesterRepo.GetByID(ctx, course.SemesterID)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		overridden := false
+		if semesterPeriod(semester, time.Now()) != addDropPeriod {
+			if override == nil {
+				return domain.ErrRegistrationClosed
+			}
+			overridden = true
 		}
-		waitlistPosition = &pos
-	}
 
-	enrollment := &domain.CourseEnrollment{
-		StudentID:        studentID,
-		CourseID:         courseID,
-		EnrollmentStatus: status,
-		EnrolledBy:       enrolledBy,
-		WaitlistPosition: waitlistPosition,
-	}
+		// Check if already enrolled
+		_, err = s.repo.GetByStudentAndCourse(ctx, studentID, courseID)
+		if err == nil {
+			return domain.ErrAlreadyEnrolled
+		}
+		if err != domain.ErrEnrollmentNotFound {
+			return err
+		}
 
-	if err := s.repo.Create(ctx, enrollment); err != nil {
-		return nil, err
-	}
+		// Determine enrollment status (enrolled or waitlisted)
+		status := "enrolled"
+		var waitlistPosition *int
+		if !hasFreeSeat(course, course.CurrentEnrollment) {
+			status = "waitlisted"
+			pos, err := s.repo.GetNextWaitlistPosition(ctx, courseID)
+			if err != nil {
+				return err
+			}
+			waitlistPosition = &pos
+		}
 
-	// Increment course enrollment count if enrolled (not waitlisted)
-	if status == "enrolled" {
-		if err := s.courseRepo.IncrementEnrollment(ctx, courseID); err != nil {
-			// Log error but don't fail
+		enrollment = &domain.CourseEnrollment{
+			StudentID:        studentID,
+			CourseID:         courseID,
+			EnrollmentStatus: status,
+			EnrolledBy:       enrolledBy,
+			WaitlistPosition: waitlistPosition,
+		}
+
+		// The enrollment count trigger keeps current_enrollment up to date
+		if err := s.repo.Create(ctx, enrollment); err != nil {
+			return err
+		}
+
+		if overridden {
+			if err := s.recordOverride(ctx, enrollment, "enroll", override); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.enrollment.created", enrollment.EnrollmentID.String(), map[string]interface{}{
-			"enrollment_id": enrollment.EnrollmentID,
-			"student_id":    studentID,
-			"course_id":     courseID,
-			"status":        status,
-		})
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		eventType := models.EventStudentEnrolled
+		if status == "waitlisted" {
+			eventType = models.EventWaitlistAdded
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, enrollment.CourseID.String(), enrollmentEvent(eventType, enrollment))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return enrollment, nil
 }
 
-func (s *enrollmentService) DropCourse(ctx context.Context, courseID, studentID uuid.UUID, reason string) error {
-	enrollment, err := s.repo.GetByStudentAndCourse(ctx, studentID, courseID)
-	if err != nil {
-		return err
-	}
+// DropCourse drops a student from a course. Drops during the add/drop period
+// leave no record, later drops are recorded as a withdrawal with a W until the
+// withdrawal deadline, and are refused after it unless overridden.
+func (s *enrollmentService) DropCourse(ctx context.Context, courseID, studentID uuid.UUID, reason string, override *domain.DeadlineOverride) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the course so that the freed seat goes to exactly one waitlisted student
+		course, err := s.courseRepo.GetByIDForUpdate(ctx, courseID)
+		if err != nil {
+			return err
+		}
 
-	if enrollment.EnrollmentStatus == "completed" {
-		return domain.ErrCannotDropCompletedCourse
-	}
+		enrollment, err := s.repo.GetByStudentAndCourse(ctx, studentID, courseID)
+		if err != nil {
+			return err
+		}
+
+		// Withdrawn, failed and dropped enrollments stay on the record
+		switch enrollment.EnrollmentStatus {
+		case "enrolled", "waitlisted":
+		case "completed":
+			return domain.ErrCannotDropCompletedCourse
+		default:
+			return domain.ErrInvalidEnrollmentStatus
+		}
 
-	wasEnrolled := enrollment.EnrollmentStatus == "enrolled"
+		semester, err := s.semesterRepo.GetByID(ctx, course.SemesterID)
+		if err != nil {
+			return err
+		}
 
-	now := time.Now()
-	enrollment.EnrollmentStatus = "dropped"
-	enrollment.DroppedDate = &now
-	enrollment.DropReason = &reason
+		wasEnrolled := enrollment.EnrollmentStatus == "enrolled"
 
-	if err := s.repo.Update(ctx, enrollment); err != nil {
-		return err
-	}
+		now := time.Now()
+		enrollment.DroppedDate = &now
+		enrollment.DropReason = &reason
+
+		// Waitlisted students never held a seat, so leaving is never recorded
+		eventType := models.EventStudentDropped
+		period := semesterPeriod(semester, now)
+		if !wasEnrolled || period <= addDropPeriod {
+			enrollment.EnrollmentStatus = "dropped"
+			if err := s.repo.Delete(ctx, enrollment.EnrollmentID); err != nil {
+				return err
+			}
+		} else {
+			if period == afterWithdrawal {
+				if override == nil {
+					return domain.ErrWithdrawalDeadlinePassed
+				}
+				if err := s.recordOverride(ctx, enrollment, "drop", override); err != nil {
+					return err
+				}
+			}
 
-	// Decrement course enrollment count if was enrolled
-	if wasEnrolled {
-		if err := s.courseRepo.DecrementEnrollment(ctx, courseID); err != nil {
-			// Log error but don't fail
+			eventType = models.EventStudentWithdrawn
+			enrollment.EnrollmentStatus = "withdrawn"
+			enrollment.Grade = strPtr(withdrawalGrade)
+			enrollment.GradePoints = nil
+			if err := s.repo.Update(ctx, enrollment); err != nil {
+				return err
+			}
 		}
 
-		// Promote next student from waitlist
-		promoted, err := s.repo.PromoteFromWaitlist(ctx, courseID)
-		if err == nil && promoted != nil {
-			// Increment enrollment count for promoted student
-			s.courseRepo.IncrementEnrollment(ctx, courseID)
-
-			// Publish promotion event
-			if s.producer != nil {
-				s.producer.PublishEvent("course.enrollment.promoted", promoted.EnrollmentID.String(), map[string]interface{}{
-					"enrollment_id": promoted.EnrollmentID,
-					"student_id":    promoted.StudentID,
-					"course_id":     courseID,
-				})
+		// Publish event
+		if s.producer != nil {
+			err := s.producer.PublishEvent(ctx, domain.CourseEventsTopic, enrollment.CourseID.String(), enrollmentEvent(eventType, enrollment))
+			if err != nil {
+				return err
 			}
 		}
-	}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.enrollment.dropped", enrollment.EnrollmentID.String(), map[string]interface{}{
-			"enrollment_id": enrollment.EnrollmentID,
-			"student_id":    studentID,
-			"course_id":     courseID,
-			"reason":        reason,
-		})
-	}
+		// Promote next student from waitlist if the drop freed a seat
+		if !wasEnrolled || !hasFreeSeat(course, course.CurrentEnrollment-1) {
+			return nil
+		}
+		promoted, err := s.repo.PromoteFromWaitlist(ctx, courseID)
+		if err != nil {
+			return err
+		}
+		if promoted == nil || s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, promoted.CourseID.String(), enrollmentEvent(models.EventWaitlistPromoted, promoted))
+	})
+}
 
-	return nil
+// recordOverride records that an admin enrolled or dropped a student while the
+// registration window for it was closed
+func (s *enrollmentService) recordOverride(ctx context.Context, enrollment *domain.CourseEnrollment, action string, override *domain.DeadlineOverride) error {
+	return s.repo.RecordOverride(ctx, &domain.EnrollmentOverride{
+		EnrollmentID: enrollment.EnrollmentID,
+		StudentID:    enrollment.StudentID,
+		CourseID:     enrollment.CourseID,
+		Action:       action,
+		Reason:       override.Reason,
+		OverriddenBy: override.OverriddenBy,
+	})
 }
 
-func (s *enrollmentService) UpdateEnrollment(ctx context.Context, enrollmentID uuid.UUID, status string, grade *string, gradePoints *float64) error {
+func (s *enrollmentService) GetEnrollment(ctx context.Context, enrollmentID uuid.UUID) (*domain.CourseEnrollment, error) {
+	return s.repo.GetByID(ctx, enrollmentID)
+}
+
+// UpdateEnrollment changes the status of an enrollment. Enrollments are
+// completed with a grade only when the grades of the course are approved, and
+// dropped only through DropCourse, which enforces the registration deadlines.
+func (s *enrollmentService) UpdateEnrollment(ctx context.Context, enrollmentID uuid.UUID, status string) error {
 	enrollment, err := s.repo.GetByID(ctx, enrollmentID)
 	if err != nil {
 		return err
@@ -170,10 +258,7 @@ func (s *enrollmentService) UpdateEnrollment(ctx context.Context, enrollmentID u
 
 	// Validate status transition
 	validTransitions := map[string][]string{
-		"enrolled":   {"completed", "dropped"},
-		"waitlisted": {"enrolled", "dropped"},
-		"dropped":    {},
-		"completed":  {},
+		"waitlisted": {"enrolled"},
 	}
 
 	valid := false
@@ -187,32 +272,32 @@ func (s *enrollmentService) UpdateEnrollment(ctx context.Context, enrollmentID u
 		return domain.ErrInvalidEnrollmentStatus
 	}
 
+	previousStatus := enrollment.EnrollmentStatus
 	enrollment.EnrollmentStatus = status
-	if grade != nil {
-		enrollment.Grade = grade
-	}
-	if gradePoints != nil {
-		enrollment.GradePoints = gradePoints
-	}
-	if status == "completed" {
-		now := time.Now()
-		enrollment.CompletionDate = &now
-	}
 
-	if err := s.repo.Update(ctx, enrollment); err != nil {
-		return err
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Moving a waitlisted student in takes a seat, which must still be free
+		if status == "enrolled" && previousStatus != "enrolled" {
+			course, err := s.courseRepo.GetByIDForUpdate(ctx, enrollment.CourseID)
+			if err != nil {
+				return err
+			}
+			if !hasFreeSeat(course, course.CurrentEnrollment) {
+				return domain.ErrCourseFull
+			}
+			enrollment.WaitlistPosition = nil
+		}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.enrollment.updated", enrollment.EnrollmentID.String(), map[string]interface{}{
-			"enrollment_id": enrollmentID,
-			"status":        status,
-			"grade":         grade,
-		})
-	}
+		if err := s.repo.Update(ctx, enrollment); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, enrollment.CourseID.String(), enrollmentEvent(models.EventEnrollmentUpdated, enrollment))
+	})
 }
 
 func (s *enrollmentService) GetStudentEnrollments(ctx context.Context, studentID uuid.UUID, filter domain.EnrollmentFilter, page, limit int) ([]*domain.EnrollmentWithDetails, int64, error) {
@@ -221,98 +306,312 @@ func (s *enrollmentService) GetStudentEnrollments(ctx context.Context, studentID
 	return s.repo.ListByStudent(ctx, filter, limit, offset)
 }
 
+// BulkEnroll enrolls a cohort of students in a course. The requirements of
+// the whole cohort are checked with a single query before enrolling anyone,
+// and the cohort is then enrolled in one transaction that locks the course
+// once and inserts every enrollment with a single statement.
 func (s *enrollmentService) BulkEnroll(ctx context.Context, courseID uuid.UUID, studentIDs []uuid.UUID, skipPrerequisites bool) ([]domain.BulkEnrollResult, error) {
-	results := make([]domain.BulkEnrollResult, 0, len(studentIDs))
+	var checks map[uuid.UUID]*domain.RequirementCheck
+	if !skipPrerequisites {
+		if _, err := s.courseRepo.GetByID(ctx, courseID); err != nil {
+			return nil, err
+		}
+		unmet, err := s.repo.GetUnmetRequirementsForStudents(ctx, studentIDs, courseID)
+		if err != nil {
+			return nil, err
+		}
+		checks = requirementChecks(studentIDs, unmet)
+	}
 
-	for _, studentID := range studentIDs {
-		result := domain.BulkEnrollResult{
-			StudentID: studentID,
-			Status:    "success",
+	results := make([]domain.BulkEnrollResult, len(studentIDs))
+	created := make([]*domain.CourseEnrollment, len(studentIDs))
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the course so that concurrent enrollments cannot take the same seats
+		course, err := s.courseRepo.GetByIDForUpdate(ctx, courseID)
+		if err != nil {
+			return err
+		}
+		if course.Status != "active" {
+			return domain.ErrRegistrationClosed
+		}
+		semester, err := s.semesterRepo.GetByID(ctx, course.SemesterID)
+		if err != nil {
+			return err
+		}
+		if semesterPeriod(semester, time.Now()) != addDropPeriod {
+			return domain.ErrRegistrationClosed
 		}
 
-		// Check prerequisites if not skipping
-		if !skipPrerequisites {
-			met, _, err := s.CheckPrerequisites(ctx, studentID, courseID)
-			if err != nil {
-				result.Status = "failed"
-				result.Error = err.Error()
-				results = append(results, result)
+		existing, err := s.studentRepo.ListExistingIDs(ctx, studentIDs)
+		if err != nil {
+			return err
+		}
+		enrolled, err := s.repo.ListEnrolledStudentIDs(ctx, courseID, studentIDs)
+		if err != nil {
+			return err
+		}
+
+		var enrollments []*domain.CourseEnrollment
+		seatsTaken := course.CurrentEnrollment
+		waitlistPosition := 0
+		for i, studentID := range studentIDs {
+			results[i] = domain.BulkEnrollResult{StudentID: studentID, Status: "failed"}
+			if !existing[studentID] {
+				results[i].Error = domain.ErrStudentNotFound.Error()
+				continue
+			}
+			if check := checks[studentID]; check != nil && !check.Met {
+				results[i].Error = requirementsNotMet(check).Error()
 				continue
 			}
-			if !met {
-				result.Status = "failed"
-				result.Error = domain.ErrPrerequisitesNotMet.Error()
-				results = append(results, result)
+			if enrolled[studentID] {
+				results[i].Error = domain.ErrAlreadyEnrolled.Error()
 				continue
 			}
+			enrolled[studentID] = true
+
+			enrollment := &domain.CourseEnrollment{
+				StudentID:        studentID,
+				CourseID:         courseID,
+				EnrollmentStatus: "enrolled",
+				EnrolledBy:       "admin",
+			}
+			if hasFreeSeat(course, seatsTaken) {
+				seatsTaken++
+			} else {
+				if waitlistPosition == 0 {
+					if waitlistPosition, err = s.repo.GetNextWaitlistPosition(ctx, courseID); err != nil {
+						return err
+					}
+				}
+				position := waitlistPosition
+				waitlistPosition++
+				enrollment.EnrollmentStatus = "waitlisted"
+				enrollment.WaitlistPosition = &position
+			}
+			enrollments = append(enrollments, enrollment)
+			created[i] = enrollment
+		}
+		if len(enrollments) == 0 {
+			return nil
 		}
 
-		enrollment, err := s.EnrollStudent(ctx, courseID, studentID, "bulk_enrollment")
-		if err != nil {
-			result.Status = "failed"
-			result.Error = err.Error()
-		} else {
-			result.EnrollmentID = &enrollment.EnrollmentID
+		// The enrollment count trigger keeps current_enrollment up to date
+		if err := s.repo.CreateBatch(ctx, enrollments); err != nil {
+			return err
+		}
+
+		// Publish events
+		if s.producer == nil {
+			return nil
+		}
+		for _, enrollment := range enrollments {
+			eventType := models.EventStudentEnrolled
 			if enrollment.EnrollmentStatus == "waitlisted" {
-				result.Status = "waitlisted"
+				eventType = models.EventWaitlistAdded
+			}
+			if err := s.producer.PublishEvent(ctx, domain.CourseEventsTopic, enrollment.CourseID.String(), enrollmentEvent(eventType, enrollment)); err != nil {
+				return err
 			}
 		}
-		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	for i, enrollment := range created {
+		if enrollment == nil {
+			continue
+		}
+		results[i].Status = "success"
+		if enrollment.EnrollmentStatus == "waitlisted" {
+			results[i].Status = "waitlisted"
+		}
+		results[i].EnrollmentID = &enrollment.EnrollmentID
+	}
 	return results, nil
 }
 
-func (s *enrollmentService) CheckPrerequisites(ctx context.Context, studentID, courseID uuid.UUID) (bool, []domain.SubjectBasic, error) {
-	// Get the course to find its subject
-	course, err := s.courseRepo.GetByID(ctx, courseID)
+// CheckPrerequisites explains which prerequisites and corequisites of a course
+// the student does not meet. Prerequisites must have been completed with a
+// passing grade, and with at least their minimum grade if they have one.
+// Corequisites are met by a passing completion or by an enrollment in the same
+// semester. Waived requirements and optional prerequisites do not block
+// enrollment.
+func (s *enrollmentService) CheckPrerequisites(ctx context.Context, studentID, courseID uuid.UUID) (*domain.RequirementCheck, error) {
+	if _, err := s.courseRepo.GetByID(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	unmet, err := s.repo.GetUnmetRequirements(ctx, studentID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	return requirementChecks([]uuid.UUID{studentID}, unmet)[studentID], nil
+}
+
+func (s *enrollmentService) GrantWaiver(ctx context.Context, waiver *domain.PrerequisiteWaiver) error {
+	course, err := s.courseRepo.GetByID(ctx, waiver.CourseID)
 	if err != nil {
-		return false, nil, err
+		return err
+	}
+	if _, err := s.studentRepo.GetByID(ctx, waiver.StudentID); err != nil {
+		return err
 	}
 
-	// Get subject prerequisites
+	// Only existing requirements of the course can be waived
 	prereqs, err := s.subjectRepo.GetPrerequisites(ctx, course.SubjectID)
 	if err != nil {
-		return false, nil, err
+		return err
 	}
+	for _, p := range prereqs {
+		if p.PrerequisiteSubjectID == waiver.WaivedSubjectID {
+			waiver.RequirementType = domain.RequirementPrerequisite
+		}
+	}
+	if waiver.RequirementType == "" {
+		coreqs, err := s.subjectRepo.GetCorequisites(ctx, course.SubjectID)
+		if err != nil {
+			return err
+		}
+		for _, c := range coreqs {
+			if c.SubjectID == waiver.WaivedSubjectID {
+				waiver.RequirementType = domain.RequirementCorequisite
+			}
+		}
+	}
+	if waiver.RequirementType == "" {
+		return domain.ErrRequirementNotFound
+	}
+
+	return s.repo.CreateWaiver(ctx, waiver)
+}
 
-	if len(prereqs) == 0 {
-		return true, nil, nil
+func (s *enrollmentService) ListWaivers(ctx context.Context, studentID, courseID uuid.UUID) ([]*domain.PrerequisiteWaiver, error) {
+	return s.repo.ListWaivers(ctx, studentID, courseID)
+}
+
+// requirementsNotMet returns the error refusing an enrollment that check
+// does not allow, explaining every unmet requirement
+func requirementsNotMet(check *domain.RequirementCheck) error {
+	reasons := make([]string, len(check.Unmet))
+	for i, r := range check.Unmet {
+		reasons[i] = r.Reason
 	}
+	return fmt.Errorf("%w: %s", domain.ErrPrerequisitesNotMet, strings.Join(reasons, "; "))
+}
 
-	// Get student's completed courses
-	filter := domain.EnrollmentFilter{
-		StudentID: &studentID,
-		Status:    strPtr("completed"),
+// requirementChecks builds the requirement check of each of the students
+// from the requirements they do not meet
+func requirementChecks(studentIDs []uuid.UUID, unmet []*domain.UnmetRequirement) map[uuid.UUID]*domain.RequirementCheck {
+	checks := make(map[uuid.UUID]*domain.RequirementCheck, len(studentIDs))
+	for _, studentID := range studentIDs {
+		checks[studentID] = &domain.RequirementCheck{Met: true}
 	}
-	completedEnrollments, _, err := s.repo.ListByStudent(ctx, filter, 1000, 0)
-	if err != nil {
-		return false, nil, err
+	for _, u := range unmet {
+		check, ok := checks[u.StudentID]
+		if !ok {
+			continue
+		}
+		result := domain.RequirementResult{
+			Type:    u.Type,
+			Subject: u.Subject,
+			Reason:  unmetReason(u),
+		}
+		addRequirement(check, result, u.IsMandatory, u.Waived)
 	}
+	return checks
+}
 
-	// Build set of completed subject IDs
-	completedSubjects := make(map[uuid.UUID]bool)
-	for _, e := range completedEnrollments {
-		// Need to get course to find subject ID
-		c, err := s.courseRepo.GetByID(ctx, e.CourseID)
-		if err == nil {
-			completedSubjects[c.SubjectID] = true
-		}
+// unmetReason explains why a student does not meet a requirement
+func unmetReason(u *domain.UnmetRequirement) string {
+	code := u.Subject.SubjectCode
+	switch {
+	case u.Type == domain.RequirementCorequisite:
+		return fmt.Sprintf("%s must be taken in the same semester or completed before", code)
+	case !u.Completed:
+		return fmt.Sprintf("%s has not been completed", code)
+	case u.GradePoints == nil:
+		return fmt.Sprintf("%s requires a minimum grade but none was recorded", code)
+	case *u.GradePoints < u.PassGradePoints:
+		return fmt.Sprintf("%s was not passed", code)
+	default:
+		return fmt.Sprintf("%s requires grade points of at least %.2f, got %.2f", code, *u.MinGradePoints, *u.GradePoints)
 	}
+}
 
-	// Check which prerequisites are not met
-	var missingPrereqs []domain.SubjectBasic
-	for _, prereq := range prereqs {
-		if prereq.IsMandatory && !completedSubjects[prereq.PrerequisiteSubjectID] {
-			missingPrereqs = append(missingPrereqs, domain.SubjectBasic{
-				SubjectID:   prereq.PrerequisiteSubjectID,
-				SubjectCode: prereq.SubjectCode,
-				SubjectName: prereq.SubjectName,
-			})
-		}
+// addRequirement records a requirement the student does not meet in check.
+// Only mandatory requirements that have not been waived make the check fail.
+func addRequirement(check *domain.RequirementCheck, result domain.RequirementResult, mandatory, waived bool) {
+	switch {
+	case waived:
+		check.Waived = append(check.Waived, result)
+	case !mandatory:
+		check.Warnings = append(check.Warnings, result)
+	default:
+		check.Unmet = append(check.Unmet, result)
+		check.Met = false
+	}
+}
+
+// withdrawalGrade is recorded for students who withdraw after the add/drop period
+const withdrawalGrade = "W"
+
+// registrationPeriod is a part of a semester with its own enrollment rules
+type registrationPeriod int
+
+const (
+	beforeRegistration registrationPeriod = iota
+	// Registration and the add/drop period that follows it
+	addDropPeriod
+	withdrawalPeriod
+	afterWithdrawal
+)
+
+// semesterPeriod returns the registration period of semester that the day of
+// now falls in. Deadlines are inclusive.
+func semesterPeriod(semester *domain.Semester, now time.Time) registrationPeriod {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if semester.RegistrationStart != nil && day.Before(*semester.RegistrationStart) {
+		return beforeRegistration
 	}
 
-	return len(missingPrereqs) == 0, missingPrereqs, nil
+	addDropEnd := semester.AddDropEnd
+	if addDropEnd == nil {
+		addDropEnd = semester.RegistrationEnd
+	}
+	if addDropEnd == nil || !day.After(*addDropEnd) {
+		return addDropPeriod
+	}
+
+	withdrawalDeadline := semester.EndDate
+	if semester.WithdrawalDeadline != nil {
+		withdrawalDeadline = *semester.WithdrawalDeadline
+	}
+	if !day.After(withdrawalDeadline) {
+		return withdrawalPeriod
+	}
+	return afterWithdrawal
+}
+
+// hasFreeSeat reports whether course has a seat left when enrolled students
+// take up its seats
+func hasFreeSeat(course *domain.Course, enrolled int) bool {
+	return course.MaxStudents == nil || enrolled < *course.MaxStudents
+}
+
+// enrollmentEvent creates an enrollment event describing enrollment
+func enrollmentEvent(eventType models.EventType, enrollment *domain.CourseEnrollment) *models.EnrollmentEvent {
+	event := models.NewEnrollmentEvent(eventType, enrollment.EnrollmentID, enrollment.StudentID, enrollment.CourseID, enrollment.EnrollmentStatus)
+	event.WaitlistPosition = enrollment.WaitlistPosition
+	event.Grade = enrollment.Grade
+	event.GradePoints = enrollment.GradePoints
+	if enrollment.DropReason != nil {
+		event.Reason = *enrollment.DropReason
+	}
+	return event
 }
 
 func strPtr(s string) *string {
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
@@ -18,12 +19,11 @@ func TestEnrollmentService_EnrollStudent(t *testing.T) {
 	mockRepo := mocks.NewMockEnrollmentRepository(ctrl)
 	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
 	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
-	// unused mocks for this test
 	mockSubjectRepo := mocks.NewMockSubjectRepository(ctrl)
 	mockSemesterRepo := mocks.NewMockSemesterRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewEnrollmentService(mockRepo, mockCourseRepo, mockStudentRepo, mockSubjectRepo, mockSemesterRepo, mockProducer)
+	service := NewEnrollmentService(mockRepo, mockCourseRepo, mockStudentRepo, mockSubjectRepo, mockSemesterRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success Enrolled", func(t *testing.T) {
 		studentID := uuid.New()
@@ -39,7 +39,9 @@ func TestEnrollmentService_EnrollStudent(t *testing.T) {
 		student := &domain.Student{StudentID: studentID}
 
 		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(student, nil)
-		mockCourseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		expectNoRequirements(mockRepo, mockCourseRepo, course, studentID)
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(0, 7, 30), nil)
 		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(nil, domain.ErrEnrollmentNotFound)
 
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *domain.CourseEnrollment) error {
@@ -47,10 +49,9 @@ func TestEnrollmentService_EnrollStudent(t *testing.T) {
 			return nil
 		})
 
-		mockCourseRepo.EXPECT().IncrementEnrollment(gomock.Any(), courseID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.enrollment.created", gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, gomock.Any(), gomock.Any()).Return(nil)
 
-		enrollment, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin")
+		enrollment, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin", nil)
 		assert.NoError(t, err)
 		assert.Equal(t, "enrolled", enrollment.EnrollmentStatus)
 	})
@@ -69,7 +70,9 @@ func TestEnrollmentService_EnrollStudent(t *testing.T) {
 		student := &domain.Student{StudentID: studentID}
 
 		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(student, nil)
-		mockCourseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		expectNoRequirements(mockRepo, mockCourseRepo, course, studentID)
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(0, 7, 30), nil)
 		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(nil, domain.ErrEnrollmentNotFound)
 
 		// Expect waitlist position check
@@ -81,10 +84,9 @@ func TestEnrollmentService_EnrollStudent(t *testing.T) {
 			return nil
 		})
 
-		// Should NOT increment enrollment
-		mockProducer.EXPECT().PublishEvent("course.enrollment.created", gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, gomock.Any(), gomock.Any()).Return(nil)
 
-		enrollment, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin")
+		enrollment, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin", nil)
 		assert.NoError(t, err)
 		assert.Equal(t, "waitlisted", enrollment.EnrollmentStatus)
 	})
@@ -96,11 +98,514 @@ func TestEnrollmentService_EnrollStudent(t *testing.T) {
 		student := &domain.Student{StudentID: studentID}
 
 		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(student, nil)
-		mockCourseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		expectNoRequirements(mockRepo, mockCourseRepo, course, studentID)
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(0, 7, 30), nil)
 		// Return existing enrollment
 		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(&domain.CourseEnrollment{}, nil)
 
-		_, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin")
+		_, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin", nil)
 		assert.ErrorIs(t, err, domain.ErrAlreadyEnrolled)
 	})
+
+	t.Run("Registration Closed", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID, Status: "active"}
+		student := &domain.Student{StudentID: studentID}
+
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(student, nil)
+		expectNoRequirements(mockRepo, mockCourseRepo, course, studentID)
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(-14, -7, 30), nil)
+
+		_, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin", nil)
+		assert.ErrorIs(t, err, domain.ErrRegistrationClosed)
+	})
+
+	t.Run("Admin Override After Registration", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		adminID := uuid.New()
+		course := &domain.Course{CourseID: courseID, Status: "active"}
+		student := &domain.Student{StudentID: studentID}
+		override := &domain.DeadlineOverride{Reason: "late transfer", OverriddenBy: adminID}
+
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(student, nil)
+		expectNoRequirements(mockRepo, mockCourseRepo, course, studentID)
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(-14, -7, 30), nil)
+		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(nil, domain.ErrEnrollmentNotFound)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().RecordOverride(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, o *domain.EnrollmentOverride) error {
+			assert.Equal(t, "enroll", o.Action)
+			assert.Equal(t, "late transfer", o.Reason)
+			assert.Equal(t, adminID, o.OverriddenBy)
+			return nil
+		})
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, gomock.Any(), gomock.Any()).Return(nil)
+
+		enrollment, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin", override)
+		assert.NoError(t, err)
+		assert.Equal(t, "enrolled", enrollment.EnrollmentStatus)
+	})
+
+	t.Run("Prerequisites Not Met", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID, SubjectID: uuid.New(), Status: "active"}
+		student := &domain.Student{StudentID: studentID}
+
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(student, nil)
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		mockRepo.EXPECT().GetUnmetRequirements(gomock.Any(), studentID, courseID).Return([]*domain.UnmetRequirement{
+			{StudentID: studentID, Type: domain.RequirementPrerequisite, Subject: domain.SubjectBasic{SubjectCode: "CS101"}, IsMandatory: true},
+		}, nil)
+
+		_, err := service.EnrollStudent(context.Background(), courseID, studentID, "admin", nil)
+		assert.ErrorIs(t, err, domain.ErrPrerequisitesNotMet)
+		assert.Contains(t, err.Error(), "CS101 has not been completed")
+	})
+}
+
+func TestEnrollmentService_DropCourse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
+	mockSemesterRepo := mocks.NewMockSemesterRepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+
+	service := NewEnrollmentService(mockRepo, mockCourseRepo, nil, nil, mockSemesterRepo, newTestTransactor(ctrl), mockProducer)
+
+	t.Run("Promotes From Waitlist", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		maxStudents := 10
+		course := &domain.Course{CourseID: courseID, MaxStudents: &maxStudents, CurrentEnrollment: 10}
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: studentID, CourseID: courseID, EnrollmentStatus: "enrolled"}
+		promoted := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: courseID, EnrollmentStatus: "enrolled"}
+
+		gomock.InOrder(
+			mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil),
+			mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(enrollment, nil),
+			mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(0, 7, 30), nil),
+			mockRepo.EXPECT().Delete(gomock.Any(), enrollment.EnrollmentID).Return(nil),
+			mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil),
+			mockRepo.EXPECT().PromoteFromWaitlist(gomock.Any(), courseID).Return(promoted, nil),
+			mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil),
+		)
+
+		err := service.DropCourse(context.Background(), courseID, studentID, "schedule conflict", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Waitlisted Drop Frees No Seat", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		maxStudents := 10
+		course := &domain.Course{CourseID: courseID, MaxStudents: &maxStudents, CurrentEnrollment: 10}
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: studentID, CourseID: courseID, EnrollmentStatus: "waitlisted"}
+
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(enrollment, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(-14, -7, 30), nil)
+		mockRepo.EXPECT().Delete(gomock.Any(), enrollment.EnrollmentID).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
+
+		err := service.DropCourse(context.Background(), courseID, studentID, "schedule conflict", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Withdrawal Records W", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID}
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: studentID, CourseID: courseID, EnrollmentStatus: "enrolled"}
+
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(enrollment, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(-14, -7, 30), nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *domain.CourseEnrollment) error {
+			assert.Equal(t, "withdrawn", e.EnrollmentStatus)
+			assert.Equal(t, "W", *e.Grade)
+			return nil
+		})
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().PromoteFromWaitlist(gomock.Any(), courseID).Return(nil, nil)
+
+		err := service.DropCourse(context.Background(), courseID, studentID, "medical", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Withdrawal Deadline Passed", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID}
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: studentID, CourseID: courseID, EnrollmentStatus: "enrolled"}
+
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(enrollment, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(-30, -21, -7), nil)
+
+		err := service.DropCourse(context.Background(), courseID, studentID, "", nil)
+		assert.ErrorIs(t, err, domain.ErrWithdrawalDeadlinePassed)
+	})
+
+	t.Run("Admin Override After Withdrawal Deadline", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID}
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: studentID, CourseID: courseID, EnrollmentStatus: "enrolled"}
+		override := &domain.DeadlineOverride{Reason: "medical emergency", OverriddenBy: uuid.New()}
+
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(enrollment, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(-30, -21, -7), nil)
+		mockRepo.EXPECT().RecordOverride(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, o *domain.EnrollmentOverride) error {
+			assert.Equal(t, "drop", o.Action)
+			assert.Equal(t, enrollment.EnrollmentID, o.EnrollmentID)
+			return nil
+		})
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().PromoteFromWaitlist(gomock.Any(), courseID).Return(nil, nil)
+
+		err := service.DropCourse(context.Background(), courseID, studentID, "", override)
+		assert.NoError(t, err)
+		assert.Equal(t, "withdrawn", enrollment.EnrollmentStatus)
+	})
+
+	t.Run("Completed Course", func(t *testing.T) {
+		studentID := uuid.New()
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID}
+		enrollment := &domain.CourseEnrollment{StudentID: studentID, CourseID: courseID, EnrollmentStatus: "completed"}
+
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(enrollment, nil)
+
+		err := service.DropCourse(context.Background(), courseID, studentID, "", nil)
+		assert.ErrorIs(t, err, domain.ErrCannotDropCompletedCourse)
+	})
+
+	for _, status := range []string{"withdrawn", "failed", "dropped"} {
+		t.Run("Cannot Drop "+status, func(t *testing.T) {
+			studentID := uuid.New()
+			courseID := uuid.New()
+			course := &domain.Course{CourseID: courseID}
+			enrollment := &domain.CourseEnrollment{StudentID: studentID, CourseID: courseID, EnrollmentStatus: status}
+
+			mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+			mockRepo.EXPECT().GetByStudentAndCourse(gomock.Any(), studentID, courseID).Return(enrollment, nil)
+
+			err := service.DropCourse(context.Background(), courseID, studentID, "", nil)
+			assert.ErrorIs(t, err, domain.ErrInvalidEnrollmentStatus)
+		})
+	}
+}
+
+// semesterAt returns a semester whose registration end, add/drop end and
+// withdrawal deadline fall the given number of days from today
+func semesterAt(registrationEnd, addDropEnd, withdrawalDeadline int) *domain.Semester {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	day := func(offset int) *time.Time {
+		d := today.AddDate(0, 0, offset)
+		return &d
+	}
+	return &domain.Semester{
+		StartDate:          *day(registrationEnd - 7),
+		EndDate:            *day(withdrawalDeadline + 30),
+		RegistrationStart:  day(registrationEnd - 14),
+		RegistrationEnd:    day(registrationEnd),
+		AddDropEnd:         day(addDropEnd),
+		WithdrawalDeadline: day(withdrawalDeadline),
+	}
+}
+
+func TestEnrollmentService_UpdateEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+
+	service := NewEnrollmentService(mockRepo, mockCourseRepo, nil, nil, nil, newTestTransactor(ctrl), mockProducer)
+
+	t.Run("Waitlisted To Enrolled In Full Course", func(t *testing.T) {
+		courseID := uuid.New()
+		maxStudents := 10
+		position := 1
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), CourseID: courseID, EnrollmentStatus: "waitlisted", WaitlistPosition: &position}
+		course := &domain.Course{CourseID: courseID, MaxStudents: &maxStudents, CurrentEnrollment: 10}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+
+		err := service.UpdateEnrollment(context.Background(), enrollment.EnrollmentID, "enrolled")
+		assert.ErrorIs(t, err, domain.ErrCourseFull)
+	})
+
+	t.Run("Drop Outside Drop Course", func(t *testing.T) {
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), CourseID: uuid.New(), EnrollmentStatus: "enrolled"}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+
+		err := service.UpdateEnrollment(context.Background(), enrollment.EnrollmentID, "dropped")
+		assert.Equal(t, domain.ErrInvalidEnrollmentStatus, err)
+		assert.Equal(t, "enrolled", enrollment.EnrollmentStatus)
+	})
+
+	t.Run("Complete Outside Gradebook", func(t *testing.T) {
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), EnrollmentStatus: "enrolled"}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+
+		err := service.UpdateEnrollment(context.Background(), enrollment.EnrollmentID, "completed")
+		assert.Equal(t, domain.ErrInvalidEnrollmentStatus, err)
+	})
+}
+
+func TestEnrollmentService_CheckPrerequisites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
+
+	service := NewEnrollmentService(mockRepo, mockCourseRepo, nil, nil, nil, newTestTransactor(ctrl), nil)
+
+	points := func(p float64) *float64 { return &p }
+
+	t.Run("Met", func(t *testing.T) {
+		studentID := uuid.New()
+		course := &domain.Course{CourseID: uuid.New()}
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), course.CourseID).Return(course, nil)
+		mockRepo.EXPECT().GetUnmetRequirements(gomock.Any(), studentID, course.CourseID).Return(nil, nil)
+
+		check, err := service.CheckPrerequisites(context.Background(), studentID, course.CourseID)
+		assert.NoError(t, err)
+		assert.True(t, check.Met)
+		assert.Empty(t, check.Unmet)
+	})
+
+	t.Run("Unmet Requirements", func(t *testing.T) {
+		studentID := uuid.New()
+		course := &domain.Course{CourseID: uuid.New()}
+		unmet := []*domain.UnmetRequirement{
+			{StudentID: studentID, Type: domain.RequirementPrerequisite, Subject: domain.SubjectBasic{SubjectCode: "CS101"}, IsMandatory: true,
+				MinGradePoints: points(6), PassGradePoints: 4, Completed: true, GradePoints: points(5)},
+			// Below the pass mark of a 4-point scale
+			{StudentID: studentID, Type: domain.RequirementPrerequisite, Subject: domain.SubjectBasic{SubjectCode: "CS102"}, IsMandatory: true,
+				PassGradePoints: 1, Completed: true, GradePoints: points(0.7)},
+			{StudentID: studentID, Type: domain.RequirementPrerequisite, Subject: domain.SubjectBasic{SubjectCode: "CS103"}, IsMandatory: true,
+				MinGradePoints: points(6), Completed: true},
+			{StudentID: studentID, Type: domain.RequirementPrerequisite, Subject: domain.SubjectBasic{SubjectCode: "CS104"}},
+			{StudentID: studentID, Type: domain.RequirementCorequisite, Subject: domain.SubjectBasic{SubjectCode: "MA101"}, IsMandatory: true},
+		}
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), course.CourseID).Return(course, nil)
+		mockRepo.EXPECT().GetUnmetRequirements(gomock.Any(), studentID, course.CourseID).Return(unmet, nil)
+
+		check, err := service.CheckPrerequisites(context.Background(), studentID, course.CourseID)
+		assert.NoError(t, err)
+		assert.False(t, check.Met)
+		assert.Len(t, check.Unmet, 4)
+		assert.Equal(t, "CS101 requires grade points of at least 6.00, got 5.00", check.Unmet[0].Reason)
+		assert.Equal(t, "CS102 was not passed", check.Unmet[1].Reason)
+		assert.Equal(t, "CS103 requires a minimum grade but none was recorded", check.Unmet[2].Reason)
+		assert.Equal(t, domain.RequirementCorequisite, check.Unmet[3].Type)
+		assert.Equal(t, "MA101 must be taken in the same semester or completed before", check.Unmet[3].Reason)
+		assert.Len(t, check.Warnings, 1)
+		assert.Equal(t, "CS104 has not been completed", check.Warnings[0].Reason)
+	})
+
+	t.Run("Waived", func(t *testing.T) {
+		studentID := uuid.New()
+		course := &domain.Course{CourseID: uuid.New()}
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), course.CourseID).Return(course, nil)
+		mockRepo.EXPECT().GetUnmetRequirements(gomock.Any(), studentID, course.CourseID).Return([]*domain.UnmetRequirement{
+			{StudentID: studentID, Type: domain.RequirementCorequisite, Subject: domain.SubjectBasic{SubjectCode: "CH101"}, IsMandatory: true, Waived: true},
+		}, nil)
+
+		check, err := service.CheckPrerequisites(context.Background(), studentID, course.CourseID)
+		assert.NoError(t, err)
+		assert.True(t, check.Met)
+		assert.Len(t, check.Waived, 1)
+	})
+
+	t.Run("Course Not Found", func(t *testing.T) {
+		courseID := uuid.New()
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(nil, domain.ErrCourseNotFound)
+
+		_, err := service.CheckPrerequisites(context.Background(), uuid.New(), courseID)
+		assert.ErrorIs(t, err, domain.ErrCourseNotFound)
+	})
+}
+
+func TestEnrollmentService_BulkEnroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
+	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
+	mockSemesterRepo := mocks.NewMockSemesterRepository(ctrl)
+
+	service := NewEnrollmentService(mockRepo, mockCourseRepo, mockStudentRepo, nil, mockSemesterRepo, newTestTransactor(ctrl), nil)
+
+	maxStudents := 50
+	course := &domain.Course{CourseID: uuid.New(), Status: "active", MaxStudents: &maxStudents}
+
+	t.Run("Checks Cohort Requirements Once", func(t *testing.T) {
+		eligible := uuid.New()
+		ineligible := uuid.New()
+		missing := uuid.New()
+		enrolled := uuid.New()
+		studentIDs := []uuid.UUID{eligible, ineligible, missing, enrolled}
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), course.CourseID).Return(course, nil)
+		mockRepo.EXPECT().GetUnmetRequirementsForStudents(gomock.Any(), studentIDs, course.CourseID).Return([]*domain.UnmetRequirement{
+			{StudentID: ineligible, Type: domain.RequirementPrerequisite, Subject: domain.SubjectBasic{SubjectCode: "CS101"}, IsMandatory: true},
+		}, nil)
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), course.CourseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(0, 7, 30), nil)
+		mockStudentRepo.EXPECT().ListExistingIDs(gomock.Any(), studentIDs).Return(map[uuid.UUID]bool{eligible: true, ineligible: true, enrolled: true}, nil)
+		mockRepo.EXPECT().ListEnrolledStudentIDs(gomock.Any(), course.CourseID, studentIDs).Return(map[uuid.UUID]bool{enrolled: true}, nil)
+		mockRepo.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, enrollments []*domain.CourseEnrollment) error {
+			assert.Len(t, enrollments, 1)
+			assert.Equal(t, eligible, enrollments[0].StudentID)
+			assert.Equal(t, "enrolled", enrollments[0].EnrollmentStatus)
+			assert.Equal(t, "admin", enrollments[0].EnrolledBy)
+			enrollments[0].EnrollmentID = uuid.New()
+			return nil
+		})
+
+		results, err := service.BulkEnroll(context.Background(), course.CourseID, studentIDs, false)
+		assert.NoError(t, err)
+		assert.Len(t, results, 4)
+		assert.Equal(t, "success", results[0].Status)
+		assert.NotNil(t, results[0].EnrollmentID)
+		assert.Equal(t, "failed", results[1].Status)
+		assert.Contains(t, results[1].Error, "CS101 has not been completed")
+		assert.Equal(t, "failed", results[2].Status)
+		assert.Equal(t, domain.ErrStudentNotFound.Error(), results[2].Error)
+		assert.Equal(t, "failed", results[3].Status)
+		assert.Equal(t, domain.ErrAlreadyEnrolled.Error(), results[3].Error)
+	})
+
+	t.Run("Waitlists Once Full", func(t *testing.T) {
+		seats := 1
+		full := &domain.Course{CourseID: uuid.New(), Status: "active", MaxStudents: &seats}
+		studentIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+		existing := map[uuid.UUID]bool{studentIDs[0]: true, studentIDs[1]: true, studentIDs[2]: true}
+
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), full.CourseID).Return(full, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), full.SemesterID).Return(semesterAt(0, 7, 30), nil)
+		mockStudentRepo.EXPECT().ListExistingIDs(gomock.Any(), studentIDs).Return(existing, nil)
+		mockRepo.EXPECT().ListEnrolledStudentIDs(gomock.Any(), full.CourseID, studentIDs).Return(map[uuid.UUID]bool{}, nil)
+		mockRepo.EXPECT().GetNextWaitlistPosition(gomock.Any(), full.CourseID).Return(4, nil)
+		mockRepo.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, enrollments []*domain.CourseEnrollment) error {
+			assert.Len(t, enrollments, 3)
+			assert.Equal(t, "enrolled", enrollments[0].EnrollmentStatus)
+			assert.Nil(t, enrollments[0].WaitlistPosition)
+			assert.Equal(t, "waitlisted", enrollments[1].EnrollmentStatus)
+			assert.Equal(t, 4, *enrollments[1].WaitlistPosition)
+			assert.Equal(t, "waitlisted", enrollments[2].EnrollmentStatus)
+			assert.Equal(t, 5, *enrollments[2].WaitlistPosition)
+			return nil
+		})
+
+		results, err := service.BulkEnroll(context.Background(), full.CourseID, studentIDs, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "success", results[0].Status)
+		assert.Equal(t, "waitlisted", results[1].Status)
+		assert.Equal(t, "waitlisted", results[2].Status)
+	})
+
+	t.Run("Duplicate Student", func(t *testing.T) {
+		studentID := uuid.New()
+		studentIDs := []uuid.UUID{studentID, studentID}
+
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), course.CourseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(0, 7, 30), nil)
+		mockStudentRepo.EXPECT().ListExistingIDs(gomock.Any(), studentIDs).Return(map[uuid.UUID]bool{studentID: true}, nil)
+		mockRepo.EXPECT().ListEnrolledStudentIDs(gomock.Any(), course.CourseID, studentIDs).Return(map[uuid.UUID]bool{}, nil)
+		mockRepo.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).Return(nil)
+
+		results, err := service.BulkEnroll(context.Background(), course.CourseID, studentIDs, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "success", results[0].Status)
+		assert.Equal(t, domain.ErrAlreadyEnrolled.Error(), results[1].Error)
+	})
+
+	t.Run("Registration Closed", func(t *testing.T) {
+		mockCourseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), course.CourseID).Return(course, nil)
+		mockSemesterRepo.EXPECT().GetByID(gomock.Any(), course.SemesterID).Return(semesterAt(-14, -7, 30), nil)
+
+		_, err := service.BulkEnroll(context.Background(), course.CourseID, []uuid.UUID{uuid.New()}, true)
+		assert.ErrorIs(t, err, domain.ErrRegistrationClosed)
+	})
+
+	t.Run("Course Not Found", func(t *testing.T) {
+		courseID := uuid.New()
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(nil, domain.ErrCourseNotFound)
+
+		_, err := service.BulkEnroll(context.Background(), courseID, []uuid.UUID{uuid.New()}, false)
+		assert.ErrorIs(t, err, domain.ErrCourseNotFound)
+	})
+}
+
+func TestEnrollmentService_GrantWaiver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
+	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
+	mockSubjectRepo := mocks.NewMockSubjectRepository(ctrl)
+
+	service := NewEnrollmentService(mockRepo, mockCourseRepo, mockStudentRepo, mockSubjectRepo, nil, newTestTransactor(ctrl), nil)
+
+	t.Run("Corequisite", func(t *testing.T) {
+		course := &domain.Course{CourseID: uuid.New(), SubjectID: uuid.New()}
+		coreq := domain.SubjectBasic{SubjectID: uuid.New()}
+		waiver := &domain.PrerequisiteWaiver{StudentID: uuid.New(), CourseID: course.CourseID, WaivedSubjectID: coreq.SubjectID, Reason: "lab taken abroad", GrantedBy: uuid.New()}
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), course.CourseID).Return(course, nil)
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), waiver.StudentID).Return(&domain.Student{StudentID: waiver.StudentID}, nil)
+		mockSubjectRepo.EXPECT().GetPrerequisites(gomock.Any(), course.SubjectID).Return(nil, nil)
+		mockSubjectRepo.EXPECT().GetCorequisites(gomock.Any(), course.SubjectID).Return([]domain.SubjectBasic{coreq}, nil)
+		mockRepo.EXPECT().CreateWaiver(gomock.Any(), waiver).Return(nil)
+
+		err := service.GrantWaiver(context.Background(), waiver)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RequirementCorequisite, waiver.RequirementType)
+	})
+
+	t.Run("Not A Requirement", func(t *testing.T) {
+		course := &domain.Course{CourseID: uuid.New(), SubjectID: uuid.New()}
+		waiver := &domain.PrerequisiteWaiver{StudentID: uuid.New(), CourseID: course.CourseID, WaivedSubjectID: uuid.New(), Reason: "none", GrantedBy: uuid.New()}
+
+		mockCourseRepo.EXPECT().GetByID(gomock.Any(), course.CourseID).Return(course, nil)
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), waiver.StudentID).Return(&domain.Student{StudentID: waiver.StudentID}, nil)
+		mockSubjectRepo.EXPECT().GetPrerequisites(gomock.Any(), course.SubjectID).Return(nil, nil)
+		mockSubjectRepo.EXPECT().GetCorequisites(gomock.Any(), course.SubjectID).Return(nil, nil)
+
+		err := service.GrantWaiver(context.Background(), waiver)
+		assert.ErrorIs(t, err, domain.ErrRequirementNotFound)
+	})
+}
+
+// expectNoRequirements expects the requirement check of a student whose
+// enrollment in course meets every requirement
+func expectNoRequirements(repo *mocks.MockEnrollmentRepository, courseRepo *mocks.MockCourseRepository, course *domain.Course, studentID uuid.UUID) {
+	courseRepo.EXPECT().GetByID(gomock.Any(), course.CourseID).Return(course, nil)
+	repo.EXPECT().GetUnmetRequirements(gomock.Any(), studentID, course.CourseID).Return(nil, nil)
 }
@@ -4,27 +4,31 @@ import (
 	"context"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type facultyAssignmentService struct {
-	repo     domain.FacultyCourseRepository
-	faculty  domain.FacultyRepository
-	course   domain.CourseRepository
-	producer domain.EventProducer
+	repo       domain.FacultyCourseRepository
+	faculty    domain.FacultyRepository
+	course     domain.CourseRepository
+	transactor domain.Transactor
+	producer   domain.EventProducer
 }
 
 func NewFacultyAssignmentService(
 	repo domain.FacultyCourseRepository,
 	faculty domain.FacultyRepository,
 	course domain.CourseRepository,
+	transactor domain.Transactor,
 	producer domain.EventProducer,
 ) domain.FacultyAssignmentService {
 	return &facultyAssignmentService{
-		repo:     repo,
-		faculty:  faculty,
-		course:   course,
-		producer: producer,
+		repo:       repo,
+		faculty:    faculty,
+		course:     course,
+		transactor: transactor,
+		producer:   producer,
 	}
 }
 
@@ -59,20 +63,21 @@ func (s *facultyAssignmentService) AssignFaculty(ctx context.Context, courseID,
 		IsActive:   true,
 	}
 
-	if err := s.repo.Create(ctx, fc); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, fc); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, fc.CourseID.String(), facultyAssignmentEvent(models.EventFacultyAssigned, fc))
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.faculty.assigned", fc.FacultyCourseID.String(), map[string]interface{}{
-			"faculty_id": facultyID,
-			"course_id":  courseID,
-			"role":       role,
-			"is_primary": isPrimary,
-		})
-	}
-
 	return fc, nil
 }
 
@@ -85,39 +90,42 @@ func (s *facultyAssignmentService) UpdateAssignment(ctx context.Context, courseI
 	fc.Role = role
 	fc.IsPrimary = isPrimary
 
-	if err := s.repo.Update(ctx, fc); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.faculty.assignment_updated", fc.FacultyCourseID.String(), map[string]interface{}{
-			"faculty_id": facultyID,
-			"course_id":  courseID,
-			"role":       role,
-			"is_primary": isPrimary,
-		})
-	}
-
-	return nil
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, fc); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, fc.CourseID.String(), facultyAssignmentEvent(models.EventFacultyAssignmentUpdated, fc))
+	})
 }
 
 func (s *facultyAssignmentService) RemoveFaculty(ctx context.Context, courseID, facultyID uuid.UUID) error {
-	if err := s.repo.Delete(ctx, facultyID, courseID); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.faculty.removed", facultyID.String(), map[string]interface{}{
-			"faculty_id": facultyID,
-			"course_id":  courseID,
-		})
-	}
-
-	return nil
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, facultyID, courseID); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, courseID.String(), models.NewFacultyAssignmentEvent(models.EventFacultyUnassigned, facultyID, courseID))
+	})
 }
 
 func (s *facultyAssignmentService) ListCourseFaculty(ctx context.Context, courseID uuid.UUID) ([]*domain.FacultyCourseWithDetails, error) {
 	return s.repo.ListByCourse(ctx, courseID)
 }
+
+// facultyAssignmentEvent creates a faculty assignment event describing fc
+func facultyAssignmentEvent(eventType models.EventType, fc *domain.FacultyCourse) *models.FacultyAssignmentEvent {
+	event := models.NewFacultyAssignmentEvent(eventType, fc.FacultyID, fc.CourseID)
+	event.FacultyCourseID = fc.FacultyCourseID
+	event.Role = fc.Role
+	event.IsPrimary = fc.IsPrimary
+	return event
+}
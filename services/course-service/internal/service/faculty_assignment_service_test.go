@@ -20,7 +20,7 @@ func TestFacultyAssignmentService_AssignFaculty(t *testing.T) {
 	mockCourseRepo := mocks.NewMockCourseRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewFacultyAssignmentService(mockRepo, mockFacultyRepo, mockCourseRepo, mockProducer)
+	service := NewFacultyAssignmentService(mockRepo, mockFacultyRepo, mockCourseRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
@@ -41,7 +41,7 @@ func TestFacultyAssignmentService_AssignFaculty(t *testing.T) {
 			assert.True(t, fc.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.faculty.assigned", gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, gomock.Any(), gomock.Any()).Return(nil)
 
 		result, err := service.AssignFaculty(context.Background(), courseID, facultyID, assignedBy, "instructor", true)
 		assert.NoError(t, err)
@@ -103,7 +103,7 @@ func TestFacultyAssignmentService_UpdateAssignment(t *testing.T) {
 	mockRepo := mocks.NewMockFacultyCourseRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewFacultyAssignmentService(mockRepo, nil, nil, mockProducer)
+	service := NewFacultyAssignmentService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
@@ -124,7 +124,7 @@ func TestFacultyAssignmentService_UpdateAssignment(t *testing.T) {
 			assert.True(t, f.IsPrimary)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.faculty.assignment_updated", fcID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateAssignment(context.Background(), courseID, facultyID, "instructor", true)
 		assert.NoError(t, err)
@@ -148,14 +148,14 @@ func TestFacultyAssignmentService_RemoveFaculty(t *testing.T) {
 	mockRepo := mocks.NewMockFacultyCourseRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewFacultyAssignmentService(mockRepo, nil, nil, mockProducer)
+	service := NewFacultyAssignmentService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
 		courseID := uuid.New()
 
 		mockRepo.EXPECT().Delete(gomock.Any(), facultyID, courseID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.faculty.removed", facultyID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
 
 		err := service.RemoveFaculty(context.Background(), courseID, facultyID)
 		assert.NoError(t, err)
@@ -178,7 +178,7 @@ func TestFacultyAssignmentService_ListCourseFaculty(t *testing.T) {
 
 	mockRepo := mocks.NewMockFacultyCourseRepository(ctrl)
 
-	service := NewFacultyAssignmentService(mockRepo, nil, nil, nil)
+	service := NewFacultyAssignmentService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		courseID := uuid.New()
@@ -4,27 +4,31 @@ import (
 	"context"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type facultyService struct {
-	repo     domain.FacultyRepository
-	deptRepo domain.DepartmentRepository
-	fcRepo   domain.FacultyCourseRepository
-	producer domain.EventProducer
+	repo       domain.FacultyRepository
+	deptRepo   domain.DepartmentRepository
+	fcRepo     domain.FacultyCourseRepository
+	transactor domain.Transactor
+	producer   domain.EventProducer
 }
 
 func NewFacultyService(
 	repo domain.FacultyRepository,
 	deptRepo domain.DepartmentRepository,
 	fcRepo domain.FacultyCourseRepository,
+	transactor domain.Transactor,
 	producer domain.EventProducer,
 ) domain.FacultyService {
 	return &facultyService{
-		repo:     repo,
-		deptRepo: deptRepo,
-		fcRepo:   fcRepo,
-		producer: producer,
+		repo:       repo,
+		deptRepo:   deptRepo,
+		fcRepo:     fcRepo,
+		transactor: transactor,
+		producer:   producer,
 	}
 }
 
@@ -36,21 +40,17 @@ func (s *facultyService) CreateFaculty(ctx context.Context, faculty *domain.Facu
 	}
 
 	faculty.IsActive = true
-	if err := s.repo.Create(ctx, faculty); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.faculty.created", faculty.FacultyID.String(), map[string]interface{}{
-			"faculty_id":    faculty.FacultyID,
-			"user_id":       faculty.UserID,
-			"employee_id":   faculty.EmployeeID,
-			"department_id": faculty.DepartmentID,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, faculty); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, faculty.FacultyID.String(), facultyEvent(models.EventFacultyCreated, faculty))
+	})
 }
 
 func (s *facultyService) GetFaculty(ctx context.Context, id uuid.UUID) (*domain.FacultyWithDetails, error) {
@@ -111,34 +111,31 @@ func (s *facultyService) UpdateFaculty(ctx context.Context, id uuid.UUID, update
 		faculty.IsActive = isActive
 	}
 
-	if err := s.repo.Update(ctx, faculty); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.faculty.updated", faculty.FacultyID.String(), map[string]interface{}{
-			"faculty_id":  faculty.FacultyID,
-			"employee_id": faculty.EmployeeID,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, faculty); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, faculty.FacultyID.String(), facultyEvent(models.EventFacultyUpdated, faculty))
+	})
 }
 
 func (s *facultyService) DeleteFaculty(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.faculty.deleted", id.String(), map[string]interface{}{
-			"faculty_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewFacultyEvent(models.EventFacultyDeleted, id))
+	})
 }
 
 func (s *facultyService) ListFaculty(ctx context.Context, filter domain.FacultyFilter, page, limit int) ([]*domain.FacultyWithDetails, int64, error) {
@@ -163,3 +160,12 @@ func (s *facultyService) GetFacultyCourses(ctx context.Context, facultyID uuid.U
 	}
 	return courses[offset:end], total, nil
 }
+
+// facultyEvent creates a faculty event describing faculty
+func facultyEvent(eventType models.EventType, faculty *domain.Faculty) *models.FacultyEvent {
+	event := models.NewFacultyEvent(eventType, faculty.FacultyID)
+	event.UserID = faculty.UserID
+	event.EmployeeID = faculty.EmployeeID
+	event.DepartmentID = faculty.DepartmentID
+	return event
+}
@@ -21,7 +21,7 @@ func TestFacultyService_CreateFaculty(t *testing.T) {
 	mockFCRepo := mocks.NewMockFacultyCourseRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewFacultyService(mockRepo, mockDeptRepo, mockFCRepo, mockProducer)
+	service := NewFacultyService(mockRepo, mockDeptRepo, mockFCRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
@@ -45,7 +45,7 @@ func TestFacultyService_CreateFaculty(t *testing.T) {
 			assert.True(t, f.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.faculty.created", facultyID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, facultyID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateFaculty(context.Background(), faculty)
 		assert.NoError(t, err)
@@ -90,7 +90,7 @@ func TestFacultyService_GetFaculty(t *testing.T) {
 
 	mockRepo := mocks.NewMockFacultyRepository(ctrl)
 
-	service := NewFacultyService(mockRepo, nil, nil, nil)
+	service := NewFacultyService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
@@ -136,7 +136,7 @@ func TestFacultyService_GetFacultyByUserID(t *testing.T) {
 
 	mockRepo := mocks.NewMockFacultyRepository(ctrl)
 
-	service := NewFacultyService(mockRepo, nil, nil, nil)
+	service := NewFacultyService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		userID := uuid.New()
@@ -180,7 +180,7 @@ func TestFacultyService_UpdateFaculty(t *testing.T) {
 	mockRepo := mocks.NewMockFacultyRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewFacultyService(mockRepo, nil, nil, mockProducer)
+	service := NewFacultyService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
@@ -203,7 +203,7 @@ func TestFacultyService_UpdateFaculty(t *testing.T) {
 			assert.False(t, f.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.faculty.updated", facultyID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, facultyID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateFaculty(context.Background(), facultyID, updates)
 		assert.NoError(t, err)
@@ -227,13 +227,13 @@ func TestFacultyService_DeleteFaculty(t *testing.T) {
 	mockRepo := mocks.NewMockFacultyRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewFacultyService(mockRepo, nil, nil, mockProducer)
+	service := NewFacultyService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
 
 		mockRepo.EXPECT().Delete(gomock.Any(), facultyID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.faculty.deleted", facultyID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, facultyID.String(), gomock.Any()).Return(nil)
 
 		err := service.DeleteFaculty(context.Background(), facultyID)
 		assert.NoError(t, err)
@@ -255,7 +255,7 @@ func TestFacultyService_ListFaculty(t *testing.T) {
 
 	mockRepo := mocks.NewMockFacultyRepository(ctrl)
 
-	service := NewFacultyService(mockRepo, nil, nil, nil)
+	service := NewFacultyService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		deptID := uuid.New()
@@ -294,7 +294,7 @@ func TestFacultyService_GetFacultyCourses(t *testing.T) {
 
 	mockFCRepo := mocks.NewMockFacultyCourseRepository(ctrl)
 
-	service := NewFacultyService(nil, nil, mockFCRepo, nil)
+	service := NewFacultyService(nil, nil, mockFCRepo, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		facultyID := uuid.New()
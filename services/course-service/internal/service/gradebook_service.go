@@ -0,0 +1,544 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/google/uuid"
+)
+
+type gradebookService struct {
+	repo              domain.GradebookRepository
+	enrollRepo        domain.EnrollmentRepository
+	courseRepo        domain.CourseRepository
+	deptRepo          domain.DepartmentRepository
+	facultyCourseRepo domain.FacultyCourseRepository
+	studentRepo       domain.StudentRepository
+	scaleRepo         domain.GradingScaleRepository
+	transactor        domain.Transactor
+	producer          domain.EventProducer
+}
+
+func NewGradebookService(
+	repo domain.GradebookRepository,
+	enrollRepo domain.EnrollmentRepository,
+	courseRepo domain.CourseRepository,
+	deptRepo domain.DepartmentRepository,
+	facultyCourseRepo domain.FacultyCourseRepository,
+	studentRepo domain.StudentRepository,
+	scaleRepo domain.GradingScaleRepository,
+	transactor domain.Transactor,
+	producer domain.EventProducer,
+) domain.GradebookService {
+	return &gradebookService{
+		repo:              repo,
+		enrollRepo:        enrollRepo,
+		courseRepo:        courseRepo,
+		deptRepo:          deptRepo,
+		facultyCourseRepo: facultyCourseRepo,
+		studentRepo:       studentRepo,
+		scaleRepo:         scaleRepo,
+		transactor:        transactor,
+		producer:          producer,
+	}
+}
+
+// SubmitGrades records the grades of the whole roster of a course for the
+// head of department to approve. Grades can be resubmitted until they are
+// approved.
+func (s *gradebookService) SubmitGrades(ctx context.Context, courseID uuid.UUID, grades []domain.GradeSubmission, actor domain.GradeActor) (*domain.Gradebook, error) {
+	if err := s.requirePrimaryFaculty(ctx, courseID, actor); err != nil {
+		return nil, err
+	}
+
+	gradebook := &domain.Gradebook{
+		CourseID:    courseID,
+		Status:      domain.GradebookSubmitted,
+		SubmittedBy: actor.UserID,
+	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the course so that the roster cannot change while it is graded
+		if _, err := s.courseRepo.GetByIDForUpdate(ctx, courseID); err != nil {
+			return err
+		}
+
+		current, err := s.repo.GetByCourse(ctx, courseID)
+		if err != nil && err != domain.ErrGradebookNotFound {
+			return err
+		}
+		if current != nil && current.Status == domain.GradebookApproved {
+			return domain.ErrGradesLocked
+		}
+
+		roster, err := s.enrollRepo.ListRoster(ctx, courseID)
+		if err != nil {
+			return err
+		}
+		gradebook.Entries, err = s.gradeRoster(ctx, roster, grades)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, gradebook); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, courseID.String(), gradebookEvent(models.EventGradesSubmitted, gradebook, actor))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gradebook, nil
+}
+
+// gradeRoster maps the submitted grades onto the roster of a course. Every
+// student of the roster needs exactly one grade, which must be on the grading
+// scale of their program.
+func (s *gradebookService) gradeRoster(ctx context.Context, roster []*domain.CourseEnrollment, grades []domain.GradeSubmission) ([]domain.GradebookEntry, error) {
+	if len(roster) == 0 {
+		return nil, fmt.Errorf("%w: no students are enrolled in the course", domain.ErrIncompleteGradebook)
+	}
+
+	submitted := make(map[uuid.UUID]string, len(grades))
+	for _, g := range grades {
+		if _, ok := submitted[g.StudentID]; ok {
+			return nil, fmt.Errorf("%w: student %s is graded twice", domain.ErrIncompleteGradebook, g.StudentID)
+		}
+		submitted[g.StudentID] = g.Grade
+	}
+
+	entries := make([]domain.GradebookEntry, 0, len(roster))
+	for _, enrollment := range roster {
+		grade, ok := submitted[enrollment.StudentID]
+		if !ok {
+			return nil, fmt.Errorf("%w: student %s has no grade", domain.ErrIncompleteGradebook, enrollment.StudentID)
+		}
+		delete(submitted, enrollment.StudentID)
+
+		scale, err := s.scaleRepo.GetForStudent(ctx, enrollment.StudentID)
+		if err != nil {
+			return nil, err
+		}
+		mapping, ok := scaleGrade(scale, grade)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s given to student %s", domain.ErrInvalidGrade, grade, enrollment.StudentID)
+		}
+		entries = append(entries, domain.GradebookEntry{
+			EnrollmentID: enrollment.EnrollmentID,
+			StudentID:    enrollment.StudentID,
+			Grade:        mapping.Grade,
+			GradePoints:  mapping.GradePoints,
+		})
+	}
+	for studentID := range submitted {
+		return nil, fmt.Errorf("%w: student %s is not enrolled in the course", domain.ErrIncompleteGradebook, studentID)
+	}
+	return entries, nil
+}
+
+func (s *gradebookService) GetGradebook(ctx context.Context, courseID uuid.UUID, actor domain.GradeActor) (*domain.Gradebook, error) {
+	if err := s.requireCourseStaff(ctx, courseID, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByCourse(ctx, courseID)
+}
+
+// ApproveGrades releases the submitted grades to the enrollments of the
+// roster, completing them or failing those graded below the pass mark, and
+// locks the grades. The students' GPAs and earned credits are recomputed.
+func (s *gradebookService) ApproveGrades(ctx context.Context, courseID uuid.UUID, comment *string, actor domain.GradeActor) error {
+	if err := s.requireDepartmentHead(ctx, courseID, actor); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.courseRepo.GetByIDForUpdate(ctx, courseID); err != nil {
+			return err
+		}
+
+		gradebook, err := s.submittedGradebook(ctx, courseID)
+		if err != nil {
+			return err
+		}
+		if gradebook.SubmittedBy == actor.UserID {
+			return domain.ErrForbidden
+		}
+
+		// The roster may have changed through late enrollments or withdrawals
+		roster, err := s.enrollRepo.ListRoster(ctx, courseID)
+		if err != nil {
+			return err
+		}
+		entries := make(map[uuid.UUID]domain.GradebookEntry, len(gradebook.Entries))
+		for _, e := range gradebook.Entries {
+			entries[e.EnrollmentID] = e
+		}
+		if len(roster) != len(entries) {
+			return fmt.Errorf("%w: the roster changed after the grades were submitted", domain.ErrIncompleteGradebook)
+		}
+
+		now := time.Now()
+		for _, enrollment := range roster {
+			entry, ok := entries[enrollment.EnrollmentID]
+			if !ok {
+				return fmt.Errorf("%w: the roster changed after the grades were submitted", domain.ErrIncompleteGradebook)
+			}
+
+			scale, err := s.scaleRepo.GetForStudent(ctx, enrollment.StudentID)
+			if err != nil {
+				return err
+			}
+			enrollment.EnrollmentStatus = gradedStatus(entry.GradePoints, scale)
+			enrollment.Grade = &entry.Grade
+			enrollment.GradePoints = &entry.GradePoints
+			enrollment.CompletionDate = &now
+			if err := s.enrollRepo.Update(ctx, enrollment); err != nil {
+				return err
+			}
+			if err := s.recomputeAcademicRecord(ctx, enrollment.StudentID); err != nil {
+				return err
+			}
+			if s.producer != nil {
+				err := s.producer.PublishEvent(ctx, domain.CourseEventsTopic, courseID.String(), enrollmentEvent(models.EventEnrollmentCompleted, enrollment))
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		gradebook.Status = domain.GradebookApproved
+		gradebook.ReviewedBy = &actor.UserID
+		gradebook.ReviewedAt = &now
+		gradebook.ReviewComment = comment
+		if err := s.repo.UpdateStatus(ctx, gradebook); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, courseID.String(), gradebookEvent(models.EventGradesApproved, gradebook, actor))
+	})
+}
+
+// ReturnGrades sends submitted grades back to the faculty for correction
+func (s *gradebookService) ReturnGrades(ctx context.Context, courseID uuid.UUID, comment string, actor domain.GradeActor) error {
+	if err := s.requireDepartmentHead(ctx, courseID, actor); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.courseRepo.GetByIDForUpdate(ctx, courseID); err != nil {
+			return err
+		}
+
+		gradebook, err := s.submittedGradebook(ctx, courseID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		gradebook.Status = domain.GradebookReturned
+		gradebook.ReviewedBy = &actor.UserID
+		gradebook.ReviewedAt = &now
+		gradebook.ReviewComment = &comment
+		if err := s.repo.UpdateStatus(ctx, gradebook); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, courseID.String(), gradebookEvent(models.EventGradesReturned, gradebook, actor))
+	})
+}
+
+// submittedGradebook gets the gradebook of a course that awaits approval
+func (s *gradebookService) submittedGradebook(ctx context.Context, courseID uuid.UUID) (*domain.Gradebook, error) {
+	gradebook, err := s.repo.GetByCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	switch gradebook.Status {
+	case domain.GradebookSubmitted:
+		return gradebook, nil
+	case domain.GradebookApproved:
+		return nil, domain.ErrGradesLocked
+	default:
+		return nil, domain.ErrGradesNotSubmitted
+	}
+}
+
+// RequestAmendment asks the head of department to change an approved grade
+func (s *gradebookService) RequestAmendment(ctx context.Context, enrollmentID uuid.UUID, grade, reason string, actor domain.GradeActor) (*domain.GradeAmendment, error) {
+	enrollment, err := s.enrollRepo.GetByID(ctx, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requirePrimaryFaculty(ctx, enrollment.CourseID, actor); err != nil {
+		return nil, err
+	}
+
+	gradebook, err := s.repo.GetByCourse(ctx, enrollment.CourseID)
+	if err != nil && err != domain.ErrGradebookNotFound {
+		return nil, err
+	}
+	graded := enrollment.EnrollmentStatus == "completed" || enrollment.EnrollmentStatus == "failed"
+	if gradebook == nil || gradebook.Status != domain.GradebookApproved || !graded {
+		return nil, domain.ErrGradesNotApproved
+	}
+
+	amendments, err := s.repo.ListAmendments(ctx, enrollment.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range amendments {
+		if a.EnrollmentID == enrollment.EnrollmentID && a.Status == domain.AmendmentPending {
+			return nil, domain.ErrAmendmentPending
+		}
+	}
+
+	scale, err := s.scaleRepo.GetForStudent(ctx, enrollment.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	mapping, ok := scaleGrade(scale, grade)
+	if !ok {
+		return nil, domain.ErrInvalidGrade
+	}
+
+	amendment := &domain.GradeAmendment{
+		EnrollmentID:        enrollment.EnrollmentID,
+		CourseID:            enrollment.CourseID,
+		StudentID:           enrollment.StudentID,
+		PreviousGrade:       enrollment.Grade,
+		PreviousGradePoints: enrollment.GradePoints,
+		NewGrade:            mapping.Grade,
+		NewGradePoints:      mapping.GradePoints,
+		Reason:              reason,
+		Status:              domain.AmendmentPending,
+		RequestedBy:         actor.UserID,
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateAmendment(ctx, amendment); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, amendment.CourseID.String(), amendmentEvent(models.EventGradeAmendmentRequested, amendment, actor))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return amendment, nil
+}
+
+// ApproveAmendment changes the grade of the amended enrollment, completing or
+// failing it against the pass mark, and recomputes the student's GPAs and
+// earned credits
+func (s *gradebookService) ApproveAmendment(ctx context.Context, amendmentID uuid.UUID, comment *string, actor domain.GradeActor) error {
+	amendment, err := s.reviewableAmendment(ctx, amendmentID, actor)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		amendment.Status = domain.AmendmentApproved
+		amendment.ReviewedBy = &actor.UserID
+		amendment.ReviewedAt = &now
+		amendment.ReviewComment = comment
+		if err := s.repo.UpdateAmendment(ctx, amendment); err != nil {
+			return err
+		}
+
+		enrollment, err := s.enrollRepo.GetByID(ctx, amendment.EnrollmentID)
+		if err != nil {
+			return err
+		}
+		scale, err := s.scaleRepo.GetForStudent(ctx, enrollment.StudentID)
+		if err != nil {
+			return err
+		}
+		enrollment.EnrollmentStatus = gradedStatus(amendment.NewGradePoints, scale)
+		enrollment.Grade = &amendment.NewGrade
+		enrollment.GradePoints = &amendment.NewGradePoints
+		if err := s.enrollRepo.Update(ctx, enrollment); err != nil {
+			return err
+		}
+		if err := s.recomputeAcademicRecord(ctx, enrollment.StudentID); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, amendment.CourseID.String(), amendmentEvent(models.EventGradeAmendmentApproved, amendment, actor))
+	})
+}
+
+func (s *gradebookService) RejectAmendment(ctx context.Context, amendmentID uuid.UUID, comment string, actor domain.GradeActor) error {
+	amendment, err := s.reviewableAmendment(ctx, amendmentID, actor)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		amendment.Status = domain.AmendmentRejected
+		amendment.ReviewedBy = &actor.UserID
+		amendment.ReviewedAt = &now
+		amendment.ReviewComment = &comment
+		if err := s.repo.UpdateAmendment(ctx, amendment); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, amendment.CourseID.String(), amendmentEvent(models.EventGradeAmendmentRejected, amendment, actor))
+	})
+}
+
+// reviewableAmendment gets a pending amendment that actor may review. Nobody
+// reviews their own amendment.
+func (s *gradebookService) reviewableAmendment(ctx context.Context, amendmentID uuid.UUID, actor domain.GradeActor) (*domain.GradeAmendment, error) {
+	amendment, err := s.repo.GetAmendment(ctx, amendmentID)
+	if err != nil {
+		return nil, err
+	}
+	if amendment.Status != domain.AmendmentPending {
+		return nil, domain.ErrAmendmentReviewed
+	}
+	if err := s.requireDepartmentHead(ctx, amendment.CourseID, actor); err != nil {
+		return nil, err
+	}
+	if amendment.RequestedBy == actor.UserID {
+		return nil, domain.ErrForbidden
+	}
+	return amendment, nil
+}
+
+func (s *gradebookService) ListAmendments(ctx context.Context, courseID uuid.UUID, actor domain.GradeActor) ([]*domain.GradeAmendment, error) {
+	if err := s.requireCourseStaff(ctx, courseID, actor); err != nil {
+		return nil, err
+	}
+	amendments, err := s.repo.ListAmendments(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if amendments == nil {
+		amendments = []*domain.GradeAmendment{}
+	}
+	return amendments, nil
+}
+
+// requirePrimaryFaculty checks that actor is an admin or the primary faculty
+// of a course
+func (s *gradebookService) requirePrimaryFaculty(ctx context.Context, courseID uuid.UUID, actor domain.GradeActor) error {
+	if actor.IsAdmin {
+		return nil
+	}
+	if actor.FacultyID == nil {
+		return domain.ErrForbidden
+	}
+	isPrimary, err := s.facultyCourseRepo.IsPrimaryFaculty(ctx, *actor.FacultyID, courseID)
+	if err != nil {
+		return err
+	}
+	if !isPrimary {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// requireDepartmentHead checks that actor is an admin or the head of the
+// department offering a course
+func (s *gradebookService) requireDepartmentHead(ctx context.Context, courseID uuid.UUID, actor domain.GradeActor) error {
+	course, err := s.courseRepo.GetByID(ctx, courseID)
+	if err != nil {
+		return err
+	}
+	if actor.IsAdmin {
+		return nil
+	}
+	if actor.FacultyID == nil {
+		return domain.ErrForbidden
+	}
+	dept, err := s.deptRepo.GetByID(ctx, course.DepartmentID)
+	if err != nil {
+		return err
+	}
+	if dept.HeadOfDepartment == nil || *dept.HeadOfDepartment != *actor.FacultyID {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// requireCourseStaff checks that actor is an admin, faculty assigned to a
+// course or the head of the department offering it
+func (s *gradebookService) requireCourseStaff(ctx context.Context, courseID uuid.UUID, actor domain.GradeActor) error {
+	if actor.IsAdmin {
+		return nil
+	}
+	if actor.FacultyID == nil {
+		return domain.ErrForbidden
+	}
+	_, err := s.facultyCourseRepo.GetAssignment(ctx, *actor.FacultyID, courseID)
+	if err == nil {
+		return nil
+	}
+	if err != domain.ErrAssignmentNotFound {
+		return err
+	}
+	return s.requireDepartmentHead(ctx, courseID, actor)
+}
+
+// recomputeAcademicRecord recomputes the GPAs and earned credits of a student
+// from all of their graded attempts
+func (s *gradebookService) recomputeAcademicRecord(ctx context.Context, studentID uuid.UUID) error {
+	scale, err := s.scaleRepo.GetForStudent(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	attempts, err := s.enrollRepo.ListGradedAttempts(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	return s.studentRepo.UpdateAcademicRecord(ctx, academicRecord(studentID, attempts, scale))
+}
+
+// gradebookEvent creates a gradebook event describing gradebook
+func gradebookEvent(eventType models.EventType, gradebook *domain.Gradebook, actor domain.GradeActor) *models.GradebookEvent {
+	event := models.NewGradebookEvent(eventType, gradebook.CourseID, gradebook.Status)
+	event.ActorID = actor.UserID
+	event.StudentCount = len(gradebook.Entries)
+	if gradebook.ReviewComment != nil {
+		event.Comment = *gradebook.ReviewComment
+	}
+	return event
+}
+
+// amendmentEvent creates a grade amendment event describing amendment
+func amendmentEvent(eventType models.EventType, amendment *domain.GradeAmendment, actor domain.GradeActor) *models.GradeAmendmentEvent {
+	event := models.NewGradeAmendmentEvent(eventType, amendment.AmendmentID, amendment.EnrollmentID, amendment.StudentID, amendment.CourseID, amendment.Status)
+	event.ActorID = actor.UserID
+	event.PreviousGrade = amendment.PreviousGrade
+	event.NewGrade = amendment.NewGrade
+	event.Reason = amendment.Reason
+	return event
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type gradebookMocks struct {
+	repo              *mocks.MockGradebookRepository
+	enrollRepo        *mocks.MockEnrollmentRepository
+	courseRepo        *mocks.MockCourseRepository
+	deptRepo          *mocks.MockDepartmentRepository
+	facultyCourseRepo *mocks.MockFacultyCourseRepository
+	studentRepo       *mocks.MockStudentRepository
+	scaleRepo         *mocks.MockGradingScaleRepository
+	producer          *mocks.MockEventProducer
+}
+
+func newGradebookTestService(ctrl *gomock.Controller) (domain.GradebookService, *gradebookMocks) {
+	m := &gradebookMocks{
+		repo:              mocks.NewMockGradebookRepository(ctrl),
+		enrollRepo:        mocks.NewMockEnrollmentRepository(ctrl),
+		courseRepo:        mocks.NewMockCourseRepository(ctrl),
+		deptRepo:          mocks.NewMockDepartmentRepository(ctrl),
+		facultyCourseRepo: mocks.NewMockFacultyCourseRepository(ctrl),
+		studentRepo:       mocks.NewMockStudentRepository(ctrl),
+		scaleRepo:         mocks.NewMockGradingScaleRepository(ctrl),
+		producer:          mocks.NewMockEventProducer(ctrl),
+	}
+	service := NewGradebookService(m.repo, m.enrollRepo, m.courseRepo, m.deptRepo, m.facultyCourseRepo, m.studentRepo, m.scaleRepo, newTestTransactor(ctrl), m.producer)
+	return service, m
+}
+
+var testGradingScale = &domain.GradingScale{
+	MaxGradePoints:  10,
+	PassGradePoints: 4,
+	RepeatPolicy:    domain.RepeatPolicyBest,
+	Grades:          []domain.GradeMapping{{Grade: "A+", GradePoints: 9}, {Grade: "B", GradePoints: 7}, {Grade: "F", GradePoints: 0}},
+}
+
+func TestGradebookService_SubmitGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newGradebookTestService(ctrl)
+
+	facultyID := uuid.New()
+	faculty := domain.GradeActor{UserID: uuid.New(), FacultyID: &facultyID}
+
+	t.Run("Success", func(t *testing.T) {
+		courseID := uuid.New()
+		roster := []*domain.CourseEnrollment{
+			{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: courseID},
+			{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: courseID},
+		}
+		grades := []domain.GradeSubmission{
+			{StudentID: roster[1].StudentID, Grade: "b"},
+			{StudentID: roster[0].StudentID, Grade: "A+"},
+		}
+
+		m.facultyCourseRepo.EXPECT().IsPrimaryFaculty(gomock.Any(), facultyID, courseID).Return(true, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(&domain.Course{CourseID: courseID}, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(nil, domain.ErrGradebookNotFound)
+		m.enrollRepo.EXPECT().ListRoster(gomock.Any(), courseID).Return(roster, nil)
+		m.scaleRepo.EXPECT().GetForStudent(gomock.Any(), gomock.Any()).Return(testGradingScale, nil).Times(2)
+		m.repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.producer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil)
+
+		gradebook, err := service.SubmitGrades(context.Background(), courseID, grades, faculty)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.GradebookSubmitted, gradebook.Status)
+		assert.Len(t, gradebook.Entries, 2)
+		assert.Equal(t, "A+", gradebook.Entries[0].Grade)
+		assert.Equal(t, 9.0, gradebook.Entries[0].GradePoints)
+		assert.Equal(t, "B", gradebook.Entries[1].Grade)
+	})
+
+	t.Run("Not Primary Faculty", func(t *testing.T) {
+		courseID := uuid.New()
+
+		m.facultyCourseRepo.EXPECT().IsPrimaryFaculty(gomock.Any(), facultyID, courseID).Return(false, nil)
+
+		_, err := service.SubmitGrades(context.Background(), courseID, nil, faculty)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+
+	t.Run("Student Missing", func(t *testing.T) {
+		courseID := uuid.New()
+		roster := []*domain.CourseEnrollment{
+			{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: courseID},
+			{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: courseID},
+		}
+		grades := []domain.GradeSubmission{{StudentID: roster[0].StudentID, Grade: "A+"}}
+
+		m.facultyCourseRepo.EXPECT().IsPrimaryFaculty(gomock.Any(), facultyID, courseID).Return(true, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(&domain.Course{CourseID: courseID}, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(nil, domain.ErrGradebookNotFound)
+		m.enrollRepo.EXPECT().ListRoster(gomock.Any(), courseID).Return(roster, nil)
+		m.scaleRepo.EXPECT().GetForStudent(gomock.Any(), roster[0].StudentID).Return(testGradingScale, nil)
+
+		_, err := service.SubmitGrades(context.Background(), courseID, grades, faculty)
+		assert.ErrorIs(t, err, domain.ErrIncompleteGradebook)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		courseID := uuid.New()
+
+		m.facultyCourseRepo.EXPECT().IsPrimaryFaculty(gomock.Any(), facultyID, courseID).Return(true, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(&domain.Course{CourseID: courseID}, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(&domain.Gradebook{CourseID: courseID, Status: domain.GradebookApproved}, nil)
+
+		_, err := service.SubmitGrades(context.Background(), courseID, nil, faculty)
+		assert.Equal(t, domain.ErrGradesLocked, err)
+	})
+}
+
+func TestGradebookService_ApproveGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newGradebookTestService(ctrl)
+
+	headID := uuid.New()
+	head := domain.GradeActor{UserID: uuid.New(), FacultyID: &headID}
+	deptID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID, DepartmentID: deptID}
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: courseID, EnrollmentStatus: "enrolled"}
+		gradebook := &domain.Gradebook{
+			CourseID: courseID,
+			Status:   domain.GradebookSubmitted,
+			Entries:  []domain.GradebookEntry{{EnrollmentID: enrollment.EnrollmentID, StudentID: enrollment.StudentID, Grade: "A+", GradePoints: 9}},
+		}
+		attempts := []*domain.GradedAttempt{{SubjectID: uuid.New(), SemesterID: uuid.New(), Credits: 4, GradePoints: 9}}
+
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		m.deptRepo.EXPECT().GetByID(gomock.Any(), deptID).Return(&domain.Department{DepartmentID: deptID, HeadOfDepartment: &headID}, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(gradebook, nil)
+		m.enrollRepo.EXPECT().ListRoster(gomock.Any(), courseID).Return([]*domain.CourseEnrollment{enrollment}, nil)
+		m.enrollRepo.EXPECT().Update(gomock.Any(), enrollment).DoAndReturn(func(ctx context.Context, e *domain.CourseEnrollment) error {
+			assert.Equal(t, "completed", e.EnrollmentStatus)
+			assert.Equal(t, "A+", *e.Grade)
+			assert.Equal(t, 9.0, *e.GradePoints)
+			assert.NotNil(t, e.CompletionDate)
+			return nil
+		})
+		m.scaleRepo.EXPECT().GetForStudent(gomock.Any(), enrollment.StudentID).Return(testGradingScale, nil).Times(2)
+		m.enrollRepo.EXPECT().ListGradedAttempts(gomock.Any(), enrollment.StudentID).Return(attempts, nil)
+		m.studentRepo.EXPECT().UpdateAcademicRecord(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record *domain.AcademicRecord) error {
+			assert.Equal(t, 9.0, *record.CGPA)
+			return nil
+		})
+		m.repo.EXPECT().UpdateStatus(gomock.Any(), gradebook).DoAndReturn(func(ctx context.Context, g *domain.Gradebook) error {
+			assert.Equal(t, domain.GradebookApproved, g.Status)
+			assert.Equal(t, head.UserID, *g.ReviewedBy)
+			return nil
+		})
+		m.producer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil).Times(2)
+
+		err := service.ApproveGrades(context.Background(), courseID, nil, head)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Below Pass Mark", func(t *testing.T) {
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID, DepartmentID: deptID}
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: courseID, EnrollmentStatus: "enrolled"}
+		gradebook := &domain.Gradebook{
+			CourseID: courseID,
+			Status:   domain.GradebookSubmitted,
+			Entries:  []domain.GradebookEntry{{EnrollmentID: enrollment.EnrollmentID, StudentID: enrollment.StudentID, Grade: "F", GradePoints: 0}},
+		}
+
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		m.deptRepo.EXPECT().GetByID(gomock.Any(), deptID).Return(&domain.Department{DepartmentID: deptID, HeadOfDepartment: &headID}, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(gradebook, nil)
+		m.enrollRepo.EXPECT().ListRoster(gomock.Any(), courseID).Return([]*domain.CourseEnrollment{enrollment}, nil)
+		m.enrollRepo.EXPECT().Update(gomock.Any(), enrollment).DoAndReturn(func(ctx context.Context, e *domain.CourseEnrollment) error {
+			assert.Equal(t, "failed", e.EnrollmentStatus)
+			assert.Equal(t, "F", *e.Grade)
+			return nil
+		})
+		m.scaleRepo.EXPECT().GetForStudent(gomock.Any(), enrollment.StudentID).Return(testGradingScale, nil).Times(2)
+		m.enrollRepo.EXPECT().ListGradedAttempts(gomock.Any(), enrollment.StudentID).Return(nil, nil)
+		m.studentRepo.EXPECT().UpdateAcademicRecord(gomock.Any(), gomock.Any()).Return(nil)
+		m.repo.EXPECT().UpdateStatus(gomock.Any(), gradebook).Return(nil)
+		m.producer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, courseID.String(), gomock.Any()).Return(nil).Times(2)
+
+		err := service.ApproveGrades(context.Background(), courseID, nil, head)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Own Submission", func(t *testing.T) {
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID, DepartmentID: deptID}
+
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		m.deptRepo.EXPECT().GetByID(gomock.Any(), deptID).Return(&domain.Department{DepartmentID: deptID, HeadOfDepartment: &headID}, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(&domain.Gradebook{CourseID: courseID, Status: domain.GradebookSubmitted, SubmittedBy: head.UserID}, nil)
+
+		err := service.ApproveGrades(context.Background(), courseID, nil, head)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+
+	t.Run("Not Head Of Department", func(t *testing.T) {
+		courseID := uuid.New()
+		otherID := uuid.New()
+
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(&domain.Course{CourseID: courseID, DepartmentID: deptID}, nil)
+		m.deptRepo.EXPECT().GetByID(gomock.Any(), deptID).Return(&domain.Department{DepartmentID: deptID, HeadOfDepartment: &otherID}, nil)
+
+		err := service.ApproveGrades(context.Background(), courseID, nil, head)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+
+	t.Run("Roster Changed", func(t *testing.T) {
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID}
+		gradebook := &domain.Gradebook{
+			CourseID: courseID,
+			Status:   domain.GradebookSubmitted,
+			Entries:  []domain.GradebookEntry{{EnrollmentID: uuid.New(), Grade: "B", GradePoints: 7}},
+		}
+		late := &domain.CourseEnrollment{EnrollmentID: uuid.New(), CourseID: courseID, EnrollmentStatus: "enrolled"}
+
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(gradebook, nil)
+		m.enrollRepo.EXPECT().ListRoster(gomock.Any(), courseID).Return([]*domain.CourseEnrollment{late}, nil)
+
+		err := service.ApproveGrades(context.Background(), courseID, nil, domain.GradeActor{UserID: uuid.New(), IsAdmin: true})
+		assert.ErrorIs(t, err, domain.ErrIncompleteGradebook)
+	})
+
+	t.Run("Not Submitted", func(t *testing.T) {
+		courseID := uuid.New()
+		course := &domain.Course{CourseID: courseID}
+
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), courseID).Return(course, nil)
+		m.courseRepo.EXPECT().GetByIDForUpdate(gomock.Any(), courseID).Return(course, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), courseID).Return(&domain.Gradebook{CourseID: courseID, Status: domain.GradebookReturned}, nil)
+
+		err := service.ApproveGrades(context.Background(), courseID, nil, domain.GradeActor{UserID: uuid.New(), IsAdmin: true})
+		assert.Equal(t, domain.ErrGradesNotSubmitted, err)
+	})
+}
+
+func TestGradebookService_RequestAmendment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newGradebookTestService(ctrl)
+
+	facultyID := uuid.New()
+	faculty := domain.GradeActor{UserID: uuid.New(), FacultyID: &facultyID}
+
+	t.Run("Success", func(t *testing.T) {
+		grade, points := "B", 7.0
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: uuid.New(), EnrollmentStatus: "completed", Grade: &grade, GradePoints: &points}
+
+		m.enrollRepo.EXPECT().GetByID(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+		m.facultyCourseRepo.EXPECT().IsPrimaryFaculty(gomock.Any(), facultyID, enrollment.CourseID).Return(true, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), enrollment.CourseID).Return(&domain.Gradebook{Status: domain.GradebookApproved}, nil)
+		m.repo.EXPECT().ListAmendments(gomock.Any(), enrollment.CourseID).Return([]*domain.GradeAmendment{
+			{EnrollmentID: enrollment.EnrollmentID, Status: domain.AmendmentRejected},
+			{EnrollmentID: uuid.New(), Status: domain.AmendmentPending},
+		}, nil)
+		m.scaleRepo.EXPECT().GetForStudent(gomock.Any(), enrollment.StudentID).Return(testGradingScale, nil)
+		m.repo.EXPECT().CreateAmendment(gomock.Any(), gomock.Any()).Return(nil)
+		m.producer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, enrollment.CourseID.String(), gomock.Any()).Return(nil)
+
+		amendment, err := service.RequestAmendment(context.Background(), enrollment.EnrollmentID, "a+", "Re-evaluated final exam", faculty)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AmendmentPending, amendment.Status)
+		assert.Equal(t, "B", *amendment.PreviousGrade)
+		assert.Equal(t, "A+", amendment.NewGrade)
+		assert.Equal(t, 9.0, amendment.NewGradePoints)
+	})
+
+	t.Run("Already Pending", func(t *testing.T) {
+		grade, points := "F", 0.0
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: uuid.New(), EnrollmentStatus: "failed", Grade: &grade, GradePoints: &points}
+
+		m.enrollRepo.EXPECT().GetByID(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+		m.facultyCourseRepo.EXPECT().IsPrimaryFaculty(gomock.Any(), facultyID, enrollment.CourseID).Return(true, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), enrollment.CourseID).Return(&domain.Gradebook{Status: domain.GradebookApproved}, nil)
+		m.repo.EXPECT().ListAmendments(gomock.Any(), enrollment.CourseID).Return([]*domain.GradeAmendment{
+			{EnrollmentID: enrollment.EnrollmentID, Status: domain.AmendmentPending},
+		}, nil)
+
+		_, err := service.RequestAmendment(context.Background(), enrollment.EnrollmentID, "B", "Re-evaluated final exam", faculty)
+		assert.Equal(t, domain.ErrAmendmentPending, err)
+	})
+
+	t.Run("Grades Not Approved", func(t *testing.T) {
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: uuid.New(), EnrollmentStatus: "enrolled"}
+
+		m.enrollRepo.EXPECT().GetByID(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+		m.facultyCourseRepo.EXPECT().IsPrimaryFaculty(gomock.Any(), facultyID, enrollment.CourseID).Return(true, nil)
+		m.repo.EXPECT().GetByCourse(gomock.Any(), enrollment.CourseID).Return(&domain.Gradebook{Status: domain.GradebookSubmitted}, nil)
+
+		_, err := service.RequestAmendment(context.Background(), enrollment.EnrollmentID, "A+", "Re-evaluated final exam", faculty)
+		assert.Equal(t, domain.ErrGradesNotApproved, err)
+	})
+}
+
+func TestGradebookService_ApproveAmendment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := newGradebookTestService(ctrl)
+
+	admin := domain.GradeActor{UserID: uuid.New(), IsAdmin: true}
+
+	t.Run("Success", func(t *testing.T) {
+		grade, points := "F", 0.0
+		enrollment := &domain.CourseEnrollment{EnrollmentID: uuid.New(), StudentID: uuid.New(), CourseID: uuid.New(), EnrollmentStatus: "failed", Grade: &grade, GradePoints: &points}
+		amendment := &domain.GradeAmendment{
+			AmendmentID:    uuid.New(),
+			EnrollmentID:   enrollment.EnrollmentID,
+			CourseID:       enrollment.CourseID,
+			StudentID:      enrollment.StudentID,
+			NewGrade:       "A+",
+			NewGradePoints: 9,
+			Status:         domain.AmendmentPending,
+			RequestedBy:    uuid.New(),
+		}
+
+		m.repo.EXPECT().GetAmendment(gomock.Any(), amendment.AmendmentID).Return(amendment, nil)
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), amendment.CourseID).Return(&domain.Course{CourseID: amendment.CourseID}, nil)
+		m.repo.EXPECT().UpdateAmendment(gomock.Any(), amendment).Return(nil)
+		m.enrollRepo.EXPECT().GetByID(gomock.Any(), enrollment.EnrollmentID).Return(enrollment, nil)
+		m.enrollRepo.EXPECT().Update(gomock.Any(), enrollment).DoAndReturn(func(ctx context.Context, e *domain.CourseEnrollment) error {
+			assert.Equal(t, "completed", e.EnrollmentStatus)
+			assert.Equal(t, "A+", *e.Grade)
+			assert.Equal(t, 9.0, *e.GradePoints)
+			return nil
+		})
+		m.scaleRepo.EXPECT().GetForStudent(gomock.Any(), enrollment.StudentID).Return(testGradingScale, nil).Times(2)
+		m.enrollRepo.EXPECT().ListGradedAttempts(gomock.Any(), enrollment.StudentID).Return(nil, nil)
+		m.studentRepo.EXPECT().UpdateAcademicRecord(gomock.Any(), gomock.Any()).Return(nil)
+		m.producer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, amendment.CourseID.String(), gomock.Any()).Return(nil)
+
+		err := service.ApproveAmendment(context.Background(), amendment.AmendmentID, nil, admin)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AmendmentApproved, amendment.Status)
+		assert.Equal(t, admin.UserID, *amendment.ReviewedBy)
+	})
+
+	t.Run("Own Amendment", func(t *testing.T) {
+		amendment := &domain.GradeAmendment{AmendmentID: uuid.New(), CourseID: uuid.New(), Status: domain.AmendmentPending, RequestedBy: admin.UserID}
+
+		m.repo.EXPECT().GetAmendment(gomock.Any(), amendment.AmendmentID).Return(amendment, nil)
+		m.courseRepo.EXPECT().GetByID(gomock.Any(), amendment.CourseID).Return(&domain.Course{CourseID: amendment.CourseID}, nil)
+
+		err := service.ApproveAmendment(context.Background(), amendment.AmendmentID, nil, admin)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+
+	t.Run("Already Reviewed", func(t *testing.T) {
+		amendment := &domain.GradeAmendment{AmendmentID: uuid.New(), Status: domain.AmendmentRejected}
+
+		m.repo.EXPECT().GetAmendment(gomock.Any(), amendment.AmendmentID).Return(amendment, nil)
+
+		err := service.ApproveAmendment(context.Background(), amendment.AmendmentID, nil, admin)
+		assert.Equal(t, domain.ErrAmendmentReviewed, err)
+	})
+}
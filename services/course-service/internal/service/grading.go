@@ -0,0 +1,108 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// scaleGrade looks up a letter grade on scale, ignoring case
+func scaleGrade(scale *domain.GradingScale, grade string) (domain.GradeMapping, bool) {
+	grade = strings.ToUpper(strings.TrimSpace(grade))
+	for _, g := range scale.Grades {
+		if g.Grade == grade {
+			return g, true
+		}
+	}
+	return domain.GradeMapping{}, false
+}
+
+// gradedStatus is the status of an enrollment graded with the grade points on
+// the student's scale
+func gradedStatus(gradePoints float64, scale *domain.GradingScale) string {
+	if gradePoints < scale.PassGradePoints {
+		return "failed"
+	}
+	return "completed"
+}
+
+// academicRecord computes the semester GPAs, CGPA and earned credits of a
+// student from their graded attempts, oldest first. GPAs are weighted by
+// subject credits. Every attempt counts towards the GPA of its semester, while
+// the CGPA and earned credits count a single attempt per subject, chosen by
+// the repeat policy of scale.
+func academicRecord(studentID uuid.UUID, attempts []*domain.GradedAttempt, scale *domain.GradingScale) *domain.AcademicRecord {
+	semesters := make(map[uuid.UUID]*gpaTotals)
+	var semesterOrder []uuid.UUID
+	counted := make(map[uuid.UUID]*domain.GradedAttempt)
+	var subjectOrder []uuid.UUID
+
+	for _, a := range attempts {
+		totals, ok := semesters[a.SemesterID]
+		if !ok {
+			totals = &gpaTotals{}
+			semesters[a.SemesterID] = totals
+			semesterOrder = append(semesterOrder, a.SemesterID)
+		}
+		totals.add(a, scale)
+
+		// Attempts are oldest first, so later attempts win ties
+		previous, ok := counted[a.SubjectID]
+		if !ok {
+			subjectOrder = append(subjectOrder, a.SubjectID)
+		}
+		if !ok || scale.RepeatPolicy == domain.RepeatPolicyLatest || a.GradePoints >= previous.GradePoints {
+			counted[a.SubjectID] = a
+		}
+	}
+
+	record := &domain.AcademicRecord{
+		StudentID: studentID,
+		Semesters: make([]domain.SemesterGPA, 0, len(semesterOrder)),
+	}
+	for _, semesterID := range semesterOrder {
+		totals := semesters[semesterID]
+		record.Semesters = append(record.Semesters, domain.SemesterGPA{
+			StudentID:        studentID,
+			SemesterID:       semesterID,
+			GPA:              totals.gpa(),
+			CreditsAttempted: totals.attempted,
+			CreditsEarned:    totals.earned,
+		})
+	}
+
+	var cumulative gpaTotals
+	for _, subjectID := range subjectOrder {
+		cumulative.add(counted[subjectID], scale)
+	}
+	record.CGPA = cumulative.gpa()
+	record.TotalCreditsEarned = cumulative.earned
+	return record
+}
+
+// gpaTotals sums the credit weighted grade points of graded attempts
+type gpaTotals struct {
+	weightedPoints float64
+	attempted      int
+	earned         int
+}
+
+func (t *gpaTotals) add(attempt *domain.GradedAttempt, scale *domain.GradingScale) {
+	t.weightedPoints += attempt.GradePoints * float64(attempt.Credits)
+	t.attempted += attempt.Credits
+	if attempt.GradePoints >= scale.PassGradePoints {
+		t.earned += attempt.Credits
+	}
+}
+
+// gpa returns the credit weighted average grade points rounded to two
+// decimals, or nil if no credits were attempted
+func (t *gpaTotals) gpa() *float64 {
+	if t.attempted == 0 {
+		return nil
+	}
+	gpa := math.Round(t.weightedPoints/float64(t.attempted)*100) / 100
+	return &gpa
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type gradingScaleService struct {
+	repo domain.GradingScaleRepository
+}
+
+func NewGradingScaleService(repo domain.GradingScaleRepository) domain.GradingScaleService {
+	return &gradingScaleService{repo: repo}
+}
+
+func (s *gradingScaleService) CreateGradingScale(ctx context.Context, scale *domain.GradingScale) error {
+	if err := validateGradingScale(scale); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, scale)
+}
+
+func (s *gradingScaleService) GetGradingScale(ctx context.Context, id uuid.UUID) (*domain.GradingScale, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// UpdateGradingScale changes a grading scale. Grades already recorded keep
+// the grade points they were given.
+func (s *gradingScaleService) UpdateGradingScale(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	scale, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Apply updates
+	if name, ok := updates["scale_name"].(string); ok {
+		scale.ScaleName = name
+	}
+	if maxPoints, ok := updates["max_grade_points"].(float64); ok {
+		scale.MaxGradePoints = maxPoints
+	}
+	if passPoints, ok := updates["pass_grade_points"].(float64); ok {
+		scale.PassGradePoints = passPoints
+	}
+	if policy, ok := updates["repeat_policy"].(string); ok {
+		scale.RepeatPolicy = policy
+	}
+	if isDefault, ok := updates["is_default"].(bool); ok {
+		// There is always a default scale, it changes by making another scale the default
+		if scale.IsDefault && !isDefault {
+			return fmt.Errorf("%w: make another scale the default instead", domain.ErrInvalidGradingScale)
+		}
+		scale.IsDefault = isDefault
+	}
+	if grades, ok := updates["grades"].([]domain.GradeMapping); ok {
+		scale.Grades = grades
+	}
+
+	if err := validateGradingScale(scale); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, scale)
+}
+
+func (s *gradingScaleService) DeleteGradingScale(ctx context.Context, id uuid.UUID) error {
+	scale, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if scale.IsDefault {
+		return domain.ErrGradingScaleInUse
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *gradingScaleService) ListGradingScales(ctx context.Context) ([]*domain.GradingScale, error) {
+	return s.repo.List(ctx)
+}
+
+// validateGradingScale checks that the grades of scale are unique and within
+// its maximum grade points, and normalizes them to upper case
+func validateGradingScale(scale *domain.GradingScale) error {
+	if scale.RepeatPolicy == "" {
+		scale.RepeatPolicy = domain.RepeatPolicyBest
+	}
+	if scale.RepeatPolicy != domain.RepeatPolicyBest && scale.RepeatPolicy != domain.RepeatPolicyLatest {
+		return fmt.Errorf("%w: unknown repeat policy %q", domain.ErrInvalidGradingScale, scale.RepeatPolicy)
+	}
+	if scale.MaxGradePoints <= 0 || scale.MaxGradePoints > 10 {
+		return fmt.Errorf("%w: maximum grade points must be above 0 and at most 10", domain.ErrInvalidGradingScale)
+	}
+	if scale.PassGradePoints < 0 || scale.PassGradePoints > scale.MaxGradePoints {
+		return fmt.Errorf("%w: pass grade points must be between 0 and the maximum", domain.ErrInvalidGradingScale)
+	}
+	if len(scale.Grades) == 0 {
+		return fmt.Errorf("%w: at least one grade is required", domain.ErrInvalidGradingScale)
+	}
+
+	seen := make(map[string]bool, len(scale.Grades))
+	for i := range scale.Grades {
+		g := &scale.Grades[i]
+		g.Grade = strings.ToUpper(strings.TrimSpace(g.Grade))
+		if g.Grade == "" || len(g.Grade) > 5 {
+			return fmt.Errorf("%w: grades must have 1 to 5 characters", domain.ErrInvalidGradingScale)
+		}
+		if seen[g.Grade] {
+			return fmt.Errorf("%w: grade %s is listed twice", domain.ErrInvalidGradingScale, g.Grade)
+		}
+		seen[g.Grade] = true
+		if g.GradePoints < 0 || g.GradePoints > scale.MaxGradePoints {
+			return fmt.Errorf("%w: grade %s must have between 0 and %.2f grade points", domain.ErrInvalidGradingScale, g.Grade, scale.MaxGradePoints)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGradingScaleService_CreateGradingScale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGradingScaleRepository(ctrl)
+	service := NewGradingScaleService(mockRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		scale := &domain.GradingScale{
+			ScaleName:       "4-point",
+			MaxGradePoints:  4,
+			PassGradePoints: 1,
+			Grades:          []domain.GradeMapping{{Grade: " a ", GradePoints: 4}, {Grade: "f", GradePoints: 0}},
+		}
+
+		mockRepo.EXPECT().Create(gomock.Any(), scale).Return(nil)
+
+		err := service.CreateGradingScale(context.Background(), scale)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RepeatPolicyBest, scale.RepeatPolicy)
+		assert.Equal(t, "A", scale.Grades[0].Grade)
+		assert.Equal(t, "F", scale.Grades[1].Grade)
+	})
+
+	t.Run("Duplicate Grade", func(t *testing.T) {
+		scale := &domain.GradingScale{
+			ScaleName:      "4-point",
+			MaxGradePoints: 4,
+			Grades:         []domain.GradeMapping{{Grade: "A", GradePoints: 4}, {Grade: "a", GradePoints: 3.7}},
+		}
+
+		err := service.CreateGradingScale(context.Background(), scale)
+		assert.ErrorIs(t, err, domain.ErrInvalidGradingScale)
+	})
+
+	t.Run("Grade Points Above Maximum", func(t *testing.T) {
+		scale := &domain.GradingScale{
+			ScaleName:      "4-point",
+			MaxGradePoints: 4,
+			Grades:         []domain.GradeMapping{{Grade: "A", GradePoints: 10}},
+		}
+
+		err := service.CreateGradingScale(context.Background(), scale)
+		assert.ErrorIs(t, err, domain.ErrInvalidGradingScale)
+	})
+
+	t.Run("Pass Above Maximum", func(t *testing.T) {
+		scale := &domain.GradingScale{
+			ScaleName:       "4-point",
+			MaxGradePoints:  4,
+			PassGradePoints: 5,
+			Grades:          []domain.GradeMapping{{Grade: "A", GradePoints: 4}},
+		}
+
+		err := service.CreateGradingScale(context.Background(), scale)
+		assert.ErrorIs(t, err, domain.ErrInvalidGradingScale)
+	})
+}
+
+func TestGradingScaleService_UpdateGradingScale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGradingScaleRepository(ctrl)
+	service := NewGradingScaleService(mockRepo)
+
+	t.Run("Unset Default", func(t *testing.T) {
+		scaleID := uuid.New()
+		scale := &domain.GradingScale{ScaleID: scaleID, IsDefault: true}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), scaleID).Return(scale, nil)
+
+		err := service.UpdateGradingScale(context.Background(), scaleID, map[string]interface{}{"is_default": false})
+		assert.ErrorIs(t, err, domain.ErrInvalidGradingScale)
+	})
+
+	t.Run("Change Repeat Policy", func(t *testing.T) {
+		scaleID := uuid.New()
+		scale := &domain.GradingScale{
+			ScaleID:         scaleID,
+			MaxGradePoints:  10,
+			PassGradePoints: 4,
+			RepeatPolicy:    domain.RepeatPolicyBest,
+			Grades:          []domain.GradeMapping{{Grade: "O", GradePoints: 10}},
+		}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), scaleID).Return(scale, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), scale).Return(nil)
+
+		err := service.UpdateGradingScale(context.Background(), scaleID, map[string]interface{}{"repeat_policy": domain.RepeatPolicyLatest})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RepeatPolicyLatest, scale.RepeatPolicy)
+	})
+}
+
+func TestGradingScaleService_DeleteGradingScale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGradingScaleRepository(ctrl)
+	service := NewGradingScaleService(mockRepo)
+
+	t.Run("Default Scale", func(t *testing.T) {
+		scaleID := uuid.New()
+		mockRepo.EXPECT().GetByID(gomock.Any(), scaleID).Return(&domain.GradingScale{ScaleID: scaleID, IsDefault: true}, nil)
+
+		err := service.DeleteGradingScale(context.Background(), scaleID)
+		assert.ErrorIs(t, err, domain.ErrGradingScaleInUse)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		scaleID := uuid.New()
+		mockRepo.EXPECT().GetByID(gomock.Any(), scaleID).Return(&domain.GradingScale{ScaleID: scaleID}, nil)
+		mockRepo.EXPECT().Delete(gomock.Any(), scaleID).Return(nil)
+
+		err := service.DeleteGradingScale(context.Background(), scaleID)
+		assert.NoError(t, err)
+	})
+}
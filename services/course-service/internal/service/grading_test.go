@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcademicRecord(t *testing.T) {
+	studentID := uuid.New()
+	fall, spring := uuid.New(), uuid.New()
+	maths, physics := uuid.New(), uuid.New()
+
+	// Physics is failed in the fall and repeated in the spring, where maths is
+	// repeated for a lower grade
+	attempts := []*domain.GradedAttempt{
+		{SubjectID: maths, SemesterID: fall, Credits: 4, GradePoints: 9},
+		{SubjectID: physics, SemesterID: fall, Credits: 2, GradePoints: 0},
+		{SubjectID: physics, SemesterID: spring, Credits: 2, GradePoints: 6},
+		{SubjectID: maths, SemesterID: spring, Credits: 4, GradePoints: 5},
+	}
+
+	t.Run("Best Attempt", func(t *testing.T) {
+		scale := &domain.GradingScale{PassGradePoints: 4, RepeatPolicy: domain.RepeatPolicyBest}
+
+		record := academicRecord(studentID, attempts, scale)
+
+		assert.Len(t, record.Semesters, 2)
+		assert.Equal(t, fall, record.Semesters[0].SemesterID)
+		assert.Equal(t, 6.0, *record.Semesters[0].GPA)
+		assert.Equal(t, 6, record.Semesters[0].CreditsAttempted)
+		assert.Equal(t, 4, record.Semesters[0].CreditsEarned)
+		assert.Equal(t, 5.33, *record.Semesters[1].GPA)
+		assert.Equal(t, 6, record.Semesters[1].CreditsEarned)
+
+		// Maths counts with 9 and physics with 6
+		assert.Equal(t, 8.0, *record.CGPA)
+		assert.Equal(t, 6, record.TotalCreditsEarned)
+	})
+
+	t.Run("Latest Attempt", func(t *testing.T) {
+		scale := &domain.GradingScale{PassGradePoints: 4, RepeatPolicy: domain.RepeatPolicyLatest}
+
+		record := academicRecord(studentID, attempts, scale)
+
+		// Maths counts with 5 and physics with 6
+		assert.Equal(t, 5.33, *record.CGPA)
+		assert.Equal(t, 6, record.TotalCreditsEarned)
+	})
+
+	t.Run("No Attempts", func(t *testing.T) {
+		scale := &domain.GradingScale{PassGradePoints: 4, RepeatPolicy: domain.RepeatPolicyBest}
+
+		record := academicRecord(studentID, nil, scale)
+
+		assert.Nil(t, record.CGPA)
+		assert.Equal(t, 0, record.TotalCreditsEarned)
+		assert.Empty(t, record.Semesters)
+	})
+}
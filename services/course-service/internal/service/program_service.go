@@ -4,17 +4,20 @@ import (
 	"context"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type programService struct {
-	repo     domain.ProgramRepository
-	deptRepo domain.DepartmentRepository
-	producer domain.EventProducer
+	repo       domain.ProgramRepository
+	deptRepo   domain.DepartmentRepository
+	scaleRepo  domain.GradingScaleRepository
+	transactor domain.Transactor
+	producer   domain.EventProducer
 }
 
-func NewProgramService(repo domain.ProgramRepository, deptRepo domain.DepartmentRepository, producer domain.EventProducer) domain.ProgramService {
-	return &programService{repo: repo, deptRepo: deptRepo, producer: producer}
+func NewProgramService(repo domain.ProgramRepository, deptRepo domain.DepartmentRepository, scaleRepo domain.GradingScaleRepository, transactor domain.Transactor, producer domain.EventProducer) domain.ProgramService {
+	return &programService{repo: repo, deptRepo: deptRepo, scaleRepo: scaleRepo, transactor: transactor, producer: producer}
 }
 
 func (s *programService) CreateProgram(ctx context.Context, program *domain.Program) error {
@@ -24,22 +27,25 @@ func (s *programService) CreateProgram(ctx context.Context, program *domain.Prog
 		return err
 	}
 
-	program.IsActive = true
-	if err := s.repo.Create(ctx, program); err != nil {
-		return err
+	// Validate grading scale exists
+	if program.GradingScaleID != nil {
+		if _, err := s.scaleRepo.GetByID(ctx, *program.GradingScaleID); err != nil {
+			return err
+		}
 	}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.program.created", program.ProgramID.String(), map[string]interface{}{
-			"program_id":    program.ProgramID,
-			"program_name":  program.ProgramName,
-			"program_code":  program.ProgramCode,
-			"department_id": program.DepartmentID,
-		})
-	}
+	program.IsActive = true
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, program); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, program.ProgramID.String(), programEvent(models.EventProgramCreated, program))
+	})
 }
 
 func (s *programService) GetProgram(ctx context.Context, id uuid.UUID) (*domain.ProgramWithDepartment, error) {
@@ -83,38 +89,54 @@ func (s *programService) UpdateProgram(ctx context.Context, id uuid.UUID, update
 	if isActive, ok := updates["is_active"].(bool); ok {
 		prog.IsActive = isActive
 	}
-
-	if err := s.repo.Update(ctx, prog); err != nil {
-		return err
+	if scaleID, ok := updates["grading_scale_id"]; ok {
+		if scaleID == nil {
+			prog.GradingScaleID = nil
+		} else if id, ok := scaleID.(uuid.UUID); ok {
+			if _, err := s.scaleRepo.GetByID(ctx, id); err != nil {
+				return err
+			}
+			prog.GradingScaleID = &id
+		}
 	}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.program.updated", prog.ProgramID.String(), map[string]interface{}{
-			"program_id":   prog.ProgramID,
-			"program_name": prog.ProgramName,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, prog); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, prog.ProgramID.String(), programEvent(models.EventProgramUpdated, prog))
+	})
 }
 
 func (s *programService) DeleteProgram(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.program.deleted", id.String(), map[string]interface{}{
-			"program_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewProgramEvent(models.EventProgramDeleted, id))
+	})
 }
 
 func (s *programService) ListPrograms(ctx context.Context, filter domain.ProgramFilter, page, limit int) ([]*domain.ProgramWithDepartment, int64, error) {
 	offset := (page - 1) * limit
 	return s.repo.List(ctx, filter, limit, offset)
 }
+
+// programEvent creates a program event describing program
+func programEvent(eventType models.EventType, program *domain.Program) *models.ProgramEvent {
+	event := models.NewProgramEvent(eventType, program.ProgramID)
+	event.ProgramName = program.ProgramName
+	event.ProgramCode = program.ProgramCode
+	event.DepartmentID = program.DepartmentID
+	return event
+}
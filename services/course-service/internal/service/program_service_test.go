@@ -18,9 +18,10 @@ func TestProgramService_CreateProgram(t *testing.T) {
 
 	mockRepo := mocks.NewMockProgramRepository(ctrl)
 	mockDeptRepo := mocks.NewMockDepartmentRepository(ctrl)
+	mockScaleRepo := mocks.NewMockGradingScaleRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewProgramService(mockRepo, mockDeptRepo, mockProducer)
+	service := NewProgramService(mockRepo, mockDeptRepo, mockScaleRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		programID := uuid.New()
@@ -44,7 +45,7 @@ func TestProgramService_CreateProgram(t *testing.T) {
 			assert.True(t, p.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.program.created", programID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, programID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateProgram(context.Background(), program)
 		assert.NoError(t, err)
@@ -63,6 +64,21 @@ func TestProgramService_CreateProgram(t *testing.T) {
 		assert.ErrorIs(t, err, domain.ErrDepartmentNotFound)
 	})
 
+	t.Run("Grading Scale Not Found", func(t *testing.T) {
+		scaleID := uuid.New()
+		program := &domain.Program{
+			ProgramID:      uuid.New(),
+			DepartmentID:   uuid.New(),
+			GradingScaleID: &scaleID,
+		}
+
+		mockDeptRepo.EXPECT().GetByID(gomock.Any(), program.DepartmentID).Return(&domain.Department{DepartmentID: program.DepartmentID}, nil)
+		mockScaleRepo.EXPECT().GetByID(gomock.Any(), scaleID).Return(nil, domain.ErrGradingScaleNotFound)
+
+		err := service.CreateProgram(context.Background(), program)
+		assert.ErrorIs(t, err, domain.ErrGradingScaleNotFound)
+	})
+
 	t.Run("Repository Error", func(t *testing.T) {
 		programID := uuid.New()
 		deptID := uuid.New()
@@ -89,7 +105,7 @@ func TestProgramService_GetProgram(t *testing.T) {
 
 	mockRepo := mocks.NewMockProgramRepository(ctrl)
 
-	service := NewProgramService(mockRepo, nil, nil)
+	service := NewProgramService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		programID := uuid.New()
@@ -135,7 +151,7 @@ func TestProgramService_UpdateProgram(t *testing.T) {
 	mockRepo := mocks.NewMockProgramRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewProgramService(mockRepo, nil, mockProducer)
+	service := NewProgramService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		programID := uuid.New()
@@ -158,7 +174,7 @@ func TestProgramService_UpdateProgram(t *testing.T) {
 			assert.Equal(t, 5, p.DurationYears)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.program.updated", programID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, programID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateProgram(context.Background(), programID, updates)
 		assert.NoError(t, err)
@@ -195,7 +211,7 @@ func TestProgramService_UpdateProgram(t *testing.T) {
 			assert.False(t, p.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.program.updated", programID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, programID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateProgram(context.Background(), programID, updates)
 		assert.NoError(t, err)
@@ -209,13 +225,13 @@ func TestProgramService_DeleteProgram(t *testing.T) {
 	mockRepo := mocks.NewMockProgramRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewProgramService(mockRepo, nil, mockProducer)
+	service := NewProgramService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		programID := uuid.New()
 
 		mockRepo.EXPECT().Delete(gomock.Any(), programID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.program.deleted", programID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, programID.String(), gomock.Any()).Return(nil)
 
 		err := service.DeleteProgram(context.Background(), programID)
 		assert.NoError(t, err)
@@ -237,7 +253,7 @@ func TestProgramService_ListPrograms(t *testing.T) {
 
 	mockRepo := mocks.NewMockProgramRepository(ctrl)
 
-	service := NewProgramService(mockRepo, nil, nil)
+	service := NewProgramService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		deptID := uuid.New()
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"go.uber.org/zap"
+)
+
+// rolloverLease is how long a claimed rollover stays locked to the instance
+// running it without progress. A rollover whose lock expired, such as one left
+// running by a stopped instance, is resumed by the next runner to claim it.
+const rolloverLease = 5 * time.Minute
+
+// SemesterRolloverRunner runs the semester rollovers started through the
+// semester rollover service. Each step commits with the progress it made:
+// courses are closed once, and every student is promoted or flagged once in
+// the transaction recording them in the rollover ledger. An interrupted
+// rollover is therefore resumed where it stopped.
+type SemesterRolloverRunner struct {
+	repo        domain.SemesterRolloverRepository
+	semRepo     domain.SemesterRepository
+	studentRepo domain.StudentRepository
+	transactor  domain.Transactor
+	producer    domain.EventProducer
+}
+
+func NewSemesterRolloverRunner(
+	repo domain.SemesterRolloverRepository,
+	semRepo domain.SemesterRepository,
+	studentRepo domain.StudentRepository,
+	transactor domain.Transactor,
+	producer domain.EventProducer,
+) *SemesterRolloverRunner {
+	return &SemesterRolloverRunner{
+		repo:        repo,
+		semRepo:     semRepo,
+		studentRepo: studentRepo,
+		transactor:  transactor,
+		producer:    producer,
+	}
+}
+
+// Start runs the pending rollovers every interval until ctx is done
+func (r *SemesterRolloverRunner) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.RunPending(ctx); err != nil {
+				logger.Error("Failed to run semester rollover", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RunPending claims and runs rollovers until none is left to run
+func (r *SemesterRolloverRunner) RunPending(ctx context.Context) error {
+	for {
+		rollover, err := r.repo.Claim(ctx, rolloverLease)
+		if err == domain.ErrRolloverNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.Run(ctx, rollover); err != nil {
+			return fmt.Errorf("rollover %s: %w", rollover.RolloverID, err)
+		}
+	}
+}
+
+// Run completes the courses of the semester of a claimed rollover, promotes or
+// flags its students and makes the next semester current
+func (r *SemesterRolloverRunner) Run(ctx context.Context, rollover *domain.SemesterRollover) error {
+	// Students may have been enrolled again since the rollover was started
+	ungraded, err := r.repo.ListUngradedCourses(ctx, rollover.SemesterID)
+	if err != nil {
+		return err
+	}
+	if len(ungraded) > 0 {
+		return r.fail(ctx, rollover, fmt.Sprintf("%s: %d courses", domain.ErrUngradedEnrollments, len(ungraded)))
+	}
+
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		courses, dropped, err := r.repo.CloseCourses(ctx, rollover.SemesterID)
+		if err != nil {
+			return err
+		}
+		rollover.CoursesCompleted += courses
+		rollover.EnrollmentsDropped += dropped
+		return r.save(ctx, rollover)
+	})
+	if err != nil {
+		return err
+	}
+
+	candidates, err := r.repo.ListCandidates(ctx, rollover.SemesterID)
+	if err != nil {
+		return err
+	}
+	for _, c := range candidates {
+		if err := r.rollStudent(ctx, rollover, c); err != nil {
+			return err
+		}
+	}
+
+	return r.finish(ctx, rollover)
+}
+
+// rollStudent records the outcome of the rollover for a student, promoting
+// them unless they are flagged
+func (r *SemesterRolloverRunner) rollStudent(ctx context.Context, rollover *domain.SemesterRollover, candidate *domain.RolloverCandidate) error {
+	outcome := rolloverOutcome(candidate)
+
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		recorded, err := r.repo.RecordStudent(ctx, rollover, &outcome)
+		if err != nil || !recorded {
+			return err
+		}
+
+		if outcome.ToSemester == nil {
+			rollover.StudentsFlagged++
+			return r.save(ctx, rollover)
+		}
+
+		if err := r.studentRepo.UpdateSemester(ctx, candidate.StudentID, *outcome.ToSemester); err != nil {
+			return err
+		}
+		rollover.StudentsPromoted++
+		if err := r.save(ctx, rollover); err != nil {
+			return err
+		}
+
+		// Publish event
+		if r.producer == nil {
+			return nil
+		}
+		event := models.NewStudentEvent(models.EventStudentPromoted, candidate.StudentID)
+		event.RegistrationNumber = candidate.RegistrationNumber
+		event.NewSemester = *outcome.ToSemester
+		event.CGPA = candidate.CGPA
+		return r.producer.PublishEvent(ctx, domain.CourseEventsTopic, candidate.StudentID.String(), event)
+	})
+}
+
+// finish makes the next semester current and publishes the summary of the
+// rollover
+func (r *SemesterRolloverRunner) finish(ctx context.Context, rollover *domain.SemesterRollover) error {
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.semRepo.SetCurrent(ctx, rollover.NextSemesterID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		rollover.Status = domain.RolloverCompleted
+		rollover.CompletedAt = &now
+		rollover.LockedUntil = nil
+		if err := r.repo.Update(ctx, rollover); err != nil {
+			return err
+		}
+
+		// Publish events
+		if r.producer == nil {
+			return nil
+		}
+		nextID := rollover.NextSemesterID
+		err := r.producer.PublishEvent(ctx, domain.CourseEventsTopic, nextID.String(), models.NewSemesterEvent(models.EventCurrentSemesterChanged, nextID))
+		if err != nil {
+			return err
+		}
+		event := models.NewSemesterRolloverEvent(rollover.RolloverID, rollover.SemesterID)
+		event.ActorID = rollover.RequestedBy
+		event.NextSemesterID = nextID
+		event.CoursesCompleted = rollover.CoursesCompleted
+		event.EnrollmentsDropped = rollover.EnrollmentsDropped
+		event.StudentsPromoted = rollover.StudentsPromoted
+		event.StudentsFlagged = rollover.StudentsFlagged
+		return r.producer.PublishEvent(ctx, domain.CourseEventsTopic, rollover.SemesterID.String(), event)
+	})
+}
+
+// fail stops a rollover that cannot complete. It can be started again once the
+// cause is fixed.
+func (r *SemesterRolloverRunner) fail(ctx context.Context, rollover *domain.SemesterRollover, reason string) error {
+	logger.Warn("Semester rollover failed",
+		zap.String("rollover_id", rollover.RolloverID.String()),
+		zap.String("reason", reason),
+	)
+	rollover.Status = domain.RolloverFailed
+	rollover.Error = &reason
+	rollover.LockedUntil = nil
+	return r.repo.Update(ctx, rollover)
+}
+
+// save stores the progress of a rollover and extends its lock
+func (r *SemesterRolloverRunner) save(ctx context.Context, rollover *domain.SemesterRollover) error {
+	lockedUntil := time.Now().Add(rolloverLease)
+	rollover.LockedUntil = &lockedUntil
+	return r.repo.Update(ctx, rollover)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSemesterRolloverRunner_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSemesterRolloverRepository(ctrl)
+	mockSemRepo := mocks.NewMockSemesterRepository(ctrl)
+	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+	runner := NewSemesterRolloverRunner(mockRepo, mockSemRepo, mockStudentRepo, newTestTransactor(ctrl), mockProducer)
+
+	newRollover := func() *domain.SemesterRollover {
+		return &domain.SemesterRollover{
+			RolloverID:     uuid.New(),
+			SemesterID:     uuid.New(),
+			NextSemesterID: uuid.New(),
+			Status:         domain.RolloverRunning,
+			RequestedBy:    uuid.New(),
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		rollover := newRollover()
+		cgpa := 7.5
+		promoted := &domain.RolloverCandidate{StudentID: uuid.New(), CurrentSemester: 3, CGPA: &cgpa, IsActive: true, ProgramSemesters: 8, PassGradePoints: 4}
+		flagged := &domain.RolloverCandidate{StudentID: uuid.New(), CurrentSemester: 8, IsActive: true, ProgramSemesters: 8, PassGradePoints: 4}
+		// Recorded by another runner in the meantime
+		recorded := &domain.RolloverCandidate{StudentID: uuid.New(), CurrentSemester: 3, IsActive: true, ProgramSemesters: 8}
+
+		mockRepo.EXPECT().ListUngradedCourses(gomock.Any(), rollover.SemesterID).Return(nil, nil)
+		mockRepo.EXPECT().CloseCourses(gomock.Any(), rollover.SemesterID).Return(5, 2, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), rollover).Return(nil).Times(4)
+		mockRepo.EXPECT().ListCandidates(gomock.Any(), rollover.SemesterID).Return([]*domain.RolloverCandidate{promoted, flagged, recorded}, nil)
+
+		mockRepo.EXPECT().RecordStudent(gomock.Any(), rollover, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, rollover *domain.SemesterRollover, student *domain.RolloverStudent) (bool, error) {
+				assert.Equal(t, 4, *student.ToSemester)
+				return true, nil
+			})
+		mockStudentRepo.EXPECT().UpdateSemester(gomock.Any(), promoted.StudentID, 4).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, promoted.StudentID.String(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+				e := event.(*models.StudentEvent)
+				assert.Equal(t, models.EventStudentPromoted, e.EventType)
+				assert.Equal(t, &cgpa, e.CGPA)
+				return nil
+			})
+
+		mockRepo.EXPECT().RecordStudent(gomock.Any(), rollover, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, rollover *domain.SemesterRollover, student *domain.RolloverStudent) (bool, error) {
+				assert.Equal(t, domain.RolloverFlagFinalSemester, student.FlagReason)
+				return true, nil
+			})
+		mockRepo.EXPECT().RecordStudent(gomock.Any(), rollover, gomock.Any()).Return(false, nil)
+
+		mockSemRepo.EXPECT().SetCurrent(gomock.Any(), rollover.NextSemesterID).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, rollover.NextSemesterID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, rollover.SemesterID.String(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+				e := event.(*models.SemesterRolloverEvent)
+				assert.Equal(t, models.EventSemesterRolledOver, e.EventType)
+				assert.Equal(t, rollover.RequestedBy, e.ActorID)
+				assert.Equal(t, 5, e.CoursesCompleted)
+				assert.Equal(t, 2, e.EnrollmentsDropped)
+				assert.Equal(t, 1, e.StudentsPromoted)
+				assert.Equal(t, 1, e.StudentsFlagged)
+				return nil
+			})
+
+		err := runner.Run(context.Background(), rollover)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RolloverCompleted, rollover.Status)
+		assert.NotNil(t, rollover.CompletedAt)
+		assert.Nil(t, rollover.LockedUntil)
+	})
+
+	t.Run("Resumed", func(t *testing.T) {
+		// Courses were closed and every student recorded before the rollover stopped
+		rollover := newRollover()
+		rollover.CoursesCompleted = 5
+		rollover.StudentsPromoted = 40
+
+		mockRepo.EXPECT().ListUngradedCourses(gomock.Any(), rollover.SemesterID).Return(nil, nil)
+		mockRepo.EXPECT().CloseCourses(gomock.Any(), rollover.SemesterID).Return(0, 0, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), rollover).Return(nil).Times(2)
+		mockRepo.EXPECT().ListCandidates(gomock.Any(), rollover.SemesterID).Return(nil, nil)
+		mockSemRepo.EXPECT().SetCurrent(gomock.Any(), rollover.NextSemesterID).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		err := runner.Run(context.Background(), rollover)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, rollover.CoursesCompleted)
+		assert.Equal(t, 40, rollover.StudentsPromoted)
+	})
+
+	t.Run("Ungraded Enrollments", func(t *testing.T) {
+		rollover := newRollover()
+
+		mockRepo.EXPECT().ListUngradedCourses(gomock.Any(), rollover.SemesterID).
+			Return([]domain.UngradedCourse{{CourseID: uuid.New(), UngradedStudents: 1}}, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), rollover).Return(nil)
+
+		err := runner.Run(context.Background(), rollover)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RolloverFailed, rollover.Status)
+		assert.NotNil(t, rollover.Error)
+	})
+}
+
+func TestSemesterRolloverRunner_RunPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSemesterRolloverRepository(ctrl)
+	runner := NewSemesterRolloverRunner(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
+
+	t.Run("Nothing To Run", func(t *testing.T) {
+		mockRepo.EXPECT().Claim(gomock.Any(), rolloverLease).Return(nil, domain.ErrRolloverNotFound)
+
+		err := runner.RunPending(context.Background())
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type semesterRolloverService struct {
+	repo    domain.SemesterRolloverRepository
+	semRepo domain.SemesterRepository
+}
+
+func NewSemesterRolloverService(repo domain.SemesterRolloverRepository, semRepo domain.SemesterRepository) domain.SemesterRolloverService {
+	return &semesterRolloverService{repo: repo, semRepo: semRepo}
+}
+
+// PreviewRollover reports what a rollover of the semester would do, without
+// changing anything
+func (s *semesterRolloverService) PreviewRollover(ctx context.Context, semesterID, nextSemesterID uuid.UUID) (*domain.RolloverPreview, error) {
+	if err := s.checkSemesters(ctx, semesterID, nextSemesterID); err != nil {
+		return nil, err
+	}
+
+	ungraded, err := s.repo.ListUngradedCourses(ctx, semesterID)
+	if err != nil {
+		return nil, err
+	}
+	courses, waitlisted, err := s.repo.CountClosable(ctx, semesterID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.repo.ListCandidates(ctx, semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.RolloverPreview{
+		SemesterID:        semesterID,
+		NextSemesterID:    nextSemesterID,
+		Ready:             len(ungraded) == 0,
+		UngradedCourses:   ungraded,
+		CoursesToComplete: courses,
+		EnrollmentsToDrop: waitlisted,
+		PromotedStudents:  []domain.RolloverStudent{},
+		FlaggedStudents:   []domain.RolloverStudent{},
+	}
+	for _, c := range candidates {
+		outcome := rolloverOutcome(c)
+		if outcome.ToSemester == nil {
+			preview.FlaggedStudents = append(preview.FlaggedStudents, outcome)
+		} else {
+			preview.PromotedStudents = append(preview.PromotedStudents, outcome)
+		}
+	}
+	return preview, nil
+}
+
+// StartRollover queues a rollover of the semester for the rollover runner.
+// It is rejected while students of the semester are enrolled without a grade.
+func (s *semesterRolloverService) StartRollover(ctx context.Context, semesterID, nextSemesterID, requestedBy uuid.UUID) (*domain.SemesterRollover, error) {
+	if err := s.checkSemesters(ctx, semesterID, nextSemesterID); err != nil {
+		return nil, err
+	}
+
+	latest, err := s.repo.GetLatest(ctx, semesterID)
+	switch {
+	case err == domain.ErrRolloverNotFound:
+	case err != nil:
+		return nil, err
+	case latest.Status == domain.RolloverCompleted:
+		return nil, domain.ErrSemesterRolledOver
+	case latest.Status != domain.RolloverFailed:
+		return nil, domain.ErrRolloverInProgress
+	}
+
+	ungraded, err := s.repo.ListUngradedCourses(ctx, semesterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ungraded) > 0 {
+		var students int
+		for _, c := range ungraded {
+			students += c.UngradedStudents
+		}
+		return nil, fmt.Errorf("%w: %d students in %d courses", domain.ErrUngradedEnrollments, students, len(ungraded))
+	}
+
+	rollover := &domain.SemesterRollover{
+		SemesterID:     semesterID,
+		NextSemesterID: nextSemesterID,
+		Status:         domain.RolloverPending,
+		RequestedBy:    requestedBy,
+	}
+	if err := s.repo.Create(ctx, rollover); err != nil {
+		return nil, err
+	}
+	return rollover, nil
+}
+
+// GetRollover returns the last rollover started for the semester
+func (s *semesterRolloverService) GetRollover(ctx context.Context, semesterID uuid.UUID) (*domain.SemesterRollover, error) {
+	if _, err := s.semRepo.GetByID(ctx, semesterID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetLatest(ctx, semesterID)
+}
+
+// checkSemesters checks that the semester is the current one and that the
+// next semester starts after it
+func (s *semesterRolloverService) checkSemesters(ctx context.Context, semesterID, nextSemesterID uuid.UUID) error {
+	semester, err := s.semRepo.GetByID(ctx, semesterID)
+	if err != nil {
+		return err
+	}
+	if !semester.IsCurrent {
+		return fmt.Errorf("%w: only the current semester can be rolled over", domain.ErrInvalidRollover)
+	}
+	if nextSemesterID == semesterID {
+		return fmt.Errorf("%w: the next semester must be another semester", domain.ErrInvalidRollover)
+	}
+
+	next, err := s.semRepo.GetByID(ctx, nextSemesterID)
+	if err != nil {
+		return err
+	}
+	if !next.StartDate.After(semester.StartDate) {
+		return fmt.Errorf("%w: the next semester must start after %s", domain.ErrInvalidRollover, semester.SemesterCode)
+	}
+	return nil
+}
+
+// rolloverOutcome promotes a student to their next semester, unless they are
+// inactive, on probation or in the last semester of their program
+func rolloverOutcome(c *domain.RolloverCandidate) domain.RolloverStudent {
+	outcome := domain.RolloverStudent{
+		StudentID:          c.StudentID,
+		RegistrationNumber: c.RegistrationNumber,
+		FromSemester:       c.CurrentSemester,
+	}
+	switch {
+	case !c.IsActive:
+		outcome.FlagReason = domain.RolloverFlagInactive
+	case c.CGPA != nil && *c.CGPA < c.PassGradePoints:
+		outcome.FlagReason = domain.RolloverFlagProbation
+	case c.CurrentSemester >= c.ProgramSemesters:
+		outcome.FlagReason = domain.RolloverFlagFinalSemester
+	default:
+		next := c.CurrentSemester + 1
+		outcome.ToSemester = &next
+	}
+	return outcome
+}
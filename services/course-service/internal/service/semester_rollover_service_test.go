@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func rolloverSemesters() (*domain.Semester, *domain.Semester) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	current := &domain.Semester{SemesterID: uuid.New(), SemesterCode: "2025-ODD", StartDate: start, IsCurrent: true}
+	next := &domain.Semester{SemesterID: uuid.New(), SemesterCode: "2026-EVEN", StartDate: start.AddDate(0, 6, 0)}
+	return current, next
+}
+
+func TestSemesterRolloverService_PreviewRollover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSemesterRolloverRepository(ctrl)
+	mockSemRepo := mocks.NewMockSemesterRepository(ctrl)
+	service := NewSemesterRolloverService(mockRepo, mockSemRepo)
+
+	current, next := rolloverSemesters()
+	lowCGPA, goodCGPA := 3.5, 8.2
+	candidate := func(semester int, cgpa *float64, active bool) *domain.RolloverCandidate {
+		return &domain.RolloverCandidate{
+			StudentID:        uuid.New(),
+			CurrentSemester:  semester,
+			CGPA:             cgpa,
+			IsActive:         active,
+			ProgramSemesters: 8,
+			PassGradePoints:  4,
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ungraded := []domain.UngradedCourse{{CourseID: uuid.New(), CourseCode: "CS101-A", UngradedStudents: 2}}
+		candidates := []*domain.RolloverCandidate{
+			candidate(3, &goodCGPA, true),
+			candidate(1, nil, true),
+			candidate(3, &lowCGPA, true),
+			candidate(5, &goodCGPA, false),
+			candidate(8, &goodCGPA, true),
+		}
+
+		mockSemRepo.EXPECT().GetByID(gomock.Any(), current.SemesterID).Return(current, nil)
+		mockSemRepo.EXPECT().GetByID(gomock.Any(), next.SemesterID).Return(next, nil)
+		mockRepo.EXPECT().ListUngradedCourses(gomock.Any(), current.SemesterID).Return(ungraded, nil)
+		mockRepo.EXPECT().CountClosable(gomock.Any(), current.SemesterID).Return(12, 4, nil)
+		mockRepo.EXPECT().ListCandidates(gomock.Any(), current.SemesterID).Return(candidates, nil)
+
+		preview, err := service.PreviewRollover(context.Background(), current.SemesterID, next.SemesterID)
+		assert.NoError(t, err)
+		assert.False(t, preview.Ready)
+		assert.Equal(t, 12, preview.CoursesToComplete)
+		assert.Equal(t, 4, preview.EnrollmentsToDrop)
+
+		assert.Len(t, preview.PromotedStudents, 2)
+		assert.Equal(t, 4, *preview.PromotedStudents[0].ToSemester)
+		assert.Equal(t, 2, *preview.PromotedStudents[1].ToSemester)
+
+		assert.Len(t, preview.FlaggedStudents, 3)
+		assert.Equal(t, domain.RolloverFlagProbation, preview.FlaggedStudents[0].FlagReason)
+		assert.Equal(t, domain.RolloverFlagInactive, preview.FlaggedStudents[1].FlagReason)
+		assert.Equal(t, domain.RolloverFlagFinalSemester, preview.FlaggedStudents[2].FlagReason)
+		assert.Nil(t, preview.FlaggedStudents[2].ToSemester)
+	})
+
+	t.Run("Not The Current Semester", func(t *testing.T) {
+		past := &domain.Semester{SemesterID: uuid.New(), StartDate: current.StartDate.AddDate(-1, 0, 0)}
+
+		mockSemRepo.EXPECT().GetByID(gomock.Any(), past.SemesterID).Return(past, nil)
+
+		_, err := service.PreviewRollover(context.Background(), past.SemesterID, next.SemesterID)
+		assert.True(t, errors.Is(err, domain.ErrInvalidRollover))
+	})
+
+	t.Run("Next Semester Starts Earlier", func(t *testing.T) {
+		earlier := &domain.Semester{SemesterID: uuid.New(), StartDate: current.StartDate.AddDate(0, -6, 0)}
+
+		mockSemRepo.EXPECT().GetByID(gomock.Any(), current.SemesterID).Return(current, nil)
+		mockSemRepo.EXPECT().GetByID(gomock.Any(), earlier.SemesterID).Return(earlier, nil)
+
+		_, err := service.PreviewRollover(context.Background(), current.SemesterID, earlier.SemesterID)
+		assert.True(t, errors.Is(err, domain.ErrInvalidRollover))
+	})
+}
+
+func TestSemesterRolloverService_StartRollover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSemesterRolloverRepository(ctrl)
+	mockSemRepo := mocks.NewMockSemesterRepository(ctrl)
+	service := NewSemesterRolloverService(mockRepo, mockSemRepo)
+
+	current, next := rolloverSemesters()
+	adminID := uuid.New()
+	expectSemesters := func() {
+		mockSemRepo.EXPECT().GetByID(gomock.Any(), current.SemesterID).Return(current, nil)
+		mockSemRepo.EXPECT().GetByID(gomock.Any(), next.SemesterID).Return(next, nil)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		expectSemesters()
+		mockRepo.EXPECT().GetLatest(gomock.Any(), current.SemesterID).Return(nil, domain.ErrRolloverNotFound)
+		mockRepo.EXPECT().ListUngradedCourses(gomock.Any(), current.SemesterID).Return([]domain.UngradedCourse{}, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, rollover *domain.SemesterRollover) error {
+			assert.Equal(t, domain.RolloverPending, rollover.Status)
+			assert.Equal(t, next.SemesterID, rollover.NextSemesterID)
+			assert.Equal(t, adminID, rollover.RequestedBy)
+			return nil
+		})
+
+		rollover, err := service.StartRollover(context.Background(), current.SemesterID, next.SemesterID, adminID)
+		assert.NoError(t, err)
+		assert.Equal(t, current.SemesterID, rollover.SemesterID)
+	})
+
+	t.Run("Restart After Failure", func(t *testing.T) {
+		expectSemesters()
+		mockRepo.EXPECT().GetLatest(gomock.Any(), current.SemesterID).Return(&domain.SemesterRollover{Status: domain.RolloverFailed}, nil)
+		mockRepo.EXPECT().ListUngradedCourses(gomock.Any(), current.SemesterID).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := service.StartRollover(context.Background(), current.SemesterID, next.SemesterID, adminID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Ungraded Enrollments", func(t *testing.T) {
+		ungraded := []domain.UngradedCourse{
+			{CourseID: uuid.New(), UngradedStudents: 2},
+			{CourseID: uuid.New(), UngradedStudents: 1},
+		}
+
+		expectSemesters()
+		mockRepo.EXPECT().GetLatest(gomock.Any(), current.SemesterID).Return(nil, domain.ErrRolloverNotFound)
+		mockRepo.EXPECT().ListUngradedCourses(gomock.Any(), current.SemesterID).Return(ungraded, nil)
+
+		_, err := service.StartRollover(context.Background(), current.SemesterID, next.SemesterID, adminID)
+		assert.True(t, errors.Is(err, domain.ErrUngradedEnrollments))
+		assert.Contains(t, err.Error(), "3 students in 2 courses")
+	})
+
+	t.Run("Already In Progress", func(t *testing.T) {
+		expectSemesters()
+		mockRepo.EXPECT().GetLatest(gomock.Any(), current.SemesterID).Return(&domain.SemesterRollover{Status: domain.RolloverRunning}, nil)
+
+		_, err := service.StartRollover(context.Background(), current.SemesterID, next.SemesterID, adminID)
+		assert.Equal(t, domain.ErrRolloverInProgress, err)
+	})
+
+	t.Run("Already Rolled Over", func(t *testing.T) {
+		expectSemesters()
+		mockRepo.EXPECT().GetLatest(gomock.Any(), current.SemesterID).Return(&domain.SemesterRollover{Status: domain.RolloverCompleted}, nil)
+
+		_, err := service.StartRollover(context.Background(), current.SemesterID, next.SemesterID, adminID)
+		assert.Equal(t, domain.ErrSemesterRolledOver, err)
+	})
+}
@@ -5,33 +5,32 @@ import (
 	"time"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type semesterService struct {
-	repo     domain.SemesterRepository
-	producer domain.EventProducer
+	repo       domain.SemesterRepository
+	transactor domain.Transactor
+	producer   domain.EventProducer
 }
 
-func NewSemesterService(repo domain.SemesterRepository, producer domain.EventProducer) domain.SemesterService {
-	return &semesterService{repo: repo, producer: producer}
+func NewSemesterService(repo domain.SemesterRepository, transactor domain.Transactor, producer domain.EventProducer) domain.SemesterService {
+	return &semesterService{repo: repo, transactor: transactor, producer: producer}
 }
 
 func (s *semesterService) CreateSemester(ctx context.Context, semester *domain.Semester) error {
-	if err := s.repo.Create(ctx, semester); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.semester.created", semester.SemesterID.String(), map[string]interface{}{
-			"semester_id":   semester.SemesterID,
-			"semester_name": semester.SemesterName,
-			"academic_year": semester.AcademicYear,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, semester); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, semester.SemesterID.String(), semesterEvent(models.EventSemesterCreated, semester))
+	})
 }
 
 func (s *semesterService) GetSemester(ctx context.Context, id uuid.UUID) (*domain.Semester, error) {
@@ -75,35 +74,46 @@ func (s *semesterService) UpdateSemester(ctx context.Context, id uuid.UUID, upda
 			sem.RegistrationEnd = &t
 		}
 	}
-
-	if err := s.repo.Update(ctx, sem); err != nil {
-		return err
+	if addDropEnd, ok := updates["add_drop_end"]; ok {
+		if addDropEnd == nil {
+			sem.AddDropEnd = nil
+		} else if t, ok := addDropEnd.(time.Time); ok {
+			sem.AddDropEnd = &t
+		}
 	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.semester.updated", sem.SemesterID.String(), map[string]interface{}{
-			"semester_id":   sem.SemesterID,
-			"semester_name": sem.SemesterName,
-		})
+	if withdrawalDeadline, ok := updates["withdrawal_deadline"]; ok {
+		if withdrawalDeadline == nil {
+			sem.WithdrawalDeadline = nil
+		} else if t, ok := withdrawalDeadline.(time.Time); ok {
+			sem.WithdrawalDeadline = &t
+		}
 	}
 
-	return nil
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, sem); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, sem.SemesterID.String(), semesterEvent(models.EventSemesterUpdated, sem))
+	})
 }
 
 func (s *semesterService) DeleteSemester(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.semester.deleted", id.String(), map[string]interface{}{
-			"semester_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewSemesterEvent(models.EventSemesterDeleted, id))
+	})
 }
 
 func (s *semesterService) ListSemesters(ctx context.Context, filter domain.SemesterFilter, page, limit int) ([]*domain.Semester, int64, error) {
@@ -112,16 +122,23 @@ func (s *semesterService) ListSemesters(ctx context.Context, filter domain.Semes
 }
 
 func (s *semesterService) SetCurrentSemester(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.SetCurrent(ctx, id); err != nil {
-		return err
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetCurrent(ctx, id); err != nil {
+			return err
+		}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.semester.current_changed", id.String(), map[string]interface{}{
-			"semester_id": id,
-		})
-	}
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewSemesterEvent(models.EventCurrentSemesterChanged, id))
+	})
+}
 
-	return nil
+// semesterEvent creates a semester event describing semester
+func semesterEvent(eventType models.EventType, semester *domain.Semester) *models.SemesterEvent {
+	event := models.NewSemesterEvent(eventType, semester.SemesterID)
+	event.SemesterName = semester.SemesterName
+	event.AcademicYear = semester.AcademicYear
+	return event
 }
@@ -20,7 +20,7 @@ func TestSemesterService_CreateSemester(t *testing.T) {
 	mockRepo := mocks.NewMockSemesterRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewSemesterService(mockRepo, mockProducer)
+	service := NewSemesterService(mockRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		semesterID := uuid.New()
@@ -35,7 +35,7 @@ func TestSemesterService_CreateSemester(t *testing.T) {
 		}
 
 		mockRepo.EXPECT().Create(gomock.Any(), semester).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.semester.created", semesterID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, semesterID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateSemester(context.Background(), semester)
 		assert.NoError(t, err)
@@ -61,7 +61,7 @@ func TestSemesterService_GetSemester(t *testing.T) {
 
 	mockRepo := mocks.NewMockSemesterRepository(ctrl)
 
-	service := NewSemesterService(mockRepo, nil)
+	service := NewSemesterService(mockRepo, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		semesterID := uuid.New()
@@ -98,7 +98,7 @@ func TestSemesterService_GetCurrentSemester(t *testing.T) {
 
 	mockRepo := mocks.NewMockSemesterRepository(ctrl)
 
-	service := NewSemesterService(mockRepo, nil)
+	service := NewSemesterService(mockRepo, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		semester := &domain.Semester{
@@ -131,7 +131,7 @@ func TestSemesterService_UpdateSemester(t *testing.T) {
 	mockRepo := mocks.NewMockSemesterRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewSemesterService(mockRepo, mockProducer)
+	service := NewSemesterService(mockRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		semesterID := uuid.New()
@@ -153,7 +153,7 @@ func TestSemesterService_UpdateSemester(t *testing.T) {
 			assert.Equal(t, 2025, s.AcademicYear)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.semester.updated", semesterID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, semesterID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateSemester(context.Background(), semesterID, updates)
 		assert.NoError(t, err)
@@ -195,7 +195,7 @@ func TestSemesterService_UpdateSemester(t *testing.T) {
 			assert.Nil(t, s.RegistrationEnd)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.semester.updated", semesterID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, semesterID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateSemester(context.Background(), semesterID, updates)
 		assert.NoError(t, err)
@@ -209,13 +209,13 @@ func TestSemesterService_DeleteSemester(t *testing.T) {
 	mockRepo := mocks.NewMockSemesterRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewSemesterService(mockRepo, mockProducer)
+	service := NewSemesterService(mockRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		semesterID := uuid.New()
 
 		mockRepo.EXPECT().Delete(gomock.Any(), semesterID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.semester.deleted", semesterID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, semesterID.String(), gomock.Any()).Return(nil)
 
 		err := service.DeleteSemester(context.Background(), semesterID)
 		assert.NoError(t, err)
@@ -237,7 +237,7 @@ func TestSemesterService_ListSemesters(t *testing.T) {
 
 	mockRepo := mocks.NewMockSemesterRepository(ctrl)
 
-	service := NewSemesterService(mockRepo, nil)
+	service := NewSemesterService(mockRepo, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		year := 2024
@@ -277,13 +277,13 @@ func TestSemesterService_SetCurrentSemester(t *testing.T) {
 	mockRepo := mocks.NewMockSemesterRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewSemesterService(mockRepo, mockProducer)
+	service := NewSemesterService(mockRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		semesterID := uuid.New()
 
 		mockRepo.EXPECT().SetCurrent(gomock.Any(), semesterID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.semester.current_changed", semesterID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, semesterID.String(), gomock.Any()).Return(nil)
 
 		err := service.SetCurrentSemester(context.Background(), semesterID)
 		assert.NoError(t, err)
@@ -4,27 +4,31 @@ import (
 	"context"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type studentService struct {
-	repo     domain.StudentRepository
-	deptRepo domain.DepartmentRepository
-	progRepo domain.ProgramRepository
-	producer domain.EventProducer
+	repo       domain.StudentRepository
+	deptRepo   domain.DepartmentRepository
+	progRepo   domain.ProgramRepository
+	transactor domain.Transactor
+	producer   domain.EventProducer
 }
 
 func NewStudentService(
 	repo domain.StudentRepository,
 	deptRepo domain.DepartmentRepository,
 	progRepo domain.ProgramRepository,
+	transactor domain.Transactor,
 	producer domain.EventProducer,
 ) domain.StudentService {
 	return &studentService{
-		repo:     repo,
-		deptRepo: deptRepo,
-		progRepo: progRepo,
-		producer: producer,
+		repo:       repo,
+		deptRepo:   deptRepo,
+		progRepo:   progRepo,
+		transactor: transactor,
+		producer:   producer,
 	}
 }
 
@@ -47,22 +51,17 @@ func (s *studentService) CreateStudent(ctx context.Context, student *domain.Stud
 	}
 	student.TotalCreditsEarned = 0
 
-	if err := s.repo.Create(ctx, student); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.student.created", student.StudentID.String(), map[string]interface{}{
-			"student_id":          student.StudentID,
-			"user_id":             student.UserID,
-			"registration_number": student.RegistrationNumber,
-			"department_id":       student.DepartmentID,
-			"program_id":          student.ProgramID,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, student); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, student.StudentID.String(), studentEvent(models.EventStudentCreated, student))
+	})
 }
 
 func (s *studentService) GetStudent(ctx context.Context, id uuid.UUID) (*domain.StudentWithDetails, error) {
@@ -95,34 +94,31 @@ func (s *studentService) UpdateStudent(ctx context.Context, id uuid.UUID, update
 		student.IsActive = isActive
 	}
 
-	if err := s.repo.Update(ctx, student); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.student.updated", student.StudentID.String(), map[string]interface{}{
-			"student_id":          student.StudentID,
-			"registration_number": student.RegistrationNumber,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, student); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, student.StudentID.String(), studentEvent(models.EventStudentUpdated, student))
+	})
 }
 
 func (s *studentService) DeleteStudent(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.student.deleted", id.String(), map[string]interface{}{
-			"student_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewStudentEvent(models.EventStudentDeleted, id))
+	})
 }
 
 func (s *studentService) ListStudents(ctx context.Context, filter domain.StudentFilter, page, limit int) ([]*domain.StudentWithDetails, int64, error) {
@@ -130,19 +126,55 @@ func (s *studentService) ListStudents(ctx context.Context, filter domain.Student
 	return s.repo.List(ctx, filter, limit, offset)
 }
 
-func (s *studentService) PromoteStudent(ctx context.Context, id uuid.UUID, newSemester int, cgpa *float64, creditsEarned int) error {
-	if err := s.repo.UpdateSemester(ctx, id, newSemester, cgpa, creditsEarned); err != nil {
-		return err
-	}
+// PromoteStudent moves a student to a new semester. Their CGPA is kept up to
+// date as their grades change, and is only reported in the event.
+func (s *studentService) PromoteStudent(ctx context.Context, id uuid.UUID, newSemester int) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateSemester(ctx, id, newSemester); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		student, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		event := models.NewStudentEvent(models.EventStudentPromoted, id)
+		event.NewSemester = newSemester
+		event.CGPA = student.CurrentCGPA
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), event)
+	})
+}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.student.promoted", id.String(), map[string]interface{}{
-			"student_id":   id,
-			"new_semester": newSemester,
-			"cgpa":         cgpa,
-		})
+func (s *studentService) GetAcademicRecord(ctx context.Context, id uuid.UUID) (*domain.AcademicRecord, error) {
+	student, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	semesters, err := s.repo.ListSemesterGPAs(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	if semesters == nil {
+		semesters = []domain.SemesterGPA{}
+	}
+	return &domain.AcademicRecord{
+		StudentID:          student.StudentID,
+		CGPA:               student.CurrentCGPA,
+		TotalCreditsEarned: student.TotalCreditsEarned,
+		Semesters:          semesters,
+	}, nil
+}
 
-	return nil
+// studentEvent creates a student event describing student
+func studentEvent(eventType models.EventType, student *domain.Student) *models.StudentEvent {
+	event := models.NewStudentEvent(eventType, student.StudentID)
+	event.UserID = student.UserID
+	event.RegistrationNumber = student.RegistrationNumber
+	event.DepartmentID = student.DepartmentID
+	event.ProgramID = student.ProgramID
+	return event
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -21,7 +22,7 @@ func TestStudentService_CreateStudent(t *testing.T) {
 	mockProgRepo := mocks.NewMockProgramRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewStudentService(mockRepo, mockDeptRepo, mockProgRepo, mockProducer)
+	service := NewStudentService(mockRepo, mockDeptRepo, mockProgRepo, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		studentID := uuid.New()
@@ -49,7 +50,7 @@ func TestStudentService_CreateStudent(t *testing.T) {
 			assert.Equal(t, 0, s.TotalCreditsEarned)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.student.created", studentID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, studentID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateStudent(context.Background(), student)
 		assert.NoError(t, err)
@@ -130,7 +131,7 @@ func TestStudentService_CreateStudent(t *testing.T) {
 			assert.Equal(t, 3, s.CurrentSemester) // Should keep existing value
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.student.created", studentID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, studentID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateStudent(context.Background(), student)
 		assert.NoError(t, err)
@@ -143,7 +144,7 @@ func TestStudentService_GetStudent(t *testing.T) {
 
 	mockRepo := mocks.NewMockStudentRepository(ctrl)
 
-	service := NewStudentService(mockRepo, nil, nil, nil)
+	service := NewStudentService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		studentID := uuid.New()
@@ -183,7 +184,7 @@ func TestStudentService_GetStudentByUserID(t *testing.T) {
 
 	mockRepo := mocks.NewMockStudentRepository(ctrl)
 
-	service := NewStudentService(mockRepo, nil, nil, nil)
+	service := NewStudentService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		userID := uuid.New()
@@ -226,7 +227,7 @@ func TestStudentService_UpdateStudent(t *testing.T) {
 	mockRepo := mocks.NewMockStudentRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewStudentService(mockRepo, nil, nil, mockProducer)
+	service := NewStudentService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		studentID := uuid.New()
@@ -249,7 +250,7 @@ func TestStudentService_UpdateStudent(t *testing.T) {
 			assert.False(t, s.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.student.updated", studentID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, studentID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateStudent(context.Background(), studentID, updates)
 		assert.NoError(t, err)
@@ -283,7 +284,7 @@ func TestStudentService_UpdateStudent(t *testing.T) {
 			assert.Nil(t, s.RollNumber)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.student.updated", studentID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, studentID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateStudent(context.Background(), studentID, updates)
 		assert.NoError(t, err)
@@ -297,13 +298,13 @@ func TestStudentService_DeleteStudent(t *testing.T) {
 	mockRepo := mocks.NewMockStudentRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewStudentService(mockRepo, nil, nil, mockProducer)
+	service := NewStudentService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		studentID := uuid.New()
 
 		mockRepo.EXPECT().Delete(gomock.Any(), studentID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.student.deleted", studentID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, studentID.String(), gomock.Any()).Return(nil)
 
 		err := service.DeleteStudent(context.Background(), studentID)
 		assert.NoError(t, err)
@@ -325,7 +326,7 @@ func TestStudentService_ListStudents(t *testing.T) {
 
 	mockRepo := mocks.NewMockStudentRepository(ctrl)
 
-	service := NewStudentService(mockRepo, nil, nil, nil)
+	service := NewStudentService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		deptID := uuid.New()
@@ -365,25 +366,30 @@ func TestStudentService_PromoteStudent(t *testing.T) {
 	mockRepo := mocks.NewMockStudentRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewStudentService(mockRepo, nil, nil, mockProducer)
+	service := NewStudentService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		studentID := uuid.New()
-		cgpa := 3.5
+		cgpa := 8.5
 
-		mockRepo.EXPECT().UpdateSemester(gomock.Any(), studentID, 4, &cgpa, 60).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.student.promoted", studentID.String(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().UpdateSemester(gomock.Any(), studentID, 4).Return(nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(&domain.Student{StudentID: studentID, CurrentSemester: 4, CurrentCGPA: &cgpa}, nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, studentID.String(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+				assert.Equal(t, &cgpa, event.(*models.StudentEvent).CGPA)
+				return nil
+			})
 
-		err := service.PromoteStudent(context.Background(), studentID, 4, &cgpa, 60)
+		err := service.PromoteStudent(context.Background(), studentID, 4)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Student Not Found", func(t *testing.T) {
 		studentID := uuid.New()
 
-		mockRepo.EXPECT().UpdateSemester(gomock.Any(), studentID, 4, (*float64)(nil), 60).Return(domain.ErrStudentNotFound)
+		mockRepo.EXPECT().UpdateSemester(gomock.Any(), studentID, 4).Return(domain.ErrStudentNotFound)
 
-		err := service.PromoteStudent(context.Background(), studentID, 4, nil, 60)
+		err := service.PromoteStudent(context.Background(), studentID, 4)
 		assert.ErrorIs(t, err, domain.ErrStudentNotFound)
 	})
 }
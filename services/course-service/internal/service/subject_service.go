@@ -2,22 +2,32 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/google/uuid"
 )
 
 type subjectService struct {
-	repo     domain.SubjectRepository
-	deptRepo domain.DepartmentRepository
-	producer domain.EventProducer
+	repo       domain.SubjectRepository
+	deptRepo   domain.DepartmentRepository
+	scaleRepo  domain.GradingScaleRepository
+	transactor domain.Transactor
+	producer   domain.EventProducer
 }
 
-func NewSubjectService(repo domain.SubjectRepository, deptRepo domain.DepartmentRepository, producer domain.EventProducer) domain.SubjectService {
-	return &subjectService{repo: repo, deptRepo: deptRepo, producer: producer}
+func NewSubjectService(repo domain.SubjectRepository, deptRepo domain.DepartmentRepository, scaleRepo domain.GradingScaleRepository, transactor domain.Transactor, producer domain.EventProducer) domain.SubjectService {
+	return &subjectService{repo: repo, deptRepo: deptRepo, scaleRepo: scaleRepo, transactor: transactor, producer: producer}
 }
 
 func (s *subjectService) CreateSubject(ctx context.Context, subject *domain.Subject, prerequisites []domain.SubjectPrerequisite, corequisites []uuid.UUID) error {
+	for i := range prerequisites {
+		if err := s.validatePrerequisite(ctx, &prerequisites[i]); err != nil {
+			return err
+		}
+	}
+
 	// Validate department exists
 	_, err := s.deptRepo.GetByID(ctx, subject.DepartmentID)
 	if err != nil {
@@ -25,13 +35,25 @@ func (s *subjectService) CreateSubject(ctx context.Context, subject *domain.Subj
 	}
 
 	subject.IsActive = true
-	if err := s.repo.Create(ctx, subject); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, subject); err != nil {
+			return err
+		}
+
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, subject.SubjectID.String(), subjectEvent(models.EventSubjectCreated, subject))
+	})
+	if err != nil {
 		return err
 	}
 
 	// Add prerequisites
 	for _, prereq := range prerequisites {
-		if err := s.repo.AddPrerequisite(ctx, subject.SubjectID, prereq.PrerequisiteSubjectID, prereq.IsMandatory); err != nil {
+		prereq.SubjectID = subject.SubjectID
+		if err := s.repo.AddPrerequisite(ctx, &prereq); err != nil {
 			// Log error but continue - prerequisite addition is non-critical
 			continue
 		}
@@ -44,17 +66,6 @@ func (s *subjectService) CreateSubject(ctx context.Context, subject *domain.Subj
 		}
 	}
 
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.subject.created", subject.SubjectID.String(), map[string]interface{}{
-			"subject_id":    subject.SubjectID,
-			"subject_name":  subject.SubjectName,
-			"subject_code":  subject.SubjectCode,
-			"department_id": subject.DepartmentID,
-			"credits":       subject.Credits,
-		})
-	}
-
 	return nil
 }
 
@@ -100,34 +111,31 @@ func (s *subjectService) UpdateSubject(ctx context.Context, id uuid.UUID, update
 		subj.IsActive = isActive
 	}
 
-	if err := s.repo.Update(ctx, subj); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.subject.updated", subj.SubjectID.String(), map[string]interface{}{
-			"subject_id":   subj.SubjectID,
-			"subject_name": subj.SubjectName,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, subj); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, subj.SubjectID.String(), subjectEvent(models.EventSubjectUpdated, subj))
+	})
 }
 
 func (s *subjectService) DeleteSubject(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
-	}
-
-	// Publish event
-	if s.producer != nil {
-		s.producer.PublishEvent("course.subject.deleted", id.String(), map[string]interface{}{
-			"subject_id": id,
-		})
-	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish event
+		if s.producer == nil {
+			return nil
+		}
+		return s.producer.PublishEvent(ctx, domain.CourseEventsTopic, id.String(), models.NewSubjectEvent(models.EventSubjectDeleted, id))
+	})
 }
 
 func (s *subjectService) ListSubjects(ctx context.Context, filter domain.SubjectFilter, page, limit int) ([]*domain.Subject, int64, error) {
@@ -135,16 +143,20 @@ func (s *subjectService) ListSubjects(ctx context.Context, filter domain.Subject
 	return s.repo.List(ctx, filter, limit, offset)
 }
 
-func (s *subjectService) AddPrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID, isMandatory bool) error {
+func (s *subjectService) AddPrerequisite(ctx context.Context, prerequisite *domain.SubjectPrerequisite) error {
+	if err := s.validatePrerequisite(ctx, prerequisite); err != nil {
+		return err
+	}
+
 	// Validate both subjects exist
-	if _, err := s.repo.GetByID(ctx, subjectID); err != nil {
+	if _, err := s.repo.GetByID(ctx, prerequisite.SubjectID); err != nil {
 		return err
 	}
-	if _, err := s.repo.GetByID(ctx, prerequisiteID); err != nil {
+	if _, err := s.repo.GetByID(ctx, prerequisite.PrerequisiteSubjectID); err != nil {
 		return err
 	}
 
-	return s.repo.AddPrerequisite(ctx, subjectID, prerequisiteID, isMandatory)
+	return s.repo.AddPrerequisite(ctx, prerequisite)
 }
 
 func (s *subjectService) RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error {
@@ -166,3 +178,119 @@ func (s *subjectService) AddCorequisite(ctx context.Context, subjectID, corequis
 func (s *subjectService) RemoveCorequisite(ctx context.Context, subjectID, corequisiteID uuid.UUID) error {
 	return s.repo.RemoveCorequisite(ctx, subjectID, corequisiteID)
 }
+
+func (s *subjectService) GetPrerequisiteTree(ctx context.Context, subjectID uuid.UUID) (*domain.PrerequisiteTree, error) {
+	subject, err := s.repo.GetByID(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, err := s.repo.GetPrerequisiteChain(ctx, subjectID, false)
+	if err != nil {
+		return nil, err
+	}
+	downstream, err := s.repo.GetPrerequisiteChain(ctx, subjectID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PrerequisiteTree{
+		Subject: domain.SubjectBasic{
+			SubjectID:   subject.SubjectID,
+			SubjectCode: subject.SubjectCode,
+			SubjectName: subject.SubjectName,
+			Credits:     subject.Credits,
+			SubjectType: subject.SubjectType,
+		},
+		Prerequisites: prerequisiteTree(upstream, subjectID, false),
+		Unlocks:       prerequisiteTree(downstream, subjectID, true),
+	}, nil
+}
+
+func (s *subjectService) GetPrerequisiteGraph(ctx context.Context, filter domain.PrerequisiteGraphFilter) (*domain.PrerequisiteGraph, error) {
+	return s.repo.GetPrerequisiteGraph(ctx, filter)
+}
+
+// prerequisiteTree builds the tree of subjects below root in graph, following
+// prerequisites, or the subjects requiring them when downstream is set.
+// Subjects shared by several branches appear in each of them.
+func prerequisiteTree(graph *domain.PrerequisiteGraph, root uuid.UUID, downstream bool) []*domain.PrerequisiteTreeNode {
+	subjects := make(map[uuid.UUID]domain.SubjectBasic, len(graph.Subjects))
+	for _, subject := range graph.Subjects {
+		subjects[subject.SubjectID] = subject
+	}
+
+	// Edges leading away from each subject
+	next := make(map[uuid.UUID][]domain.SubjectPrerequisite)
+	for _, edge := range graph.Edges {
+		from := edge.SubjectID
+		if downstream {
+			from = edge.PrerequisiteSubjectID
+		}
+		next[from] = append(next[from], edge)
+	}
+
+	// Subjects on the current branch, so that cycles stored before they were
+	// rejected cannot make the tree infinite
+	onBranch := map[uuid.UUID]bool{root: true}
+
+	var build func(id uuid.UUID) []*domain.PrerequisiteTreeNode
+	build = func(id uuid.UUID) []*domain.PrerequisiteTreeNode {
+		nodes := make([]*domain.PrerequisiteTreeNode, 0, len(next[id]))
+		for _, edge := range next[id] {
+			child := edge.PrerequisiteSubjectID
+			if downstream {
+				child = edge.SubjectID
+			}
+			if onBranch[child] {
+				continue
+			}
+
+			onBranch[child] = true
+			nodes = append(nodes, &domain.PrerequisiteTreeNode{
+				Subject:        subjects[child],
+				IsMandatory:    edge.IsMandatory,
+				MinGrade:       edge.MinGrade,
+				MinGradePoints: edge.MinGradePoints,
+				Children:       build(child),
+			})
+			onBranch[child] = false
+		}
+		return nodes
+	}
+	return build(root)
+}
+
+// validatePrerequisite checks that the minimum grade of prerequisite is a
+// grade of a grading scale, and stores it in upper case. Students are held to
+// the grade points of the minimum grade on the scale of their program.
+func (s *subjectService) validatePrerequisite(ctx context.Context, prerequisite *domain.SubjectPrerequisite) error {
+	if prerequisite.MinGrade == nil {
+		return nil
+	}
+	scales, err := s.scaleRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	grade := strings.ToUpper(strings.TrimSpace(*prerequisite.MinGrade))
+	for _, scale := range scales {
+		for _, g := range scale.Grades {
+			if g.Grade == grade {
+				prerequisite.MinGrade = &grade
+				return nil
+			}
+		}
+	}
+	return domain.ErrInvalidMinimumGrade
+}
+
+// subjectEvent creates a subject event describing subject
+func subjectEvent(eventType models.EventType, subject *domain.Subject) *models.SubjectEvent {
+	event := models.NewSubjectEvent(eventType, subject.SubjectID)
+	event.SubjectName = subject.SubjectName
+	event.SubjectCode = subject.SubjectCode
+	event.DepartmentID = subject.DepartmentID
+	event.Credits = subject.Credits
+	return event
+}
@@ -20,7 +20,7 @@ func TestSubjectService_CreateSubject(t *testing.T) {
 	mockDeptRepo := mocks.NewMockDepartmentRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewSubjectService(mockRepo, mockDeptRepo, mockProducer)
+	service := NewSubjectService(mockRepo, mockDeptRepo, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
@@ -41,7 +41,7 @@ func TestSubjectService_CreateSubject(t *testing.T) {
 			assert.True(t, s.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.subject.created", subjectID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, subjectID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateSubject(context.Background(), subject, nil, nil)
 		assert.NoError(t, err)
@@ -69,8 +69,13 @@ func TestSubjectService_CreateSubject(t *testing.T) {
 
 		mockDeptRepo.EXPECT().GetByID(gomock.Any(), deptID).Return(dept, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
-		mockRepo.EXPECT().AddPrerequisite(gomock.Any(), subjectID, prereqID, true).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.subject.created", subjectID.String(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().AddPrerequisite(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, p *domain.SubjectPrerequisite) error {
+			assert.Equal(t, subjectID, p.SubjectID)
+			assert.Equal(t, prereqID, p.PrerequisiteSubjectID)
+			assert.True(t, p.IsMandatory)
+			return nil
+		})
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, subjectID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateSubject(context.Background(), subject, prerequisites, nil)
 		assert.NoError(t, err)
@@ -96,7 +101,7 @@ func TestSubjectService_CreateSubject(t *testing.T) {
 		mockDeptRepo.EXPECT().GetByID(gomock.Any(), deptID).Return(dept, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		mockRepo.EXPECT().AddCorequisite(gomock.Any(), subjectID, coreqID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.subject.created", subjectID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, subjectID.String(), gomock.Any()).Return(nil)
 
 		err := service.CreateSubject(context.Background(), subject, nil, corequisites)
 		assert.NoError(t, err)
@@ -140,7 +145,7 @@ func TestSubjectService_GetSubject(t *testing.T) {
 
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, nil)
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
@@ -185,7 +190,7 @@ func TestSubjectService_UpdateSubject(t *testing.T) {
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, mockProducer)
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
@@ -207,7 +212,7 @@ func TestSubjectService_UpdateSubject(t *testing.T) {
 			assert.Equal(t, 4, s.Credits)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.subject.updated", subjectID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, subjectID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateSubject(context.Background(), subjectID, updates)
 		assert.NoError(t, err)
@@ -248,7 +253,7 @@ func TestSubjectService_UpdateSubject(t *testing.T) {
 			assert.False(t, s.IsActive)
 			return nil
 		})
-		mockProducer.EXPECT().PublishEvent("course.subject.updated", subjectID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, subjectID.String(), gomock.Any()).Return(nil)
 
 		err := service.UpdateSubject(context.Background(), subjectID, updates)
 		assert.NoError(t, err)
@@ -262,13 +267,13 @@ func TestSubjectService_DeleteSubject(t *testing.T) {
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, mockProducer)
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
 
 		mockRepo.EXPECT().Delete(gomock.Any(), subjectID).Return(nil)
-		mockProducer.EXPECT().PublishEvent("course.subject.deleted", subjectID.String(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), domain.CourseEventsTopic, subjectID.String(), gomock.Any()).Return(nil)
 
 		err := service.DeleteSubject(context.Background(), subjectID)
 		assert.NoError(t, err)
@@ -290,7 +295,7 @@ func TestSubjectService_ListSubjects(t *testing.T) {
 
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, nil)
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		deptID := uuid.New()
@@ -328,8 +333,18 @@ func TestSubjectService_AddPrerequisite(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
+	mockScaleRepo := mocks.NewMockGradingScaleRepository(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, nil)
+	service := NewSubjectService(mockRepo, nil, mockScaleRepo, newTestTransactor(ctrl), nil)
+
+	scales := []*domain.GradingScale{
+		{ScaleName: "10-point", MaxGradePoints: 10, PassGradePoints: 4, Grades: []domain.GradeMapping{
+			{Grade: "O", GradePoints: 10}, {Grade: "B+", GradePoints: 7}, {Grade: "B", GradePoints: 6}, {Grade: "F", GradePoints: 0},
+		}},
+		{ScaleName: "4-point", MaxGradePoints: 4, PassGradePoints: 1, Grades: []domain.GradeMapping{
+			{Grade: "A", GradePoints: 4}, {Grade: "A-", GradePoints: 3.7}, {Grade: "B", GradePoints: 3}, {Grade: "F", GradePoints: 0},
+		}},
+	}
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
@@ -337,13 +352,57 @@ func TestSubjectService_AddPrerequisite(t *testing.T) {
 
 		subject := &domain.Subject{SubjectID: subjectID}
 		prereq := &domain.Subject{SubjectID: prereqID}
+		prerequisite := &domain.SubjectPrerequisite{SubjectID: subjectID, PrerequisiteSubjectID: prereqID, IsMandatory: true}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), subjectID).Return(subject, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), prereqID).Return(prereq, nil)
+		mockRepo.EXPECT().AddPrerequisite(gomock.Any(), prerequisite).Return(nil)
+
+		err := service.AddPrerequisite(context.Background(), prerequisite)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Minimum Grade", func(t *testing.T) {
+		subjectID := uuid.New()
+		prereqID := uuid.New()
+		minGrade := "b+"
+
+		subject := &domain.Subject{SubjectID: subjectID}
+		prereq := &domain.Subject{SubjectID: prereqID}
+		prerequisite := &domain.SubjectPrerequisite{SubjectID: subjectID, PrerequisiteSubjectID: prereqID, IsMandatory: true, MinGrade: &minGrade}
 
+		mockScaleRepo.EXPECT().List(gomock.Any()).Return(scales, nil)
 		mockRepo.EXPECT().GetByID(gomock.Any(), subjectID).Return(subject, nil)
 		mockRepo.EXPECT().GetByID(gomock.Any(), prereqID).Return(prereq, nil)
-		mockRepo.EXPECT().AddPrerequisite(gomock.Any(), subjectID, prereqID, true).Return(nil)
+		mockRepo.EXPECT().AddPrerequisite(gomock.Any(), prerequisite).Return(nil)
 
-		err := service.AddPrerequisite(context.Background(), subjectID, prereqID, true)
+		err := service.AddPrerequisite(context.Background(), prerequisite)
 		assert.NoError(t, err)
+		assert.Equal(t, "B+", *prerequisite.MinGrade)
+	})
+
+	t.Run("Grade Of Another Scale", func(t *testing.T) {
+		minGrade := "a-"
+		prerequisite := &domain.SubjectPrerequisite{SubjectID: uuid.New(), PrerequisiteSubjectID: uuid.New(), IsMandatory: true, MinGrade: &minGrade}
+
+		mockScaleRepo.EXPECT().List(gomock.Any()).Return(scales, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), prerequisite.SubjectID).Return(&domain.Subject{}, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), prerequisite.PrerequisiteSubjectID).Return(&domain.Subject{}, nil)
+		mockRepo.EXPECT().AddPrerequisite(gomock.Any(), prerequisite).Return(nil)
+
+		err := service.AddPrerequisite(context.Background(), prerequisite)
+		assert.NoError(t, err)
+		assert.Equal(t, "A-", *prerequisite.MinGrade)
+	})
+
+	t.Run("Invalid Minimum Grade", func(t *testing.T) {
+		minGrade := "Z"
+		prerequisite := &domain.SubjectPrerequisite{SubjectID: uuid.New(), PrerequisiteSubjectID: uuid.New(), MinGrade: &minGrade}
+
+		mockScaleRepo.EXPECT().List(gomock.Any()).Return(scales, nil)
+
+		err := service.AddPrerequisite(context.Background(), prerequisite)
+		assert.ErrorIs(t, err, domain.ErrInvalidMinimumGrade)
 	})
 
 	t.Run("Subject Not Found", func(t *testing.T) {
@@ -352,7 +411,7 @@ func TestSubjectService_AddPrerequisite(t *testing.T) {
 
 		mockRepo.EXPECT().GetByID(gomock.Any(), subjectID).Return(nil, domain.ErrSubjectNotFound)
 
-		err := service.AddPrerequisite(context.Background(), subjectID, prereqID, true)
+		err := service.AddPrerequisite(context.Background(), &domain.SubjectPrerequisite{SubjectID: subjectID, PrerequisiteSubjectID: prereqID, IsMandatory: true})
 		assert.ErrorIs(t, err, domain.ErrSubjectNotFound)
 	})
 
@@ -365,7 +424,92 @@ func TestSubjectService_AddPrerequisite(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), subjectID).Return(subject, nil)
 		mockRepo.EXPECT().GetByID(gomock.Any(), prereqID).Return(nil, domain.ErrSubjectNotFound)
 
-		err := service.AddPrerequisite(context.Background(), subjectID, prereqID, true)
+		err := service.AddPrerequisite(context.Background(), &domain.SubjectPrerequisite{SubjectID: subjectID, PrerequisiteSubjectID: prereqID, IsMandatory: true})
+		assert.ErrorIs(t, err, domain.ErrSubjectNotFound)
+	})
+}
+
+func TestSubjectService_GetPrerequisiteTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSubjectRepository(ctrl)
+
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
+
+	t.Run("Success", func(t *testing.T) {
+		// CS101 -> CS201 -> CS301, CS101 -> CS301 and CS301 -> CS401
+		cs101 := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "CS101"}
+		cs201 := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "CS201"}
+		cs301 := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "CS301"}
+		cs401 := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "CS401"}
+		minGrade := "B"
+
+		upstream := &domain.PrerequisiteGraph{
+			Subjects: []domain.SubjectBasic{cs101, cs201, cs301},
+			Edges: []domain.SubjectPrerequisite{
+				{SubjectID: cs301.SubjectID, PrerequisiteSubjectID: cs201.SubjectID, IsMandatory: true, MinGrade: &minGrade},
+				{SubjectID: cs201.SubjectID, PrerequisiteSubjectID: cs101.SubjectID, IsMandatory: true},
+				{SubjectID: cs301.SubjectID, PrerequisiteSubjectID: cs101.SubjectID},
+			},
+		}
+		downstream := &domain.PrerequisiteGraph{
+			Subjects: []domain.SubjectBasic{cs301, cs401},
+			Edges: []domain.SubjectPrerequisite{
+				{SubjectID: cs401.SubjectID, PrerequisiteSubjectID: cs301.SubjectID, IsMandatory: true},
+			},
+		}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), cs301.SubjectID).Return(&domain.Subject{SubjectID: cs301.SubjectID, SubjectCode: "CS301"}, nil)
+		mockRepo.EXPECT().GetPrerequisiteChain(gomock.Any(), cs301.SubjectID, false).Return(upstream, nil)
+		mockRepo.EXPECT().GetPrerequisiteChain(gomock.Any(), cs301.SubjectID, true).Return(downstream, nil)
+
+		tree, err := service.GetPrerequisiteTree(context.Background(), cs301.SubjectID)
+		assert.NoError(t, err)
+		assert.Equal(t, "CS301", tree.Subject.SubjectCode)
+
+		assert.Len(t, tree.Prerequisites, 2)
+		assert.Equal(t, "CS201", tree.Prerequisites[0].Subject.SubjectCode)
+		assert.Equal(t, "B", *tree.Prerequisites[0].MinGrade)
+		assert.Len(t, tree.Prerequisites[0].Children, 1)
+		assert.Equal(t, "CS101", tree.Prerequisites[0].Children[0].Subject.SubjectCode)
+		assert.Equal(t, "CS101", tree.Prerequisites[1].Subject.SubjectCode)
+		assert.False(t, tree.Prerequisites[1].IsMandatory)
+
+		assert.Len(t, tree.Unlocks, 1)
+		assert.Equal(t, "CS401", tree.Unlocks[0].Subject.SubjectCode)
+		assert.Empty(t, tree.Unlocks[0].Children)
+	})
+
+	t.Run("Stored Cycle", func(t *testing.T) {
+		a := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "A"}
+		b := domain.SubjectBasic{SubjectID: uuid.New(), SubjectCode: "B"}
+		cycle := &domain.PrerequisiteGraph{
+			Subjects: []domain.SubjectBasic{a, b},
+			Edges: []domain.SubjectPrerequisite{
+				{SubjectID: a.SubjectID, PrerequisiteSubjectID: b.SubjectID, IsMandatory: true},
+				{SubjectID: b.SubjectID, PrerequisiteSubjectID: a.SubjectID, IsMandatory: true},
+			},
+		}
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), a.SubjectID).Return(&domain.Subject{SubjectID: a.SubjectID}, nil)
+		mockRepo.EXPECT().GetPrerequisiteChain(gomock.Any(), a.SubjectID, false).Return(cycle, nil)
+		mockRepo.EXPECT().GetPrerequisiteChain(gomock.Any(), a.SubjectID, true).Return(cycle, nil)
+
+		tree, err := service.GetPrerequisiteTree(context.Background(), a.SubjectID)
+		assert.NoError(t, err)
+		assert.Len(t, tree.Prerequisites, 1)
+		assert.Empty(t, tree.Prerequisites[0].Children)
+		assert.Len(t, tree.Unlocks, 1)
+		assert.Empty(t, tree.Unlocks[0].Children)
+	})
+
+	t.Run("Subject Not Found", func(t *testing.T) {
+		subjectID := uuid.New()
+
+		mockRepo.EXPECT().GetByID(gomock.Any(), subjectID).Return(nil, domain.ErrSubjectNotFound)
+
+		_, err := service.GetPrerequisiteTree(context.Background(), subjectID)
 		assert.ErrorIs(t, err, domain.ErrSubjectNotFound)
 	})
 }
@@ -376,7 +520,7 @@ func TestSubjectService_RemovePrerequisite(t *testing.T) {
 
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, nil)
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
@@ -395,7 +539,7 @@ func TestSubjectService_AddCorequisite(t *testing.T) {
 
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, nil)
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
@@ -442,7 +586,7 @@ func TestSubjectService_RemoveCorequisite(t *testing.T) {
 
 	mockRepo := mocks.NewMockSubjectRepository(ctrl)
 
-	service := NewSubjectService(mockRepo, nil, nil)
+	service := NewSubjectService(mockRepo, nil, nil, newTestTransactor(ctrl), nil)
 
 	t.Run("Success", func(t *testing.T) {
 		subjectID := uuid.New()
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type transcriptService struct {
+	repo        domain.TranscriptRepository
+	studentRepo domain.StudentRepository
+	enrollRepo  domain.EnrollmentRepository
+	scaleRepo   domain.GradingScaleRepository
+	signingKey  []byte
+}
+
+// NewTranscriptService creates a transcript service signing the hashes of
+// issued transcripts with signingKey
+func NewTranscriptService(
+	repo domain.TranscriptRepository,
+	studentRepo domain.StudentRepository,
+	enrollRepo domain.EnrollmentRepository,
+	scaleRepo domain.GradingScaleRepository,
+	signingKey []byte,
+) domain.TranscriptService {
+	return &transcriptService{
+		repo:        repo,
+		studentRepo: studentRepo,
+		enrollRepo:  enrollRepo,
+		scaleRepo:   scaleRepo,
+		signingKey:  signingKey,
+	}
+}
+
+// IssueTranscript builds the transcript of a student from their graded
+// courses and records it for verification. A record that was issued before
+// keeps its verification code and issue date.
+func (s *transcriptService) IssueTranscript(ctx context.Context, studentID, issuedBy uuid.UUID) (*domain.Transcript, error) {
+	student, err := s.studentRepo.GetWithDetails(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := s.scaleRepo.GetForStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	courses, err := s.enrollRepo.ListGradedCourses(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := transcriptRecord(student, scale, courses)
+	contents, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transcript: %w", err)
+	}
+	hash := contentHash(contents)
+
+	issued, err := s.repo.GetByContentHash(ctx, studentID, hash)
+	if err == domain.ErrTranscriptNotFound {
+		issued = &domain.IssuedTranscript{
+			VerificationCode: newVerificationCode(),
+			StudentID:        studentID,
+			Contents:         contents,
+			ContentHash:      hash,
+			Signature:        s.sign(hash),
+			IssuedBy:         issuedBy,
+		}
+		err = s.repo.Create(ctx, issued)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Transcript{
+		TranscriptRecord: *record,
+		VerificationCode: issued.VerificationCode,
+		ContentHash:      issued.ContentHash,
+		IssuedAt:         issued.IssuedAt,
+	}, nil
+}
+
+// VerifyTranscript looks up an issued transcript by its verification code and
+// checks its contents against their signed hash
+func (s *transcriptService) VerifyTranscript(ctx context.Context, code string) (*domain.TranscriptVerification, error) {
+	issued, err := s.repo.GetByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+
+	var record domain.TranscriptRecord
+	if err := json.Unmarshal(issued.Contents, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode transcript: %w", err)
+	}
+
+	valid := contentHash(issued.Contents) == issued.ContentHash &&
+		hmac.Equal([]byte(issued.Signature), []byte(s.sign(issued.ContentHash)))
+	return &domain.TranscriptVerification{
+		VerificationCode: issued.VerificationCode,
+		Valid:            valid,
+		ContentHash:      issued.ContentHash,
+		IssuedAt:         issued.IssuedAt,
+		Transcript:       record,
+	}, nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of a content hash
+func (s *transcriptService) sign(hash string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(hash))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// transcriptRecord groups the graded courses of a student by semester. GPAs
+// and earned credits are computed on the student's grading scale, the same
+// way as their academic record.
+func transcriptRecord(student *domain.StudentWithDetails, scale *domain.GradingScale, courses []*domain.GradedCourse) *domain.TranscriptRecord {
+	attempts := make([]*domain.GradedAttempt, len(courses))
+	semesters := make(map[uuid.UUID]*domain.TranscriptSemester)
+	var order []uuid.UUID
+	for i, c := range courses {
+		attempts[i] = &domain.GradedAttempt{
+			EnrollmentID:  c.EnrollmentID,
+			SubjectID:     c.SubjectID,
+			SemesterID:    c.SemesterID,
+			SemesterStart: c.SemesterStart,
+			Credits:       c.Credits,
+			GradePoints:   c.GradePoints,
+		}
+
+		semester, ok := semesters[c.SemesterID]
+		if !ok {
+			semester = &domain.TranscriptSemester{
+				SemesterID:   c.SemesterID,
+				SemesterCode: c.SemesterCode,
+				SemesterName: c.SemesterName,
+				AcademicYear: c.AcademicYear,
+			}
+			semesters[c.SemesterID] = semester
+			order = append(order, c.SemesterID)
+		}
+		course := c.TranscriptCourse
+		course.Passed = c.GradePoints >= scale.PassGradePoints
+		semester.Courses = append(semester.Courses, course)
+	}
+
+	academic := academicRecord(student.StudentID, attempts, scale)
+	gpas := make(map[uuid.UUID]domain.SemesterGPA, len(academic.Semesters))
+	for _, gpa := range academic.Semesters {
+		gpas[gpa.SemesterID] = gpa
+	}
+
+	record := &domain.TranscriptRecord{
+		StudentID:          student.StudentID,
+		StudentName:        student.Name,
+		RegistrationNumber: student.RegistrationNumber,
+		Department:         student.Department,
+		Program:            student.Program,
+		BatchYear:          student.BatchYear,
+		CurrentSemester:    student.CurrentSemester,
+		Standing:           academicStanding(&student.Student, academic.CGPA, scale),
+		GradingScale:       scale.ScaleName,
+		MaxGradePoints:     scale.MaxGradePoints,
+		Semesters:          make([]domain.TranscriptSemester, 0, len(order)),
+		CGPA:               academic.CGPA,
+		TotalCreditsEarned: academic.TotalCreditsEarned,
+	}
+	for _, semesterID := range order {
+		semester := semesters[semesterID]
+		gpa := gpas[semesterID]
+		semester.GPA = gpa.GPA
+		semester.CreditsAttempted = gpa.CreditsAttempted
+		semester.CreditsEarned = gpa.CreditsEarned
+		record.Semesters = append(record.Semesters, *semester)
+	}
+	return record
+}
+
+// academicStanding returns the standing of a student. Students whose CGPA is
+// below the pass grade points of their scale are on probation.
+func academicStanding(student *domain.Student, cgpa *float64, scale *domain.GradingScale) string {
+	if !student.IsActive {
+		return domain.StandingInactive
+	}
+	if cgpa != nil && *cgpa < scale.PassGradePoints {
+		return domain.StandingProbation
+	}
+	return domain.StandingGood
+}
+
+// contentHash returns the hex encoded SHA-256 digest of transcript contents
+func contentHash(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
+
+// newVerificationCode returns a random code of four groups of four characters
+func newVerificationCode() string {
+	b := make([]byte, 10)
+	rand.Read(b)
+	code := base32.StdEncoding.EncodeToString(b)
+	return code[0:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:16]
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testSigningKey = []byte("test-signing-key")
+
+// testGradedCourses returns the courses of a student who failed a subject in
+// their first semester and passed it on the retake
+func testGradedCourses() []*domain.GradedCourse {
+	first := uuid.New()
+	second := uuid.New()
+	retaken := uuid.New()
+	firstStart := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	secondStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	course := func(semesterID uuid.UUID, start time.Time, code string, subjectID uuid.UUID, credits int, grade string, points float64) *domain.GradedCourse {
+		return &domain.GradedCourse{
+			TranscriptCourse: domain.TranscriptCourse{
+				EnrollmentID: uuid.New(),
+				SubjectID:    subjectID,
+				SubjectCode:  code,
+				Credits:      credits,
+				Grade:        grade,
+				GradePoints:  points,
+			},
+			SemesterID:    semesterID,
+			SemesterCode:  start.Format("2006-01"),
+			SemesterStart: start,
+		}
+	}
+	return []*domain.GradedCourse{
+		course(first, firstStart, "CS101", uuid.New(), 4, "A+", 9),
+		course(first, firstStart, "MA101", retaken, 3, "F", 0),
+		course(second, secondStart, "MA101", retaken, 3, "B", 7),
+	}
+}
+
+func TestTranscriptService_IssueTranscript(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranscriptRepository(ctrl)
+	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
+	mockEnrollRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockScaleRepo := mocks.NewMockGradingScaleRepository(ctrl)
+	service := NewTranscriptService(mockRepo, mockStudentRepo, mockEnrollRepo, mockScaleRepo, testSigningKey)
+
+	issuedBy := uuid.New()
+
+	t.Run("Issue New", func(t *testing.T) {
+		studentID := uuid.New()
+		student := &domain.StudentWithDetails{
+			Student: domain.Student{StudentID: studentID, RegistrationNumber: "REG001", BatchYear: 2025, CurrentSemester: 2, IsActive: true},
+			Name:    "Asha Patel",
+		}
+
+		mockStudentRepo.EXPECT().GetWithDetails(gomock.Any(), studentID).Return(student, nil)
+		mockScaleRepo.EXPECT().GetForStudent(gomock.Any(), studentID).Return(testGradingScale, nil)
+		mockEnrollRepo.EXPECT().ListGradedCourses(gomock.Any(), studentID).Return(testGradedCourses(), nil)
+		mockRepo.EXPECT().GetByContentHash(gomock.Any(), studentID, gomock.Any()).Return(nil, domain.ErrTranscriptNotFound)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, issued *domain.IssuedTranscript) error {
+				assert.Equal(t, studentID, issued.StudentID)
+				assert.Equal(t, issuedBy, issued.IssuedBy)
+				assert.Len(t, issued.VerificationCode, 19)
+				assert.Equal(t, contentHash(issued.Contents), issued.ContentHash)
+				issued.IssuedAt = time.Now()
+				return nil
+			})
+
+		transcript, err := service.IssueTranscript(context.Background(), studentID, issuedBy)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StandingGood, transcript.Standing)
+		assert.Len(t, transcript.Semesters, 2)
+
+		first := transcript.Semesters[0]
+		assert.Len(t, first.Courses, 2)
+		assert.True(t, first.Courses[0].Passed)
+		assert.False(t, first.Courses[1].Passed)
+		assert.Equal(t, 5.14, *first.GPA)
+		assert.Equal(t, 7, first.CreditsAttempted)
+		assert.Equal(t, 4, first.CreditsEarned)
+
+		// The failed attempt does not count towards the CGPA
+		assert.Equal(t, 8.14, *transcript.CGPA)
+		assert.Equal(t, 7, transcript.TotalCreditsEarned)
+	})
+
+	t.Run("Reuse Issued", func(t *testing.T) {
+		studentID := uuid.New()
+		student := &domain.StudentWithDetails{Student: domain.Student{StudentID: studentID, IsActive: true}}
+		issued := &domain.IssuedTranscript{VerificationCode: "ABCD-EFGH-IJKL-MNOP", StudentID: studentID, IssuedAt: time.Now()}
+
+		mockStudentRepo.EXPECT().GetWithDetails(gomock.Any(), studentID).Return(student, nil)
+		mockScaleRepo.EXPECT().GetForStudent(gomock.Any(), studentID).Return(testGradingScale, nil)
+		mockEnrollRepo.EXPECT().ListGradedCourses(gomock.Any(), studentID).Return(nil, nil)
+		mockRepo.EXPECT().GetByContentHash(gomock.Any(), studentID, gomock.Any()).Return(issued, nil)
+
+		transcript, err := service.IssueTranscript(context.Background(), studentID, issuedBy)
+		assert.NoError(t, err)
+		assert.Equal(t, issued.VerificationCode, transcript.VerificationCode)
+		assert.Empty(t, transcript.Semesters)
+		assert.Nil(t, transcript.CGPA)
+	})
+
+	t.Run("Probation", func(t *testing.T) {
+		studentID := uuid.New()
+		student := &domain.StudentWithDetails{Student: domain.Student{StudentID: studentID, IsActive: true}}
+		courses := testGradedCourses()[1:2]
+
+		mockStudentRepo.EXPECT().GetWithDetails(gomock.Any(), studentID).Return(student, nil)
+		mockScaleRepo.EXPECT().GetForStudent(gomock.Any(), studentID).Return(testGradingScale, nil)
+		mockEnrollRepo.EXPECT().ListGradedCourses(gomock.Any(), studentID).Return(courses, nil)
+		mockRepo.EXPECT().GetByContentHash(gomock.Any(), studentID, gomock.Any()).Return(nil, domain.ErrTranscriptNotFound)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		transcript, err := service.IssueTranscript(context.Background(), studentID, issuedBy)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StandingProbation, transcript.Standing)
+	})
+
+	t.Run("Student Not Found", func(t *testing.T) {
+		studentID := uuid.New()
+
+		mockStudentRepo.EXPECT().GetWithDetails(gomock.Any(), studentID).Return(nil, domain.ErrStudentNotFound)
+
+		_, err := service.IssueTranscript(context.Background(), studentID, issuedBy)
+		assert.Equal(t, domain.ErrStudentNotFound, err)
+	})
+}
+
+func TestTranscriptService_VerifyTranscript(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranscriptRepository(ctrl)
+	service := NewTranscriptService(mockRepo, nil, nil, nil, testSigningKey)
+	signer := &transcriptService{signingKey: testSigningKey}
+
+	issued := func() *domain.IssuedTranscript {
+		contents, _ := json.Marshal(domain.TranscriptRecord{RegistrationNumber: "REG001"})
+		hash := contentHash(contents)
+		return &domain.IssuedTranscript{
+			VerificationCode: "ABCD-EFGH-IJKL-MNOP",
+			Contents:         contents,
+			ContentHash:      hash,
+			Signature:        signer.sign(hash),
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		mockRepo.EXPECT().GetByCode(gomock.Any(), "ABCD-EFGH-IJKL-MNOP").Return(issued(), nil)
+
+		verification, err := service.VerifyTranscript(context.Background(), " abcd-efgh-ijkl-mnop ")
+		assert.NoError(t, err)
+		assert.True(t, verification.Valid)
+		assert.Equal(t, "REG001", verification.Transcript.RegistrationNumber)
+	})
+
+	t.Run("Tampered Contents", func(t *testing.T) {
+		tampered := issued()
+		tampered.Contents, _ = json.Marshal(domain.TranscriptRecord{RegistrationNumber: "REG002"})
+		mockRepo.EXPECT().GetByCode(gomock.Any(), tampered.VerificationCode).Return(tampered, nil)
+
+		verification, err := service.VerifyTranscript(context.Background(), tampered.VerificationCode)
+		assert.NoError(t, err)
+		assert.False(t, verification.Valid)
+	})
+
+	t.Run("Forged Signature", func(t *testing.T) {
+		forged := issued()
+		forged.Signature = (&transcriptService{signingKey: []byte("other-key")}).sign(forged.ContentHash)
+		mockRepo.EXPECT().GetByCode(gomock.Any(), forged.VerificationCode).Return(forged, nil)
+
+		verification, err := service.VerifyTranscript(context.Background(), forged.VerificationCode)
+		assert.NoError(t, err)
+		assert.False(t, verification.Valid)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().GetByCode(gomock.Any(), "ZZZZ-ZZZZ-ZZZZ-ZZZZ").Return(nil, domain.ErrTranscriptNotFound)
+
+		_, err := service.VerifyTranscript(context.Background(), "ZZZZ-ZZZZ-ZZZZ-ZZZZ")
+		assert.Equal(t, domain.ErrTranscriptNotFound, err)
+	})
+}
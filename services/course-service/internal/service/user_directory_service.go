@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+)
+
+type userDirectoryService struct {
+	repo        domain.UserDirectoryRepository
+	facultyRepo domain.FacultyRepository
+	studentRepo domain.StudentRepository
+	transactor  domain.Transactor
+}
+
+func NewUserDirectoryService(
+	repo domain.UserDirectoryRepository,
+	facultyRepo domain.FacultyRepository,
+	studentRepo domain.StudentRepository,
+	transactor domain.Transactor,
+) domain.UserDirectoryService {
+	return &userDirectoryService{
+		repo:        repo,
+		facultyRepo: facultyRepo,
+		studentRepo: studentRepo,
+		transactor:  transactor,
+	}
+}
+
+func (s *userDirectoryService) HandleUserEvent(ctx context.Context, event *models.UserEvent) error {
+	switch event.EventType {
+	case models.EventUserCreated, models.EventUserUpdated:
+		entry := &domain.UserDirectoryEntry{
+			UserID:         event.UserID,
+			Email:          event.Email,
+			FirstName:      event.FirstName,
+			LastName:       event.LastName,
+			Status:         event.Status,
+			EventTimestamp: event.Timestamp,
+		}
+		if event.Phone != "" {
+			entry.Phone = &event.Phone
+		}
+		if entry.Status == "" {
+			entry.Status = "active"
+		}
+		return s.repo.Upsert(ctx, entry)
+
+	case models.EventUserActivated:
+		return s.repo.UpdateStatus(ctx, event.UserID, "active", event.Timestamp)
+
+	case models.EventUserSuspended, models.EventUserDeleted:
+		status := "suspended"
+		if event.EventType == models.EventUserDeleted {
+			status = "deleted"
+		}
+
+		// A user who lost access must not stay on rosters as active faculty or student
+		return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.repo.UpdateStatus(ctx, event.UserID, status, event.Timestamp); err != nil {
+				return err
+			}
+			if err := s.facultyRepo.DeactivateByUserID(ctx, event.UserID); err != nil {
+				return err
+			}
+			return s.studentRepo.DeactivateByUserID(ctx, event.UserID)
+		})
+	}
+
+	// Other events on user.events, such as role changes, are not about users
+	return nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/course-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserDirectoryService_HandleUserEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserDirectoryRepository(ctrl)
+	mockFacultyRepo := mocks.NewMockFacultyRepository(ctrl)
+	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
+
+	service := NewUserDirectoryService(mockRepo, mockFacultyRepo, mockStudentRepo, newTestTransactor(ctrl))
+
+	t.Run("Created", func(t *testing.T) {
+		event := models.NewUserEvent(models.EventUserCreated, uuid.New(), "jane@example.com")
+		event.FirstName = "Jane"
+		event.LastName = "Doe"
+		event.Phone = "+15550100"
+		event.Status = "active"
+
+		mockRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry *domain.UserDirectoryEntry) error {
+			assert.Equal(t, event.UserID, entry.UserID)
+			assert.Equal(t, "jane@example.com", entry.Email)
+			assert.Equal(t, "Jane", entry.FirstName)
+			assert.Equal(t, "Doe", entry.LastName)
+			assert.Equal(t, "+15550100", *entry.Phone)
+			assert.Equal(t, "active", entry.Status)
+			assert.Equal(t, event.Timestamp, entry.EventTimestamp)
+			return nil
+		})
+
+		err := service.HandleUserEvent(context.Background(), event)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Activated", func(t *testing.T) {
+		event := models.NewUserEvent(models.EventUserActivated, uuid.New(), "jane@example.com")
+
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), event.UserID, "active", event.Timestamp).Return(nil)
+
+		err := service.HandleUserEvent(context.Background(), event)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Suspended Deactivates Records", func(t *testing.T) {
+		event := models.NewUserEvent(models.EventUserSuspended, uuid.New(), "jane@example.com")
+
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), event.UserID, "suspended", event.Timestamp).Return(nil)
+		mockFacultyRepo.EXPECT().DeactivateByUserID(gomock.Any(), event.UserID).Return(nil)
+		mockStudentRepo.EXPECT().DeactivateByUserID(gomock.Any(), event.UserID).Return(nil)
+
+		err := service.HandleUserEvent(context.Background(), event)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Deleted Deactivates Records", func(t *testing.T) {
+		event := models.NewUserEvent(models.EventUserDeleted, uuid.New(), "jane@example.com")
+
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), event.UserID, "deleted", event.Timestamp).Return(nil)
+		mockFacultyRepo.EXPECT().DeactivateByUserID(gomock.Any(), event.UserID).Return(nil)
+		mockStudentRepo.EXPECT().DeactivateByUserID(gomock.Any(), event.UserID).Return(nil)
+
+		err := service.HandleUserEvent(context.Background(), event)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Deactivation Failure", func(t *testing.T) {
+		event := models.NewUserEvent(models.EventUserDeleted, uuid.New(), "jane@example.com")
+		dbErr := errors.New("db error")
+
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), event.UserID, "deleted", event.Timestamp).Return(nil)
+		mockFacultyRepo.EXPECT().DeactivateByUserID(gomock.Any(), event.UserID).Return(dbErr)
+
+		err := service.HandleUserEvent(context.Background(), event)
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("Other Events Ignored", func(t *testing.T) {
+		event := &models.UserEvent{UserID: uuid.New()}
+		event.EventType = models.EventRoleCreated
+
+		err := service.HandleUserEvent(context.Background(), event)
+		assert.NoError(t, err)
+	})
+}
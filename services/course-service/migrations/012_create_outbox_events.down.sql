@@ -0,0 +1,4 @@
+-- 012_create_outbox_events.down.sql
+DROP INDEX IF EXISTS idx_outbox_events_sent_at;
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+DROP TABLE IF EXISTS outbox_events CASCADE;
@@ -0,0 +1,3 @@
+-- 013_create_processed_events.down.sql
+DROP INDEX IF EXISTS idx_processed_events_processed_at;
+DROP TABLE IF EXISTS processed_events CASCADE;
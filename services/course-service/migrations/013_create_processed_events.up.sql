@@ -0,0 +1,12 @@
+-- 013_create_processed_events.up.sql
+-- Create processed events ledger (IDs of consumed events, recorded with their side effects)
+
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer_group VARCHAR(255) NOT NULL,
+    event_id UUID NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (consumer_group, event_id)
+);
+
+-- Old records are pruned once redeliveries are no longer expected
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events(consumer_group, processed_at);
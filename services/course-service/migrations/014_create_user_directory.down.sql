@@ -0,0 +1,4 @@
+-- 014_create_user_directory.down.sql
+DROP TRIGGER IF EXISTS update_user_directory_updated_at ON user_directory;
+DROP INDEX IF EXISTS idx_user_directory_email;
+DROP TABLE IF EXISTS user_directory CASCADE;
@@ -0,0 +1,23 @@
+-- 014_create_user_directory.up.sql
+-- Create user directory (local projection of User Service users, kept up to date from user.events)
+
+CREATE TABLE IF NOT EXISTS user_directory (
+    user_id UUID PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    first_name VARCHAR(100),
+    last_name VARCHAR(100),
+    phone VARCHAR(20),
+    status VARCHAR(20) NOT NULL,
+    -- Timestamp of the last event applied, older redelivered events are ignored
+    event_timestamp TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_user_directory_email ON user_directory(email);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_user_directory_updated_at
+    BEFORE UPDATE ON user_directory
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
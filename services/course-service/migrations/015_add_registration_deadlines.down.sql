@@ -0,0 +1,13 @@
+-- 015_add_registration_deadlines.down.sql
+DROP INDEX IF EXISTS idx_enrollment_overrides_course;
+DROP INDEX IF EXISTS idx_enrollment_overrides_enrollment;
+DROP TABLE IF EXISTS enrollment_overrides CASCADE;
+
+UPDATE course_enrollments SET enrollment_status = 'dropped' WHERE enrollment_status = 'withdrawn';
+ALTER TABLE course_enrollments DROP CONSTRAINT IF EXISTS course_enrollments_enrollment_status_check;
+ALTER TABLE course_enrollments ADD CONSTRAINT course_enrollments_enrollment_status_check
+    CHECK(enrollment_status IN ('enrolled', 'waitlisted', 'dropped', 'completed', 'failed'));
+
+ALTER TABLE semesters
+    DROP COLUMN IF EXISTS withdrawal_deadline,
+    DROP COLUMN IF EXISTS add_drop_end;
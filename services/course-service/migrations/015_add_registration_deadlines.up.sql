@@ -0,0 +1,27 @@
+-- 015_add_registration_deadlines.up.sql
+-- Add add/drop and withdrawal deadlines to semesters, the withdrawn enrollment
+-- status, and a log of admin overrides of those deadlines
+
+ALTER TABLE semesters
+    ADD COLUMN IF NOT EXISTS add_drop_end DATE,
+    ADD COLUMN IF NOT EXISTS withdrawal_deadline DATE;
+
+ALTER TABLE course_enrollments DROP CONSTRAINT IF EXISTS course_enrollments_enrollment_status_check;
+ALTER TABLE course_enrollments ADD CONSTRAINT course_enrollments_enrollment_status_check
+    CHECK(enrollment_status IN ('enrolled', 'waitlisted', 'dropped', 'withdrawn', 'completed', 'failed'));
+
+CREATE TABLE IF NOT EXISTS enrollment_overrides (
+    override_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- Not a foreign key, enrollments dropped in the add/drop period are deleted
+    enrollment_id UUID NOT NULL,
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK(action IN ('enroll', 'drop')),
+    reason TEXT NOT NULL,
+    overridden_by UUID NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_enrollment_overrides_enrollment ON enrollment_overrides(enrollment_id);
+CREATE INDEX IF NOT EXISTS idx_enrollment_overrides_course ON enrollment_overrides(course_id);
@@ -0,0 +1,10 @@
+-- 016_add_prerequisite_rules.down.sql
+DROP INDEX IF EXISTS idx_prerequisite_waivers_course;
+DROP TABLE IF EXISTS prerequisite_waivers CASCADE;
+
+ALTER TABLE subject_prerequisites
+    DROP COLUMN IF EXISTS min_grade_points,
+    DROP COLUMN IF EXISTS min_grade;
+
+ALTER TABLE IF EXISTS subject_corequisites RENAME TO course_corequisites;
+ALTER TABLE IF EXISTS subject_prerequisites RENAME TO course_prerequisites;
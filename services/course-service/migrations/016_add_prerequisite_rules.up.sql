@@ -0,0 +1,26 @@
+-- 016_add_prerequisite_rules.up.sql
+-- Add minimum grades to prerequisites and a log of prerequisite and
+-- corequisite waivers granted to students
+
+-- The service queries the requisite tables by their subject names
+ALTER TABLE IF EXISTS course_prerequisites RENAME TO subject_prerequisites;
+ALTER TABLE IF EXISTS course_corequisites RENAME TO subject_corequisites;
+
+ALTER TABLE subject_prerequisites
+    ADD COLUMN IF NOT EXISTS min_grade VARCHAR(5),
+    ADD COLUMN IF NOT EXISTS min_grade_points NUMERIC(4,2) CHECK (min_grade_points BETWEEN 0 AND 10);
+
+CREATE TABLE IF NOT EXISTS prerequisite_waivers (
+    waiver_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    waived_subject_id UUID NOT NULL REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    requirement_type VARCHAR(20) NOT NULL CHECK(requirement_type IN ('prerequisite', 'corequisite')),
+    reason TEXT NOT NULL,
+    granted_by UUID NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT unique_prerequisite_waiver UNIQUE (student_id, course_id, waived_subject_id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_prerequisite_waivers_course ON prerequisite_waivers(course_id);
@@ -0,0 +1,12 @@
+-- 017_create_grading_scales.down.sql
+DROP TABLE IF EXISTS student_semester_gpas CASCADE;
+
+ALTER TABLE course_enrollments ALTER COLUMN grade_points TYPE NUMERIC(3,2);
+
+DROP INDEX IF EXISTS idx_programs_grading_scale;
+ALTER TABLE programs DROP COLUMN IF EXISTS grading_scale_id;
+
+DROP TRIGGER IF EXISTS update_grading_scales_updated_at ON grading_scales;
+DROP INDEX IF EXISTS idx_grading_scales_default;
+DROP TABLE IF EXISTS grading_scale_grades CASCADE;
+DROP TABLE IF EXISTS grading_scales CASCADE;
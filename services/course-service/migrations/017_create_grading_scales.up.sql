@@ -0,0 +1,73 @@
+-- 017_create_grading_scales.up.sql
+-- Create grading scales that map letter grades to grade points per program,
+-- and the semester GPAs computed from them
+
+CREATE TABLE IF NOT EXISTS grading_scales (
+    scale_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scale_name VARCHAR(100) UNIQUE NOT NULL,
+    max_grade_points NUMERIC(4,2) NOT NULL CHECK(max_grade_points > 0 AND max_grade_points <= 10),
+    pass_grade_points NUMERIC(4,2) NOT NULL CHECK(pass_grade_points >= 0),
+    repeat_policy VARCHAR(10) NOT NULL DEFAULT 'best' CHECK(repeat_policy IN ('best', 'latest')),
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    CHECK(pass_grade_points <= max_grade_points)
+);
+
+CREATE TABLE IF NOT EXISTS grading_scale_grades (
+    scale_id UUID NOT NULL REFERENCES grading_scales(scale_id) ON DELETE CASCADE,
+    grade VARCHAR(5) NOT NULL,
+    grade_points NUMERIC(4,2) NOT NULL CHECK(grade_points >= 0),
+    PRIMARY KEY (scale_id, grade)
+);
+
+-- Programs without a grading scale use the default scale
+ALTER TABLE programs
+    ADD COLUMN IF NOT EXISTS grading_scale_id UUID REFERENCES grading_scales(scale_id) ON DELETE RESTRICT;
+
+-- Grade points of 10 do not fit NUMERIC(3,2)
+ALTER TABLE course_enrollments ALTER COLUMN grade_points TYPE NUMERIC(4,2);
+
+CREATE TABLE IF NOT EXISTS student_semester_gpas (
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    semester_id UUID NOT NULL REFERENCES semesters(semester_id) ON DELETE CASCADE,
+    gpa NUMERIC(4,2),
+    credits_attempted INTEGER NOT NULL DEFAULT 0,
+    credits_earned INTEGER NOT NULL DEFAULT 0,
+    computed_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (student_id, semester_id)
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_grading_scales_default ON grading_scales(is_default) WHERE is_default;
+CREATE INDEX IF NOT EXISTS idx_programs_grading_scale ON programs(grading_scale_id);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_grading_scales_updated_at
+    BEFORE UPDATE ON grading_scales
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 10-point and 4-point scales
+WITH scale AS (
+    INSERT INTO grading_scales (scale_name, max_grade_points, pass_grade_points, is_default)
+    VALUES ('10-point', 10, 4, true)
+    RETURNING scale_id
+)
+INSERT INTO grading_scale_grades (scale_id, grade, grade_points)
+SELECT scale.scale_id, g.grade, g.grade_points
+FROM scale, (VALUES
+    ('O', 10), ('A+', 9), ('A', 8), ('B+', 7), ('B', 6), ('C', 5), ('P', 4), ('F', 0)
+) AS g(grade, grade_points);
+
+WITH scale AS (
+    INSERT INTO grading_scales (scale_name, max_grade_points, pass_grade_points)
+    VALUES ('4-point', 4, 1)
+    RETURNING scale_id
+)
+INSERT INTO grading_scale_grades (scale_id, grade, grade_points)
+SELECT scale.scale_id, g.grade, g.grade_points
+FROM scale, (VALUES
+    ('A', 4.0), ('A-', 3.7), ('B+', 3.3), ('B', 3.0), ('B-', 2.7), ('C+', 2.3),
+    ('C', 2.0), ('C-', 1.7), ('D+', 1.3), ('D', 1.0), ('F', 0.0)
+) AS g(grade, grade_points);
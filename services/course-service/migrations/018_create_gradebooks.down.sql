@@ -0,0 +1,10 @@
+-- 018_create_gradebooks.down.sql
+DROP INDEX IF EXISTS idx_grade_amendments_pending;
+DROP INDEX IF EXISTS idx_grade_amendments_enrollment;
+DROP INDEX IF EXISTS idx_grade_amendments_course;
+DROP INDEX IF EXISTS idx_gradebook_entries_course;
+DROP TABLE IF EXISTS grade_amendments CASCADE;
+DROP TABLE IF EXISTS gradebook_entries CASCADE;
+
+DROP TRIGGER IF EXISTS update_course_gradebooks_updated_at ON course_gradebooks;
+DROP TABLE IF EXISTS course_gradebooks CASCADE;
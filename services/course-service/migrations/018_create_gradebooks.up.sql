@@ -0,0 +1,55 @@
+-- 018_create_gradebooks.up.sql
+-- Create gradebooks holding the grades submitted for a course roster until the
+-- head of department approves them, and the amendments of approved grades
+
+CREATE TABLE IF NOT EXISTS course_gradebooks (
+    course_id UUID PRIMARY KEY REFERENCES courses(course_id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK(status IN ('submitted', 'returned', 'approved')),
+    submitted_by UUID NOT NULL,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    reviewed_by UUID,
+    reviewed_at TIMESTAMPTZ,
+    review_comment TEXT,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS gradebook_entries (
+    enrollment_id UUID PRIMARY KEY REFERENCES course_enrollments(enrollment_id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES course_gradebooks(course_id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    grade VARCHAR(5) NOT NULL,
+    grade_points NUMERIC(4,2) NOT NULL CHECK(grade_points >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS grade_amendments (
+    amendment_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    enrollment_id UUID NOT NULL REFERENCES course_enrollments(enrollment_id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    previous_grade VARCHAR(5),
+    previous_grade_points NUMERIC(4,2),
+    new_grade VARCHAR(5) NOT NULL,
+    new_grade_points NUMERIC(4,2) NOT NULL CHECK(new_grade_points >= 0),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'approved', 'rejected')),
+    requested_by UUID NOT NULL,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    reviewed_by UUID,
+    reviewed_at TIMESTAMPTZ,
+    review_comment TEXT
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_gradebook_entries_course ON gradebook_entries(course_id);
+CREATE INDEX IF NOT EXISTS idx_grade_amendments_course ON grade_amendments(course_id);
+CREATE INDEX IF NOT EXISTS idx_grade_amendments_enrollment ON grade_amendments(enrollment_id);
+
+-- An enrollment has at most one pending amendment
+CREATE UNIQUE INDEX IF NOT EXISTS idx_grade_amendments_pending ON grade_amendments(enrollment_id) WHERE status = 'pending';
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_course_gradebooks_updated_at
+    BEFORE UPDATE ON course_gradebooks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
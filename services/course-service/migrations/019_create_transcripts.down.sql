@@ -0,0 +1,3 @@
+-- 019_create_transcripts.down.sql
+DROP INDEX IF EXISTS idx_transcripts_student_hash;
+DROP TABLE IF EXISTS transcripts CASCADE;
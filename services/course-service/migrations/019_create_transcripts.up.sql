@@ -0,0 +1,16 @@
+-- 019_create_transcripts.up.sql
+-- Create issued transcripts, kept so that their verification codes can be
+-- checked against the signed hash of their contents
+
+CREATE TABLE IF NOT EXISTS transcripts (
+    verification_code VARCHAR(20) PRIMARY KEY,
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    contents TEXT NOT NULL,
+    content_hash CHAR(64) NOT NULL,
+    signature CHAR(64) NOT NULL,
+    issued_by UUID NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_transcripts_student_hash ON transcripts(student_id, content_hash);
@@ -0,0 +1,10 @@
+-- 020_create_curricula.down.sql
+DROP INDEX IF EXISTS idx_curriculum_elective_subjects_subject;
+DROP INDEX IF EXISTS idx_curriculum_elective_buckets_curriculum;
+DROP INDEX IF EXISTS idx_curriculum_core_subjects_subject;
+DROP TABLE IF EXISTS curriculum_elective_subjects CASCADE;
+DROP TABLE IF EXISTS curriculum_elective_buckets CASCADE;
+DROP TABLE IF EXISTS curriculum_core_subjects CASCADE;
+
+DROP TRIGGER IF EXISTS update_curricula_updated_at ON curricula;
+DROP TABLE IF EXISTS curricula CASCADE;
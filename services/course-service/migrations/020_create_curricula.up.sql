@@ -0,0 +1,46 @@
+-- 020_create_curricula.up.sql
+-- Create curriculum versions of programs, holding the core subjects, elective
+-- buckets and total credits a batch of students has to complete to graduate
+
+CREATE TABLE IF NOT EXISTS curricula (
+    curriculum_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    program_id UUID NOT NULL REFERENCES programs(program_id) ON DELETE CASCADE,
+    batch_year INTEGER NOT NULL CHECK(batch_year >= 2000 AND batch_year <= 2100),
+    version_name VARCHAR(100) NOT NULL,
+    total_credits INTEGER NOT NULL CHECK(total_credits > 0),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    UNIQUE(program_id, batch_year)
+);
+
+CREATE TABLE IF NOT EXISTS curriculum_core_subjects (
+    curriculum_id UUID NOT NULL REFERENCES curricula(curriculum_id) ON DELETE CASCADE,
+    subject_id UUID NOT NULL REFERENCES subjects(subject_id) ON DELETE RESTRICT,
+    semester_number INTEGER NOT NULL CHECK(semester_number > 0),
+    PRIMARY KEY (curriculum_id, subject_id)
+);
+
+CREATE TABLE IF NOT EXISTS curriculum_elective_buckets (
+    bucket_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    curriculum_id UUID NOT NULL REFERENCES curricula(curriculum_id) ON DELETE CASCADE,
+    bucket_name VARCHAR(100) NOT NULL,
+    min_credits INTEGER NOT NULL CHECK(min_credits > 0),
+    UNIQUE(curriculum_id, bucket_name)
+);
+
+CREATE TABLE IF NOT EXISTS curriculum_elective_subjects (
+    bucket_id UUID NOT NULL REFERENCES curriculum_elective_buckets(bucket_id) ON DELETE CASCADE,
+    subject_id UUID NOT NULL REFERENCES subjects(subject_id) ON DELETE RESTRICT,
+    PRIMARY KEY (bucket_id, subject_id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_curriculum_core_subjects_subject ON curriculum_core_subjects(subject_id);
+CREATE INDEX IF NOT EXISTS idx_curriculum_elective_buckets_curriculum ON curriculum_elective_buckets(curriculum_id);
+CREATE INDEX IF NOT EXISTS idx_curriculum_elective_subjects_subject ON curriculum_elective_subjects(subject_id);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_curricula_updated_at
+    BEFORE UPDATE ON curricula
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
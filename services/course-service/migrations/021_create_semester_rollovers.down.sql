@@ -0,0 +1,9 @@
+-- 021_create_semester_rollovers.down.sql
+DROP INDEX IF EXISTS idx_semester_rollovers_active;
+DROP INDEX IF EXISTS idx_semester_rollover_students_rollover;
+DROP INDEX IF EXISTS idx_semester_rollovers_unfinished;
+DROP INDEX IF EXISTS idx_semester_rollovers_semester;
+DROP TABLE IF EXISTS semester_rollover_students CASCADE;
+
+DROP TRIGGER IF EXISTS update_semester_rollovers_updated_at ON semester_rollovers;
+DROP TABLE IF EXISTS semester_rollovers CASCADE;
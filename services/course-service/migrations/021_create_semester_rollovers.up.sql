@@ -0,0 +1,51 @@
+-- 021_create_semester_rollovers.up.sql
+-- Create semester rollover jobs, which complete the courses of a semester,
+-- promote its students and make the next semester current, and the ledger of
+-- the students each rollover promoted or flagged
+
+CREATE TABLE IF NOT EXISTS semester_rollovers (
+    rollover_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    semester_id UUID NOT NULL REFERENCES semesters(semester_id) ON DELETE CASCADE,
+    next_semester_id UUID NOT NULL REFERENCES semesters(semester_id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'running', 'completed', 'failed')),
+    courses_completed INTEGER NOT NULL DEFAULT 0,
+    enrollments_dropped INTEGER NOT NULL DEFAULT 0,
+    students_promoted INTEGER NOT NULL DEFAULT 0,
+    students_flagged INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    requested_by UUID NOT NULL,
+    locked_until TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    CHECK(next_semester_id <> semester_id)
+);
+
+-- A student is promoted or flagged at most once per semester, also when a
+-- failed rollover is started again
+CREATE TABLE IF NOT EXISTS semester_rollover_students (
+    semester_id UUID NOT NULL REFERENCES semesters(semester_id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    rollover_id UUID NOT NULL REFERENCES semester_rollovers(rollover_id) ON DELETE CASCADE,
+    from_semester INTEGER NOT NULL,
+    to_semester INTEGER,
+    flag_reason VARCHAR(20) CHECK(flag_reason IN ('inactive', 'probation', 'final_semester')),
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (semester_id, student_id),
+    CHECK((to_semester IS NULL) <> (flag_reason IS NULL))
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_semester_rollovers_semester ON semester_rollovers(semester_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_semester_rollovers_unfinished ON semester_rollovers(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_semester_rollover_students_rollover ON semester_rollover_students(rollover_id);
+
+-- A semester has at most one unfinished rollover
+CREATE UNIQUE INDEX IF NOT EXISTS idx_semester_rollovers_active ON semester_rollovers(semester_id) WHERE status IN ('pending', 'running');
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_semester_rollovers_updated_at
+    BEFORE UPDATE ON semester_rollovers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
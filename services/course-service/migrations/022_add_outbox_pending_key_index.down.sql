@@ -0,0 +1,2 @@
+-- 022_add_outbox_pending_key_index.down.sql
+DROP INDEX IF EXISTS idx_outbox_events_pending_key;
@@ -9,19 +9,27 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
 	httpHandler "github.com/SureshAmal/NimbusU-backend/services/user-service/internal/handler/http"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/repository/postgres"
+	redisRepo "github.com/SureshAmal/NimbusU-backend/services/user-service/internal/repository/redis"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/service"
 	"github.com/SureshAmal/NimbusU-backend/shared/config"
 	"github.com/SureshAmal/NimbusU-backend/shared/database"
 	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
 	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"github.com/SureshAmal/NimbusU-backend/shared/middleware"
 	"github.com/SureshAmal/NimbusU-backend/shared/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
 
+// keyActivationDelay is how long a new signing key is published in the JWKS
+// before it signs tokens, giving verifiers time to pick it up
+const keyActivationDelay = time.Hour
+
 // @title           NimbusU User Service API
 // @version         1.0
 // @description     User Management Service for NimbusU University Platform
@@ -65,6 +73,7 @@ func main() {
 		logger.Fatal("Failed to connect to PostgreSQL", zap.Error(err))
 	}
 	defer database.ClosePostgresPool(pgPool)
+	db := database.NewDB(pgPool)
 
 	// Connect to Redis
 	logger.Info("Connecting to Redis", zap.String("url", cfg.Redis.URL))
@@ -82,21 +91,75 @@ func main() {
 	}
 	defer kafkaProducer.Close()
 
+	// Dead letters of the event consumers are inspected and re-driven by admins
+	deadLetters, err := kafka.NewDeadLetterQueue(cfg.Kafka, kafkaProducer)
+	if err != nil {
+		logger.Fatal("Failed to initialize Kafka dead-letter queue", zap.Error(err))
+	}
+	defer deadLetters.Close()
+
+	// Events are written to the outbox in the transaction of the change that
+	// raised them, and relayed to Kafka from there
+	outbox := kafka.NewOutbox(db)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go kafka.NewOutboxRelay(db, kafkaProducer).Start(relayCtx)
+
+	// Initialize repositories
+	logger.Info("Initializing repositories")
+	userRepo := postgres.NewUserRepository(db)
+	profileRepo := postgres.NewUserProfileRepository(db)
+	roleRepo := postgres.NewRoleRepository(db)
+	permissionRepo := postgres.NewPermissionRepository(db)
+	rolePermissionRepo := postgres.NewRolePermissionRepository(db)
+	sessionRepo := postgres.NewSessionRepository(db)
+	passwordTokenRepo := postgres.NewPasswordResetTokenRepository(db)
+	activityLogRepo := postgres.NewActivityLogRepository(db)
+	mfaRepo := postgres.NewMFARepository(db)
+	loginAttemptRepo := redisRepo.NewLoginAttemptRepository(redisClient)
+	signingKeyRepo := postgres.NewSigningKeyRepository(db)
+
+	// Load the signing keys, creating the first one on a fresh database.
+	// A replaced key is kept until every token it signed has expired.
+	keyRing := utils.NewKeyRing()
+	keyRotationSvc := service.NewKeyRotationService(
+		signingKeyRepo,
+		keyRing,
+		cfg.JWT.SigningAlgorithm,
+		time.Duration(cfg.JWT.KeyRotationInterval)*time.Second,
+		keyActivationDelay,
+		time.Duration(max(cfg.JWT.AccessTokenExpiry, cfg.JWT.RefreshTokenExpiry))*time.Second,
+	)
+	if err := keyRotationSvc.Sync(context.Background()); err != nil {
+		logger.Fatal("Failed to load signing keys", zap.Error(err))
+	}
+
+	rotationCtx, stopRotation := context.WithCancel(context.Background())
+	defer stopRotation()
+	go runKeyRotation(rotationCtx, keyRotationSvc)
+
 	// Initialize JWT manager
 	jwtManager := utils.NewJWTManager(
-		cfg.JWT.Secret,
+		keyRing,
 		cfg.JWT.AccessTokenExpiry,
 		cfg.JWT.RefreshTokenExpiry,
 	)
 
-	// Initialize repositories
-	logger.Info("Initializing repositories")
-	userRepo := postgres.NewUserRepository(pgPool)
-	profileRepo := postgres.NewUserProfileRepository(pgPool)
-	roleRepo := postgres.NewRoleRepository(pgPool)
-	sessionRepo := postgres.NewSessionRepository(pgPool)
-	passwordTokenRepo := postgres.NewPasswordResetTokenRepository(pgPool)
-	activityLogRepo := postgres.NewActivityLogRepository(pgPool)
+	// Role permissions are resolved per request and cached in Redis
+	permissionResolver := middleware.NewPermissionResolver(redisClient, func(ctx context.Context, roleID uuid.UUID) ([]string, error) {
+		permissions, err := rolePermissionRepo.GetPermissionsByRole(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, len(permissions))
+		for i, permission := range permissions {
+			keys[i] = middleware.PermissionKey(permission.Resource, permission.Action)
+		}
+		return keys, nil
+	}, 10*time.Minute)
+
+	// Revoked access tokens are rejected until they would have expired
+	tokenRevocations := middleware.NewTokenRevocationList(redisClient, time.Duration(cfg.JWT.AccessTokenExpiry)*time.Second)
 
 	// Initialize services
 	logger.Info("Initializing services")
@@ -105,25 +168,53 @@ func main() {
 		profileRepo,
 		roleRepo,
 		activityLogRepo,
-		kafkaProducer,
+		tokenRevocations,
+		db,
+		outbox,
 	)
 
 	authSvc := service.NewAuthService(
 		userRepo,
 		profileRepo,
 		roleRepo,
+		permissionRepo,
 		sessionRepo,
 		passwordTokenRepo,
 		activityLogRepo,
+		mfaRepo,
+		loginAttemptRepo,
 		jwtManager,
-		kafkaProducer,
+		tokenRevocations,
+		db,
+		outbox,
 		cfg.JWT.RefreshTokenExpiry,
+		domain.LockoutPolicy{
+			MaxFailedAttempts:   cfg.Lockout.MaxFailedAttempts,
+			MaxFailedIPAttempts: cfg.Lockout.MaxFailedIPAttempts,
+			AttemptWindow:       time.Duration(cfg.Lockout.AttemptWindow) * time.Second,
+			BackoffBase:         time.Duration(cfg.Lockout.BackoffBase) * time.Second,
+			LockoutDuration:     time.Duration(cfg.Lockout.LockoutDuration) * time.Second,
+		},
+	)
+
+	roleSvc := service.NewRoleService(
+		roleRepo,
+		permissionRepo,
+		rolePermissionRepo,
+		userRepo,
+		activityLogRepo,
+		permissionResolver,
+		db,
+		outbox,
 	)
 
 	// Initialize HTTP handlers
 	logger.Info("Initializing HTTP handlers")
 	authHandler := httpHandler.NewAuthHandler(authSvc)
 	userHandler := httpHandler.NewUserHandler(userSvc)
+	roleHandler := httpHandler.NewRoleHandler(roleSvc)
+	jwksHandler := httpHandler.NewJWKSHandler(keyRing)
+	dlqHandler := httpHandler.NewDLQHandler(deadLetters)
 
 	// Setup Gin router
 	if cfg.Server.Env == "production" {
@@ -134,7 +225,7 @@ func main() {
 	router.Use(gin.Recovery())
 
 	// Setup routes
-	httpHandler.SetupRoutes(router, authHandler, userHandler, jwtManager, redisClient)
+	httpHandler.SetupRoutes(router, authHandler, userHandler, roleHandler, jwksHandler, dlqHandler, jwtManager, redisClient, permissionResolver, tokenRevocations)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -170,3 +261,21 @@ func main() {
 
 	logger.Info("Server exited successfully")
 }
+
+// runKeyRotation rotates and reloads the signing keys until ctx is done. Every
+// instance runs it, the repository makes sure only one of them creates a key.
+func runKeyRotation(ctx context.Context, keyRotationSvc domain.KeyRotationService) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := keyRotationSvc.Sync(ctx); err != nil {
+				logger.Warn("Failed to sync signing keys", zap.Error(err))
+			}
+		}
+	}
+}
@@ -25,6 +25,7 @@ type Role struct {
 	RoleID      uuid.UUID `json:"role_id" db:"role_id"`
 	RoleName    string    `json:"role_name" db:"role_name"`
 	Description *string   `json:"description" db:"description"`
+	MFARequired bool      `json:"mfa_required" db:"mfa_required"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -86,13 +87,63 @@ type PasswordResetToken struct {
 
 // ActiveSession represents an active user session
 type ActiveSession struct {
-	SessionID    uuid.UUID `json:"session_id" db:"session_id"`
-	UserID       uuid.UUID `json:"user_id" db:"user_id"`
-	RefreshToken string    `json:"refresh_token" db:"refresh_token"`
-	DeviceInfo   *string   `json:"device_info" db:"device_info"`
-	IPAddress    *string   `json:"ip_address" db:"ip_address"`
-	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	SessionID        uuid.UUID  `json:"session_id" db:"session_id"`
+	UserID           uuid.UUID  `json:"user_id" db:"user_id"`
+	FamilyID         uuid.UUID  `json:"family_id" db:"family_id"`  // Shared by every session rotated from the same login
+	RefreshTokenHash string     `json:"-" db:"refresh_token_hash"` // SHA-256 of the refresh token, never the token itself
+	DeviceInfo       *string    `json:"device_info" db:"device_info"`
+	IPAddress        *string    `json:"ip_address" db:"ip_address"`
+	RotatedAt        *time.Time `json:"rotated_at,omitempty" db:"rotated_at"` // Set once the refresh token was exchanged
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
+
+// UserMFA represents a user's TOTP second factor
+type UserMFA struct {
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	TOTPSecret   string     `json:"-" db:"totp_secret"` // Never expose in JSON
+	IsEnabled    bool       `json:"is_enabled" db:"is_enabled"`
+	LastUsedStep int64      `json:"-" db:"last_used_step"`
+	EnabledAt    *time.Time `json:"enabled_at" db:"enabled_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// SigningKey represents a stored JWT signing key. The newest key whose ActivatesAt
+// has passed signs new tokens; older keys are kept so their tokens still verify.
+type SigningKey struct {
+	KeyID         string    `json:"key_id" db:"key_id"`
+	Algorithm     string    `json:"algorithm" db:"algorithm"`
+	PrivateKeyPEM string    `json:"-" db:"private_key"` // Never expose in JSON
+	ActivatesAt   time.Time `json:"activates_at" db:"activates_at"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// LockoutPolicy configures brute-force protection on login. Failures are counted
+// per email and per IP address; past half of a limit every further failure doubles
+// the wait before the next attempt, and reaching the limit locks for LockoutDuration.
+type LockoutPolicy struct {
+	MaxFailedAttempts   int
+	MaxFailedIPAttempts int
+	AttemptWindow       time.Duration
+	BackoffBase         time.Duration
+	LockoutDuration     time.Duration
+}
+
+// LoginResult represents the outcome of a login step. Either the session tokens
+// are set, or MFAToken is set and the login must be completed via MFA verification.
+type LoginResult struct {
+	AccessToken  string
+	RefreshToken string
+	User         *UserWithProfile
+
+	MFAToken              string
+	MFAEnrollmentRequired bool
+	MFASecret             string
+	MFAProvisioningURI    string
+
+	// BackupCodes are returned once, when MFA enrollment completes
+	BackupCodes []string
 }
 
 // UserWithProfile combines User and UserProfile
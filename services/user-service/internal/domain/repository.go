@@ -2,10 +2,17 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Transactor runs fn in a database transaction. Repository calls and published
+// events made with the context passed to fn join the transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
 	// User CRUD
@@ -18,6 +25,7 @@ type UserRepository interface {
 
 	// List and search
 	List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*User, int64, error)
+	CountByRole(ctx context.Context, roleID uuid.UUID) (int64, error)
 
 	// Status management
 	UpdateStatus(ctx context.Context, userID uuid.UUID, status string) error
@@ -36,6 +44,9 @@ type UserProfileRepository interface {
 type RoleRepository interface {
 	Create(ctx context.Context, role *Role) error
 	GetByID(ctx context.Context, roleID uuid.UUID) (*Role, error)
+	// GetByIDForUpdate gets a role and locks it until the transaction ends.
+	// Users cannot be given a locked role meanwhile.
+	GetByIDForUpdate(ctx context.Context, roleID uuid.UUID) (*Role, error)
 	GetByName(ctx context.Context, roleName string) (*Role, error)
 	List(ctx context.Context) ([]*Role, error)
 	Update(ctx context.Context, role *Role) error
@@ -46,6 +57,7 @@ type RoleRepository interface {
 type PermissionRepository interface {
 	Create(ctx context.Context, permission *Permission) error
 	GetByID(ctx context.Context, permissionID uuid.UUID) (*Permission, error)
+	GetByName(ctx context.Context, permissionName string) (*Permission, error)
 	List(ctx context.Context) ([]*Permission, error)
 	GetByRoleID(ctx context.Context, roleID uuid.UUID) ([]*Permission, error)
 }
@@ -57,6 +69,41 @@ type RolePermissionRepository interface {
 	GetPermissionsByRole(ctx context.Context, roleID uuid.UUID) ([]*Permission, error)
 }
 
+// MFARepository defines the interface for TOTP factors and backup codes
+type MFARepository interface {
+	Upsert(ctx context.Context, mfa *UserMFA) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*UserMFA, error)
+	Enable(ctx context.Context, userID uuid.UUID) error
+	MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+
+	// Backup codes are stored as SHA-256 hashes and consumed at most once
+	ReplaceBackupCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	UseBackupCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+}
+
+// SigningKeyRepository defines the interface for JWT signing keys
+type SigningKeyRepository interface {
+	List(ctx context.Context) ([]*SigningKey, error)
+	// Rotate stores key unless another instance already added a key activating after
+	// rotateBefore, and reports whether key was stored
+	Rotate(ctx context.Context, key *SigningKey, rotateBefore time.Time) (bool, error)
+	Delete(ctx context.Context, keyID string) error
+}
+
+// LoginAttemptRepository defines the interface for failed login counters and lockouts.
+// Keys identify what is being throttled, e.g. an email address or an IP address.
+type LoginAttemptRepository interface {
+	// RecordFailure increments the failure counter of a key and returns the new count.
+	// The counter expires once window has passed since the first failure.
+	RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error)
+	Lock(ctx context.Context, key string, duration time.Duration) error
+	// LockTTL returns how long a key stays locked, or zero when it is not locked
+	LockTTL(ctx context.Context, key string) (time.Duration, error)
+	// Clear removes both the failure counter and any lock of a key
+	Clear(ctx context.Context, key string) error
+}
+
 // ActivityLogRepository defines the interface for activity logging
 type ActivityLogRepository interface {
 	Create(ctx context.Context, log *UserActivityLog) error
@@ -75,9 +122,13 @@ type PasswordResetTokenRepository interface {
 // SessionRepository defines the interface for session management
 type SessionRepository interface {
 	Create(ctx context.Context, session *ActiveSession) error
-	GetByRefreshToken(ctx context.Context, refreshToken string) (*ActiveSession, error)
+	// GetByRefreshTokenHash also returns rotated sessions so a replayed token can be detected
+	GetByRefreshTokenHash(ctx context.Context, tokenHash string) (*ActiveSession, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*ActiveSession, error)
+	// MarkRotated returns ErrSessionNotFound if the session was already rotated
+	MarkRotated(ctx context.Context, sessionID uuid.UUID) error
 	Delete(ctx context.Context, sessionID uuid.UUID) error
+	DeleteByFamilyID(ctx context.Context, familyID uuid.UUID) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 	DeleteExpired(ctx context.Context) error
 }
@@ -3,7 +3,9 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
 	"github.com/google/uuid"
 )
 
@@ -11,15 +13,48 @@ var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrAccountLocked      = errors.New("too many failed login attempts")
 	ErrInvalidToken       = errors.New("invalid token")
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
 	ErrTokenExpired       = errors.New("token has expired")
 	ErrRoleNotFound       = errors.New("role not found")
 	ErrPermissionNotFound = errors.New("permission not found")
+	ErrRoleAlreadyExists  = errors.New("role already exists")
+	ErrRoleHasUsers       = errors.New("role is still assigned to users")
+	ErrRoleProtected      = errors.New("built-in roles cannot be renamed or deleted")
+	ErrPermissionExists   = errors.New("permission already exists")
+	ErrMFANotEnabled      = errors.New("mfa is not enabled")
+	ErrMFAAlreadyEnabled  = errors.New("mfa is already enabled")
+	ErrInvalidMFACode     = errors.New("invalid mfa code")
+	ErrMFARequiredByRole  = errors.New("mfa is required for this role")
 	ErrProfileNotFound    = errors.New("profile not found")
 	ErrSessionNotFound    = errors.New("session not found")
 	ErrUnauthorized       = errors.New("unauthorized")
+	ErrRoleOutranksActor  = errors.New("user's role holds permissions the actor's role lacks")
 )
 
+// LoginThrottledError wraps ErrInvalidCredentials or ErrAccountLocked with the
+// time the client has to wait before the next login attempt is accepted
+type LoginThrottledError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// KeyRotationService keeps the JWT key ring in sync with the stored signing keys
+type KeyRotationService interface {
+	// Sync rotates the signing key when it is due, drops keys no token can still
+	// depend on and loads the remaining keys into the key ring
+	Sync(ctx context.Context) error
+}
+
 // UserService defines business logic for user management
 type UserService interface {
 	// User management
@@ -45,9 +80,9 @@ type UserService interface {
 // AuthService defines business logic for authentication
 type AuthService interface {
 	// Authentication
-	Login(ctx context.Context, email, password string, ipAddress, userAgent string) (accessToken, refreshToken string, user *UserWithProfile, err error)
-	Logout(ctx context.Context, userID uuid.UUID, refreshToken string) error
-	RefreshToken(ctx context.Context, refreshToken string) (accessToken, newRefreshToken string, err error)
+	Login(ctx context.Context, email, password string, ipAddress, userAgent string) (*LoginResult, error)
+	Logout(ctx context.Context, userID uuid.UUID, refreshToken, accessTokenID string, accessTokenExpiresAt time.Time) error
+	RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (accessToken, newRefreshToken string, err error)
 
 	// Password management
 	ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) error
@@ -58,6 +93,17 @@ type AuthService interface {
 	GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*ActiveSession, error)
 	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
 	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+
+	// Multi-factor authentication
+	VerifyMFA(ctx context.Context, mfaToken, code, ipAddress, userAgent string) (*LoginResult, error)
+	EnrollMFA(ctx context.Context, userID uuid.UUID) (secret, provisioningURI string, err error)
+	EnableMFA(ctx context.Context, userID uuid.UUID, code string) (backupCodes []string, err error)
+	DisableMFA(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateBackupCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	ResetMFA(ctx context.Context, userID, actorID uuid.UUID) error
+
+	// Brute-force protection
+	UnlockAccount(ctx context.Context, userID, actorID uuid.UUID) error
 }
 
 // RoleService defines business logic for role management
@@ -68,15 +114,37 @@ type RoleService interface {
 	ListRoles(ctx context.Context) ([]*Role, error)
 	UpdateRole(ctx context.Context, roleID uuid.UUID, updates map[string]interface{}) error
 	DeleteRole(ctx context.Context, roleID uuid.UUID) error
+	SetMFARequired(ctx context.Context, roleID uuid.UUID, required bool, actorID uuid.UUID) error
 
 	// Permission management
-	AssignPermission(ctx context.Context, roleID, permissionID uuid.UUID) error
-	RevokePermission(ctx context.Context, roleID, permissionID uuid.UUID) error
+	CreatePermission(ctx context.Context, permission *Permission) error
+	ListPermissions(ctx context.Context) ([]*Permission, error)
+	AssignPermission(ctx context.Context, roleID, permissionID, actorID uuid.UUID) error
+	RevokePermission(ctx context.Context, roleID, permissionID, actorID uuid.UUID) error
 	GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]*Permission, error)
 }
 
-// EventProducer defines interface for publishing events
+// PermissionCache defines interface for dropping cached role permissions
+type PermissionCache interface {
+	InvalidateRole(ctx context.Context, roleID uuid.UUID) error
+}
+
+// TokenRevoker defines interface for revoking access tokens before they expire
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+// EventProducer defines interface for publishing events. Events are written to
+// the outbox, so an event published within Transactor.WithinTransaction is only
+// delivered if the transaction commits.
 type EventProducer interface {
-	PublishEvent(topic string, key string, event interface{}) error
-	Close() error
+	PublishEvent(ctx context.Context, topic string, key string, event interface{}) error
+}
+
+// DeadLetterQueue defines interface for inspecting and re-driving messages that
+// event consumers gave up on
+type DeadLetterQueue interface {
+	List(ctx context.Context, topic string, limit int) ([]*kafka.DeadLetter, error)
+	Redrive(ctx context.Context, topic string, partition int32, offset int64) error
 }
@@ -6,6 +6,17 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
+// VerifyMFARequest represents the second step of an MFA login
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFACodeRequest represents a TOTP or backup code confirming an MFA change
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
 // RegisterRequest represents user registration data
 type RegisterRequest struct {
 	RegisterNo int64  `json:"register_no" binding:"required"`
@@ -78,6 +89,25 @@ type CreateRoleRequest struct {
 	Description *string `json:"description"`
 }
 
+// UpdateRoleRequest represents role update data
+type UpdateRoleRequest struct {
+	RoleName    string  `json:"role_name"`
+	Description *string `json:"description"`
+}
+
+// SetRoleMFARequest represents enforcing or relaxing MFA for a role
+type SetRoleMFARequest struct {
+	MFARequired *bool `json:"mfa_required" binding:"required"`
+}
+
+// CreatePermissionRequest represents permission creation data
+type CreatePermissionRequest struct {
+	PermissionName string  `json:"permission_name" binding:"required"`
+	Resource       string  `json:"resource" binding:"required"`
+	Action         string  `json:"action" binding:"required"`
+	Description    *string `json:"description"`
+}
+
 // BulkUserImportRequest represents bulk user import
 type BulkUserImportRequest struct {
 	Users []CreateUserRequest `json:"users" binding:"required,min=1"`
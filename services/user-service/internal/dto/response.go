@@ -49,6 +49,27 @@ type LoginResponse struct {
 	TokenType    string                  `json:"token_type"`
 	ExpiresIn    int                     `json:"expires_in"`
 	User         UserWithProfileResponse `json:"user"`
+	BackupCodes  []string                `json:"backup_codes,omitempty"`
+}
+
+// MFAChallengeResponse is returned by login when a second factor is required
+type MFAChallengeResponse struct {
+	MFAToken           string `json:"mfa_token"`
+	ExpiresIn          int    `json:"expires_in"`
+	EnrollmentRequired bool   `json:"enrollment_required"`
+	Secret             string `json:"secret,omitempty"`
+	ProvisioningURI    string `json:"provisioning_uri,omitempty"`
+}
+
+// MFAEnrollmentResponse represents a pending TOTP enrollment
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// BackupCodesResponse represents freshly issued one-time backup codes
+type BackupCodesResponse struct {
+	BackupCodes []string `json:"backup_codes"`
 }
 
 // RefreshTokenResponse represents token refresh response
@@ -64,6 +85,7 @@ type RoleResponse struct {
 	RoleID      uuid.UUID `json:"role_id"`
 	RoleName    string    `json:"role_name"`
 	Description *string   `json:"description"`
+	MFARequired bool      `json:"mfa_required"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
@@ -135,6 +157,7 @@ func ToRoleResponse(role *domain.Role) RoleResponse {
 		RoleID:      role.RoleID,
 		RoleName:    role.RoleName,
 		Description: role.Description,
+		MFARequired: role.MFARequired,
 		CreatedAt:   role.CreatedAt,
 	}
 }
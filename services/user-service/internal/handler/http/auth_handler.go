@@ -1,7 +1,10 @@
 package http
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/dto"
@@ -23,14 +26,17 @@ func NewAuthHandler(authService domain.AuthService) *AuthHandler {
 
 // Login handles user login
 // @Summary      User Login
-// @Description  Authenticate user and return access and refresh tokens
+// @Description  Authenticate user and return access and refresh tokens, or an MFA challenge when a second factor is required
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request body dto.LoginRequest true "Login Credentials"
 // @Success      200  {object}  utils.APIResponse{data=dto.LoginResponse}
+// @Success      202  {object}  utils.APIResponse{data=dto.MFAChallengeResponse}
 // @Failure      400  {object}  utils.APIResponse
 // @Failure      401  {object}  utils.APIResponse
+// @Failure      429  {object}  utils.APIResponse
+// @Header       401,429  {integer}  Retry-After  "Seconds to wait before the next login attempt"
 // @Failure      500  {object}  utils.APIResponse
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -43,30 +49,354 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	ipAddress := c.ClientIP()
 	userAgent := c.GetHeader("User-Agent")
 
-	accessToken, refreshToken, user, err := h.authService.Login(c.Request.Context(), req.Email, req.Password, ipAddress, userAgent)
+	result, err := h.authService.Login(c.Request.Context(), req.Email, req.Password, ipAddress, userAgent)
 	if err != nil {
-		if err == domain.ErrInvalidCredentials {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidCredentials):
 			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid credentials", err)
+		case errors.Is(err, domain.ErrAccountLocked):
+			utils.ErrorResponse(c, http.StatusTooManyRequests, "Too many failed login attempts, try again later", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Login failed", err)
+		}
+		return
+	}
+
+	if result.MFAToken != "" {
+		response := dto.MFAChallengeResponse{
+			MFAToken:           result.MFAToken,
+			ExpiresIn:          int(utils.MFATokenDuration.Seconds()),
+			EnrollmentRequired: result.MFAEnrollmentRequired,
+			Secret:             result.MFASecret,
+			ProvisioningURI:    result.MFAProvisioningURI,
+		}
+
+		utils.SuccessResponse(c, http.StatusAccepted, "MFA verification required", response)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", toLoginResponse(result))
+}
+
+// VerifyMFA completes a login that returned an MFA challenge
+// @Summary      Verify MFA
+// @Description  Exchange an MFA challenge token and a TOTP or backup code for access and refresh tokens
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.VerifyMFARequest true "MFA Challenge and Code"
+// @Success      200  {object}  utils.APIResponse{data=dto.LoginResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      429  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	ipAddress := c.ClientIP()
+	userAgent := c.GetHeader("User-Agent")
+
+	result, err := h.authService.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code, ipAddress, userAgent)
+	if err != nil {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidToken), errors.Is(err, domain.ErrUnauthorized), errors.Is(err, domain.ErrMFANotEnabled):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid or expired MFA token", err)
+		case errors.Is(err, domain.ErrInvalidMFACode):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid MFA code", err)
+		case errors.Is(err, domain.ErrAccountLocked):
+			utils.ErrorResponse(c, http.StatusTooManyRequests, "Too many failed login attempts, try again later", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "MFA verification failed", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", toLoginResponse(result))
+}
+
+// EnrollMFA starts TOTP enrollment for the current user
+// @Summary      Enroll MFA
+// @Description  Generate a TOTP secret and provisioning URI for the current user. MFA is enabled once the first code is confirmed.
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse{data=dto.MFAEnrollmentResponse}
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /auth/mfa/enroll [post]
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	secret, provisioningURI, err := h.authService.EnrollMFA(c.Request.Context(), userID)
+	if err != nil {
+		if err == domain.ErrMFAAlreadyEnabled {
+			utils.ErrorResponse(c, http.StatusConflict, "MFA is already enabled", err)
 			return
 		}
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Login failed", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to start MFA enrollment", err)
 		return
 	}
 
-	response := dto.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	response := dto.MFAEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: provisioningURI,
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA enrollment started", response)
+}
+
+// EnableMFA confirms TOTP enrollment with the first code
+// @Summary      Enable MFA
+// @Description  Confirm the first TOTP code to enable MFA and receive one-time backup codes
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.MFACodeRequest true "TOTP Code"
+// @Success      200  {object}  utils.APIResponse{data=dto.BackupCodesResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /auth/mfa/enable [post]
+func (h *AuthHandler) EnableMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	backupCodes, err := h.authService.EnableMFA(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		switch err {
+		case domain.ErrMFANotEnabled:
+			utils.ErrorResponse(c, http.StatusBadRequest, "MFA enrollment has not been started", err)
+		case domain.ErrMFAAlreadyEnabled:
+			utils.ErrorResponse(c, http.StatusConflict, "MFA is already enabled", err)
+		case domain.ErrInvalidMFACode:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid MFA code", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to enable MFA", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA enabled", dto.BackupCodesResponse{BackupCodes: backupCodes})
+}
+
+// DisableMFA turns off MFA for the current user
+// @Summary      Disable MFA
+// @Description  Disable MFA for the current user after confirming a TOTP or backup code
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.MFACodeRequest true "TOTP or Backup Code"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      403  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /auth/mfa/disable [post]
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	if err := h.authService.DisableMFA(c.Request.Context(), userID, req.Code); err != nil {
+		switch err {
+		case domain.ErrMFANotEnabled:
+			utils.ErrorResponse(c, http.StatusBadRequest, "MFA is not enabled", err)
+		case domain.ErrInvalidMFACode:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid MFA code", err)
+		case domain.ErrMFARequiredByRole:
+			utils.ErrorResponse(c, http.StatusForbidden, "MFA is required for your role", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to disable MFA", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA disabled", nil)
+}
+
+// RegenerateBackupCodes replaces the current user's backup codes
+// @Summary      Regenerate Backup Codes
+// @Description  Invalidate all existing backup codes and issue a new set after confirming a TOTP code
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.MFACodeRequest true "TOTP Code"
+// @Success      200  {object}  utils.APIResponse{data=dto.BackupCodesResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /auth/mfa/backup-codes [post]
+func (h *AuthHandler) RegenerateBackupCodes(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	backupCodes, err := h.authService.RegenerateBackupCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		switch err {
+		case domain.ErrMFANotEnabled:
+			utils.ErrorResponse(c, http.StatusBadRequest, "MFA is not enabled", err)
+		case domain.ErrInvalidMFACode:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid MFA code", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to regenerate backup codes", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Backup codes regenerated", dto.BackupCodesResponse{BackupCodes: backupCodes})
+}
+
+// ResetUserMFA removes a user's second factor (admin only)
+// @Summary      Reset User MFA
+// @Description  Remove a user's TOTP factor and backup codes, e.g. after a lost device (Admin only)
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      403  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/users/{id}/mfa/reset [post]
+func (h *AuthHandler) ResetUserMFA(c *gin.Context) {
+	actorID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	if err := h.authService.ResetMFA(c.Request.Context(), userID, actorID); err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "User not found", err)
+		case domain.ErrMFANotEnabled:
+			utils.ErrorResponse(c, http.StatusNotFound, "MFA is not set up for this user", err)
+		case domain.ErrUnauthorized:
+			utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", err)
+		case domain.ErrRoleOutranksActor:
+			utils.ErrorResponse(c, http.StatusForbidden, "Cannot reset the MFA of a user with permissions your role lacks", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to reset MFA", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA reset successfully", nil)
+}
+
+// UnlockAccount lifts a login lockout (admin only)
+// @Summary      Unlock User Account
+// @Description  Clear the failed login attempts and lockout of a user's account (Admin only)
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/users/{id}/unlock [post]
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	actorID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	if err := h.authService.UnlockAccount(c.Request.Context(), userID, actorID); err != nil {
+		if err == domain.ErrUserNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "User not found", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unlock account", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Account unlocked successfully", nil)
+}
+
+func toLoginResponse(result *domain.LoginResult) dto.LoginResponse {
+	return dto.LoginResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
 		TokenType:    "Bearer",
 		ExpiresIn:    3600, // 1 hour
-		User:         dto.ToUserWithProfileResponse(user),
+		User:         dto.ToUserWithProfileResponse(result.User),
+		BackupCodes:  result.BackupCodes,
 	}
-
-	utils.SuccessResponse(c, http.StatusOK, "Login successful", response)
 }
 
 // RefreshToken handles token refresh
 // @Summary      Refresh Access Token
-// @Description  Get a new access token using a valid refresh token
+// @Description  Exchange a refresh token for a new access and refresh token. Each refresh token can be used once; replaying a rotated token revokes every session of its login.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -82,8 +412,15 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
+	ipAddress := c.ClientIP()
+	userAgent := c.GetHeader("User-Agent")
+
+	accessToken, refreshToken, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken, ipAddress, userAgent)
 	if err != nil {
+		if err == domain.ErrRefreshTokenReused {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Refresh token reuse detected, please log in again", err)
+			return
+		}
 		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid or expired refresh token", err)
 		return
 	}
@@ -118,13 +455,19 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
+	claims, exists := middleware.GetClaims(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
 	var req dto.RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
-	if err := h.authService.Logout(c.Request.Context(), userID, req.RefreshToken); err != nil {
+	if err := h.authService.Logout(c.Request.Context(), userID, req.RefreshToken, claims.ID, claims.ExpiresAt.Time); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Logout failed", err)
 		return
 	}
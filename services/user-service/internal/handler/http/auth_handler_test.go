@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
@@ -46,7 +48,7 @@ func TestAuthHandler_Login(t *testing.T) {
 		// Expect Login call
 		mockAuthService.EXPECT().
 			Login(gomock.Any(), req.Email, req.Password, gomock.Any(), gomock.Any()).
-			Return(accessToken, refreshToken, mockUser, nil)
+			Return(&domain.LoginResult{AccessToken: accessToken, RefreshToken: refreshToken, User: mockUser}, nil)
 
 		// Create request
 		w := httptest.NewRecorder()
@@ -70,6 +72,33 @@ func TestAuthHandler_Login(t *testing.T) {
 		assert.Equal(t, refreshToken, data["refresh_token"])
 	})
 
+	t.Run("MFA Challenge", func(t *testing.T) {
+		req := dto.LoginRequest{
+			Email:    "mfa@example.com",
+			Password: "password123",
+		}
+		jsonValue, _ := json.Marshal(req)
+
+		mockAuthService.EXPECT().
+			Login(gomock.Any(), req.Email, req.Password, gomock.Any(), gomock.Any()).
+			Return(&domain.LoginResult{MFAToken: "mfa_token"}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, "mfa_token", data["mfa_token"])
+		assert.Nil(t, data["access_token"])
+	})
+
 	t.Run("Invalid Credentials", func(t *testing.T) {
 		req := dto.LoginRequest{
 			Email:    "test@example.com",
@@ -79,7 +108,7 @@ func TestAuthHandler_Login(t *testing.T) {
 
 		mockAuthService.EXPECT().
 			Login(gomock.Any(), req.Email, req.Password, gomock.Any(), gomock.Any()).
-			Return("", "", nil, domain.ErrInvalidCredentials)
+			Return(nil, domain.ErrInvalidCredentials)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -91,6 +120,28 @@ func TestAuthHandler_Login(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 
+	t.Run("Locked", func(t *testing.T) {
+		req := dto.LoginRequest{
+			Email:    "test@example.com",
+			Password: "password123",
+		}
+		jsonValue, _ := json.Marshal(req)
+
+		mockAuthService.EXPECT().
+			Login(gomock.Any(), req.Email, req.Password, gomock.Any(), gomock.Any()).
+			Return(nil, &domain.LoginThrottledError{Err: domain.ErrAccountLocked, RetryAfter: 1500 * time.Millisecond})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+
 	t.Run("Invalid Request Body", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -122,7 +173,7 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 		newRefreshToken := "new_refresh_token"
 
 		mockAuthService.EXPECT().
-			RefreshToken(gomock.Any(), req.RefreshToken).
+			RefreshToken(gomock.Any(), req.RefreshToken, gomock.Any(), gomock.Any()).
 			Return(newAccessToken, newRefreshToken, nil)
 
 		w := httptest.NewRecorder()
@@ -147,7 +198,7 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 		jsonValue, _ := json.Marshal(req)
 
 		mockAuthService.EXPECT().
-			RefreshToken(gomock.Any(), req.RefreshToken).
+			RefreshToken(gomock.Any(), req.RefreshToken, gomock.Any(), gomock.Any()).
 			Return("", "", domain.ErrInvalidToken)
 
 		w := httptest.NewRecorder()
@@ -160,3 +211,115 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+func TestAuthHandler_VerifyMFA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	handler := NewAuthHandler(mockAuthService)
+
+	t.Run("Success", func(t *testing.T) {
+		req := dto.VerifyMFARequest{
+			MFAToken: "mfa_token",
+			Code:     "123456",
+		}
+		jsonValue, _ := json.Marshal(req)
+
+		mockUser := &domain.UserWithProfile{
+			User: domain.User{
+				Email: "test@example.com",
+			},
+		}
+
+		mockAuthService.EXPECT().
+			VerifyMFA(gomock.Any(), req.MFAToken, req.Code, gomock.Any(), gomock.Any()).
+			Return(&domain.LoginResult{AccessToken: "access_token", RefreshToken: "refresh_token", User: mockUser}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/auth/mfa/verify", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.VerifyMFA(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, "access_token", data["access_token"])
+	})
+
+	t.Run("Invalid Code", func(t *testing.T) {
+		req := dto.VerifyMFARequest{
+			MFAToken: "mfa_token",
+			Code:     "000000",
+		}
+		jsonValue, _ := json.Marshal(req)
+
+		mockAuthService.EXPECT().
+			VerifyMFA(gomock.Any(), req.MFAToken, req.Code, gomock.Any(), gomock.Any()).
+			Return(nil, domain.ErrInvalidMFACode)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/auth/mfa/verify", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.VerifyMFA(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		req := dto.VerifyMFARequest{
+			MFAToken: "mfa_token",
+			Code:     "000000",
+		}
+		jsonValue, _ := json.Marshal(req)
+
+		mockAuthService.EXPECT().
+			VerifyMFA(gomock.Any(), req.MFAToken, req.Code, gomock.Any(), gomock.Any()).
+			Return(nil, &domain.LoginThrottledError{Err: domain.ErrAccountLocked, RetryAfter: 15 * time.Minute})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/auth/mfa/verify", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.VerifyMFA(c)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "900", w.Header().Get("Retry-After"))
+	})
+}
+
+func TestAuthHandler_DisableMFA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	handler := NewAuthHandler(mockAuthService)
+
+	userID := uuid.New()
+	jsonValue, _ := json.Marshal(dto.MFACodeRequest{Code: "123456"})
+
+	mockAuthService.EXPECT().
+		DisableMFA(gomock.Any(), userID, "123456").
+		Return(domain.ErrMFARequiredByRole)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/auth/mfa/disable", bytes.NewBuffer(jsonValue))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user_id", userID) // Simulate Auth Middleware
+
+	handler.DisableMFA(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultDeadLetterLimit = 50
+
+type DLQHandler struct {
+	deadLetters domain.DeadLetterQueue
+}
+
+func NewDLQHandler(deadLetters domain.DeadLetterQueue) *DLQHandler {
+	return &DLQHandler{
+		deadLetters: deadLetters,
+	}
+}
+
+// ListDeadLetters returns the most recent dead letters of a topic (admin only)
+// @Summary      List Dead Letters
+// @Description  Get the most recent messages consumers dead-lettered for a topic, newest first (Admin only)
+// @Tags         events
+// @Security     BearerAuth
+// @Produce      json
+// @Param        topic  path      string  true   "Original topic, e.g. user.events"
+// @Param        limit  query     int     false  "Maximum number of messages (default 50, max 500)"
+// @Success      200  {object}  utils.APIResponse{data=[]kafka.DeadLetter}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      403  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/events/dlq/{topic} [get]
+func (h *DLQHandler) ListDeadLetters(c *gin.Context) {
+	limit := defaultDeadLetterLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+		limit = min(parsed, kafka.MaxDeadLetters)
+	}
+
+	letters, err := h.deadLetters.List(c.Request.Context(), c.Param("topic"), limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list dead letters", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Dead letters retrieved", letters)
+}
+
+// RedriveDeadLetter publishes a dead letter back to its original topic (admin only)
+// @Summary      Redrive Dead Letter
+// @Description  Publish a dead-lettered message back to its original topic. Only the consumer group that dead-lettered it handles it again (Admin only)
+// @Tags         events
+// @Security     BearerAuth
+// @Produce      json
+// @Param        topic      path      string  true  "Original topic, e.g. user.events"
+// @Param        partition  path      int     true  "Dead-letter topic partition"
+// @Param        offset     path      int     true  "Dead-letter topic offset"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      403  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/events/dlq/{topic}/{partition}/{offset}/redrive [post]
+func (h *DLQHandler) RedriveDeadLetter(c *gin.Context) {
+	partition, err := strconv.ParseInt(c.Param("partition"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid partition", err)
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.Param("offset"), 10, 64)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid offset", err)
+		return
+	}
+
+	if err := h.deadLetters.Redrive(c.Request.Context(), c.Param("topic"), int32(partition), offset); err != nil {
+		if errors.Is(err, kafka.ErrDeadLetterNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "Dead letter not found", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to redrive dead letter", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Dead letter redriven", nil)
+}
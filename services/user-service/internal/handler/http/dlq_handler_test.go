@@ -0,0 +1,110 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/kafka"
+)
+
+func TestDLQHandler_ListDeadLetters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeadLetters := mocks.NewMockDeadLetterQueue(ctrl)
+	handler := NewDLQHandler(mockDeadLetters)
+
+	t.Run("Success", func(t *testing.T) {
+		mockDeadLetters.EXPECT().List(gomock.Any(), "user.events", kafka.MaxDeadLetters).Return([]*kafka.DeadLetter{
+			{Topic: "user.events.dlq", OriginalTopic: "user.events", Error: "boom"},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/admin/events/dlq/user.events?limit=1000", nil)
+		c.Params = gin.Params{{Key: "topic", Value: "user.events"}}
+
+		handler.ListDeadLetters(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "boom")
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/admin/events/dlq/user.events?limit=0", nil)
+		c.Params = gin.Params{{Key: "topic", Value: "user.events"}}
+
+		handler.ListDeadLetters(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDLQHandler_RedriveDeadLetter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeadLetters := mocks.NewMockDeadLetterQueue(ctrl)
+	handler := NewDLQHandler(mockDeadLetters)
+
+	t.Run("Success", func(t *testing.T) {
+		mockDeadLetters.EXPECT().Redrive(gomock.Any(), "user.events", int32(2), int64(42)).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/admin/events/dlq/user.events/2/42/redrive", nil)
+		c.Params = gin.Params{
+			{Key: "topic", Value: "user.events"},
+			{Key: "partition", Value: "2"},
+			{Key: "offset", Value: "42"},
+		}
+
+		handler.RedriveDeadLetter(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockDeadLetters.EXPECT().Redrive(gomock.Any(), "user.events", int32(0), int64(7)).Return(kafka.ErrDeadLetterNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/admin/events/dlq/user.events/0/7/redrive", nil)
+		c.Params = gin.Params{
+			{Key: "topic", Value: "user.events"},
+			{Key: "partition", Value: "0"},
+			{Key: "offset", Value: "7"},
+		}
+
+		handler.RedriveDeadLetter(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid Offset", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/admin/events/dlq/user.events/0/abc/redrive", nil)
+		c.Params = gin.Params{
+			{Key: "topic", Value: "user.events"},
+			{Key: "partition", Value: "0"},
+			{Key: "offset", Value: "abc"},
+		}
+
+		handler.RedriveDeadLetter(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
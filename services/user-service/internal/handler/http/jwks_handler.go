@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	keyRing *utils.KeyRing
+}
+
+func NewJWKSHandler(keyRing *utils.KeyRing) *JWKSHandler {
+	return &JWKSHandler{
+		keyRing: keyRing,
+	}
+}
+
+// GetJWKS returns the public keys access tokens are signed with
+// @Summary      JSON Web Key Set
+// @Description  Public keys other services verify access tokens with (RFC 7517). Keys are matched by the kid token header.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  utils.JSONWebKeySet
+// @Router       /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	// Verifiers refetch on an unknown kid, so a short cache is safe
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keyRing.JWKS())
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+)
+
+func TestJWKSHandler_GetJWKS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	current, err := utils.GenerateSigningKey(utils.AlgorithmEdDSA, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	next, err := utils.GenerateSigningKey(utils.AlgorithmRS256, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	handler := NewJWKSHandler(utils.NewKeyRing(current, next))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, utils.JWKSPath, nil)
+
+	handler.GetJWKS(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Cache-Control"))
+
+	var set utils.JSONWebKeySet
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &set))
+	assert.Len(t, set.Keys, 2)
+
+	// Keys that are not active yet are published too
+	for i, key := range []*utils.SigningKey{current, next} {
+		publicKey, err := set.Keys[i].PublicKey()
+		assert.NoError(t, err)
+		assert.Equal(t, key.KeyID, publicKey.KeyID)
+		assert.Equal(t, key.PrivateKey.Public(), publicKey.Key)
+	}
+}
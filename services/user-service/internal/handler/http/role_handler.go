@@ -0,0 +1,466 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/shared/middleware"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RoleHandler struct {
+	roleService domain.RoleService
+}
+
+func NewRoleHandler(roleService domain.RoleService) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+	}
+}
+
+// CreateRole creates a new role (admin only)
+// @Summary      Create Role
+// @Description  Create a new role (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateRoleRequest true "Role Creation Data"
+// @Success      201  {object}  utils.APIResponse{data=dto.RoleResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	role := &domain.Role{
+		RoleName:    req.RoleName,
+		Description: req.Description,
+	}
+
+	if err := h.roleService.CreateRole(c.Request.Context(), role); err != nil {
+		if err == domain.ErrRoleAlreadyExists {
+			utils.ErrorResponse(c, http.StatusConflict, "Role already exists", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Role created successfully", dto.ToRoleResponse(role))
+}
+
+// ListRoles returns all roles (admin only)
+// @Summary      List Roles
+// @Description  Get all roles (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse{data=[]dto.RoleResponse}
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles [get]
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleService.ListRoles(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list roles", err)
+		return
+	}
+
+	roleResponses := make([]dto.RoleResponse, len(roles))
+	for i, role := range roles {
+		roleResponses[i] = dto.ToRoleResponse(role)
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Roles retrieved", roleResponses)
+}
+
+// GetRole returns role by ID (admin only)
+// @Summary      Get Role by ID
+// @Description  Get role details by ID (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Role ID"
+// @Success      200  {object}  utils.APIResponse{data=dto.RoleResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles/{id} [get]
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role ID", err)
+		return
+	}
+
+	role, err := h.roleService.GetRole(c.Request.Context(), roleID)
+	if err != nil {
+		if err == domain.ErrRoleNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Role not found", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role retrieved", dto.ToRoleResponse(role))
+}
+
+// UpdateRole updates role by ID (admin only)
+// @Summary      Update Role
+// @Description  Update role name or description by ID (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                 true  "Role ID"
+// @Param        request  body      dto.UpdateRoleRequest  true  "Update Data"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles/{id} [put]
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role ID", err)
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.RoleName != "" {
+		updates["role_name"] = req.RoleName
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+
+	if err := h.roleService.UpdateRole(c.Request.Context(), roleID, updates); err != nil {
+		switch err {
+		case domain.ErrRoleNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Role not found", err)
+		case domain.ErrRoleAlreadyExists:
+			utils.ErrorResponse(c, http.StatusConflict, "Role already exists", err)
+		case domain.ErrRoleProtected:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Built-in roles cannot be renamed", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update role", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role updated successfully", nil)
+}
+
+// DeleteRole deletes role by ID (admin only)
+// @Summary      Delete Role
+// @Description  Delete a role that is no longer assigned to any user (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Role ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles/{id} [delete]
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role ID", err)
+		return
+	}
+
+	if err := h.roleService.DeleteRole(c.Request.Context(), roleID); err != nil {
+		switch err {
+		case domain.ErrRoleNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Role not found", err)
+		case domain.ErrRoleHasUsers:
+			utils.ErrorResponse(c, http.StatusConflict, "Role is still assigned to users", err)
+		case domain.ErrRoleProtected:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Built-in roles cannot be deleted", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete role", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role deleted successfully", nil)
+}
+
+// SetMFARequired enforces or relaxes MFA for every user of a role (admin only)
+// @Summary      Set Role MFA Requirement
+// @Description  Require (or stop requiring) users of a role to sign in with MFA (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                 true  "Role ID"
+// @Param        request  body      dto.SetRoleMFARequest  true  "MFA Requirement"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles/{id}/mfa [put]
+func (h *RoleHandler) SetMFARequired(c *gin.Context) {
+	actorID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role ID", err)
+		return
+	}
+
+	var req dto.SetRoleMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	if err := h.roleService.SetMFARequired(c.Request.Context(), roleID, *req.MFARequired, actorID); err != nil {
+		if err == domain.ErrRoleNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Role not found", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update role MFA requirement", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role MFA requirement updated", nil)
+}
+
+// GetRolePermissions returns the permissions granted to a role (admin only)
+// @Summary      Get Role Permissions
+// @Description  Get all permissions granted to a role (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Role ID"
+// @Success      200  {object}  utils.APIResponse{data=[]dto.PermissionResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles/{id}/permissions [get]
+func (h *RoleHandler) GetRolePermissions(c *gin.Context) {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role ID", err)
+		return
+	}
+
+	permissions, err := h.roleService.GetRolePermissions(c.Request.Context(), roleID)
+	if err != nil {
+		if err == domain.ErrRoleNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Role not found", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get role permissions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role permissions retrieved", toPermissionResponses(permissions))
+}
+
+// AssignPermission grants a permission to a role (admin only)
+// @Summary      Assign Permission
+// @Description  Grant a permission to a role (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                       true  "Role ID"
+// @Param        request  body      dto.AssignPermissionRequest  true  "Permission to grant"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles/{id}/permissions [post]
+func (h *RoleHandler) AssignPermission(c *gin.Context) {
+	actorID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role ID", err)
+		return
+	}
+
+	var req dto.AssignPermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	permissionID, err := uuid.Parse(req.PermissionID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid permission ID", err)
+		return
+	}
+
+	if err := h.roleService.AssignPermission(c.Request.Context(), roleID, permissionID, actorID); err != nil {
+		switch err {
+		case domain.ErrRoleNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Role not found", err)
+		case domain.ErrPermissionNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Permission not found", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to assign permission", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Permission assigned successfully", nil)
+}
+
+// RevokePermission removes a permission from a role (admin only)
+// @Summary      Revoke Permission
+// @Description  Revoke a permission from a role (Admin only)
+// @Tags         roles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id            path      string  true  "Role ID"
+// @Param        permissionId  path      string  true  "Permission ID"
+// @Success      200  {object}  utils.APIResponse
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      404  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/roles/{id}/permissions/{permissionId} [delete]
+func (h *RoleHandler) RevokePermission(c *gin.Context) {
+	actorID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role ID", err)
+		return
+	}
+
+	permissionID, err := uuid.Parse(c.Param("permissionId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid permission ID", err)
+		return
+	}
+
+	if err := h.roleService.RevokePermission(c.Request.Context(), roleID, permissionID, actorID); err != nil {
+		switch err {
+		case domain.ErrRoleNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Role not found", err)
+		case domain.ErrPermissionNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Permission not found", err)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke permission", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Permission revoked successfully", nil)
+}
+
+// CreatePermission creates a new permission (admin only)
+// @Summary      Create Permission
+// @Description  Create a new permission (Admin only)
+// @Tags         permissions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreatePermissionRequest true "Permission Creation Data"
+// @Success      201  {object}  utils.APIResponse{data=dto.PermissionResponse}
+// @Failure      400  {object}  utils.APIResponse
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      409  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/permissions [post]
+func (h *RoleHandler) CreatePermission(c *gin.Context) {
+	var req dto.CreatePermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	permission := &domain.Permission{
+		PermissionName: req.PermissionName,
+		Resource:       req.Resource,
+		Action:         req.Action,
+		Description:    req.Description,
+	}
+
+	if err := h.roleService.CreatePermission(c.Request.Context(), permission); err != nil {
+		if err == domain.ErrPermissionExists {
+			utils.ErrorResponse(c, http.StatusConflict, "Permission already exists", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create permission", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Permission created successfully", dto.ToPermissionResponse(permission))
+}
+
+// ListPermissions returns all permissions (admin only)
+// @Summary      List Permissions
+// @Description  Get all permissions (Admin only)
+// @Tags         permissions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  utils.APIResponse{data=[]dto.PermissionResponse}
+// @Failure      401  {object}  utils.APIResponse
+// @Failure      500  {object}  utils.APIResponse
+// @Router       /admin/permissions [get]
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	permissions, err := h.roleService.ListPermissions(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list permissions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Permissions retrieved", toPermissionResponses(permissions))
+}
+
+func toPermissionResponses(permissions []*domain.Permission) []dto.PermissionResponse {
+	responses := make([]dto.PermissionResponse, len(permissions))
+	for i, permission := range permissions {
+		responses[i] = dto.ToPermissionResponse(permission)
+	}
+	return responses
+}
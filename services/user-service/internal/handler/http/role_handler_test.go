@@ -0,0 +1,201 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/dto"
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/mocks"
+)
+
+func TestRoleHandler_DeleteRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleService := mocks.NewMockRoleService(ctrl)
+	handler := NewRoleHandler(mockRoleService)
+
+	t.Run("Success", func(t *testing.T) {
+		roleID := uuid.New()
+
+		mockRoleService.EXPECT().DeleteRole(gomock.Any(), roleID).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodDelete, "/admin/roles/"+roleID.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: roleID.String()}}
+
+		handler.DeleteRole(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Role Has Users", func(t *testing.T) {
+		roleID := uuid.New()
+
+		mockRoleService.EXPECT().DeleteRole(gomock.Any(), roleID).Return(domain.ErrRoleHasUsers)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodDelete, "/admin/roles/"+roleID.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: roleID.String()}}
+
+		handler.DeleteRole(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodDelete, "/admin/roles/not-a-uuid", nil)
+		c.Params = gin.Params{{Key: "id", Value: "not-a-uuid"}}
+
+		handler.DeleteRole(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRoleHandler_AssignPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleService := mocks.NewMockRoleService(ctrl)
+	handler := NewRoleHandler(mockRoleService)
+
+	t.Run("Success", func(t *testing.T) {
+		actorID := uuid.New()
+		roleID := uuid.New()
+		permissionID := uuid.New()
+		jsonValue, _ := json.Marshal(dto.AssignPermissionRequest{PermissionID: permissionID.String()})
+
+		mockRoleService.EXPECT().AssignPermission(gomock.Any(), roleID, permissionID, actorID).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/admin/roles/"+roleID.String()+"/permissions", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: roleID.String()}}
+		c.Set("user_id", actorID) // Simulate Auth Middleware
+
+		handler.AssignPermission(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Permission Not Found", func(t *testing.T) {
+		actorID := uuid.New()
+		roleID := uuid.New()
+		permissionID := uuid.New()
+		jsonValue, _ := json.Marshal(dto.AssignPermissionRequest{PermissionID: permissionID.String()})
+
+		mockRoleService.EXPECT().AssignPermission(gomock.Any(), roleID, permissionID, actorID).Return(domain.ErrPermissionNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/admin/roles/"+roleID.String()+"/permissions", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: roleID.String()}}
+		c.Set("user_id", actorID)
+
+		handler.AssignPermission(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/admin/roles/x/permissions", nil)
+
+		handler.AssignPermission(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestRoleHandler_ListPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleService := mocks.NewMockRoleService(ctrl)
+	handler := NewRoleHandler(mockRoleService)
+
+	mockRoleService.EXPECT().ListPermissions(gomock.Any()).Return([]*domain.Permission{
+		{PermissionID: uuid.New(), PermissionName: "users:read", Resource: "users", Action: "read"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/admin/permissions", nil)
+
+	handler.ListPermissions(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	data := response["data"].([]interface{})
+	assert.Len(t, data, 1)
+}
+
+func TestRoleHandler_SetMFARequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleService := mocks.NewMockRoleService(ctrl)
+	handler := NewRoleHandler(mockRoleService)
+
+	t.Run("Success", func(t *testing.T) {
+		roleID := uuid.New()
+		actorID := uuid.New()
+		required := true
+		jsonValue, _ := json.Marshal(dto.SetRoleMFARequest{MFARequired: &required})
+
+		mockRoleService.EXPECT().SetMFARequired(gomock.Any(), roleID, true, actorID).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPut, "/admin/roles/"+roleID.String()+"/mfa", bytes.NewBuffer(jsonValue))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: roleID.String()}}
+		c.Set("user_id", actorID) // Simulate Auth Middleware
+
+		handler.SetMFARequired(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Missing Flag", func(t *testing.T) {
+		roleID := uuid.New()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPut, "/admin/roles/"+roleID.String()+"/mfa", bytes.NewBufferString("{}"))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: roleID.String()}}
+		c.Set("user_id", uuid.New())
+
+		handler.SetMFARequired(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -17,12 +17,18 @@ func SetupRoutes(
 	router *gin.Engine,
 	authHandler *AuthHandler,
 	userHandler *UserHandler,
+	roleHandler *RoleHandler,
+	jwksHandler *JWKSHandler,
+	dlqHandler *DLQHandler,
 	jwtManager *utils.JWTManager,
 	redisClient *redis.Client,
+	permissions *middleware.PermissionResolver,
+	revocations middleware.RevocationChecker,
 ) {
 	// Apply global middleware
 	router.Use(middleware.CORSMiddleware())
 	router.Use(middleware.LoggingMiddleware())
+	router.Use(middleware.CorrelationMiddleware())
 
 	// Rate limiting (100 requests per minute per IP)
 	rateLimiter := middleware.NewRateLimiter(redisClient, 100, time.Minute)
@@ -36,6 +42,9 @@ func SetupRoutes(
 		})
 	})
 
+	// Public keys for verifying access tokens
+	router.GET(utils.JWKSPath, jwksHandler.GetJWKS)
+
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -46,10 +55,11 @@ func SetupRoutes(
 		authRoutes.POST("/refresh", authHandler.RefreshToken)
 		authRoutes.POST("/password/reset-request", authHandler.RequestPasswordReset)
 		authRoutes.POST("/password/reset", authHandler.ResetPassword)
+		authRoutes.POST("/mfa/verify", authHandler.VerifyMFA)
 	}
 
 	// Protected routes (authentication required)
-	authMiddleware := middleware.AuthMiddleware(jwtManager)
+	authMiddleware := middleware.AuthMiddleware(jwtManager, revocations)
 
 	// Authenticated auth routes
 	authProtected := router.Group("/auth")
@@ -60,6 +70,10 @@ func SetupRoutes(
 		authProtected.GET("/sessions", authHandler.GetActiveSessions)
 		authProtected.DELETE("/sessions", authHandler.RevokeAllSessions)
 		authProtected.DELETE("/sessions/:sessionId", authHandler.RevokeSession)
+		authProtected.POST("/mfa/enroll", authHandler.EnrollMFA)
+		authProtected.POST("/mfa/enable", authHandler.EnableMFA)
+		authProtected.POST("/mfa/disable", authHandler.DisableMFA)
+		authProtected.POST("/mfa/backup-codes", authHandler.RegenerateBackupCodes)
 	}
 
 	// User routes (self-service)
@@ -70,19 +84,49 @@ func SetupRoutes(
 		userRoutes.PUT("/me", userHandler.UpdateMe)
 	}
 
-	// Admin routes (admin and faculty only)
-	adminMiddleware := middleware.RoleMiddleware("admin", "faculty")
-
+	// Admin routes (authorized by the permissions granted to the caller's role)
 	adminUserRoutes := router.Group("/admin/users")
-	adminUserRoutes.Use(authMiddleware, adminMiddleware)
+	adminUserRoutes.Use(authMiddleware)
+	{
+		adminUserRoutes.POST("", permissions.RequirePermission("users", "create"), userHandler.CreateUser)
+		adminUserRoutes.GET("", permissions.RequirePermission("users", "read"), userHandler.ListUsers)
+		adminUserRoutes.GET("/:id", permissions.RequirePermission("users", "read"), userHandler.GetUser)
+		adminUserRoutes.PUT("/:id", permissions.RequirePermission("users", "update"), userHandler.UpdateUser)
+		adminUserRoutes.DELETE("/:id", permissions.RequirePermission("users", "delete"), userHandler.DeleteUser)
+		adminUserRoutes.POST("/:id/activate", permissions.RequirePermission("users", "update"), userHandler.ActivateUser)
+		adminUserRoutes.POST("/:id/suspend", permissions.RequirePermission("users", "update"), userHandler.SuspendUser)
+		adminUserRoutes.POST("/bulk-import", permissions.RequirePermission("users", "create"), userHandler.BulkImportUsers)
+		adminUserRoutes.POST("/:id/mfa/reset", permissions.RequirePermission("users", "mfa_reset"), authHandler.ResetUserMFA)
+		adminUserRoutes.POST("/:id/unlock", permissions.RequirePermission("users", "update"), authHandler.UnlockAccount)
+	}
+
+	// Role and permission management
+	adminRoleRoutes := router.Group("/admin/roles")
+	adminRoleRoutes.Use(authMiddleware)
+	{
+		adminRoleRoutes.POST("", permissions.RequirePermission("roles", "manage"), roleHandler.CreateRole)
+		adminRoleRoutes.GET("", permissions.RequirePermission("roles", "read"), roleHandler.ListRoles)
+		adminRoleRoutes.GET("/:id", permissions.RequirePermission("roles", "read"), roleHandler.GetRole)
+		adminRoleRoutes.PUT("/:id", permissions.RequirePermission("roles", "manage"), roleHandler.UpdateRole)
+		adminRoleRoutes.DELETE("/:id", permissions.RequirePermission("roles", "manage"), roleHandler.DeleteRole)
+		adminRoleRoutes.PUT("/:id/mfa", permissions.RequirePermission("roles", "manage"), roleHandler.SetMFARequired)
+		adminRoleRoutes.GET("/:id/permissions", permissions.RequirePermission("roles", "read"), roleHandler.GetRolePermissions)
+		adminRoleRoutes.POST("/:id/permissions", permissions.RequirePermission("roles", "manage"), roleHandler.AssignPermission)
+		adminRoleRoutes.DELETE("/:id/permissions/:permissionId", permissions.RequirePermission("roles", "manage"), roleHandler.RevokePermission)
+	}
+
+	adminPermissionRoutes := router.Group("/admin/permissions")
+	adminPermissionRoutes.Use(authMiddleware)
+	{
+		adminPermissionRoutes.POST("", permissions.RequirePermission("permissions", "manage"), roleHandler.CreatePermission)
+		adminPermissionRoutes.GET("", permissions.RequirePermission("permissions", "read"), roleHandler.ListPermissions)
+	}
+
+	// Messages event consumers gave up on
+	adminEventRoutes := router.Group("/admin/events")
+	adminEventRoutes.Use(authMiddleware)
 	{
-		adminUserRoutes.POST("", userHandler.CreateUser)
-		adminUserRoutes.GET("", userHandler.ListUsers)
-		adminUserRoutes.GET("/:id", userHandler.GetUser)
-		adminUserRoutes.PUT("/:id", userHandler.UpdateUser)
-		adminUserRoutes.DELETE("/:id", userHandler.DeleteUser)
-		adminUserRoutes.POST("/:id/activate", userHandler.ActivateUser)
-		adminUserRoutes.POST("/:id/suspend", userHandler.SuspendUser)
-		adminUserRoutes.POST("/bulk-import", userHandler.BulkImportUsers)
+		adminEventRoutes.GET("/dlq/:topic", permissions.RequirePermission("events", "read"), dlqHandler.ListDeadLetters)
+		adminEventRoutes.POST("/dlq/:topic/:partition/:offset/redrive", permissions.RequirePermission("events", "manage"), dlqHandler.RedriveDeadLetter)
 	}
 }
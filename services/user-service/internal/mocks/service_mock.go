@@ -12,12 +12,52 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	kafka "github.com/SureshAmal/NimbusU-backend/shared/kafka"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockKeyRotationService is a mock of KeyRotationService interface.
+type MockKeyRotationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyRotationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyRotationServiceMockRecorder is the mock recorder for MockKeyRotationService.
+type MockKeyRotationServiceMockRecorder struct {
+	mock *MockKeyRotationService
+}
+
+// NewMockKeyRotationService creates a new mock instance.
+func NewMockKeyRotationService(ctrl *gomock.Controller) *MockKeyRotationService {
+	mock := &MockKeyRotationService{ctrl: ctrl}
+	mock.recorder = &MockKeyRotationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyRotationService) EXPECT() *MockKeyRotationServiceMockRecorder {
+	return m.recorder
+}
+
+// Sync mocks base method.
+func (m *MockKeyRotationService) Sync(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockKeyRotationServiceMockRecorder) Sync(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockKeyRotationService)(nil).Sync), ctx)
+}
+
 // MockUserService is a mock of UserService interface.
 type MockUserService struct {
 	ctrl     *gomock.Controller
@@ -239,6 +279,51 @@ func (mr *MockAuthServiceMockRecorder) ChangePassword(ctx, userID, oldPassword,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthService)(nil).ChangePassword), ctx, userID, oldPassword, newPassword)
 }
 
+// DisableMFA mocks base method.
+func (m *MockAuthService) DisableMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockAuthServiceMockRecorder) DisableMFA(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockAuthService)(nil).DisableMFA), ctx, userID, code)
+}
+
+// EnableMFA mocks base method.
+func (m *MockAuthService) EnableMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockAuthServiceMockRecorder) EnableMFA(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockAuthService)(nil).EnableMFA), ctx, userID, code)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockAuthService) EnrollMFA(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockAuthServiceMockRecorder) EnrollMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockAuthService)(nil).EnrollMFA), ctx, userID)
+}
+
 // GetActiveSessions mocks base method.
 func (m *MockAuthService) GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*domain.ActiveSession, error) {
 	m.ctrl.T.Helper()
@@ -255,14 +340,12 @@ func (mr *MockAuthServiceMockRecorder) GetActiveSessions(ctx, userID any) *gomoc
 }
 
 // Login mocks base method.
-func (m *MockAuthService) Login(ctx context.Context, email, password, ipAddress, userAgent string) (string, string, *domain.UserWithProfile, error) {
+func (m *MockAuthService) Login(ctx context.Context, email, password, ipAddress, userAgent string) (*domain.LoginResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", ctx, email, password, ipAddress, userAgent)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(*domain.UserWithProfile)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret0, _ := ret[0].(*domain.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
@@ -272,23 +355,23 @@ func (mr *MockAuthServiceMockRecorder) Login(ctx, email, password, ipAddress, us
 }
 
 // Logout mocks base method.
-func (m *MockAuthService) Logout(ctx context.Context, userID uuid.UUID, refreshToken string) error {
+func (m *MockAuthService) Logout(ctx context.Context, userID uuid.UUID, refreshToken, accessTokenID string, accessTokenExpiresAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Logout", ctx, userID, refreshToken)
+	ret := m.ctrl.Call(m, "Logout", ctx, userID, refreshToken, accessTokenID, accessTokenExpiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Logout indicates an expected call of Logout.
-func (mr *MockAuthServiceMockRecorder) Logout(ctx, userID, refreshToken any) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) Logout(ctx, userID, refreshToken, accessTokenID, accessTokenExpiresAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), ctx, userID, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), ctx, userID, refreshToken, accessTokenID, accessTokenExpiresAt)
 }
 
 // RefreshToken mocks base method.
-func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken)
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken, ipAddress, userAgent)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -296,9 +379,24 @@ func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string)
 }
 
 // RefreshToken indicates an expected call of RefreshToken.
-func (mr *MockAuthServiceMockRecorder) RefreshToken(ctx, refreshToken any) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) RefreshToken(ctx, refreshToken, ipAddress, userAgent any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthService)(nil).RefreshToken), ctx, refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthService)(nil).RefreshToken), ctx, refreshToken, ipAddress, userAgent)
+}
+
+// RegenerateBackupCodes mocks base method.
+func (m *MockAuthService) RegenerateBackupCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateBackupCodes", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateBackupCodes indicates an expected call of RegenerateBackupCodes.
+func (mr *MockAuthServiceMockRecorder) RegenerateBackupCodes(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateBackupCodes", reflect.TypeOf((*MockAuthService)(nil).RegenerateBackupCodes), ctx, userID, code)
 }
 
 // RequestPasswordReset mocks base method.
@@ -316,6 +414,20 @@ func (mr *MockAuthServiceMockRecorder) RequestPasswordReset(ctx, email any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthService)(nil).RequestPasswordReset), ctx, email)
 }
 
+// ResetMFA mocks base method.
+func (m *MockAuthService) ResetMFA(ctx context.Context, userID, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetMFA", ctx, userID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetMFA indicates an expected call of ResetMFA.
+func (mr *MockAuthServiceMockRecorder) ResetMFA(ctx, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMFA", reflect.TypeOf((*MockAuthService)(nil).ResetMFA), ctx, userID, actorID)
+}
+
 // ResetPassword mocks base method.
 func (m *MockAuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
 	m.ctrl.T.Helper()
@@ -358,6 +470,35 @@ func (mr *MockAuthServiceMockRecorder) RevokeSession(ctx, sessionID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthService)(nil).RevokeSession), ctx, sessionID)
 }
 
+// UnlockAccount mocks base method.
+func (m *MockAuthService) UnlockAccount(ctx context.Context, userID, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", ctx, userID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockAuthServiceMockRecorder) UnlockAccount(ctx, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockAuthService)(nil).UnlockAccount), ctx, userID, actorID)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockAuthService) VerifyMFA(ctx context.Context, mfaToken, code, ipAddress, userAgent string) (*domain.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, mfaToken, code, ipAddress, userAgent)
+	ret0, _ := ret[0].(*domain.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockAuthServiceMockRecorder) VerifyMFA(ctx, mfaToken, code, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockAuthService)(nil).VerifyMFA), ctx, mfaToken, code, ipAddress, userAgent)
+}
+
 // MockRoleService is a mock of RoleService interface.
 type MockRoleService struct {
 	ctrl     *gomock.Controller
@@ -383,17 +524,31 @@ func (m *MockRoleService) EXPECT() *MockRoleServiceMockRecorder {
 }
 
 // AssignPermission mocks base method.
-func (m *MockRoleService) AssignPermission(ctx context.Context, roleID, permissionID uuid.UUID) error {
+func (m *MockRoleService) AssignPermission(ctx context.Context, roleID, permissionID, actorID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignPermission", ctx, roleID, permissionID)
+	ret := m.ctrl.Call(m, "AssignPermission", ctx, roleID, permissionID, actorID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AssignPermission indicates an expected call of AssignPermission.
-func (mr *MockRoleServiceMockRecorder) AssignPermission(ctx, roleID, permissionID any) *gomock.Call {
+func (mr *MockRoleServiceMockRecorder) AssignPermission(ctx, roleID, permissionID, actorID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignPermission", reflect.TypeOf((*MockRoleService)(nil).AssignPermission), ctx, roleID, permissionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignPermission", reflect.TypeOf((*MockRoleService)(nil).AssignPermission), ctx, roleID, permissionID, actorID)
+}
+
+// CreatePermission mocks base method.
+func (m *MockRoleService) CreatePermission(ctx context.Context, permission *domain.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePermission", ctx, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePermission indicates an expected call of CreatePermission.
+func (mr *MockRoleServiceMockRecorder) CreatePermission(ctx, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePermission", reflect.TypeOf((*MockRoleService)(nil).CreatePermission), ctx, permission)
 }
 
 // CreateRole mocks base method.
@@ -469,6 +624,21 @@ func (mr *MockRoleServiceMockRecorder) GetRolePermissions(ctx, roleID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockRoleService)(nil).GetRolePermissions), ctx, roleID)
 }
 
+// ListPermissions mocks base method.
+func (m *MockRoleService) ListPermissions(ctx context.Context) ([]*domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", ctx)
+	ret0, _ := ret[0].([]*domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockRoleServiceMockRecorder) ListPermissions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockRoleService)(nil).ListPermissions), ctx)
+}
+
 // ListRoles mocks base method.
 func (m *MockRoleService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
 	m.ctrl.T.Helper()
@@ -485,17 +655,31 @@ func (mr *MockRoleServiceMockRecorder) ListRoles(ctx any) *gomock.Call {
 }
 
 // RevokePermission mocks base method.
-func (m *MockRoleService) RevokePermission(ctx context.Context, roleID, permissionID uuid.UUID) error {
+func (m *MockRoleService) RevokePermission(ctx context.Context, roleID, permissionID, actorID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RevokePermission", ctx, roleID, permissionID)
+	ret := m.ctrl.Call(m, "RevokePermission", ctx, roleID, permissionID, actorID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RevokePermission indicates an expected call of RevokePermission.
-func (mr *MockRoleServiceMockRecorder) RevokePermission(ctx, roleID, permissionID any) *gomock.Call {
+func (mr *MockRoleServiceMockRecorder) RevokePermission(ctx, roleID, permissionID, actorID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePermission", reflect.TypeOf((*MockRoleService)(nil).RevokePermission), ctx, roleID, permissionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePermission", reflect.TypeOf((*MockRoleService)(nil).RevokePermission), ctx, roleID, permissionID, actorID)
+}
+
+// SetMFARequired mocks base method.
+func (m *MockRoleService) SetMFARequired(ctx context.Context, roleID uuid.UUID, required bool, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMFARequired", ctx, roleID, required, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMFARequired indicates an expected call of SetMFARequired.
+func (mr *MockRoleServiceMockRecorder) SetMFARequired(ctx, roleID, required, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMFARequired", reflect.TypeOf((*MockRoleService)(nil).SetMFARequired), ctx, roleID, required, actorID)
 }
 
 // UpdateRole mocks base method.
@@ -512,6 +696,96 @@ func (mr *MockRoleServiceMockRecorder) UpdateRole(ctx, roleID, updates any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRoleService)(nil).UpdateRole), ctx, roleID, updates)
 }
 
+// MockPermissionCache is a mock of PermissionCache interface.
+type MockPermissionCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockPermissionCacheMockRecorder is the mock recorder for MockPermissionCache.
+type MockPermissionCacheMockRecorder struct {
+	mock *MockPermissionCache
+}
+
+// NewMockPermissionCache creates a new mock instance.
+func NewMockPermissionCache(ctrl *gomock.Controller) *MockPermissionCache {
+	mock := &MockPermissionCache{ctrl: ctrl}
+	mock.recorder = &MockPermissionCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionCache) EXPECT() *MockPermissionCacheMockRecorder {
+	return m.recorder
+}
+
+// InvalidateRole mocks base method.
+func (m *MockPermissionCache) InvalidateRole(ctx context.Context, roleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateRole", ctx, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateRole indicates an expected call of InvalidateRole.
+func (mr *MockPermissionCacheMockRecorder) InvalidateRole(ctx, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateRole", reflect.TypeOf((*MockPermissionCache)(nil).InvalidateRole), ctx, roleID)
+}
+
+// MockTokenRevoker is a mock of TokenRevoker interface.
+type MockTokenRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevokerMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenRevokerMockRecorder is the mock recorder for MockTokenRevoker.
+type MockTokenRevokerMockRecorder struct {
+	mock *MockTokenRevoker
+}
+
+// NewMockTokenRevoker creates a new mock instance.
+func NewMockTokenRevoker(ctrl *gomock.Controller) *MockTokenRevoker {
+	mock := &MockTokenRevoker{ctrl: ctrl}
+	mock.recorder = &MockTokenRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRevoker) EXPECT() *MockTokenRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenRevoker) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenRevokerMockRecorder) RevokeToken(ctx, tokenID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRevoker)(nil).RevokeToken), ctx, tokenID, expiresAt)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockTokenRevoker) RevokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockTokenRevokerMockRecorder) RevokeUserTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockTokenRevoker)(nil).RevokeUserTokens), ctx, userID)
+}
+
 // MockEventProducer is a mock of EventProducer interface.
 type MockEventProducer struct {
 	ctrl     *gomock.Controller
@@ -536,30 +810,69 @@ func (m *MockEventProducer) EXPECT() *MockEventProducerMockRecorder {
 	return m.recorder
 }
 
-// Close mocks base method.
-func (m *MockEventProducer) Close() error {
+// PublishEvent mocks base method.
+func (m *MockEventProducer) PublishEvent(ctx context.Context, topic, key string, event any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Close")
+	ret := m.ctrl.Call(m, "PublishEvent", ctx, topic, key, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Close indicates an expected call of Close.
-func (mr *MockEventProducerMockRecorder) Close() *gomock.Call {
+// PublishEvent indicates an expected call of PublishEvent.
+func (mr *MockEventProducerMockRecorder) PublishEvent(ctx, topic, key, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEventProducer)(nil).Close))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvent", reflect.TypeOf((*MockEventProducer)(nil).PublishEvent), ctx, topic, key, event)
 }
 
-// PublishEvent mocks base method.
-func (m *MockEventProducer) PublishEvent(topic, key string, event any) error {
+// MockDeadLetterQueue is a mock of DeadLetterQueue interface.
+type MockDeadLetterQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterQueueMockRecorder
+	isgomock struct{}
+}
+
+// MockDeadLetterQueueMockRecorder is the mock recorder for MockDeadLetterQueue.
+type MockDeadLetterQueueMockRecorder struct {
+	mock *MockDeadLetterQueue
+}
+
+// NewMockDeadLetterQueue creates a new mock instance.
+func NewMockDeadLetterQueue(ctrl *gomock.Controller) *MockDeadLetterQueue {
+	mock := &MockDeadLetterQueue{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterQueue) EXPECT() *MockDeadLetterQueueMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockDeadLetterQueue) List(ctx context.Context, topic string, limit int) ([]*kafka.DeadLetter, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PublishEvent", topic, key, event)
+	ret := m.ctrl.Call(m, "List", ctx, topic, limit)
+	ret0, _ := ret[0].([]*kafka.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeadLetterQueueMockRecorder) List(ctx, topic, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeadLetterQueue)(nil).List), ctx, topic, limit)
+}
+
+// Redrive mocks base method.
+func (m *MockDeadLetterQueue) Redrive(ctx context.Context, topic string, partition int32, offset int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redrive", ctx, topic, partition, offset)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PublishEvent indicates an expected call of PublishEvent.
-func (mr *MockEventProducerMockRecorder) PublishEvent(topic, key, event any) *gomock.Call {
+// Redrive indicates an expected call of Redrive.
+func (mr *MockDeadLetterQueueMockRecorder) Redrive(ctx, topic, partition, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvent", reflect.TypeOf((*MockEventProducer)(nil).PublishEvent), topic, key, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redrive", reflect.TypeOf((*MockDeadLetterQueue)(nil).Redrive), ctx, topic, partition, offset)
 }
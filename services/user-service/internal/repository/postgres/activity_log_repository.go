@@ -5,16 +5,16 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 )
 
 type activityLogRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewActivityLogRepository creates a new activity log repository
-func NewActivityLogRepository(db *pgxpool.Pool) domain.ActivityLogRepository {
+func NewActivityLogRepository(db *database.DB) domain.ActivityLogRepository {
 	return &activityLogRepository{db: db}
 }
 
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+)
+
+type mfaRepository struct {
+	db *database.DB
+}
+
+// NewMFARepository creates a new MFA repository
+func NewMFARepository(db *database.DB) domain.MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) Upsert(ctx context.Context, mfa *domain.UserMFA) error {
+	query := `
+		INSERT INTO user_mfa (user_id, totp_secret, is_enabled, last_used_step, enabled_at)
+		VALUES ($1, $2, false, 0, NULL)
+		ON CONFLICT (user_id) DO UPDATE
+		SET totp_secret = EXCLUDED.totp_secret,
+		    is_enabled = false,
+		    last_used_step = 0,
+		    enabled_at = NULL,
+		    updated_at = now()
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, mfa.UserID, mfa.TOTPSecret).Scan(&mfa.CreatedAt, &mfa.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save mfa: %w", err)
+	}
+
+	mfa.IsEnabled = false
+	mfa.LastUsedStep = 0
+	mfa.EnabledAt = nil
+
+	return nil
+}
+
+func (r *mfaRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	query := `
+		SELECT user_id, totp_secret, is_enabled, last_used_step, enabled_at, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+
+	var mfa domain.UserMFA
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.TOTPSecret,
+		&mfa.IsEnabled,
+		&mfa.LastUsedStep,
+		&mfa.EnabledAt,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrMFANotEnabled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+
+	return &mfa, nil
+}
+
+func (r *mfaRepository) Enable(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE user_mfa
+		SET is_enabled = true, enabled_at = now(), updated_at = now()
+		WHERE user_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to enable mfa: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrMFANotEnabled
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) error {
+	// Only move forward so a code can never be accepted twice
+	query := `
+		UPDATE user_mfa
+		SET last_used_step = $2, updated_at = now()
+		WHERE user_id = $1 AND last_used_step < $2
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to mark totp step used: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_backup_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete backup codes: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete mfa: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrMFANotEnabled
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRepository) ReplaceBackupCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_backup_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete backup codes: %w", err)
+	}
+
+	for _, codeHash := range codeHashes {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO mfa_backup_codes (code_id, user_id, code_hash) VALUES ($1, $2, $3)`,
+			uuid.New(), userID, codeHash,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create backup code: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRepository) UseBackupCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	query := `
+		UPDATE mfa_backup_codes
+		SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return fmt.Errorf("failed to use backup code: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInvalidMFACode
+	}
+
+	return nil
+}
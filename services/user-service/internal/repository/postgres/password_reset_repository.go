@@ -7,16 +7,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 )
 
 type passwordResetTokenRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewPasswordResetTokenRepository creates a new password reset token repository
-func NewPasswordResetTokenRepository(db *pgxpool.Pool) domain.PasswordResetTokenRepository {
+func NewPasswordResetTokenRepository(db *database.DB) domain.PasswordResetTokenRepository {
 	return &passwordResetTokenRepository{db: db}
 }
 
@@ -6,16 +6,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 )
 
 type permissionRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewPermissionRepository creates a new permission repository
-func NewPermissionRepository(db *pgxpool.Pool) domain.PermissionRepository {
+func NewPermissionRepository(db *database.DB) domain.PermissionRepository {
 	return &permissionRepository{db: db}
 }
 
@@ -66,6 +66,32 @@ func (r *permissionRepository) GetByID(ctx context.Context, permissionID uuid.UU
 	return &permission, nil
 }
 
+func (r *permissionRepository) GetByName(ctx context.Context, permissionName string) (*domain.Permission, error) {
+	query := `
+		SELECT permission_id, permission_name, resource, action, description
+		FROM permissions
+		WHERE permission_name = $1
+	`
+
+	var permission domain.Permission
+	err := r.db.QueryRow(ctx, query, permissionName).Scan(
+		&permission.PermissionID,
+		&permission.PermissionName,
+		&permission.Resource,
+		&permission.Action,
+		&permission.Description,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrPermissionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permission by name: %w", err)
+	}
+
+	return &permission, nil
+}
+
 func (r *permissionRepository) List(ctx context.Context) ([]*domain.Permission, error) {
 	query := `
 		SELECT permission_id, permission_name, resource, action, description
@@ -133,11 +159,11 @@ func (r *permissionRepository) GetByRoleID(ctx context.Context, roleID uuid.UUID
 }
 
 type rolePermissionRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewRolePermissionRepository creates a new role-permission repository
-func NewRolePermissionRepository(db *pgxpool.Pool) domain.RolePermissionRepository {
+func NewRolePermissionRepository(db *database.DB) domain.RolePermissionRepository {
 	return &rolePermissionRepository{db: db}
 }
 
@@ -6,16 +6,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 )
 
 type userProfileRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewUserProfileRepository creates a new user profile repository
-func NewUserProfileRepository(db *pgxpool.Pool) domain.UserProfileRepository {
+func NewUserProfileRepository(db *database.DB) domain.UserProfileRepository {
 	return &userProfileRepository{db: db}
 }
 
@@ -3,26 +3,27 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 )
 
 type roleRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewRoleRepository creates a new role repository
-func NewRoleRepository(db *pgxpool.Pool) domain.RoleRepository {
+func NewRoleRepository(db *database.DB) domain.RoleRepository {
 	return &roleRepository{db: db}
 }
 
 func (r *roleRepository) Create(ctx context.Context, role *domain.Role) error {
 	query := `
-		INSERT INTO roles (role_id, role_name, description)
-		VALUES ($1, $2, $3)
+		INSERT INTO roles (role_id, role_name, description, mfa_required)
+		VALUES ($1, $2, $3, $4)
 		RETURNING created_at
 	`
 
@@ -30,6 +31,7 @@ func (r *roleRepository) Create(ctx context.Context, role *domain.Role) error {
 		role.RoleID,
 		role.RoleName,
 		role.Description,
+		role.MFARequired,
 	).Scan(&role.CreatedAt)
 
 	if err != nil {
@@ -41,7 +43,7 @@ func (r *roleRepository) Create(ctx context.Context, role *domain.Role) error {
 
 func (r *roleRepository) GetByID(ctx context.Context, roleID uuid.UUID) (*domain.Role, error) {
 	query := `
-		SELECT role_id, role_name, description, created_at
+		SELECT role_id, role_name, description, mfa_required, created_at
 		FROM roles
 		WHERE role_id = $1
 	`
@@ -51,6 +53,7 @@ func (r *roleRepository) GetByID(ctx context.Context, roleID uuid.UUID) (*domain
 		&role.RoleID,
 		&role.RoleName,
 		&role.Description,
+		&role.MFARequired,
 		&role.CreatedAt,
 	)
 
@@ -64,9 +67,36 @@ func (r *roleRepository) GetByID(ctx context.Context, roleID uuid.UUID) (*domain
 	return &role, nil
 }
 
+func (r *roleRepository) GetByIDForUpdate(ctx context.Context, roleID uuid.UUID) (*domain.Role, error) {
+	query := `
+		SELECT role_id, role_name, description, mfa_required, created_at
+		FROM roles
+		WHERE role_id = $1
+		FOR UPDATE
+	`
+
+	var role domain.Role
+	err := r.db.QueryRow(ctx, query, roleID).Scan(
+		&role.RoleID,
+		&role.RoleName,
+		&role.Description,
+		&role.MFARequired,
+		&role.CreatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock role: %w", err)
+	}
+
+	return &role, nil
+}
+
 func (r *roleRepository) GetByName(ctx context.Context, roleName string) (*domain.Role, error) {
 	query := `
-		SELECT role_id, role_name, description, created_at
+		SELECT role_id, role_name, description, mfa_required, created_at
 		FROM roles
 		WHERE role_name = $1
 	`
@@ -76,6 +106,7 @@ func (r *roleRepository) GetByName(ctx context.Context, roleName string) (*domai
 		&role.RoleID,
 		&role.RoleName,
 		&role.Description,
+		&role.MFARequired,
 		&role.CreatedAt,
 	)
 
@@ -91,7 +122,7 @@ func (r *roleRepository) GetByName(ctx context.Context, roleName string) (*domai
 
 func (r *roleRepository) List(ctx context.Context) ([]*domain.Role, error) {
 	query := `
-		SELECT role_id, role_name, description, created_at
+		SELECT role_id, role_name, description, mfa_required, created_at
 		FROM roles
 		ORDER BY role_name
 	`
@@ -109,6 +140,7 @@ func (r *roleRepository) List(ctx context.Context) ([]*domain.Role, error) {
 			&role.RoleID,
 			&role.RoleName,
 			&role.Description,
+			&role.MFARequired,
 			&role.CreatedAt,
 		)
 		if err != nil {
@@ -123,13 +155,14 @@ func (r *roleRepository) List(ctx context.Context) ([]*domain.Role, error) {
 func (r *roleRepository) Update(ctx context.Context, role *domain.Role) error {
 	query := `
 		UPDATE roles
-		SET role_name = $1, description = $2
-		WHERE role_id = $3
+		SET role_name = $1, description = $2, mfa_required = $3
+		WHERE role_id = $4
 	`
 
 	result, err := r.db.Exec(ctx, query,
 		role.RoleName,
 		role.Description,
+		role.MFARequired,
 		role.RoleID,
 	)
 
@@ -149,6 +182,10 @@ func (r *roleRepository) Delete(ctx context.Context, roleID uuid.UUID) error {
 
 	result, err := r.db.Exec(ctx, query, roleID)
 	if err != nil {
+		// Users reference their role, so it cannot be deleted while it has any
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return domain.ErrRoleHasUsers
+		}
 		return fmt.Errorf("failed to delete role: %w", err)
 	}
 
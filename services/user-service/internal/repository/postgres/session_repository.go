@@ -7,30 +7,31 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 )
 
 type sessionRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewSessionRepository creates a new session repository
-func NewSessionRepository(db *pgxpool.Pool) domain.SessionRepository {
+func NewSessionRepository(db *database.DB) domain.SessionRepository {
 	return &sessionRepository{db: db}
 }
 
 func (r *sessionRepository) Create(ctx context.Context, session *domain.ActiveSession) error {
 	query := `
-		INSERT INTO active_sessions (session_id, user_id, refresh_token, device_info, ip_address, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO active_sessions (session_id, user_id, family_id, refresh_token_hash, device_info, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING created_at
 	`
 
 	err := r.db.QueryRow(ctx, query,
 		session.SessionID,
 		session.UserID,
-		session.RefreshToken,
+		session.FamilyID,
+		session.RefreshTokenHash,
 		session.DeviceInfo,
 		session.IPAddress,
 		session.ExpiresAt,
@@ -43,20 +44,22 @@ func (r *sessionRepository) Create(ctx context.Context, session *domain.ActiveSe
 	return nil
 }
 
-func (r *sessionRepository) GetByRefreshToken(ctx context.Context, refreshToken string) (*domain.ActiveSession, error) {
+func (r *sessionRepository) GetByRefreshTokenHash(ctx context.Context, tokenHash string) (*domain.ActiveSession, error) {
 	query := `
-		SELECT session_id, user_id, refresh_token, device_info, ip_address, expires_at, created_at
+		SELECT session_id, user_id, family_id, refresh_token_hash, device_info, ip_address, rotated_at, expires_at, created_at
 		FROM active_sessions
-		WHERE refresh_token = $1 AND expires_at > $2
+		WHERE refresh_token_hash = $1 AND expires_at > $2
 	`
 
 	var session domain.ActiveSession
-	err := r.db.QueryRow(ctx, query, refreshToken, time.Now()).Scan(
+	err := r.db.QueryRow(ctx, query, tokenHash, time.Now()).Scan(
 		&session.SessionID,
 		&session.UserID,
-		&session.RefreshToken,
+		&session.FamilyID,
+		&session.RefreshTokenHash,
 		&session.DeviceInfo,
 		&session.IPAddress,
+		&session.RotatedAt,
 		&session.ExpiresAt,
 		&session.CreatedAt,
 	)
@@ -73,9 +76,9 @@ func (r *sessionRepository) GetByRefreshToken(ctx context.Context, refreshToken
 
 func (r *sessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.ActiveSession, error) {
 	query := `
-		SELECT session_id, user_id, refresh_token, device_info, ip_address, expires_at, created_at
+		SELECT session_id, user_id, family_id, refresh_token_hash, device_info, ip_address, rotated_at, expires_at, created_at
 		FROM active_sessions
-		WHERE user_id = $1 AND expires_at > $2
+		WHERE user_id = $1 AND expires_at > $2 AND rotated_at IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -91,9 +94,11 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 		err := rows.Scan(
 			&session.SessionID,
 			&session.UserID,
-			&session.RefreshToken,
+			&session.FamilyID,
+			&session.RefreshTokenHash,
 			&session.DeviceInfo,
 			&session.IPAddress,
+			&session.RotatedAt,
 			&session.ExpiresAt,
 			&session.CreatedAt,
 		)
@@ -106,6 +111,22 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 	return sessions, nil
 }
 
+func (r *sessionRepository) MarkRotated(ctx context.Context, sessionID uuid.UUID) error {
+	// Only the first caller can rotate a session
+	query := `UPDATE active_sessions SET rotated_at = now() WHERE session_id = $1 AND rotated_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
 func (r *sessionRepository) Delete(ctx context.Context, sessionID uuid.UUID) error {
 	query := `DELETE FROM active_sessions WHERE session_id = $1`
 
@@ -121,6 +142,17 @@ func (r *sessionRepository) Delete(ctx context.Context, sessionID uuid.UUID) err
 	return nil
 }
 
+func (r *sessionRepository) DeleteByFamilyID(ctx context.Context, familyID uuid.UUID) error {
+	query := `DELETE FROM active_sessions WHERE family_id = $1`
+
+	_, err := r.db.Exec(ctx, query, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session family: %w", err)
+	}
+
+	return nil
+}
+
 func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM active_sessions WHERE user_id = $1`
 
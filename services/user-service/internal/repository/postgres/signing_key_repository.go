@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+)
+
+// signingKeyRotationLock serializes key rotation across service instances
+const signingKeyRotationLock = 727001
+
+type signingKeyRepository struct {
+	db *database.DB
+}
+
+// NewSigningKeyRepository creates a new signing key repository
+func NewSigningKeyRepository(db *database.DB) domain.SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+func (r *signingKeyRepository) List(ctx context.Context) ([]*domain.SigningKey, error) {
+	query := `
+		SELECT key_id, algorithm, private_key, activates_at, created_at
+		FROM signing_keys
+		ORDER BY activates_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*domain.SigningKey{}
+	for rows.Next() {
+		var key domain.SigningKey
+		if err := rows.Scan(&key.KeyID, &key.Algorithm, &key.PrivateKeyPEM, &key.ActivatesAt, &key.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+
+	return keys, nil
+}
+
+func (r *signingKeyRepository) Rotate(ctx context.Context, key *domain.SigningKey, rotateBefore time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, signingKeyRotationLock); err != nil {
+		return false, fmt.Errorf("failed to lock signing keys: %w", err)
+	}
+
+	var due bool
+	err = tx.QueryRow(ctx,
+		`SELECT NOT EXISTS (SELECT 1 FROM signing_keys WHERE activates_at > $1)`,
+		rotateBefore,
+	).Scan(&due)
+	if err != nil {
+		return false, fmt.Errorf("failed to check signing keys: %w", err)
+	}
+
+	if !due {
+		return false, nil
+	}
+
+	query := `
+		INSERT INTO signing_keys (key_id, algorithm, private_key, activates_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+
+	err = tx.QueryRow(ctx, query, key.KeyID, key.Algorithm, key.PrivateKeyPEM, key.ActivatesAt).Scan(&key.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit signing key: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *signingKeyRepository) Delete(ctx context.Context, keyID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM signing_keys WHERE key_id = $1`, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to delete signing key: %w", err)
+	}
+
+	return nil
+}
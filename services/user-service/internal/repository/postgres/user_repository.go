@@ -8,16 +8,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
 )
 
 type userRepository struct {
-	db *pgxpool.Pool
+	db *database.DB
 }
 
 // NewUserRepository creates a new user repository
-func NewUserRepository(db *pgxpool.Pool) domain.UserRepository {
+func NewUserRepository(db *database.DB) domain.UserRepository {
 	return &userRepository{db: db}
 }
 
@@ -182,6 +182,17 @@ func (r *userRepository) Delete(ctx context.Context, userID uuid.UUID) error {
 	return nil
 }
 
+func (r *userRepository) CountByRole(ctx context.Context, roleID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(*) FROM users WHERE role_id = $1`
+
+	var count int64
+	if err := r.db.QueryRow(ctx, query, roleID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users by role: %w", err)
+	}
+
+	return count, nil
+}
+
 func (r *userRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*domain.User, int64, error) {
 	// Build dynamic query based on filters
 	whereClause := []string{}
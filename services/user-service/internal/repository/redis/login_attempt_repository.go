@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type loginAttemptRepository struct {
+	client *goredis.Client
+}
+
+// NewLoginAttemptRepository creates a new Redis backed login attempt repository
+func NewLoginAttemptRepository(client *goredis.Client) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{client: client}
+}
+
+func attemptsKey(key string) string {
+	return fmt.Sprintf("login_attempts:%s", key)
+}
+
+func lockKey(key string) string {
+	return fmt.Sprintf("login_lock:%s", key)
+}
+
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, attemptsKey(key))
+	// NX keeps the window anchored at the first failure
+	pipe.ExpireNX(ctx, attemptsKey(key), window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, duration time.Duration) error {
+	if err := r.client.Set(ctx, lockKey(key), time.Now().Add(duration).Unix(), duration).Err(); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) LockTTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, lockKey(key)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get login lock: %w", err)
+	}
+
+	// Negative values mean the key does not exist or has no expiry
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (r *loginAttemptRepository) Clear(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, attemptsKey(key), lockKey(key)).Err(); err != nil {
+		return fmt.Errorf("failed to clear login attempts: %w", err)
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -17,12 +19,18 @@ type authService struct {
 	userRepo           domain.UserRepository
 	profileRepo        domain.UserProfileRepository
 	roleRepo           domain.RoleRepository
+	permissionRepo     domain.PermissionRepository
 	sessionRepo        domain.SessionRepository
 	passwordTokenRepo  domain.PasswordResetTokenRepository
 	activityLogRepo    domain.ActivityLogRepository
+	mfaRepo            domain.MFARepository
+	loginAttemptRepo   domain.LoginAttemptRepository
 	jwtManager         *utils.JWTManager
+	tokenRevoker       domain.TokenRevoker
+	transactor         domain.Transactor
 	producer           domain.EventProducer
 	refreshTokenExpiry time.Duration
+	lockout            domain.LockoutPolicy
 }
 
 // NewAuthService creates a new auth service
@@ -30,134 +38,208 @@ func NewAuthService(
 	userRepo domain.UserRepository,
 	profileRepo domain.UserProfileRepository,
 	roleRepo domain.RoleRepository,
+	permissionRepo domain.PermissionRepository,
 	sessionRepo domain.SessionRepository,
 	passwordTokenRepo domain.PasswordResetTokenRepository,
 	activityLogRepo domain.ActivityLogRepository,
+	mfaRepo domain.MFARepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
 	jwtManager *utils.JWTManager,
+	tokenRevoker domain.TokenRevoker,
+	transactor domain.Transactor,
 	producer domain.EventProducer,
 	refreshTokenExpiry int,
+	lockout domain.LockoutPolicy,
 ) domain.AuthService {
 	return &authService{
 		userRepo:           userRepo,
 		profileRepo:        profileRepo,
 		roleRepo:           roleRepo,
+		permissionRepo:     permissionRepo,
 		sessionRepo:        sessionRepo,
 		passwordTokenRepo:  passwordTokenRepo,
 		activityLogRepo:    activityLogRepo,
+		mfaRepo:            mfaRepo,
+		loginAttemptRepo:   loginAttemptRepo,
 		jwtManager:         jwtManager,
+		tokenRevoker:       tokenRevoker,
+		transactor:         transactor,
 		producer:           producer,
 		refreshTokenExpiry: time.Duration(refreshTokenExpiry) * time.Second,
+		lockout:            lockout,
 	}
 }
 
-func (s *authService) Login(ctx context.Context, email, password string, ipAddress, userAgent string) (accessToken, refreshToken string, user *domain.UserWithProfile, err error) {
+func (s *authService) Login(ctx context.Context, email, password string, ipAddress, userAgent string) (*domain.LoginResult, error) {
+	// Refuse attempts while the email or IP address is backing off or locked
+	if retryAfter := s.checkLoginLock(ctx, email, ipAddress); retryAfter > 0 {
+		s.logAuthEvent(ctx, uuid.Nil, email, ipAddress, userAgent, false, "login locked")
+		return nil, &domain.LoginThrottledError{Err: domain.ErrAccountLocked, RetryAfter: retryAfter}
+	}
+
 	// Get user by email
 	foundUser, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		// Log failed login attempt
 		s.logAuthEvent(ctx, uuid.Nil, email, ipAddress, userAgent, false, "user not found")
-		return "", "", nil, domain.ErrInvalidCredentials
+		return nil, s.recordLoginFailure(ctx, nil, email, ipAddress, userAgent)
 	}
 
 	// Check if user is active
 	if foundUser.Status != "active" {
 		s.logAuthEvent(ctx, foundUser.UserID, email, ipAddress, userAgent, false, "user not active")
-		return "", "", nil, domain.ErrInvalidCredentials
+		return nil, domain.ErrInvalidCredentials
 	}
 
 	// Verify password
 	if err := utils.VerifyPassword(foundUser.PasswordHash, password); err != nil {
 		s.logAuthEvent(ctx, foundUser.UserID, email, ipAddress, userAgent, false, "invalid password")
-		return "", "", nil, domain.ErrInvalidCredentials
+		return nil, s.recordLoginFailure(ctx, foundUser, email, ipAddress, userAgent)
 	}
 
-	// Get user profile and role
-	profile, err := s.profileRepo.GetByUserID(ctx, foundUser.UserID)
+	role, err := s.roleRepo.GetByID(ctx, foundUser.RoleID)
 	if err != nil {
-		return "", "", nil, err
+		return nil, err
 	}
 
-	role, err := s.roleRepo.GetByID(ctx, foundUser.RoleID)
+	// Users with a second factor (or whose role requires one) get a challenge instead of tokens
+	mfa, err := s.mfaRepo.GetByUserID(ctx, foundUser.UserID)
+	if err != nil && err != domain.ErrMFANotEnabled {
+		return nil, err
+	}
+
+	if mfa != nil && mfa.IsEnabled {
+		return s.issueMFAChallenge(ctx, foundUser, ipAddress, userAgent)
+	}
+
+	if role.MFARequired {
+		secret, provisioningURI, err := s.startMFAEnrollment(ctx, foundUser, ipAddress, userAgent)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.issueMFAChallenge(ctx, foundUser, ipAddress, userAgent)
+		if err != nil {
+			return nil, err
+		}
+		result.MFAEnrollmentRequired = true
+		result.MFASecret = secret
+		result.MFAProvisioningURI = provisioningURI
+		return result, nil
+	}
+
+	return s.issueSession(ctx, foundUser, role, ipAddress, userAgent)
+}
+
+// issueSession creates a session with fresh tokens once every login factor has passed
+func (s *authService) issueSession(ctx context.Context, user *domain.User, role *domain.Role, ipAddress, userAgent string) (*domain.LoginResult, error) {
+	profile, err := s.profileRepo.GetByUserID(ctx, user.UserID)
 	if err != nil {
-		return "", "", nil, err
+		return nil, err
 	}
 
 	// Generate tokens
-	accessToken, err = s.jwtManager.GenerateAccessToken(foundUser.UserID, foundUser.Email, role.RoleID, role.RoleName)
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.UserID, user.Email, role.RoleID, role.RoleName)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err = s.jwtManager.GenerateRefreshToken(foundUser.UserID)
+	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.UserID)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	// Create session
+	// Create session, starting a new refresh token family
+	sessionID := uuid.New()
 	session := &domain.ActiveSession{
-		SessionID:    uuid.New(),
-		UserID:       foundUser.UserID,
-		RefreshToken: refreshToken,
-		DeviceInfo:   &userAgent,
-		IPAddress:    &ipAddress,
-		ExpiresAt:    time.Now().Add(s.refreshTokenExpiry),
+		SessionID:        sessionID,
+		UserID:           user.UserID,
+		FamilyID:         sessionID,
+		RefreshTokenHash: hashRefreshToken(refreshToken),
+		DeviceInfo:       &userAgent,
+		IPAddress:        &ipAddress,
+		ExpiresAt:        time.Now().Add(s.refreshTokenExpiry),
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Create(ctx, session); err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+
+		// Publish login success event
+		event := models.NewAuthEvent(models.EventLoginSuccess, user.UserID, user.Email, ipAddress, userAgent, true)
+		return s.producer.PublishEvent(ctx, "auth.events", user.UserID.String(), event)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.sessionRepo.Create(ctx, session); err != nil {
-		return "", "", nil, fmt.Errorf("failed to create session: %w", err)
-	}
+	// Every factor passed, so earlier failures for this email no longer count. A
+	// right password alone must not clear them, or wrong second factors would
+	// never lock the account.
+	s.loginAttemptRepo.Clear(ctx, emailAttemptKey(user.Email))
 
 	// Update last login
-	s.userRepo.UpdateLastLogin(ctx, foundUser.UserID)
+	s.userRepo.UpdateLastLogin(ctx, user.UserID)
 
 	// Log successful login
-	s.logAuthEvent(ctx, foundUser.UserID, email, ipAddress, userAgent, true, "")
+	s.logAuthEvent(ctx, user.UserID, user.Email, ipAddress, userAgent, true, "")
 
-	// Publish login success event
-	event := models.NewAuthEvent(models.EventLoginSuccess, foundUser.UserID, email, ipAddress, userAgent, true)
-	s.producer.PublishEvent("auth.events", foundUser.UserID.String(), event)
-
-	userWithProfile := &domain.UserWithProfile{
-		User:        *foundUser,
-		UserProfile: *profile,
-		Role:        role,
-	}
-
-	return accessToken, refreshToken, userWithProfile, nil
+	return &domain.LoginResult{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User: &domain.UserWithProfile{
+			User:        *user,
+			UserProfile: *profile,
+			Role:        role,
+		},
+	}, nil
 }
 
-func (s *authService) Logout(ctx context.Context, userID uuid.UUID, refreshToken string) error {
+func (s *authService) Logout(ctx context.Context, userID uuid.UUID, refreshToken, accessTokenID string, accessTokenExpiresAt time.Time) error {
 	// Get session by refresh token
-	session, err := s.sessionRepo.GetByRefreshToken(ctx, refreshToken)
+	session, err := s.sessionRepo.GetByRefreshTokenHash(ctx, hashRefreshToken(refreshToken))
 	if err != nil {
 		return err
 	}
 
-	// Delete session
-	if err := s.sessionRepo.Delete(ctx, session.SessionID); err != nil {
-		return err
-	}
-
 	// Get user for event
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	// Publish logout event
-	event := models.NewAuthEvent(models.EventLogout, userID, user.Email, "", "", true)
-	s.producer.PublishEvent("auth.events", userID.String(), event)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Delete session
+		if err := s.sessionRepo.Delete(ctx, session.SessionID); err != nil {
+			return err
+		}
+
+		// Publish logout event
+		event := models.NewAuthEvent(models.EventLogout, userID, user.Email, "", "", true)
+		return s.producer.PublishEvent(ctx, "auth.events", userID.String(), event)
+	})
+	if err != nil {
+		return err
+	}
 
-	return nil
+	// End the access token the logout was made with
+	return s.tokenRevoker.RevokeToken(ctx, accessTokenID, accessTokenExpiresAt)
 }
 
-func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (accessToken, newRefreshToken string, err error) {
+func (s *authService) RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (accessToken, newRefreshToken string, err error) {
 	// Get session
-	session, err := s.sessionRepo.GetByRefreshToken(ctx, refreshToken)
+	session, err := s.sessionRepo.GetByRefreshTokenHash(ctx, hashRefreshToken(refreshToken))
 	if err != nil {
 		return "", "", domain.ErrInvalidToken
 	}
 
+	// A refresh token that was already exchanged is being replayed, so it has leaked
+	if session.RotatedAt != nil {
+		s.revokeSessionFamily(ctx, session, ipAddress, userAgent)
+		return "", "", domain.ErrRefreshTokenReused
+	}
+
 	// Get user and role
 	user, err := s.userRepo.GetByID(ctx, session.UserID)
 	if err != nil {
@@ -184,19 +266,33 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (ac
 		return "", "", err
 	}
 
-	// Delete old session and create new one
-	s.sessionRepo.Delete(ctx, session.SessionID)
-
 	newSession := &domain.ActiveSession{
-		SessionID:    uuid.New(),
-		UserID:       user.UserID,
-		RefreshToken: newRefreshToken,
-		DeviceInfo:   session.DeviceInfo,
-		IPAddress:    session.IPAddress,
-		ExpiresAt:    time.Now().Add(s.refreshTokenExpiry),
-	}
-
-	if err := s.sessionRepo.Create(ctx, newSession); err != nil {
+		SessionID:        uuid.New(),
+		UserID:           user.UserID,
+		FamilyID:         session.FamilyID,
+		RefreshTokenHash: hashRefreshToken(newRefreshToken),
+		DeviceInfo:       session.DeviceInfo,
+		IPAddress:        session.IPAddress,
+		ExpiresAt:        time.Now().Add(s.refreshTokenExpiry),
+	}
+	if ipAddress != "" {
+		newSession.IPAddress = &ipAddress
+	}
+
+	// Retire the old session and start the new one together, so that a failed
+	// create does not leave the user with neither
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.MarkRotated(ctx, session.SessionID); err != nil {
+			return err
+		}
+		return s.sessionRepo.Create(ctx, newSession)
+	})
+	if err != nil {
+		// Losing a concurrent rotation means the token was used twice
+		if err == domain.ErrSessionNotFound {
+			s.revokeSessionFamily(ctx, session, ipAddress, userAgent)
+			return "", "", domain.ErrRefreshTokenReused
+		}
 		return "", "", err
 	}
 
@@ -222,19 +318,27 @@ func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, oldP
 
 	user.PasswordHash = hashedPassword
 
-	// Update user
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Update user
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		// Revoke all sessions (force re-login)
+		if err := s.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		// Publish password changed event
+		event := models.NewAuthEvent(models.EventPasswordChanged, userID, user.Email, "", "", true)
+		return s.producer.PublishEvent(ctx, "auth.events", userID.String(), event)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Revoke all sessions (force re-login)
-	s.sessionRepo.DeleteByUserID(ctx, userID)
-
-	// Publish password changed event
-	event := models.NewAuthEvent(models.EventPasswordChanged, userID, user.Email, "", "", true)
-	s.producer.PublishEvent("auth.events", userID.String(), event)
-
-	return nil
+	// Revoke all access tokens
+	return s.tokenRevoker.RevokeUserTokens(ctx, userID)
 }
 
 func (s *authService) RequestPasswordReset(ctx context.Context, email string) (token string, err error) {
@@ -290,22 +394,32 @@ func (s *authService) ResetPassword(ctx context.Context, token, newPassword stri
 
 	user.PasswordHash = hashedPassword
 
-	// Update user
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Update user
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		// Mark token as used
+		if err := s.passwordTokenRepo.MarkAsUsed(ctx, resetToken.TokenID); err != nil {
+			return err
+		}
+
+		// Revoke all sessions
+		if err := s.sessionRepo.DeleteByUserID(ctx, user.UserID); err != nil {
+			return err
+		}
+
+		// Publish password changed event
+		event := models.NewAuthEvent(models.EventPasswordChanged, user.UserID, user.Email, "", "", true)
+		return s.producer.PublishEvent(ctx, "auth.events", user.UserID.String(), event)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Mark token as used
-	s.passwordTokenRepo.MarkAsUsed(ctx, resetToken.TokenID)
-
-	// Revoke all sessions
-	s.sessionRepo.DeleteByUserID(ctx, user.UserID)
-
-	// Publish password changed event
-	event := models.NewAuthEvent(models.EventPasswordChanged, user.UserID, user.Email, "", "", true)
-	s.producer.PublishEvent("auth.events", user.UserID.String(), event)
-
-	return nil
+	// Revoke all access tokens
+	return s.tokenRevoker.RevokeUserTokens(ctx, user.UserID)
 }
 
 func (s *authService) GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*domain.ActiveSession, error) {
@@ -317,7 +431,68 @@ func (s *authService) RevokeSession(ctx context.Context, sessionID uuid.UUID) er
 }
 
 func (s *authService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
-	return s.sessionRepo.DeleteByUserID(ctx, userID)
+	if err := s.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.tokenRevoker.RevokeUserTokens(ctx, userID)
+}
+
+// revokeSessionFamily ends every session descended from the same login as session,
+// revokes the user's access tokens, records the reuse and raises a security event
+func (s *authService) revokeSessionFamily(ctx context.Context, session *domain.ActiveSession, ipAddress, userAgent string) {
+	email := ""
+	if user, err := s.userRepo.GetByID(ctx, session.UserID); err == nil {
+		email = user.Email
+	}
+
+	s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.DeleteByFamilyID(ctx, session.FamilyID); err != nil {
+			return err
+		}
+
+		event := models.NewAuthEvent(models.EventRefreshTokenReused, session.UserID, email, ipAddress, userAgent, false)
+		event.ErrorReason = domain.ErrRefreshTokenReused.Error()
+		event.Metadata = map[string]interface{}{
+			"family_id":  session.FamilyID,
+			"session_id": session.SessionID,
+		}
+		return s.producer.PublishEvent(ctx, "auth.events", session.UserID.String(), event)
+	})
+
+	// Access tokens are not tied to a session, so whoever holds the leaked
+	// refresh token may also hold an access token of any of the user's sessions
+	s.tokenRevoker.RevokeUserTokens(ctx, session.UserID)
+
+	resourceType := "session"
+	details, _ := json.Marshal(map[string]interface{}{
+		"family_id":  session.FamilyID,
+		"session_id": session.SessionID,
+		"rotated_at": session.RotatedAt,
+	})
+	detailsStr := string(details)
+
+	log := &domain.UserActivityLog{
+		LogID:        uuid.New(),
+		UserID:       session.UserID,
+		Action:       "refresh_token_reused",
+		ResourceType: &resourceType,
+		ResourceID:   &session.FamilyID,
+		Details:      &detailsStr,
+	}
+	if ipAddress != "" {
+		log.IPAddress = &ipAddress
+	}
+	if userAgent != "" {
+		log.UserAgent = &userAgent
+	}
+	s.activityLogRepo.Create(ctx, log)
+}
+
+// hashRefreshToken returns the SHA-256 digest refresh tokens are stored and looked up by
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
 }
 
 // Helper to log auth events
@@ -341,6 +516,6 @@ func (s *authService) logAuthEvent(ctx context.Context, userID uuid.UUID, email,
 	if !success {
 		event := models.NewAuthEvent(models.EventLoginFailed, userID, email, ipAddress, userAgent, false)
 		event.ErrorReason = errorReason
-		s.producer.PublishEvent("auth.events", email, event)
+		s.producer.PublishEvent(ctx, "auth.events", email, event)
 	}
 }
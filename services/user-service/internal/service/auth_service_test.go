@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -10,9 +12,26 @@ import (
 
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
 	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/SureshAmal/NimbusU-backend/shared/utils"
 )
 
+var testLockoutPolicy = domain.LockoutPolicy{
+	MaxFailedAttempts:   5,
+	MaxFailedIPAttempts: 20,
+	AttemptWindow:       15 * time.Minute,
+	BackoffBase:         2 * time.Second,
+	LockoutDuration:     15 * time.Minute,
+}
+
+func newTestJWTManager(t *testing.T) *utils.JWTManager {
+	key, err := utils.GenerateSigningKey(utils.AlgorithmEdDSA, time.Now())
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+	return utils.NewJWTManager(utils.NewKeyRing(key), 3600, 3600)
+}
+
 func TestAuthService_Login(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -23,21 +42,30 @@ func TestAuthService_Login(t *testing.T) {
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 	mockTokenRepo := mocks.NewMockPasswordResetTokenRepository(ctrl)
 	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
 
 	// We need real JWT manager
-	jwtManager := utils.NewJWTManager("secret", 3600, 3600)
+	jwtManager := newTestJWTManager(t)
 
 	service := NewAuthService(
 		mockUserRepo,
 		mockProfileRepo,
 		mockRoleRepo,
+		nil,
 		mockSessionRepo,
 		mockTokenRepo,
 		mockActivityRepo,
+		mockMFARepo,
+		mockLoginAttemptRepo,
 		jwtManager,
+		mockTokenRevoker,
+		newTestTransactor(ctrl),
 		mockProducer,
 		3600,
+		testLockoutPolicy,
 	)
 
 	t.Run("Success", func(t *testing.T) {
@@ -67,23 +95,98 @@ func TestAuthService_Login(t *testing.T) {
 		}
 
 		// Expectations
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
 		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().Clear(gomock.Any(), "email:"+email).Return(nil)
 		mockProfileRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(profile, nil)
 		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(role, nil)
+		mockMFARepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, domain.ErrMFANotEnabled)
 		mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 		mockUserRepo.EXPECT().UpdateLastLogin(gomock.Any(), userID).Return(nil)
 		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)                 // Log success
-		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil) // Login event
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil) // Login event
 
 		// Execute
-		accessToken, refreshToken, userWithProfile, err := service.Login(context.Background(), email, password, "127.0.0.1", "Go-Test")
+		result, err := service.Login(context.Background(), email, password, "127.0.0.1", "Go-Test")
 
 		// Assertions
 		assert.NoError(t, err)
-		assert.NotEmpty(t, accessToken)
-		assert.NotEmpty(t, refreshToken)
-		assert.Equal(t, email, userWithProfile.Email)
-		assert.Equal(t, "Test", userWithProfile.UserProfile.FirstName)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEmpty(t, result.RefreshToken)
+		assert.Empty(t, result.MFAToken)
+		assert.Equal(t, email, result.User.Email)
+		assert.Equal(t, "Test", result.User.UserProfile.FirstName)
+	})
+
+	t.Run("MFA Challenge", func(t *testing.T) {
+		email := "mfa@example.com"
+		password := "password123"
+		hashedPassword, _ := utils.HashPassword(password)
+		userID := uuid.New()
+		roleID := uuid.New()
+
+		user := &domain.User{
+			UserID:       userID,
+			Email:        email,
+			PasswordHash: hashedPassword,
+			RoleID:       roleID,
+			Status:       "active",
+		}
+
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(user, nil)
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "faculty"}, nil)
+		mockMFARepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(&domain.UserMFA{UserID: userID, IsEnabled: true}, nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *domain.UserActivityLog) error {
+			assert.Equal(t, "mfa_challenge_issued", log.Action)
+			return nil
+		})
+
+		result, err := service.Login(context.Background(), email, password, "127.0.0.1", "Go-Test")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+
+		challengedUserID, err := jwtManager.ValidateMFAToken(result.MFAToken)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, challengedUserID)
+
+		// A challenge token must never pass as an access token
+		_, err = jwtManager.ValidateAccessToken(result.MFAToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("MFA Enrollment Required By Role", func(t *testing.T) {
+		email := "enroll@example.com"
+		password := "password123"
+		hashedPassword, _ := utils.HashPassword(password)
+		userID := uuid.New()
+		roleID := uuid.New()
+
+		user := &domain.User{
+			UserID:       userID,
+			Email:        email,
+			PasswordHash: hashedPassword,
+			RoleID:       roleID,
+			Status:       "active",
+		}
+
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(user, nil)
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "admin", MFARequired: true}, nil)
+		mockMFARepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, domain.ErrMFANotEnabled)
+		mockMFARepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2) // Enrollment started, challenge issued
+
+		result, err := service.Login(context.Background(), email, password, "127.0.0.1", "Go-Test")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.AccessToken)
+		assert.NotEmpty(t, result.MFAToken)
+		assert.True(t, result.MFAEnrollmentRequired)
+		assert.NotEmpty(t, result.MFASecret)
+		assert.Contains(t, result.MFAProvisioningURI, "otpauth://totp/")
 	})
 
 	t.Run("Invalid Password", func(t *testing.T) {
@@ -99,32 +202,241 @@ func TestAuthService_Login(t *testing.T) {
 			Status:       "active",
 		}
 
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
 		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), gomock.Any(), testLockoutPolicy.AttemptWindow).Return(int64(1), nil).Times(2)
 		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)                 // Log failure
-		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil) // Login failed event
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil) // Login failed event
 
-		accessToken, refreshToken, _, err := service.Login(context.Background(), email, password, "127.0.0.1", "Go-Test")
+		result, err := service.Login(context.Background(), email, password, "127.0.0.1", "Go-Test")
 
 		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
+		assert.Nil(t, result)
 	})
 
 	t.Run("User Not Found", func(t *testing.T) {
 		email := "unknown@example.com"
 
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
 		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(nil, domain.ErrUserNotFound)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), gomock.Any(), testLockoutPolicy.AttemptWindow).Return(int64(1), nil).Times(2)
 		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil) // Log failure
-		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
-		accessToken, refreshToken, _, err := service.Login(context.Background(), email, "any", "127.0.0.1", "Go-Test")
+		result, err := service.Login(context.Background(), email, "any", "127.0.0.1", "Go-Test")
 
 		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
+		assert.Nil(t, result)
 	})
 }
 
+func TestAuthService_LoginLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
+	mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
+	jwtManager := newTestJWTManager(t)
+
+	service := NewAuthService(
+		mockUserRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		mockActivityRepo,
+		nil,
+		mockLoginAttemptRepo,
+		jwtManager,
+		mockTokenRevoker,
+		newTestTransactor(ctrl),
+		mockProducer,
+		3600,
+		testLockoutPolicy,
+	)
+
+	hashedPassword, _ := utils.HashPassword("correctpassword")
+
+	t.Run("Locked", func(t *testing.T) {
+		email := "locked@example.com"
+
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), "email:"+email).Return(30*time.Second, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), "ip:127.0.0.1").Return(time.Duration(0), nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "auth.events", email, gomock.Any()).Return(nil)
+
+		result, err := service.Login(context.Background(), email, "correctpassword", "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrAccountLocked)
+		assert.Nil(t, result)
+
+		var throttled *domain.LoginThrottledError
+		assert.ErrorAs(t, err, &throttled)
+		assert.Equal(t, 30*time.Second, throttled.RetryAfter)
+	})
+
+	t.Run("Backoff", func(t *testing.T) {
+		email := "backoff@example.com"
+		user := &domain.User{UserID: uuid.New(), Email: email, PasswordHash: hashedPassword, Status: "active"}
+
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(user, nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "auth.events", email, gomock.Any()).Return(nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), "email:"+email, testLockoutPolicy.AttemptWindow).Return(int64(4), nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), "ip:127.0.0.1", testLockoutPolicy.AttemptWindow).Return(int64(4), nil)
+		mockLoginAttemptRepo.EXPECT().Lock(gomock.Any(), "email:"+email, 4*time.Second).Return(nil) // Third failure past the free half
+
+		result, err := service.Login(context.Background(), email, "wrongpassword", "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		assert.Nil(t, result)
+
+		var throttled *domain.LoginThrottledError
+		assert.ErrorAs(t, err, &throttled)
+		assert.Equal(t, 4*time.Second, throttled.RetryAfter)
+	})
+
+	t.Run("Lockout Reached", func(t *testing.T) {
+		email := "victim@example.com"
+		user := &domain.User{UserID: uuid.New(), Email: email, PasswordHash: hashedPassword, Status: "active"}
+
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), "email:"+email, testLockoutPolicy.AttemptWindow).Return(int64(5), nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), "ip:127.0.0.1", testLockoutPolicy.AttemptWindow).Return(int64(5), nil)
+		mockLoginAttemptRepo.EXPECT().Lock(gomock.Any(), "email:"+email, testLockoutPolicy.LockoutDuration).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "auth.events", email, gomock.Any()).Return(nil) // Login failed
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "auth.events", email, gomock.Any()).DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+			authEvent := event.(*models.AuthEvent)
+			assert.Equal(t, models.EventLoginLocked, authEvent.EventType)
+			assert.Equal(t, user.UserID, authEvent.UserID)
+			assert.Equal(t, "email", authEvent.Metadata["scope"])
+			return nil
+		})
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "notification.commands", user.UserID.String(), gomock.Any()).DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+			notification := event.(*models.NotificationCommand)
+			assert.Equal(t, []string{"admin"}, notification.RecipientRoles)
+			assert.Contains(t, notification.ActionURL, user.UserID.String())
+			return nil
+		})
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *domain.UserActivityLog) error {
+			assert.Equal(t, "account_locked", log.Action)
+			return nil
+		})
+
+		result, err := service.Login(context.Background(), email, "wrongpassword", "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrAccountLocked)
+		assert.Nil(t, result)
+	})
+}
+
+// memoryLoginAttempts keeps failure counters and locks in memory, on a clock
+// the test moves forward to wait out backoff delays
+type memoryLoginAttempts struct {
+	now         time.Time
+	counts      map[string]int64
+	lockedUntil map[string]time.Time
+}
+
+func newMemoryLoginAttempts() *memoryLoginAttempts {
+	return &memoryLoginAttempts{now: time.Now(), counts: map[string]int64{}, lockedUntil: map[string]time.Time{}}
+}
+
+func (m *memoryLoginAttempts) RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	m.counts[key]++
+	return m.counts[key], nil
+}
+
+func (m *memoryLoginAttempts) Lock(ctx context.Context, key string, duration time.Duration) error {
+	m.lockedUntil[key] = m.now.Add(duration)
+	return nil
+}
+
+func (m *memoryLoginAttempts) LockTTL(ctx context.Context, key string) (time.Duration, error) {
+	if until, ok := m.lockedUntil[key]; ok && until.After(m.now) {
+		return until.Sub(m.now), nil
+	}
+	return 0, nil
+}
+
+func (m *memoryLoginAttempts) Clear(ctx context.Context, key string) error {
+	delete(m.counts, key)
+	delete(m.lockedUntil, key)
+	return nil
+}
+
+func TestAuthService_MFAFailuresLockAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+	attempts := newMemoryLoginAttempts()
+	jwtManager := newTestJWTManager(t)
+
+	service := NewAuthService(
+		mockUserRepo,
+		nil,
+		mockRoleRepo,
+		nil,
+		nil,
+		nil,
+		mockActivityRepo,
+		mockMFARepo,
+		attempts,
+		jwtManager,
+		nil,
+		newTestTransactor(ctrl),
+		mockProducer,
+		3600,
+		testLockoutPolicy,
+	)
+
+	email := "mfa@example.com"
+	hashedPassword, _ := utils.HashPassword("password123")
+	secret, _ := utils.GenerateTOTPSecret()
+	user := &domain.User{UserID: uuid.New(), Email: email, PasswordHash: hashedPassword, RoleID: uuid.New(), Status: "active"}
+
+	mockUserRepo.EXPECT().GetByEmail(gomock.Any(), email).Return(user, nil).AnyTimes()
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil).AnyTimes()
+	mockRoleRepo.EXPECT().GetByID(gomock.Any(), user.RoleID).Return(&domain.Role{RoleID: user.RoleID, RoleName: "faculty"}, nil).AnyTimes()
+	mockMFARepo.EXPECT().GetByUserID(gomock.Any(), user.UserID).Return(&domain.UserMFA{UserID: user.UserID, TOTPSecret: secret, IsEnabled: true}, nil).AnyTimes()
+	mockMFARepo.EXPECT().UseBackupCode(gomock.Any(), user.UserID, gomock.Any()).Return(domain.ErrInvalidMFACode).AnyTimes()
+	mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// The right password followed by a wrong code, waiting out every backoff delay
+	var err error
+	for i := 0; i < testLockoutPolicy.MaxFailedAttempts; i++ {
+		result, loginErr := service.Login(context.Background(), email, "password123", "127.0.0.1", "Go-Test")
+		if !assert.NoError(t, loginErr, "attempt %d", i+1) {
+			return
+		}
+
+		_, err = service.VerifyMFA(context.Background(), result.MFAToken, "000000", "127.0.0.1", "Go-Test")
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) && !errors.Is(err, domain.ErrAccountLocked) {
+			attempts.now = attempts.now.Add(throttled.RetryAfter)
+		}
+	}
+
+	assert.ErrorIs(t, err, domain.ErrAccountLocked)
+
+	// Even the right password is refused until the lockout ends
+	_, err = service.Login(context.Background(), email, "password123", "127.0.0.1", "Go-Test")
+	assert.ErrorIs(t, err, domain.ErrAccountLocked)
+}
+
 func TestAuthService_Logout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -138,24 +450,34 @@ func TestAuthService_Logout(t *testing.T) {
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 	mockTokenRepo := mocks.NewMockPasswordResetTokenRepository(ctrl)
 	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
-	jwtManager := utils.NewJWTManager("secret", 3600, 3600)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
+	jwtManager := newTestJWTManager(t)
 
 	service := NewAuthService(
 		mockUserRepo,
 		mockProfileRepo,
 		mockRoleRepo,
+		nil,
 		mockSessionRepo,
 		mockTokenRepo,
 		mockActivityRepo,
+		mockMFARepo,
+		mockLoginAttemptRepo,
 		jwtManager,
+		mockTokenRevoker,
+		newTestTransactor(ctrl),
 		mockProducer,
 		3600,
+		testLockoutPolicy,
 	)
 
 	t.Run("Success", func(t *testing.T) {
 		userID := uuid.New()
 		sessionID := uuid.New()
 		refreshToken := "valid_refresh"
+		expiresAt := time.Now().Add(time.Hour)
 
 		session := &domain.ActiveSession{
 			SessionID: sessionID,
@@ -167,13 +489,437 @@ func TestAuthService_Logout(t *testing.T) {
 			Email:  "test@example.com",
 		}
 
-		mockSessionRepo.EXPECT().GetByRefreshToken(gomock.Any(), refreshToken).Return(session, nil)
+		mockSessionRepo.EXPECT().GetByRefreshTokenHash(gomock.Any(), hashRefreshToken(refreshToken)).Return(session, nil)
 		mockSessionRepo.EXPECT().Delete(gomock.Any(), sessionID).Return(nil)
 		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
-		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockTokenRevoker.EXPECT().RevokeToken(gomock.Any(), "access-jti", expiresAt).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
-		err := service.Logout(context.Background(), userID, refreshToken)
+		err := service.Logout(context.Background(), userID, refreshToken, "access-jti", expiresAt)
 
 		assert.NoError(t, err)
 	})
 }
+
+func TestAuthService_VerifyMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockProfileRepo := mocks.NewMockUserProfileRepository(ctrl)
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockTokenRepo := mocks.NewMockPasswordResetTokenRepository(ctrl)
+	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
+	jwtManager := newTestJWTManager(t)
+
+	service := NewAuthService(
+		mockUserRepo,
+		mockProfileRepo,
+		mockRoleRepo,
+		nil,
+		mockSessionRepo,
+		mockTokenRepo,
+		mockActivityRepo,
+		mockMFARepo,
+		mockLoginAttemptRepo,
+		jwtManager,
+		mockTokenRevoker,
+		newTestTransactor(ctrl),
+		mockProducer,
+		3600,
+		testLockoutPolicy,
+	)
+
+	secret, _ := utils.GenerateTOTPSecret()
+
+	t.Run("Success", func(t *testing.T) {
+		userID := uuid.New()
+		roleID := uuid.New()
+		mfaToken, _ := jwtManager.GenerateMFAToken(userID)
+		code, _ := utils.GenerateTOTPCode(secret, time.Now())
+
+		user := &domain.User{UserID: userID, Email: "test@example.com", RoleID: roleID, Status: "active"}
+		mfa := &domain.UserMFA{UserID: userID, TOTPSecret: secret, IsEnabled: true}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(3)
+		mockMFARepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(mfa, nil)
+		mockMFARepo.EXPECT().MarkStepUsed(gomock.Any(), userID, gomock.Any()).Return(nil)
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "student"}, nil)
+		mockProfileRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(&domain.UserProfile{UserID: userID}, nil)
+		mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockLoginAttemptRepo.EXPECT().Clear(gomock.Any(), "email:test@example.com").Return(nil)
+		mockUserRepo.EXPECT().UpdateLastLogin(gomock.Any(), userID).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2) // MFA verified, login success
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := service.VerifyMFA(context.Background(), mfaToken, code, "127.0.0.1", "Go-Test")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEmpty(t, result.RefreshToken)
+	})
+
+	t.Run("Replayed Code", func(t *testing.T) {
+		userID := uuid.New()
+		mfaToken, _ := jwtManager.GenerateMFAToken(userID)
+		now := time.Now()
+		code, _ := utils.GenerateTOTPCode(secret, now)
+
+		user := &domain.User{UserID: userID, Email: "test@example.com", Status: "active"}
+		mfa := &domain.UserMFA{UserID: userID, TOTPSecret: secret, IsEnabled: true, LastUsedStep: utils.TOTPStep(now) + 1}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(3)
+		mockMFARepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(mfa, nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(3) // Challenge, email, IP
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *domain.UserActivityLog) error {
+			assert.Equal(t, "mfa_verification_failed", log.Action)
+			return nil
+		})
+
+		result, err := service.VerifyMFA(context.Background(), mfaToken, code, "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Backup Code", func(t *testing.T) {
+		userID := uuid.New()
+		roleID := uuid.New()
+		mfaToken, _ := jwtManager.GenerateMFAToken(userID)
+
+		user := &domain.User{UserID: userID, Email: "test@example.com", RoleID: roleID, Status: "active"}
+		mfa := &domain.UserMFA{UserID: userID, TOTPSecret: secret, IsEnabled: true}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(3)
+		mockMFARepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(mfa, nil)
+		mockMFARepo.EXPECT().UseBackupCode(gomock.Any(), userID, hashBackupCode("abcde12345")).Return(nil)
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "student"}, nil)
+		mockProfileRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(&domain.UserProfile{UserID: userID}, nil)
+		mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockLoginAttemptRepo.EXPECT().Clear(gomock.Any(), "email:test@example.com").Return(nil)
+		mockUserRepo.EXPECT().UpdateLastLogin(gomock.Any(), userID).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(3) // Backup code used, MFA verified, login success
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := service.VerifyMFA(context.Background(), mfaToken, "ABCDE-12345", "127.0.0.1", "Go-Test")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+	})
+
+	t.Run("Too Many Wrong Codes", func(t *testing.T) {
+		userID := uuid.New()
+		mfaToken, _ := jwtManager.GenerateMFAToken(userID)
+		challengeKey := mfaChallengeAttemptKey(mfaToken)
+
+		user := &domain.User{UserID: userID, Email: "test@example.com", Status: "active"}
+		mfa := &domain.UserMFA{UserID: userID, TOTPSecret: secret, IsEnabled: true}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(3)
+		mockMFARepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(mfa, nil)
+		mockMFARepo.EXPECT().UseBackupCode(gomock.Any(), userID, gomock.Any()).Return(domain.ErrInvalidMFACode)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), challengeKey, utils.MFATokenDuration).Return(int64(maxMFAChallengeAttempts), nil)
+		mockLoginAttemptRepo.EXPECT().Lock(gomock.Any(), challengeKey, utils.MFATokenDuration).Return(nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), "email:test@example.com", testLockoutPolicy.AttemptWindow).Return(int64(1), nil)
+		mockLoginAttemptRepo.EXPECT().RecordFailure(gomock.Any(), "ip:127.0.0.1", testLockoutPolicy.AttemptWindow).Return(int64(1), nil)
+
+		result, err := service.VerifyMFA(context.Background(), mfaToken, "000000", "127.0.0.1", "Go-Test")
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		assert.Nil(t, result)
+
+		// The challenge is rejected from now on, even with the right code
+		code, _ := utils.GenerateTOTPCode(secret, time.Now())
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), "email:test@example.com").Return(time.Duration(0), nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), "ip:127.0.0.1").Return(time.Duration(0), nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), challengeKey).Return(utils.MFATokenDuration, nil)
+
+		result, err = service.VerifyMFA(context.Background(), mfaToken, code, "127.0.0.1", "Go-Test")
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Login Locked", func(t *testing.T) {
+		userID := uuid.New()
+		mfaToken, _ := jwtManager.GenerateMFAToken(userID)
+		code, _ := utils.GenerateTOTPCode(secret, time.Now())
+
+		user := &domain.User{UserID: userID, Email: "test@example.com", Status: "active"}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), "email:test@example.com").Return(10*time.Minute, nil)
+		mockLoginAttemptRepo.EXPECT().LockTTL(gomock.Any(), "ip:127.0.0.1").Return(time.Duration(0), nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := service.VerifyMFA(context.Background(), mfaToken, code, "127.0.0.1", "Go-Test")
+
+		var throttled *domain.LoginThrottledError
+		assert.ErrorAs(t, err, &throttled)
+		assert.ErrorIs(t, err, domain.ErrAccountLocked)
+		assert.Equal(t, 10*time.Minute, throttled.RetryAfter)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		result, err := service.VerifyMFA(context.Background(), "not-a-token", "123456", "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		assert.Nil(t, result)
+	})
+}
+
+func TestAuthService_ResetMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockPermissionRepo := mocks.NewMockPermissionRepository(ctrl)
+	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+
+	service := NewAuthService(
+		mockUserRepo,
+		nil,
+		nil,
+		mockPermissionRepo,
+		nil,
+		nil,
+		mockActivityRepo,
+		mockMFARepo,
+		nil,
+		newTestJWTManager(t),
+		nil,
+		newTestTransactor(ctrl),
+		nil,
+		3600,
+		testLockoutPolicy,
+	)
+
+	adminRoleID := uuid.New()
+	facultyRoleID := uuid.New()
+	adminPermissions := []*domain.Permission{
+		{PermissionName: "users:update"},
+		{PermissionName: "users:mfa_reset"},
+		{PermissionName: "roles:manage"},
+	}
+	facultyPermissions := []*domain.Permission{
+		{PermissionName: "users:update"},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		admin := &domain.User{UserID: uuid.New(), RoleID: adminRoleID}
+		user := &domain.User{UserID: uuid.New(), RoleID: facultyRoleID}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), admin.UserID).Return(admin, nil)
+		mockPermissionRepo.EXPECT().GetByRoleID(gomock.Any(), adminRoleID).Return(adminPermissions, nil)
+		mockPermissionRepo.EXPECT().GetByRoleID(gomock.Any(), facultyRoleID).Return(facultyPermissions, nil)
+		mockMFARepo.EXPECT().Delete(gomock.Any(), user.UserID).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *domain.UserActivityLog) error {
+			assert.Equal(t, "mfa_reset", log.Action)
+			return nil
+		})
+
+		err := service.ResetMFA(context.Background(), user.UserID, admin.UserID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Same Role", func(t *testing.T) {
+		admin := &domain.User{UserID: uuid.New(), RoleID: adminRoleID}
+		user := &domain.User{UserID: uuid.New(), RoleID: adminRoleID}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), admin.UserID).Return(admin, nil)
+		mockMFARepo.EXPECT().Delete(gomock.Any(), user.UserID).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := service.ResetMFA(context.Background(), user.UserID, admin.UserID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("User Role Outranks Actor", func(t *testing.T) {
+		faculty := &domain.User{UserID: uuid.New(), RoleID: facultyRoleID}
+		user := &domain.User{UserID: uuid.New(), RoleID: adminRoleID}
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), faculty.UserID).Return(faculty, nil)
+		mockPermissionRepo.EXPECT().GetByRoleID(gomock.Any(), facultyRoleID).Return(facultyPermissions, nil)
+		mockPermissionRepo.EXPECT().GetByRoleID(gomock.Any(), adminRoleID).Return(adminPermissions, nil)
+
+		err := service.ResetMFA(context.Background(), user.UserID, faculty.UserID)
+		assert.ErrorIs(t, err, domain.ErrRoleOutranksActor)
+	})
+}
+
+func TestAuthService_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
+	jwtManager := newTestJWTManager(t)
+
+	service := NewAuthService(
+		mockUserRepo,
+		nil,
+		mockRoleRepo,
+		nil,
+		mockSessionRepo,
+		nil,
+		mockActivityRepo,
+		nil,
+		nil,
+		jwtManager,
+		mockTokenRevoker,
+		newTestTransactor(ctrl),
+		mockProducer,
+		3600,
+		testLockoutPolicy,
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		userID := uuid.New()
+		roleID := uuid.New()
+		familyID := uuid.New()
+		refreshToken := "valid_refresh"
+
+		session := &domain.ActiveSession{
+			SessionID:        uuid.New(),
+			UserID:           userID,
+			FamilyID:         familyID,
+			RefreshTokenHash: hashRefreshToken(refreshToken),
+		}
+
+		mockSessionRepo.EXPECT().GetByRefreshTokenHash(gomock.Any(), hashRefreshToken(refreshToken)).Return(session, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(&domain.User{UserID: userID, RoleID: roleID, Status: "active"}, nil)
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "student"}, nil)
+		mockSessionRepo.EXPECT().MarkRotated(gomock.Any(), session.SessionID).DoAndReturn(func(ctx context.Context, sessionID uuid.UUID) error {
+			assert.True(t, inTransaction(ctx), "session rotated outside the transaction")
+			return nil
+		})
+
+		var created *domain.ActiveSession
+		mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, newSession *domain.ActiveSession) error {
+			assert.True(t, inTransaction(ctx), "session created outside the transaction")
+			created = newSession
+			return nil
+		})
+
+		accessToken, newRefreshToken, err := service.RefreshToken(context.Background(), refreshToken, "127.0.0.1", "Go-Test")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEqual(t, refreshToken, newRefreshToken)
+		assert.Equal(t, familyID, created.FamilyID)
+		assert.Equal(t, hashRefreshToken(newRefreshToken), created.RefreshTokenHash)
+	})
+
+	t.Run("Reused Token Revokes Family", func(t *testing.T) {
+		userID := uuid.New()
+		familyID := uuid.New()
+		rotatedAt := time.Now().Add(-time.Minute)
+		refreshToken := "rotated_refresh"
+
+		session := &domain.ActiveSession{
+			SessionID: uuid.New(),
+			UserID:    userID,
+			FamilyID:  familyID,
+			RotatedAt: &rotatedAt,
+		}
+
+		mockSessionRepo.EXPECT().GetByRefreshTokenHash(gomock.Any(), hashRefreshToken(refreshToken)).Return(session, nil)
+		mockSessionRepo.EXPECT().DeleteByFamilyID(gomock.Any(), familyID).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *domain.UserActivityLog) error {
+			assert.Equal(t, "refresh_token_reused", log.Action)
+			assert.Equal(t, familyID, *log.ResourceID)
+			assert.Equal(t, "10.0.0.9", *log.IPAddress)
+			return nil
+		})
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(&domain.User{UserID: userID, Email: "test@example.com"}, nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "auth.events", userID.String(), gomock.Any()).DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+			authEvent := event.(*models.AuthEvent)
+			assert.Equal(t, models.EventRefreshTokenReused, authEvent.EventType)
+			assert.Equal(t, familyID, authEvent.Metadata["family_id"])
+			return nil
+		})
+		mockTokenRevoker.EXPECT().RevokeUserTokens(gomock.Any(), userID).Return(nil)
+
+		_, _, err := service.RefreshToken(context.Background(), refreshToken, "10.0.0.9", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+	})
+
+	t.Run("Concurrent Rotation", func(t *testing.T) {
+		userID := uuid.New()
+		roleID := uuid.New()
+		familyID := uuid.New()
+		refreshToken := "raced_refresh"
+
+		session := &domain.ActiveSession{
+			SessionID: uuid.New(),
+			UserID:    userID,
+			FamilyID:  familyID,
+		}
+		user := &domain.User{UserID: userID, RoleID: roleID, Status: "active"}
+
+		mockSessionRepo.EXPECT().GetByRefreshTokenHash(gomock.Any(), hashRefreshToken(refreshToken)).Return(session, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil).Times(2)
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "student"}, nil)
+		mockSessionRepo.EXPECT().MarkRotated(gomock.Any(), session.SessionID).Return(domain.ErrSessionNotFound)
+		mockSessionRepo.EXPECT().DeleteByFamilyID(gomock.Any(), familyID).Return(nil)
+		mockActivityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "auth.events", userID.String(), gomock.Any()).Return(nil)
+		mockTokenRevoker.EXPECT().RevokeUserTokens(gomock.Any(), userID).Return(nil)
+
+		_, _, err := service.RefreshToken(context.Background(), refreshToken, "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+	})
+
+	t.Run("Create Fails", func(t *testing.T) {
+		userID := uuid.New()
+		roleID := uuid.New()
+		refreshToken := "failing_refresh"
+		createErr := errors.New("connection reset")
+
+		session := &domain.ActiveSession{
+			SessionID: uuid.New(),
+			UserID:    userID,
+			FamilyID:  uuid.New(),
+		}
+
+		mockSessionRepo.EXPECT().GetByRefreshTokenHash(gomock.Any(), hashRefreshToken(refreshToken)).Return(session, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(&domain.User{UserID: userID, RoleID: roleID, Status: "active"}, nil)
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "student"}, nil)
+		mockSessionRepo.EXPECT().MarkRotated(gomock.Any(), session.SessionID).Return(nil)
+		mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(createErr)
+
+		// The error reaches the transactor, which rolls the rotation back
+		_, _, err := service.RefreshToken(context.Background(), refreshToken, "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, createErr)
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		mockSessionRepo.EXPECT().GetByRefreshTokenHash(gomock.Any(), hashRefreshToken("unknown")).Return(nil, domain.ErrSessionNotFound)
+
+		_, _, err := service.RefreshToken(context.Background(), "unknown", "127.0.0.1", "Go-Test")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+)
+
+type keyRotationService struct {
+	keyRepo          domain.SigningKeyRepository
+	keyRing          *utils.KeyRing
+	algorithm        string
+	rotationInterval time.Duration
+	activationDelay  time.Duration
+	retention        time.Duration
+}
+
+// NewKeyRotationService creates a new key rotation service. A new key is published
+// activationDelay before it starts signing, so every instance and JWKS consumer
+// knows it in time. A replaced key stays valid for verification for retention,
+// which must cover the longest lived token it could have signed.
+func NewKeyRotationService(
+	keyRepo domain.SigningKeyRepository,
+	keyRing *utils.KeyRing,
+	algorithm string,
+	rotationInterval time.Duration,
+	activationDelay time.Duration,
+	retention time.Duration,
+) domain.KeyRotationService {
+	return &keyRotationService{
+		keyRepo:          keyRepo,
+		keyRing:          keyRing,
+		algorithm:        algorithm,
+		rotationInterval: rotationInterval,
+		activationDelay:  activationDelay,
+		retention:        retention,
+	}
+}
+
+func (s *keyRotationService) Sync(ctx context.Context) error {
+	keys, err := s.keyRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if s.rotationDue(keys, now) {
+		activatesAt := now.Add(s.activationDelay)
+		if len(keys) == 0 {
+			// Nothing can sign tokens yet, so the first key is used right away
+			activatesAt = now
+		}
+
+		key, err := s.generateKey(activatesAt)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.keyRepo.Rotate(ctx, key, now.Add(-s.rotationInterval)); err != nil {
+			return err
+		}
+
+		// Reload, another instance may have rotated first
+		if keys, err = s.keyRepo.List(ctx); err != nil {
+			return err
+		}
+	}
+
+	keys = s.pruneKeys(ctx, keys, now)
+
+	signingKeys := make([]*utils.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		privateKey, err := utils.ParsePrivateKeyPEM(key.PrivateKeyPEM)
+		if err != nil {
+			return err
+		}
+
+		signingKeys = append(signingKeys, &utils.SigningKey{
+			KeyID:       key.KeyID,
+			Algorithm:   key.Algorithm,
+			PrivateKey:  privateKey,
+			ActivatesAt: key.ActivatesAt,
+		})
+	}
+
+	s.keyRing.SetKeys(signingKeys)
+
+	return nil
+}
+
+// rotationDue reports whether the newest key has been signing for a full interval
+func (s *keyRotationService) rotationDue(keys []*domain.SigningKey, now time.Time) bool {
+	if len(keys) == 0 {
+		return true
+	}
+
+	newest := keys[len(keys)-1]
+	return !newest.ActivatesAt.After(now.Add(-s.rotationInterval))
+}
+
+// pruneKeys deletes keys whose successor has been signing for longer than the
+// retention period, since every token they signed has expired by then
+func (s *keyRotationService) pruneKeys(ctx context.Context, keys []*domain.SigningKey, now time.Time) []*domain.SigningKey {
+	kept := make([]*domain.SigningKey, 0, len(keys))
+
+	for i, key := range keys {
+		if i+1 < len(keys) && keys[i+1].ActivatesAt.Add(s.retention).Before(now) {
+			if err := s.keyRepo.Delete(ctx, key.KeyID); err == nil {
+				continue
+			}
+		}
+		kept = append(kept, key)
+	}
+
+	return kept
+}
+
+func (s *keyRotationService) generateKey(activatesAt time.Time) (*domain.SigningKey, error) {
+	key, err := utils.GenerateSigningKey(s.algorithm, activatesAt)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeyPEM, err := utils.MarshalPrivateKeyPEM(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SigningKey{
+		KeyID:         key.KeyID,
+		Algorithm:     key.Algorithm,
+		PrivateKeyPEM: privateKeyPEM,
+		ActivatesAt:   key.ActivatesAt,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+)
+
+func newTestSigningKey(t *testing.T, activatesAt time.Time) *domain.SigningKey {
+	key, err := utils.GenerateSigningKey(utils.AlgorithmEdDSA, activatesAt)
+	assert.NoError(t, err)
+
+	privateKeyPEM, err := utils.MarshalPrivateKeyPEM(key.PrivateKey)
+	assert.NoError(t, err)
+
+	return &domain.SigningKey{
+		KeyID:         key.KeyID,
+		Algorithm:     key.Algorithm,
+		PrivateKeyPEM: privateKeyPEM,
+		ActivatesAt:   activatesAt,
+	}
+}
+
+func TestKeyRotationService_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeyRepo := mocks.NewMockSigningKeyRepository(ctrl)
+
+	const (
+		interval  = 30 * 24 * time.Hour
+		delay     = time.Hour
+		retention = 7 * 24 * time.Hour
+	)
+
+	t.Run("First Key Activates Immediately", func(t *testing.T) {
+		keyRing := utils.NewKeyRing()
+		svc := NewKeyRotationService(mockKeyRepo, keyRing, utils.AlgorithmEdDSA, interval, delay, retention)
+
+		var stored *domain.SigningKey
+		mockKeyRepo.EXPECT().List(gomock.Any()).Return(nil, nil)
+		mockKeyRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key *domain.SigningKey, rotateBefore time.Time) (bool, error) {
+				stored = key
+				return true, nil
+			})
+		mockKeyRepo.EXPECT().List(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]*domain.SigningKey, error) {
+			return []*domain.SigningKey{stored}, nil
+		})
+
+		err := svc.Sync(context.Background())
+
+		assert.NoError(t, err)
+		signingKey, err := keyRing.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, stored.KeyID, signingKey.KeyID)
+	})
+
+	t.Run("Current Key Not Due", func(t *testing.T) {
+		keyRing := utils.NewKeyRing()
+		svc := NewKeyRotationService(mockKeyRepo, keyRing, utils.AlgorithmEdDSA, interval, delay, retention)
+
+		current := newTestSigningKey(t, time.Now().Add(-24*time.Hour))
+		mockKeyRepo.EXPECT().List(gomock.Any()).Return([]*domain.SigningKey{current}, nil)
+
+		err := svc.Sync(context.Background())
+
+		assert.NoError(t, err)
+		signingKey, err := keyRing.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, current.KeyID, signingKey.KeyID)
+	})
+
+	t.Run("Rotated Key Is Published Before It Signs", func(t *testing.T) {
+		keyRing := utils.NewKeyRing()
+		svc := NewKeyRotationService(mockKeyRepo, keyRing, utils.AlgorithmEdDSA, interval, delay, retention)
+
+		current := newTestSigningKey(t, time.Now().Add(-interval-time.Minute))
+		var stored *domain.SigningKey
+
+		mockKeyRepo.EXPECT().List(gomock.Any()).Return([]*domain.SigningKey{current}, nil)
+		mockKeyRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key *domain.SigningKey, rotateBefore time.Time) (bool, error) {
+				stored = key
+				return true, nil
+			})
+		mockKeyRepo.EXPECT().List(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]*domain.SigningKey, error) {
+			return []*domain.SigningKey{current, stored}, nil
+		})
+
+		err := svc.Sync(context.Background())
+
+		assert.NoError(t, err)
+		assert.True(t, stored.ActivatesAt.After(time.Now()))
+
+		// The old key keeps signing until the new one activates
+		signingKey, err := keyRing.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, current.KeyID, signingKey.KeyID)
+		assert.Len(t, keyRing.JWKS().Keys, 2)
+	})
+
+	t.Run("Expired Key Is Pruned", func(t *testing.T) {
+		keyRing := utils.NewKeyRing()
+		svc := NewKeyRotationService(mockKeyRepo, keyRing, utils.AlgorithmEdDSA, interval, delay, retention)
+
+		old := newTestSigningKey(t, time.Now().Add(-interval-retention-48*time.Hour))
+		current := newTestSigningKey(t, time.Now().Add(-retention-24*time.Hour))
+
+		mockKeyRepo.EXPECT().List(gomock.Any()).Return([]*domain.SigningKey{old, current}, nil)
+		mockKeyRepo.EXPECT().Delete(gomock.Any(), old.KeyID).Return(nil)
+
+		err := svc.Sync(context.Background())
+
+		assert.NoError(t, err)
+		_, err = keyRing.VerificationKey(old.KeyID)
+		assert.Equal(t, utils.ErrUnknownKeyID, err)
+		_, err = keyRing.VerificationKey(current.KeyID)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/google/uuid"
+)
+
+// maxBackoffShift bounds the exponent so large limits cannot overflow the delay
+const maxBackoffShift = 30
+
+func (s *authService) UnlockAccount(ctx context.Context, userID, actorID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.loginAttemptRepo.Clear(ctx, emailAttemptKey(user.Email)); err != nil {
+		return err
+	}
+
+	s.logLockoutEvent(ctx, userID, "account_unlocked", "", "", map[string]interface{}{
+		"unlocked_by": actorID,
+	})
+
+	return nil
+}
+
+func emailAttemptKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipAttemptKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+// checkLoginLock returns how long the email or IP address has to wait before the next attempt
+func (s *authService) checkLoginLock(ctx context.Context, email, ipAddress string) time.Duration {
+	var retryAfter time.Duration
+
+	for _, key := range []string{emailAttemptKey(email), ipAttemptKey(ipAddress)} {
+		ttl, err := s.loginAttemptRepo.LockTTL(ctx, key)
+		if err != nil {
+			// If Redis fails, allow the attempt rather than locking everyone out
+			continue
+		}
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+
+	return retryAfter
+}
+
+// recordLoginFailure counts a failed attempt against the email and the IP address and
+// returns the error the caller should see, carrying a retry delay once backoff kicks in
+func (s *authService) recordLoginFailure(ctx context.Context, user *domain.User, email, ipAddress, userAgent string) error {
+	var retryAfter time.Duration
+	locked := false
+
+	limits := []struct {
+		scope string
+		key   string
+		limit int
+	}{
+		{"email", emailAttemptKey(email), s.lockout.MaxFailedAttempts},
+		{"ip", ipAttemptKey(ipAddress), s.lockout.MaxFailedIPAttempts},
+	}
+
+	for _, l := range limits {
+		count, err := s.loginAttemptRepo.RecordFailure(ctx, l.key, s.lockout.AttemptWindow)
+		if err != nil {
+			continue
+		}
+
+		delay, lockout := s.failureDelay(count, l.limit)
+		if delay == 0 {
+			continue
+		}
+
+		if err := s.loginAttemptRepo.Lock(ctx, l.key, delay); err != nil {
+			continue
+		}
+
+		if lockout {
+			locked = true
+			s.onLoginLocked(ctx, user, email, ipAddress, userAgent, l.scope, count)
+		}
+		if delay > retryAfter {
+			retryAfter = delay
+		}
+	}
+
+	if locked {
+		return &domain.LoginThrottledError{Err: domain.ErrAccountLocked, RetryAfter: retryAfter}
+	}
+	if retryAfter > 0 {
+		return &domain.LoginThrottledError{Err: domain.ErrInvalidCredentials, RetryAfter: retryAfter}
+	}
+
+	return domain.ErrInvalidCredentials
+}
+
+// failureDelay returns how long to block after the count-th failure against limit, and
+// whether that block is a full lockout. The first half of the limit is free, after that
+// every failure doubles the delay.
+func (s *authService) failureDelay(count int64, limit int) (time.Duration, bool) {
+	if limit <= 0 {
+		return 0, false
+	}
+
+	if count >= int64(limit) {
+		return s.lockout.LockoutDuration, true
+	}
+
+	free := int64(limit / 2)
+	if count <= free {
+		return 0, false
+	}
+
+	delay := s.lockout.BackoffBase << min(count-free-1, maxBackoffShift)
+	if delay > s.lockout.LockoutDuration {
+		delay = s.lockout.LockoutDuration
+	}
+
+	return delay, false
+}
+
+// onLoginLocked announces a lockout so other services can alert on credential stuffing,
+// and asks admins to review (and if needed unlock) accounts that were locked
+func (s *authService) onLoginLocked(ctx context.Context, user *domain.User, email, ipAddress, userAgent, scope string, failedAttempts int64) {
+	lockedUntil := time.Now().Add(s.lockout.LockoutDuration)
+
+	userID := uuid.Nil
+	if user != nil {
+		userID = user.UserID
+	}
+
+	event := models.NewAuthEvent(models.EventLoginLocked, userID, email, ipAddress, userAgent, false)
+	event.ErrorReason = domain.ErrAccountLocked.Error()
+	event.Metadata = map[string]interface{}{
+		"scope":           scope,
+		"failed_attempts": failedAttempts,
+		"locked_until":    lockedUntil,
+	}
+
+	key := email
+	if scope == "ip" {
+		key = ipAddress
+	}
+	s.producer.PublishEvent(ctx, "auth.events", key, event)
+
+	// Only existing accounts can be unlocked by an admin
+	if scope != "email" || user == nil {
+		return
+	}
+
+	s.logLockoutEvent(ctx, user.UserID, "account_locked", ipAddress, userAgent, map[string]interface{}{
+		"failed_attempts": failedAttempts,
+		"locked_until":    lockedUntil,
+	})
+
+	notification := models.NewNotificationCommand(
+		"user-service",
+		"security",
+		"Account locked",
+		fmt.Sprintf("%s was locked after %d failed login attempts. It unlocks automatically at %s or can be unlocked by an admin.",
+			user.Email, failedAttempts, lockedUntil.Format(time.RFC3339)),
+	)
+	notification.RecipientRoles = []string{"admin"}
+	notification.Priority = "high"
+	notification.TemplateID = "account-locked-admin"
+	notification.TemplateData = map[string]interface{}{
+		"user_id":         user.UserID,
+		"email":           user.Email,
+		"ip_address":      ipAddress,
+		"failed_attempts": failedAttempts,
+		"locked_until":    lockedUntil,
+	}
+	notification.ActionURL = fmt.Sprintf("/admin/users/%s/unlock", user.UserID)
+
+	s.producer.PublishEvent(ctx, "notification.commands", user.UserID.String(), notification)
+}
+
+// Helper to log lockout events
+func (s *authService) logLockoutEvent(ctx context.Context, userID uuid.UUID, action, ipAddress, userAgent string, details map[string]interface{}) {
+	resourceType := "user"
+	log := &domain.UserActivityLog{
+		LogID:        uuid.New(),
+		UserID:       userID,
+		Action:       action,
+		ResourceType: &resourceType,
+		ResourceID:   &userID,
+	}
+
+	if ipAddress != "" {
+		log.IPAddress = &ipAddress
+	}
+	if userAgent != "" {
+		log.UserAgent = &userAgent
+	}
+	if details != nil {
+		detailsJSON, _ := json.Marshal(details)
+		detailsStr := string(detailsJSON)
+		log.Details = &detailsStr
+	}
+
+	s.activityLogRepo.Create(ctx, log)
+}
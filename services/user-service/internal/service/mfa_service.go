@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	mfaIssuer       = "NimbusU"
+	backupCodeCount = 10
+
+	// maxMFAChallengeAttempts is how many wrong codes a login MFA challenge
+	// takes before it is rejected and the login has to start over
+	maxMFAChallengeAttempts = 5
+)
+
+func (s *authService) VerifyMFA(ctx context.Context, mfaToken, code, ipAddress, userAgent string) (*domain.LoginResult, error) {
+	userID, err := s.jwtManager.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != "active" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	// Refuse codes while the email or IP address is backing off or locked, and
+	// challenges that already took too many wrong codes
+	if retryAfter := s.checkLoginLock(ctx, user.Email, ipAddress); retryAfter > 0 {
+		s.logMFAEvent(ctx, userID, "mfa_verification_locked", ipAddress, userAgent, nil)
+		return nil, &domain.LoginThrottledError{Err: domain.ErrAccountLocked, RetryAfter: retryAfter}
+	}
+	if ttl, err := s.loginAttemptRepo.LockTTL(ctx, mfaChallengeAttemptKey(mfaToken)); err == nil && ttl > 0 {
+		return nil, domain.ErrInvalidToken
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var backupCodes []string
+	if mfa.IsEnabled {
+		if err := s.verifySecondFactor(ctx, mfa, code, ipAddress, userAgent); err != nil {
+			return nil, s.recordMFAFailure(ctx, user, mfaToken, ipAddress, userAgent, err)
+		}
+	} else {
+		// Completing an enrollment enforced by the user's role
+		if err := s.verifyTOTP(ctx, mfa, code); err != nil {
+			return nil, s.recordMFAFailure(ctx, user, mfaToken, ipAddress, userAgent, err)
+		}
+		if backupCodes, err = s.completeMFAEnrollment(ctx, userID, ipAddress, userAgent); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logMFAEvent(ctx, userID, "mfa_verified", ipAddress, userAgent, nil)
+
+	role, err := s.roleRepo.GetByID(ctx, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.issueSession(ctx, user, role, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	result.BackupCodes = backupCodes
+
+	return result, nil
+}
+
+func (s *authService) EnrollMFA(ctx context.Context, userID uuid.UUID) (secret, provisioningURI string, err error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil && err != domain.ErrMFANotEnabled {
+		return "", "", err
+	}
+	if mfa != nil && mfa.IsEnabled {
+		return "", "", domain.ErrMFAAlreadyEnabled
+	}
+
+	return s.startMFAEnrollment(ctx, user, "", "")
+}
+
+func (s *authService) EnableMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.IsEnabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	// The first code proves the authenticator app holds the secret
+	if err := s.verifyTOTP(ctx, mfa, code); err != nil {
+		s.logMFAEvent(ctx, userID, "mfa_verification_failed", "", "", nil)
+		return nil, err
+	}
+
+	return s.completeMFAEnrollment(ctx, userID, "", "")
+}
+
+func (s *authService) DisableMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, user.RoleID)
+	if err != nil {
+		return err
+	}
+	if role.MFARequired {
+		return domain.ErrMFARequiredByRole
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !mfa.IsEnabled {
+		return domain.ErrMFANotEnabled
+	}
+
+	if err := s.verifySecondFactor(ctx, mfa, code, "", ""); err != nil {
+		s.logMFAEvent(ctx, userID, "mfa_verification_failed", "", "", nil)
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logMFAEvent(ctx, userID, "mfa_disabled", "", "", nil)
+
+	return nil
+}
+
+func (s *authService) RegenerateBackupCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.IsEnabled {
+		return nil, domain.ErrMFANotEnabled
+	}
+
+	if err := s.verifyTOTP(ctx, mfa, code); err != nil {
+		s.logMFAEvent(ctx, userID, "mfa_verification_failed", "", "", nil)
+		return nil, err
+	}
+
+	backupCodes, err := s.replaceBackupCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logMFAEvent(ctx, userID, "mfa_backup_codes_regenerated", "", "", nil)
+
+	return backupCodes, nil
+}
+
+// ResetMFA removes the second factor of a user. Admins cannot reset the MFA of
+// users whose role holds permissions their own role lacks.
+func (s *authService) ResetMFA(ctx context.Context, userID, actorID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err == domain.ErrUserNotFound {
+		return domain.ErrUnauthorized
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.checkOutranks(ctx, actor.RoleID, user.RoleID); err != nil {
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logMFAEvent(ctx, userID, "mfa_reset", "", "", map[string]interface{}{
+		"reset_by": actorID,
+	})
+
+	return nil
+}
+
+// checkOutranks checks that the actor's role holds every permission of the
+// user's role
+func (s *authService) checkOutranks(ctx context.Context, actorRoleID, userRoleID uuid.UUID) error {
+	if actorRoleID == userRoleID {
+		return nil
+	}
+
+	actorPermissions, err := s.permissionRepo.GetByRoleID(ctx, actorRoleID)
+	if err != nil {
+		return err
+	}
+	held := make(map[string]bool, len(actorPermissions))
+	for _, permission := range actorPermissions {
+		held[permission.PermissionName] = true
+	}
+
+	userPermissions, err := s.permissionRepo.GetByRoleID(ctx, userRoleID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range userPermissions {
+		if !held[permission.PermissionName] {
+			return domain.ErrRoleOutranksActor
+		}
+	}
+	return nil
+}
+
+func mfaChallengeAttemptKey(mfaToken string) string {
+	hash := sha256.Sum256([]byte(mfaToken))
+	return "mfa_challenge:" + hex.EncodeToString(hash[:])
+}
+
+// recordMFAFailure counts a wrong code against the challenge and, like a wrong
+// password, against the email and the IP address of the login. It returns the
+// error the caller should see.
+func (s *authService) recordMFAFailure(ctx context.Context, user *domain.User, mfaToken, ipAddress, userAgent string, err error) error {
+	s.logMFAEvent(ctx, user.UserID, "mfa_verification_failed", ipAddress, userAgent, nil)
+	if err != domain.ErrInvalidMFACode {
+		return err
+	}
+
+	// The challenge stays rejected until it has expired anyway
+	key := mfaChallengeAttemptKey(mfaToken)
+	count, recordErr := s.loginAttemptRepo.RecordFailure(ctx, key, utils.MFATokenDuration)
+	if recordErr == nil && count >= maxMFAChallengeAttempts {
+		s.loginAttemptRepo.Lock(ctx, key, utils.MFATokenDuration)
+	}
+
+	var throttled *domain.LoginThrottledError
+	if !errors.As(s.recordLoginFailure(ctx, user, user.Email, ipAddress, userAgent), &throttled) {
+		return domain.ErrInvalidMFACode
+	}
+	if throttled.Err == domain.ErrAccountLocked {
+		return throttled
+	}
+	return &domain.LoginThrottledError{Err: domain.ErrInvalidMFACode, RetryAfter: throttled.RetryAfter}
+}
+
+// issueMFAChallenge returns the short-lived token that /auth/mfa/verify exchanges for a session
+func (s *authService) issueMFAChallenge(ctx context.Context, user *domain.User, ipAddress, userAgent string) (*domain.LoginResult, error) {
+	mfaToken, err := s.jwtManager.GenerateMFAToken(user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logMFAEvent(ctx, user.UserID, "mfa_challenge_issued", ipAddress, userAgent, nil)
+
+	return &domain.LoginResult{MFAToken: mfaToken}, nil
+}
+
+// startMFAEnrollment stores a new pending secret, replacing any earlier unconfirmed one
+func (s *authService) startMFAEnrollment(ctx context.Context, user *domain.User, ipAddress, userAgent string) (secret, provisioningURI string, err error) {
+	secret, err = utils.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	mfa := &domain.UserMFA{
+		UserID:     user.UserID,
+		TOTPSecret: secret,
+	}
+	if err := s.mfaRepo.Upsert(ctx, mfa); err != nil {
+		return "", "", err
+	}
+
+	s.logMFAEvent(ctx, user.UserID, "mfa_enrollment_started", ipAddress, userAgent, nil)
+
+	return secret, utils.TOTPProvisioningURI(secret, mfaIssuer, user.Email), nil
+}
+
+func (s *authService) completeMFAEnrollment(ctx context.Context, userID uuid.UUID, ipAddress, userAgent string) ([]string, error) {
+	if err := s.mfaRepo.Enable(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	backupCodes, err := s.replaceBackupCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logMFAEvent(ctx, userID, "mfa_enabled", ipAddress, userAgent, nil)
+
+	return backupCodes, nil
+}
+
+// verifyTOTP accepts a code from the authenticator app at most once
+func (s *authService) verifyTOTP(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	step, ok := utils.ValidateTOTPCode(mfa.TOTPSecret, code, time.Now())
+	if !ok || step <= mfa.LastUsedStep {
+		return domain.ErrInvalidMFACode
+	}
+
+	return s.mfaRepo.MarkStepUsed(ctx, mfa.UserID, step)
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused backup code
+func (s *authService) verifySecondFactor(ctx context.Context, mfa *domain.UserMFA, code, ipAddress, userAgent string) error {
+	if _, ok := utils.ValidateTOTPCode(mfa.TOTPSecret, code, time.Now()); ok {
+		return s.verifyTOTP(ctx, mfa, code)
+	}
+
+	if err := s.mfaRepo.UseBackupCode(ctx, mfa.UserID, hashBackupCode(code)); err != nil {
+		return domain.ErrInvalidMFACode
+	}
+
+	s.logMFAEvent(ctx, mfa.UserID, "mfa_backup_code_used", ipAddress, userAgent, nil)
+
+	return nil
+}
+
+func (s *authService) replaceBackupCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, backupCodeCount)
+	hashes := make([]string, backupCodeCount)
+
+	for i := range codes {
+		codeBytes := make([]byte, 5)
+		if _, err := rand.Read(codeBytes); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(codeBytes)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashBackupCode(code)
+	}
+
+	if err := s.mfaRepo.ReplaceBackupCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// hashBackupCode normalizes a backup code as typed by the user and hashes it
+func hashBackupCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// Helper to log MFA events
+func (s *authService) logMFAEvent(ctx context.Context, userID uuid.UUID, action, ipAddress, userAgent string, details map[string]interface{}) {
+	resourceType := "mfa"
+	log := &domain.UserActivityLog{
+		LogID:        uuid.New(),
+		UserID:       userID,
+		Action:       action,
+		ResourceType: &resourceType,
+	}
+
+	if ipAddress != "" {
+		log.IPAddress = &ipAddress
+	}
+	if userAgent != "" {
+		log.UserAgent = &userAgent
+	}
+	if details != nil {
+		detailsJSON, _ := json.Marshal(details)
+		detailsStr := string(detailsJSON)
+		log.Details = &detailsStr
+	}
+
+	s.activityLogRepo.Create(ctx, log)
+}
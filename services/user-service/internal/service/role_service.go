@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/google/uuid"
+)
+
+// builtinRoles are seeded by the migrations and referenced by name in the
+// route middleware, so they must never be renamed or removed.
+var builtinRoles = map[string]bool{
+	"admin":   true,
+	"faculty": true,
+	"student": true,
+	"staff":   true,
+}
+
+type roleService struct {
+	roleRepo           domain.RoleRepository
+	permissionRepo     domain.PermissionRepository
+	rolePermissionRepo domain.RolePermissionRepository
+	userRepo           domain.UserRepository
+	activityLog        domain.ActivityLogRepository
+	permissionCache    domain.PermissionCache
+	transactor         domain.Transactor
+	producer           domain.EventProducer
+}
+
+// NewRoleService creates a new role service
+func NewRoleService(
+	roleRepo domain.RoleRepository,
+	permissionRepo domain.PermissionRepository,
+	rolePermissionRepo domain.RolePermissionRepository,
+	userRepo domain.UserRepository,
+	activityLog domain.ActivityLogRepository,
+	permissionCache domain.PermissionCache,
+	transactor domain.Transactor,
+	producer domain.EventProducer,
+) domain.RoleService {
+	return &roleService{
+		roleRepo:           roleRepo,
+		permissionRepo:     permissionRepo,
+		rolePermissionRepo: rolePermissionRepo,
+		userRepo:           userRepo,
+		activityLog:        activityLog,
+		permissionCache:    permissionCache,
+		transactor:         transactor,
+		producer:           producer,
+	}
+}
+
+func (s *roleService) CreateRole(ctx context.Context, role *domain.Role) error {
+	existingRole, _ := s.roleRepo.GetByName(ctx, role.RoleName)
+	if existingRole != nil {
+		return domain.ErrRoleAlreadyExists
+	}
+
+	role.RoleID = uuid.New()
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.Create(ctx, role); err != nil {
+			return err
+		}
+
+		// Publish role created event
+		event := models.NewRoleEvent(models.EventRoleCreated, role.RoleID, role.RoleName)
+		return s.producer.PublishEvent(ctx, "user.events", role.RoleID.String(), event)
+	})
+}
+
+func (s *roleService) GetRole(ctx context.Context, roleID uuid.UUID) (*domain.Role, error) {
+	return s.roleRepo.GetByID(ctx, roleID)
+}
+
+func (s *roleService) GetRoleByName(ctx context.Context, roleName string) (*domain.Role, error) {
+	return s.roleRepo.GetByName(ctx, roleName)
+}
+
+func (s *roleService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	return s.roleRepo.List(ctx)
+}
+
+func (s *roleService) UpdateRole(ctx context.Context, roleID uuid.UUID, updates map[string]interface{}) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	// Apply updates
+	if roleName, ok := updates["role_name"].(string); ok && roleName != role.RoleName {
+		if builtinRoles[role.RoleName] {
+			return domain.ErrRoleProtected
+		}
+		existingRole, _ := s.roleRepo.GetByName(ctx, roleName)
+		if existingRole != nil {
+			return domain.ErrRoleAlreadyExists
+		}
+		role.RoleName = roleName
+	}
+	if description, ok := updates["description"].(string); ok {
+		role.Description = &description
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.Update(ctx, role); err != nil {
+			return err
+		}
+
+		// Publish role updated event
+		event := models.NewRoleEvent(models.EventRoleUpdated, role.RoleID, role.RoleName)
+		return s.producer.PublishEvent(ctx, "user.events", role.RoleID.String(), event)
+	})
+}
+
+func (s *roleService) DeleteRole(ctx context.Context, roleID uuid.UUID) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the role so no user is given it between the count and the delete
+		role, err := s.roleRepo.GetByIDForUpdate(ctx, roleID)
+		if err != nil {
+			return err
+		}
+
+		if builtinRoles[role.RoleName] {
+			return domain.ErrRoleProtected
+		}
+
+		// Refuse to orphan users that still hold this role
+		count, err := s.userRepo.CountByRole(ctx, roleID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrRoleHasUsers
+		}
+
+		if err := s.roleRepo.Delete(ctx, roleID); err != nil {
+			return err
+		}
+
+		// Publish role deleted event
+		event := models.NewRoleEvent(models.EventRoleDeleted, role.RoleID, role.RoleName)
+		return s.producer.PublishEvent(ctx, "user.events", role.RoleID.String(), event)
+	})
+	if err != nil {
+		return err
+	}
+	s.permissionCache.InvalidateRole(ctx, roleID)
+
+	return nil
+}
+
+func (s *roleService) SetMFARequired(ctx context.Context, roleID uuid.UUID, required bool, actorID uuid.UUID) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	role.MFARequired = required
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.Update(ctx, role); err != nil {
+			return err
+		}
+
+		// Publish role updated event
+		event := models.NewRoleEvent(models.EventRoleUpdated, role.RoleID, role.RoleName)
+		event.ActorID = actorID
+		return s.producer.PublishEvent(ctx, "user.events", role.RoleID.String(), event)
+	})
+	if err != nil {
+		return err
+	}
+
+	resourceType := "role"
+	detailsJSON, _ := json.Marshal(map[string]interface{}{
+		"role_name":    role.RoleName,
+		"mfa_required": required,
+	})
+	details := string(detailsJSON)
+
+	s.activityLog.Create(ctx, &domain.UserActivityLog{
+		LogID:        uuid.New(),
+		UserID:       actorID,
+		Action:       "mfa_enforcement_changed",
+		ResourceType: &resourceType,
+		ResourceID:   &role.RoleID,
+		Details:      &details,
+	})
+
+	return nil
+}
+
+func (s *roleService) CreatePermission(ctx context.Context, permission *domain.Permission) error {
+	existingPermission, _ := s.permissionRepo.GetByName(ctx, permission.PermissionName)
+	if existingPermission != nil {
+		return domain.ErrPermissionExists
+	}
+
+	permission.PermissionID = uuid.New()
+	return s.permissionRepo.Create(ctx, permission)
+}
+
+func (s *roleService) ListPermissions(ctx context.Context) ([]*domain.Permission, error) {
+	return s.permissionRepo.List(ctx)
+}
+
+func (s *roleService) AssignPermission(ctx context.Context, roleID, permissionID, actorID uuid.UUID) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	permission, err := s.permissionRepo.GetByID(ctx, permissionID)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.rolePermissionRepo.AssignPermission(ctx, roleID, permissionID); err != nil {
+			return err
+		}
+		return s.publishPermissionChange(ctx, models.EventPermissionGranted, role, permission, actorID)
+	})
+	if err != nil {
+		return err
+	}
+	s.permissionCache.InvalidateRole(ctx, roleID)
+
+	s.logPermissionChange(ctx, "permission_granted", role, permission, actorID)
+
+	return nil
+}
+
+func (s *roleService) RevokePermission(ctx context.Context, roleID, permissionID, actorID uuid.UUID) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	permission, err := s.permissionRepo.GetByID(ctx, permissionID)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.rolePermissionRepo.RevokePermission(ctx, roleID, permissionID); err != nil {
+			return err
+		}
+		return s.publishPermissionChange(ctx, models.EventPermissionRevoked, role, permission, actorID)
+	})
+	if err != nil {
+		return err
+	}
+	s.permissionCache.InvalidateRole(ctx, roleID)
+
+	s.logPermissionChange(ctx, "permission_revoked", role, permission, actorID)
+
+	return nil
+}
+
+func (s *roleService) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]*domain.Permission, error) {
+	if _, err := s.roleRepo.GetByID(ctx, roleID); err != nil {
+		return nil, err
+	}
+
+	return s.rolePermissionRepo.GetPermissionsByRole(ctx, roleID)
+}
+
+// logPermissionChange records a grant or revoke in the actor's activity log
+func (s *roleService) logPermissionChange(ctx context.Context, action string, role *domain.Role, permission *domain.Permission, actorID uuid.UUID) {
+	resourceType := "role"
+	detailsJSON, _ := json.Marshal(map[string]interface{}{
+		"role_name":       role.RoleName,
+		"permission_id":   permission.PermissionID,
+		"permission_name": permission.PermissionName,
+	})
+	details := string(detailsJSON)
+
+	s.activityLog.Create(ctx, &domain.UserActivityLog{
+		LogID:        uuid.New(),
+		UserID:       actorID,
+		Action:       action,
+		ResourceType: &resourceType,
+		ResourceID:   &role.RoleID,
+		Details:      &details,
+	})
+}
+
+// publishPermissionChange publishes the role event of a grant or revoke
+func (s *roleService) publishPermissionChange(ctx context.Context, eventType models.EventType, role *domain.Role, permission *domain.Permission, actorID uuid.UUID) error {
+	event := models.NewRoleEvent(eventType, role.RoleID, role.RoleName)
+	event.PermissionID = permission.PermissionID
+	event.PermissionName = permission.PermissionName
+	event.ActorID = actorID
+	return s.producer.PublishEvent(ctx, "user.events", role.RoleID.String(), event)
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/domain"
+	"github.com/SureshAmal/NimbusU-backend/services/user-service/internal/mocks"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+)
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockPermissionRepo := mocks.NewMockPermissionRepository(ctrl)
+	mockRolePermissionRepo := mocks.NewMockRolePermissionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockActivityLog := mocks.NewMockActivityLogRepository(ctrl)
+	mockPermissionCache := mocks.NewMockPermissionCache(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+
+	service := NewRoleService(mockRoleRepo, mockPermissionRepo, mockRolePermissionRepo, mockUserRepo, mockActivityLog, mockPermissionCache, newTestTransactor(ctrl), mockProducer)
+
+	t.Run("Success", func(t *testing.T) {
+		roleID := uuid.New()
+
+		mockRoleRepo.EXPECT().GetByIDForUpdate(gomock.Any(), roleID).DoAndReturn(func(ctx context.Context, roleID uuid.UUID) (*domain.Role, error) {
+			assert.True(t, inTransaction(ctx), "role locked outside the transaction")
+			return &domain.Role{RoleID: roleID, RoleName: "librarian"}, nil
+		})
+		mockUserRepo.EXPECT().CountByRole(gomock.Any(), roleID).DoAndReturn(func(ctx context.Context, roleID uuid.UUID) (int64, error) {
+			assert.True(t, inTransaction(ctx), "users counted outside the transaction")
+			return 0, nil
+		})
+		mockRoleRepo.EXPECT().Delete(gomock.Any(), roleID).Return(nil)
+		mockPermissionCache.EXPECT().InvalidateRole(gomock.Any(), roleID).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "user.events", roleID.String(), gomock.Any()).DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+			assert.Equal(t, models.EventRoleDeleted, event.(*models.RoleEvent).EventType)
+			return nil
+		})
+
+		err := service.DeleteRole(context.Background(), roleID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Role Has Users", func(t *testing.T) {
+		roleID := uuid.New()
+
+		mockRoleRepo.EXPECT().GetByIDForUpdate(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "librarian"}, nil)
+		mockUserRepo.EXPECT().CountByRole(gomock.Any(), roleID).Return(int64(3), nil)
+
+		err := service.DeleteRole(context.Background(), roleID)
+		assert.ErrorIs(t, err, domain.ErrRoleHasUsers)
+	})
+
+	t.Run("User Given The Role Before The Delete", func(t *testing.T) {
+		roleID := uuid.New()
+
+		// The users foreign key still refuses the delete
+		mockRoleRepo.EXPECT().GetByIDForUpdate(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "librarian"}, nil)
+		mockUserRepo.EXPECT().CountByRole(gomock.Any(), roleID).Return(int64(0), nil)
+		mockRoleRepo.EXPECT().Delete(gomock.Any(), roleID).Return(domain.ErrRoleHasUsers)
+
+		err := service.DeleteRole(context.Background(), roleID)
+		assert.ErrorIs(t, err, domain.ErrRoleHasUsers)
+	})
+
+	t.Run("Built-in Role", func(t *testing.T) {
+		roleID := uuid.New()
+
+		mockRoleRepo.EXPECT().GetByIDForUpdate(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "admin"}, nil)
+
+		err := service.DeleteRole(context.Background(), roleID)
+		assert.ErrorIs(t, err, domain.ErrRoleProtected)
+	})
+}
+
+func TestRoleService_AssignPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockPermissionRepo := mocks.NewMockPermissionRepository(ctrl)
+	mockRolePermissionRepo := mocks.NewMockRolePermissionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockActivityLog := mocks.NewMockActivityLogRepository(ctrl)
+	mockPermissionCache := mocks.NewMockPermissionCache(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+
+	service := NewRoleService(mockRoleRepo, mockPermissionRepo, mockRolePermissionRepo, mockUserRepo, mockActivityLog, mockPermissionCache, newTestTransactor(ctrl), mockProducer)
+
+	t.Run("Success", func(t *testing.T) {
+		roleID := uuid.New()
+		permissionID := uuid.New()
+		actorID := uuid.New()
+
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "faculty"}, nil)
+		mockPermissionRepo.EXPECT().GetByID(gomock.Any(), permissionID).Return(&domain.Permission{PermissionID: permissionID, PermissionName: "courses:write"}, nil)
+		mockRolePermissionRepo.EXPECT().AssignPermission(gomock.Any(), roleID, permissionID).Return(nil)
+		mockPermissionCache.EXPECT().InvalidateRole(gomock.Any(), roleID).Return(nil)
+		mockActivityLog.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *domain.UserActivityLog) error {
+			assert.Equal(t, actorID, log.UserID)
+			assert.Equal(t, "permission_granted", log.Action)
+			assert.Equal(t, roleID, *log.ResourceID)
+			assert.Contains(t, *log.Details, "courses:write")
+			return nil
+		})
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "user.events", roleID.String(), gomock.Any()).DoAndReturn(func(ctx context.Context, topic, key string, event interface{}) error {
+			roleEvent := event.(*models.RoleEvent)
+			assert.Equal(t, models.EventPermissionGranted, roleEvent.EventType)
+			assert.Equal(t, permissionID, roleEvent.PermissionID)
+			assert.Equal(t, actorID, roleEvent.ActorID)
+			return nil
+		})
+
+		err := service.AssignPermission(context.Background(), roleID, permissionID, actorID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Permission Not Found", func(t *testing.T) {
+		roleID := uuid.New()
+		permissionID := uuid.New()
+
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "faculty"}, nil)
+		mockPermissionRepo.EXPECT().GetByID(gomock.Any(), permissionID).Return(nil, domain.ErrPermissionNotFound)
+
+		err := service.AssignPermission(context.Background(), roleID, permissionID, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrPermissionNotFound)
+	})
+}
+
+func TestRoleService_RevokePermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockPermissionRepo := mocks.NewMockPermissionRepository(ctrl)
+	mockRolePermissionRepo := mocks.NewMockRolePermissionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockActivityLog := mocks.NewMockActivityLogRepository(ctrl)
+	mockPermissionCache := mocks.NewMockPermissionCache(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+
+	service := NewRoleService(mockRoleRepo, mockPermissionRepo, mockRolePermissionRepo, mockUserRepo, mockActivityLog, mockPermissionCache, newTestTransactor(ctrl), mockProducer)
+
+	roleID := uuid.New()
+	permissionID := uuid.New()
+	actorID := uuid.New()
+
+	mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "faculty"}, nil)
+	mockPermissionRepo.EXPECT().GetByID(gomock.Any(), permissionID).Return(&domain.Permission{PermissionID: permissionID, PermissionName: "courses:write"}, nil)
+	mockRolePermissionRepo.EXPECT().RevokePermission(gomock.Any(), roleID, permissionID).Return(nil)
+	mockPermissionCache.EXPECT().InvalidateRole(gomock.Any(), roleID).Return(nil)
+	mockActivityLog.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *domain.UserActivityLog) error {
+		assert.Equal(t, "permission_revoked", log.Action)
+		return nil
+	})
+	mockProducer.EXPECT().PublishEvent(gomock.Any(), "user.events", roleID.String(), gomock.Any()).Return(nil)
+
+	err := service.RevokePermission(context.Background(), roleID, permissionID, actorID)
+	assert.NoError(t, err)
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+
+	service := NewRoleService(mockRoleRepo, nil, nil, nil, nil, nil, newTestTransactor(ctrl), mockProducer)
+
+	t.Run("Rename Built-in Role", func(t *testing.T) {
+		roleID := uuid.New()
+
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "student"}, nil)
+
+		err := service.UpdateRole(context.Background(), roleID, map[string]interface{}{"role_name": "learner"})
+		assert.ErrorIs(t, err, domain.ErrRoleProtected)
+	})
+
+	t.Run("Name Taken", func(t *testing.T) {
+		roleID := uuid.New()
+
+		mockRoleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(&domain.Role{RoleID: roleID, RoleName: "librarian"}, nil)
+		mockRoleRepo.EXPECT().GetByName(gomock.Any(), "registrar").Return(&domain.Role{RoleName: "registrar"}, nil)
+
+		err := service.UpdateRole(context.Background(), roleID, map[string]interface{}{"role_name": "registrar"})
+		assert.ErrorIs(t, err, domain.ErrRoleAlreadyExists)
+	})
+}
@@ -11,11 +11,13 @@ import (
 )
 
 type userService struct {
-	userRepo    domain.UserRepository
-	profileRepo domain.UserProfileRepository
-	roleRepo    domain.RoleRepository
-	activityLog domain.ActivityLogRepository
-	producer    domain.EventProducer
+	userRepo     domain.UserRepository
+	profileRepo  domain.UserProfileRepository
+	roleRepo     domain.RoleRepository
+	activityLog  domain.ActivityLogRepository
+	tokenRevoker domain.TokenRevoker
+	transactor   domain.Transactor
+	producer     domain.EventProducer
 }
 
 // NewUserService creates a new user service
@@ -24,14 +26,18 @@ func NewUserService(
 	profileRepo domain.UserProfileRepository,
 	roleRepo domain.RoleRepository,
 	activityLog domain.ActivityLogRepository,
+	tokenRevoker domain.TokenRevoker,
+	transactor domain.Transactor,
 	producer domain.EventProducer,
 ) domain.UserService {
 	return &userService{
-		userRepo:    userRepo,
-		profileRepo: profileRepo,
-		roleRepo:    roleRepo,
-		activityLog: activityLog,
-		producer:    producer,
+		userRepo:     userRepo,
+		profileRepo:  profileRepo,
+		roleRepo:     roleRepo,
+		activityLog:  activityLog,
+		tokenRevoker: tokenRevoker,
+		transactor:   transactor,
+		producer:     producer,
 	}
 }
 
@@ -64,27 +70,20 @@ func (s *userService) CreateUser(ctx context.Context, user *domain.User, profile
 		user.Status = "active"
 	}
 
-	// Create user and profile
-	if err := s.userRepo.Create(ctx, user); err != nil {
-		return err
-	}
-
-	if err := s.profileRepo.Create(ctx, profile); err != nil {
-		// Rollback user creation if profile fails
-		s.userRepo.Delete(ctx, user.UserID)
-		return err
-	}
-
-	// Publish user created event
-	event := models.NewUserEvent(models.EventUserCreated, user.UserID, user.Email)
-	event.FirstName = profile.FirstName
-	event.LastName = profile.LastName
-	event.RoleID = user.RoleID
-	event.Status = user.Status
+	// Create user, profile and event together
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
 
-	s.producer.PublishEvent("user.events", user.UserID.String(), event)
+		if err := s.profileRepo.Create(ctx, profile); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish user created event
+		event := userEvent(models.EventUserCreated, user, profile)
+		return s.producer.PublishEvent(ctx, "user.events", user.UserID.String(), event)
+	})
 }
 
 func (s *userService) GetUser(ctx context.Context, userID uuid.UUID) (*domain.UserWithProfile, error) {
@@ -120,6 +119,13 @@ func (s *userService) UpdateUser(ctx context.Context, userID uuid.UUID, updates
 		return err
 	}
 
+	profile, err := s.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	previousRoleID, previousStatus := user.RoleID, user.Status
+
 	// Apply updates
 	if email, ok := updates["email"].(string); ok {
 		user.Email = email
@@ -131,16 +137,25 @@ func (s *userService) UpdateUser(ctx context.Context, userID uuid.UUID, updates
 		user.Status = status
 	}
 
-	// Update user
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		// Publish user updated event
+		event := userEvent(models.EventUserUpdated, user, profile)
+		return s.producer.PublishEvent(ctx, "user.events", user.UserID.String(), event)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Publish user updated event
-	event := models.NewUserEvent(models.EventUserUpdated, user.UserID, user.Email)
-	event.RoleID = user.RoleID
-	event.Status = user.Status
-	s.producer.PublishEvent("user.events", user.UserID.String(), event)
+	// Issued tokens carry the old role, and a user who is no longer active must not keep access
+	if user.RoleID != previousRoleID || (user.Status != previousStatus && user.Status != "active") {
+		if err := s.tokenRevoker.RevokeUserTokens(ctx, userID); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -151,21 +166,26 @@ func (s *userService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 		return err
 	}
 
-	// Delete profile first (foreign key constraint)
-	if err := s.profileRepo.Delete(ctx, userID); err != nil {
-		return err
-	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Delete profile first (foreign key constraint)
+		if err := s.profileRepo.Delete(ctx, userID); err != nil {
+			return err
+		}
 
-	// Delete user
-	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		// Delete user
+		if err := s.userRepo.Delete(ctx, userID); err != nil {
+			return err
+		}
+
+		// Publish user deleted event
+		event := models.NewUserEvent(models.EventUserDeleted, userID, user.Email)
+		return s.producer.PublishEvent(ctx, "user.events", userID.String(), event)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Publish user deleted event
-	event := models.NewUserEvent(models.EventUserDeleted, userID, user.Email)
-	s.producer.PublishEvent("user.events", userID.String(), event)
-
-	return nil
+	return s.tokenRevoker.RevokeUserTokens(ctx, userID)
 }
 
 func (s *userService) ListUsers(ctx context.Context, filters map[string]interface{}, page, limit int) ([]*domain.UserWithProfile, int64, error) {
@@ -204,16 +224,16 @@ func (s *userService) ActivateUser(ctx context.Context, userID uuid.UUID) error
 		return err
 	}
 
-	if err := s.userRepo.UpdateStatus(ctx, userID, "active"); err != nil {
-		return err
-	}
-
-	// Publish user activated event
-	event := models.NewUserEvent(models.EventUserActivated, userID, user.Email)
-	event.Status = "active"
-	s.producer.PublishEvent("user.events", userID.String(), event)
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdateStatus(ctx, userID, "active"); err != nil {
+			return err
+		}
 
-	return nil
+		// Publish user activated event
+		event := models.NewUserEvent(models.EventUserActivated, userID, user.Email)
+		event.Status = "active"
+		return s.producer.PublishEvent(ctx, "user.events", userID.String(), event)
+	})
 }
 
 func (s *userService) SuspendUser(ctx context.Context, userID uuid.UUID) error {
@@ -222,19 +242,30 @@ func (s *userService) SuspendUser(ctx context.Context, userID uuid.UUID) error {
 		return err
 	}
 
-	if err := s.userRepo.UpdateStatus(ctx, userID, "suspended"); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdateStatus(ctx, userID, "suspended"); err != nil {
+			return err
+		}
+
+		// Publish user suspended event
+		event := models.NewUserEvent(models.EventUserSuspended, userID, user.Email)
+		event.Status = "suspended"
+		return s.producer.PublishEvent(ctx, "user.events", userID.String(), event)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Publish user suspended event
-	event := models.NewUserEvent(models.EventUserSuspended, userID, user.Email)
-	event.Status = "suspended"
-	s.producer.PublishEvent("user.events", userID.String(), event)
-
-	return nil
+	// Refresh is already refused for suspended users, this ends their access tokens
+	return s.tokenRevoker.RevokeUserTokens(ctx, userID)
 }
 
 func (s *userService) UpdateProfile(ctx context.Context, userID uuid.UUID, profile *domain.UserProfile) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
 	existingProfile, err := s.profileRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return err
@@ -249,7 +280,15 @@ func (s *userService) UpdateProfile(ctx context.Context, userID uuid.UUID, profi
 	existingProfile.ProfilePictureURL = profile.ProfilePictureURL
 	existingProfile.Bio = profile.Bio
 
-	return s.profileRepo.Update(ctx, existingProfile)
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.profileRepo.Update(ctx, existingProfile); err != nil {
+			return err
+		}
+
+		// Names and phone are part of the user events, publish the change
+		event := userEvent(models.EventUserUpdated, user, existingProfile)
+		return s.producer.PublishEvent(ctx, "user.events", userID.String(), event)
+	})
 }
 
 func (s *userService) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.UserProfile, error) {
@@ -269,3 +308,17 @@ func (s *userService) BulkCreateUsers(ctx context.Context, users []*domain.User,
 
 	return nil
 }
+
+// userEvent creates a user event carrying a full snapshot of the user, so that
+// consumers can keep their own copy of it up to date
+func userEvent(eventType models.EventType, user *domain.User, profile *domain.UserProfile) *models.UserEvent {
+	event := models.NewUserEvent(eventType, user.UserID, user.Email)
+	event.FirstName = profile.FirstName
+	event.LastName = profile.LastName
+	if profile.Phone != nil {
+		event.Phone = *profile.Phone
+	}
+	event.RoleID = user.RoleID
+	event.Status = user.Status
+	return event
+}
@@ -20,9 +20,10 @@ func TestUserService_CreateUser(t *testing.T) {
 	mockProfileRepo := mocks.NewMockUserProfileRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 	mockActivityLog := mocks.NewMockActivityLogRepository(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewUserService(mockUserRepo, mockProfileRepo, mockRoleRepo, mockActivityLog, mockProducer)
+	service := NewUserService(mockUserRepo, mockProfileRepo, mockRoleRepo, mockActivityLog, mockTokenRevoker, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		roleID := uuid.New()
@@ -46,7 +47,7 @@ func TestUserService_CreateUser(t *testing.T) {
 			return nil
 		})
 		mockProfileRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
-		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 		err := service.CreateUser(context.Background(), user, profile)
 		assert.NoError(t, err)
@@ -75,9 +76,10 @@ func TestUserService_GetUser(t *testing.T) {
 
 	// Unused for GetUser but required for NewUserService
 	mockActivityLog := mocks.NewMockActivityLogRepository(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
 	mockProducer := mocks.NewMockEventProducer(ctrl)
 
-	service := NewUserService(mockUserRepo, mockProfileRepo, mockRoleRepo, mockActivityLog, mockProducer)
+	service := NewUserService(mockUserRepo, mockProfileRepo, mockRoleRepo, mockActivityLog, mockTokenRevoker, newTestTransactor(ctrl), mockProducer)
 
 	t.Run("Success", func(t *testing.T) {
 		userID := uuid.New()
@@ -120,8 +122,106 @@ func TestUserService_GetUser(t *testing.T) {
 	})
 }
 
+func TestUserService_RevokesAccessTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockProfileRepo := mocks.NewMockUserProfileRepository(ctrl)
+	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
+	mockActivityLog := mocks.NewMockActivityLogRepository(ctrl)
+	mockTokenRevoker := mocks.NewMockTokenRevoker(ctrl)
+	mockProducer := mocks.NewMockEventProducer(ctrl)
+
+	service := NewUserService(mockUserRepo, mockProfileRepo, mockRoleRepo, mockActivityLog, mockTokenRevoker, newTestTransactor(ctrl), mockProducer)
+
+	newUser := func() *domain.User {
+		return &domain.User{
+			UserID: uuid.New(),
+			Email:  "test@example.com",
+			RoleID: uuid.New(),
+			Status: "active",
+		}
+	}
+
+	t.Run("Suspend", func(t *testing.T) {
+		user := newUser()
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockUserRepo.EXPECT().UpdateStatus(gomock.Any(), user.UserID, "suspended").Return(nil)
+		mockTokenRevoker.EXPECT().RevokeUserTokens(gomock.Any(), user.UserID).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "user.events", user.UserID.String(), gomock.Any()).Return(nil)
+
+		err := service.SuspendUser(context.Background(), user.UserID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		user := newUser()
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockProfileRepo.EXPECT().Delete(gomock.Any(), user.UserID).Return(nil)
+		mockUserRepo.EXPECT().Delete(gomock.Any(), user.UserID).Return(nil)
+		mockTokenRevoker.EXPECT().RevokeUserTokens(gomock.Any(), user.UserID).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "user.events", user.UserID.String(), gomock.Any()).Return(nil)
+
+		err := service.DeleteUser(context.Background(), user.UserID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Role Change", func(t *testing.T) {
+		user := newUser()
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockProfileRepo.EXPECT().GetByUserID(gomock.Any(), user.UserID).Return(&domain.UserProfile{UserID: user.UserID}, nil)
+		mockUserRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockTokenRevoker.EXPECT().RevokeUserTokens(gomock.Any(), user.UserID).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "user.events", user.UserID.String(), gomock.Any()).Return(nil)
+
+		err := service.UpdateUser(context.Background(), user.UserID, map[string]interface{}{
+			"role_id": uuid.New(),
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Email Change Keeps Tokens", func(t *testing.T) {
+		user := newUser()
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockProfileRepo.EXPECT().GetByUserID(gomock.Any(), user.UserID).Return(&domain.UserProfile{UserID: user.UserID}, nil)
+		mockUserRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockProducer.EXPECT().PublishEvent(gomock.Any(), "user.events", user.UserID.String(), gomock.Any()).Return(nil)
+
+		err := service.UpdateUser(context.Background(), user.UserID, map[string]interface{}{
+			"email": "new@example.com",
+		})
+
+		assert.NoError(t, err)
+	})
+}
+
 // Password hashing helper for tests
 func init() {
 	// Reduce bcrypt cost for faster tests if configurable, but utility uses DefaultCost.
 	// We just rely on it being reasonably fast.
 }
+
+// newTestTransactor returns a transactor that runs every transaction inline
+// inTransactionKey marks the contexts a test transactor runs its functions with
+type inTransactionKey struct{}
+
+func newTestTransactor(ctrl *gomock.Controller) *mocks.MockTransactor {
+	transactor := mocks.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(context.WithValue(ctx, inTransactionKey{}, true))
+	}).AnyTimes()
+	return transactor
+}
+
+// inTransaction reports whether ctx belongs to a test transaction
+func inTransaction(ctx context.Context) bool {
+	return ctx.Value(inTransactionKey{}) != nil
+}
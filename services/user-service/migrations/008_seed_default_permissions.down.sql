@@ -0,0 +1,10 @@
+DELETE FROM permissions WHERE permission_name IN (
+    'users:create',
+    'users:read',
+    'users:update',
+    'users:delete',
+    'roles:read',
+    'roles:manage',
+    'permissions:read',
+    'permissions:manage'
+);
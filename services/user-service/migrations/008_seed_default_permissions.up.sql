@@ -0,0 +1,29 @@
+-- Seed the permissions checked by the admin routes
+INSERT INTO permissions (permission_name, resource, action, description) VALUES
+    ('users:create', 'users', 'create', 'Create and bulk-import users'),
+    ('users:read', 'users', 'read', 'View users and their profiles'),
+    ('users:update', 'users', 'update', 'Update, activate and suspend users'),
+    ('users:delete', 'users', 'delete', 'Delete users'),
+    ('roles:read', 'roles', 'read', 'View roles and their permissions'),
+    ('roles:manage', 'roles', 'manage', 'Create, update and delete roles and grant or revoke their permissions'),
+    ('permissions:read', 'permissions', 'read', 'View permissions'),
+    ('permissions:manage', 'permissions', 'manage', 'Create permissions')
+ON CONFLICT (permission_name) DO NOTHING;
+
+-- Admins hold every seeded permission
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'admin'
+  AND p.resource IN ('users', 'roles', 'permissions')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- Faculty keep the user administration they had under the role-name checks
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'faculty'
+  AND p.resource = 'users'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
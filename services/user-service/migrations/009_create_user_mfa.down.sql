@@ -0,0 +1,4 @@
+ALTER TABLE roles DROP COLUMN IF EXISTS mfa_required;
+DROP INDEX IF EXISTS idx_mfa_backup_codes_user_id;
+DROP TABLE IF EXISTS mfa_backup_codes CASCADE;
+DROP TABLE IF EXISTS user_mfa CASCADE;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_active_sessions_family_id;
+ALTER TABLE active_sessions DROP COLUMN IF EXISTS rotated_at;
+ALTER TABLE active_sessions DROP COLUMN IF EXISTS family_id;
+
+-- Hashed refresh tokens cannot be restored, so every session has to log in again
+DELETE FROM active_sessions;
+ALTER INDEX IF EXISTS idx_active_sessions_refresh_token_hash RENAME TO idx_active_sessions_refresh_token;
+ALTER TABLE active_sessions ALTER COLUMN refresh_token_hash TYPE VARCHAR(512);
+ALTER TABLE active_sessions RENAME COLUMN refresh_token_hash TO refresh_token;
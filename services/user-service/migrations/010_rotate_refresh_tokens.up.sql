@@ -0,0 +1,15 @@
+-- Store refresh tokens as SHA-256 hashes instead of plaintext
+ALTER TABLE active_sessions RENAME COLUMN refresh_token TO refresh_token_hash;
+UPDATE active_sessions SET refresh_token_hash = encode(sha256(convert_to(refresh_token_hash, 'UTF8')), 'hex');
+ALTER TABLE active_sessions ALTER COLUMN refresh_token_hash TYPE VARCHAR(64);
+ALTER INDEX IF EXISTS idx_active_sessions_refresh_token RENAME TO idx_active_sessions_refresh_token_hash;
+
+-- Sessions created by rotating a refresh token share the family of the login they stem from
+ALTER TABLE active_sessions ADD COLUMN IF NOT EXISTS family_id UUID;
+UPDATE active_sessions SET family_id = session_id WHERE family_id IS NULL;
+ALTER TABLE active_sessions ALTER COLUMN family_id SET NOT NULL;
+
+-- Rotated sessions are kept until they expire so a replayed refresh token can be recognised
+ALTER TABLE active_sessions ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_active_sessions_family_id ON active_sessions(family_id);
@@ -0,0 +1,10 @@
+-- Create signing_keys table (JWT signing keys shared by every user-service instance)
+CREATE TABLE IF NOT EXISTS signing_keys (
+    key_id VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(16) NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    private_key TEXT NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_keys_activates_at ON signing_keys(activates_at);
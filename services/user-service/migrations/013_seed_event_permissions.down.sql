@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE permission_name IN (
+    'events:read',
+    'events:manage'
+);
@@ -0,0 +1,14 @@
+-- Seed the permissions checked by the event administration routes
+INSERT INTO permissions (permission_name, resource, action, description) VALUES
+    ('events:read', 'events', 'read', 'View dead-lettered event messages'),
+    ('events:manage', 'events', 'manage', 'Re-drive dead-lettered event messages')
+ON CONFLICT (permission_name) DO NOTHING;
+
+-- Admins hold both
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'admin'
+  AND p.resource = 'events'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
@@ -0,0 +1,14 @@
+-- Seed the permission checked by the MFA reset route. Unlike the other users
+-- permissions it is not granted to faculty.
+INSERT INTO permissions (permission_name, resource, action, description) VALUES
+    ('users:mfa_reset', 'users', 'mfa_reset', 'Remove the second factor of users')
+ON CONFLICT (permission_name) DO NOTHING;
+
+-- Only admins hold it
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'admin'
+  AND p.permission_name = 'users:mfa_reset'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
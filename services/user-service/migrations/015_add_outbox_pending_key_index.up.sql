@@ -0,0 +1,2 @@
+-- The relay publishes the oldest pending event of each topic and key
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending_key ON outbox_events(topic, event_key, id) WHERE sent_at IS NULL;
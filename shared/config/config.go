@@ -31,22 +31,43 @@ type RedisConfig struct {
 type KafkaConfig struct {
 	Brokers       []string
 	ConsumerGroup string
+	MaxAttempts   int   // handler attempts per delivery before a message moves on to the next retry topic
+	RetryBackoff  int   // in milliseconds, doubled for every further attempt
+	RetryDelays   []int // in seconds, one retry topic per delay before a message is dead-lettered
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret             string
-	AccessTokenExpiry  int // in seconds
-	RefreshTokenExpiry int // in seconds
+	SigningAlgorithm    string // RS256 or EdDSA, used by the issuing service
+	KeyRotationInterval int    // in seconds
+	JWKSURL             string // where validating services fetch the issuer's public keys
+	AccessTokenExpiry   int    // in seconds
+	RefreshTokenExpiry  int    // in seconds
+}
+
+// LockoutConfig holds login brute-force protection configuration
+type LockoutConfig struct {
+	MaxFailedAttempts   int // per email before the account is locked
+	MaxFailedIPAttempts int // per IP address before the address is locked
+	AttemptWindow       int // in seconds
+	BackoffBase         int // in seconds, doubled for every further failure
+	LockoutDuration     int // in seconds
+}
+
+// TranscriptConfig holds official transcript configuration
+type TranscriptConfig struct {
+	SigningKey string // HMAC key signing the hashes of issued transcripts
 }
 
 // Config holds all configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	Kafka    KafkaConfig
-	JWT      JWTConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	Kafka      KafkaConfig
+	JWT        JWTConfig
+	Lockout    LockoutConfig
+	Transcript TranscriptConfig
 }
 
 // LoadConfig loads configuration from environment variables
@@ -70,11 +91,26 @@ func LoadConfig() *Config {
 		Kafka: KafkaConfig{
 			Brokers:       getEnvAsSlice("KAFKA_BROKERS", []string{"localhost:9092"}),
 			ConsumerGroup: getEnv("KAFKA_CONSUMER_GROUP", "nimbusu-service-group"),
+			MaxAttempts:   getEnvAsInt("KAFKA_CONSUMER_MAX_ATTEMPTS", 3),
+			RetryBackoff:  getEnvAsInt("KAFKA_CONSUMER_RETRY_BACKOFF", 200),       // 200 milliseconds
+			RetryDelays:   getEnvAsIntSlice("KAFKA_RETRY_DELAYS", []int{60, 600}), // 1 minute, 10 minutes
 		},
 		JWT: JWTConfig{
-			Secret:             getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-			AccessTokenExpiry:  getEnvAsInt("JWT_ACCESS_TOKEN_EXPIRY", 3600),    // 1 hour
-			RefreshTokenExpiry: getEnvAsInt("JWT_REFRESH_TOKEN_EXPIRY", 604800), // 7 days
+			SigningAlgorithm:    getEnv("JWT_SIGNING_ALGORITHM", "EdDSA"),
+			KeyRotationInterval: getEnvAsInt("JWT_KEY_ROTATION_INTERVAL", 2592000), // 30 days
+			JWKSURL:             getEnv("JWT_JWKS_URL", "http://localhost:8081/.well-known/jwks.json"),
+			AccessTokenExpiry:   getEnvAsInt("JWT_ACCESS_TOKEN_EXPIRY", 3600),    // 1 hour
+			RefreshTokenExpiry:  getEnvAsInt("JWT_REFRESH_TOKEN_EXPIRY", 604800), // 7 days
+		},
+		Lockout: LockoutConfig{
+			MaxFailedAttempts:   getEnvAsInt("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+			MaxFailedIPAttempts: getEnvAsInt("LOGIN_MAX_FAILED_IP_ATTEMPTS", 20),
+			AttemptWindow:       getEnvAsInt("LOGIN_ATTEMPT_WINDOW", 900),   // 15 minutes
+			BackoffBase:         getEnvAsInt("LOGIN_BACKOFF_BASE", 2),       // 2 seconds
+			LockoutDuration:     getEnvAsInt("LOGIN_LOCKOUT_DURATION", 900), // 15 minutes
+		},
+		Transcript: TranscriptConfig{
+			SigningKey: getEnv("TRANSCRIPT_SIGNING_KEY", "your-transcript-signing-key"),
 		},
 	}
 }
@@ -96,6 +132,23 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsIntSlice(key string, defaultValue []int) []int {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	values := make([]int, 0)
+	for _, part := range strings.Split(valueStr, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return defaultValue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txContextKey struct{}
+
+// DB wraps a connection pool so that queries join the transaction started by
+// WithinTransaction when their context carries one
+type DB struct {
+	pool *pgxpool.Pool
+}
+
+// NewDB creates a new transaction aware database handle
+func NewDB(pool *pgxpool.Pool) *DB {
+	return &DB{pool: pool}
+}
+
+func txFromContext(ctx context.Context) pgx.Tx {
+	tx, _ := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx
+}
+
+// WithinTransaction runs fn in a transaction. Queries made with the context passed
+// to fn join it, and it is committed only if fn succeeds. Nested calls join the
+// outer transaction.
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Exec executes a statement
+func (db *DB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Exec(ctx, sql, args...)
+	}
+	return db.pool.Exec(ctx, sql, args...)
+}
+
+// Query executes a query returning rows
+func (db *DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Query(ctx, sql, args...)
+	}
+	return db.pool.Query(ctx, sql, args...)
+}
+
+// QueryRow executes a query returning at most one row
+func (db *DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+	return db.pool.QueryRow(ctx, sql, args...)
+}
+
+// Begin starts a transaction, or a savepoint inside the transaction of ctx
+func (db *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Begin(ctx)
+	}
+	return db.pool.Begin(ctx)
+}
@@ -4,26 +4,43 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/SureshAmal/NimbusU-backend/shared/config"
 	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"go.uber.org/zap"
 )
 
-// MessageHandler is a function that processes a Kafka message
+const (
+	minRetryBackoff   = 10 * time.Millisecond
+	maxForwardBackoff = 30 * time.Second
+)
+
+// MessageHandler is a function that processes a Kafka message. Wrap an error
+// with Permanent to dead-letter the message without retrying it.
 type MessageHandler func(ctx context.Context, message []byte) error
 
-// Consumer wraps Sarama consumer group
+// Consumer wraps Sarama consumer group. A message whose handler keeps failing
+// moves down a chain of retry topics, one per configured delay, and ends up in
+// the dead-letter topic of its original topic once the chain is exhausted.
 type Consumer struct {
 	consumerGroup sarama.ConsumerGroup
-	handler       MessageHandler
+	producer      *Producer
+	handler       consumerGroupHandler
 	topics        []string
 }
 
 // consumerGroupHandler implements sarama.ConsumerGroupHandler
 type consumerGroupHandler struct {
-	handler MessageHandler
+	handler      MessageHandler
+	producer     *Producer
+	group        string
+	maxAttempts  int
+	retryBackoff time.Duration
+	retryDelays  []time.Duration
 }
 
 func (h consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
@@ -42,16 +59,11 @@ func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 			zap.Int64("offset", message.Offset),
 		)
 
-		// Process message
-		err := h.handler(session.Context(), message.Value)
-		if err != nil {
-			logger.Error("Error processing message",
-				zap.String("topic", message.Topic),
-				zap.Int64("offset", message.Offset),
-				zap.Error(err),
-			)
-			// Continue processing other messages even if one fails
-			continue
+		// Process message, retrying or dead-lettering it on failure
+		if err := h.process(session.Context(), message); err != nil {
+			// The session ended first. The message is left unmarked so that
+			// it is redelivered to the next owner of the partition.
+			return nil
 		}
 
 		// Mark message as processed
@@ -61,7 +73,120 @@ func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 	return nil
 }
 
-// NewConsumer creates a new Kafka consumer
+// process handles a message and settles it. It only fails when ctx is done
+// before the message could be handled or forwarded.
+func (h consumerGroupHandler) process(ctx context.Context, message *sarama.ConsumerMessage) error {
+	// Retried and redriven messages are meant for the group that failed them
+	if group := messageHeader(message, HeaderConsumerGroup); group != "" && group != h.group {
+		return nil
+	}
+
+	// Messages on a retry topic wait out their delay
+	if notBefore, err := strconv.ParseInt(messageHeader(message, HeaderRetryNotBefore), 10, 64); err == nil {
+		if wait := time.Until(time.UnixMilli(notBefore)); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	err := h.handle(ctx, message)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	originalTopic := messageHeader(message, HeaderOriginalTopic)
+	if originalTopic == "" {
+		originalTopic = message.Topic
+	}
+	stage, _ := strconv.Atoi(messageHeader(message, HeaderRetryStage))
+
+	// Move the message on to the next retry topic, or dead-letter it
+	var topic string
+	var headers []sarama.RecordHeader
+	if stage < len(h.retryDelays) && !IsPermanent(err) {
+		delay := h.retryDelays[stage]
+		topic = RetryTopic(originalTopic, delay)
+		headers = append(failureHeaders(message, h.group, stage+1, err), sarama.RecordHeader{
+			Key:   []byte(HeaderRetryNotBefore),
+			Value: []byte(strconv.FormatInt(time.Now().Add(delay).UnixMilli(), 10)),
+		})
+	} else {
+		topic = DeadLetterTopic(originalTopic)
+		headers = failureHeaders(message, h.group, stage, err)
+	}
+
+	logger.Error("Error processing message",
+		zap.String("topic", message.Topic),
+		zap.Int32("partition", message.Partition),
+		zap.Int64("offset", message.Offset),
+		zap.String("forwarded_to", topic),
+		zap.Error(err),
+	)
+
+	return h.forward(ctx, topic, message, headers)
+}
+
+// handle runs the handler up to maxAttempts times with exponential backoff
+func (h consumerGroupHandler) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	backoff := h.retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := h.handler(ctx, message.Value)
+		if err == nil || IsPermanent(err) || attempt >= h.maxAttempts {
+			return err
+		}
+
+		logger.Warn("Retrying message",
+			zap.String("topic", message.Topic),
+			zap.Int64("offset", message.Offset),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// forward publishes a failed message to a retry or dead-letter topic. It keeps
+// trying until it succeeds, as the message must not be marked before that.
+func (h consumerGroupHandler) forward(ctx context.Context, topic string, message *sarama.ConsumerMessage, headers []sarama.RecordHeader) error {
+	backoff := h.retryBackoff
+
+	for {
+		err := h.producer.send(topic, string(message.Key), message.Value, headers)
+		if err == nil {
+			return nil
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, maxForwardBackoff)
+	}
+}
+
+// NewConsumer creates a new Kafka consumer. It also subscribes to the retry
+// topics of the given topics, and creates the producer failed messages are
+// forwarded with.
 func NewConsumer(cfg config.KafkaConfig, topics []string, handler MessageHandler) (*Consumer, error) {
 	config := sarama.NewConfig()
 	config.Version = sarama.V2_6_0_0
@@ -69,28 +194,53 @@ func NewConsumer(cfg config.KafkaConfig, topics []string, handler MessageHandler
 	config.Consumer.Offsets.Initial = sarama.OffsetNewest
 	config.Consumer.Return.Errors = true
 
+	retryDelays := make([]time.Duration, len(cfg.RetryDelays))
+	for i, delay := range cfg.RetryDelays {
+		retryDelays[i] = time.Duration(delay) * time.Second
+	}
+
+	subscriptions := make([]string, 0, len(topics)*(len(retryDelays)+1))
+	for _, topic := range topics {
+		subscriptions = append(subscriptions, topic)
+		for _, delay := range retryDelays {
+			subscriptions = append(subscriptions, RetryTopic(topic, delay))
+		}
+	}
+
+	producer, err := NewProducer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	consumerGroup, err := sarama.NewConsumerGroup(cfg.Brokers, cfg.ConsumerGroup, config)
 	if err != nil {
+		producer.Close()
 		return nil, fmt.Errorf("failed to create Kafka consumer group: %w", err)
 	}
 
 	logger.Info("Kafka consumer created",
 		zap.Strings("brokers", cfg.Brokers),
 		zap.String("group", cfg.ConsumerGroup),
-		zap.Strings("topics", topics),
+		zap.Strings("topics", subscriptions),
 	)
 
 	return &Consumer{
 		consumerGroup: consumerGroup,
-		handler:       handler,
-		topics:        topics,
+		producer:      producer,
+		handler: consumerGroupHandler{
+			handler:      handler,
+			producer:     producer,
+			group:        cfg.ConsumerGroup,
+			maxAttempts:  max(cfg.MaxAttempts, 1),
+			retryBackoff: max(time.Duration(cfg.RetryBackoff)*time.Millisecond, minRetryBackoff),
+			retryDelays:  retryDelays,
+		},
+		topics: subscriptions,
 	}, nil
 }
 
 // Start starts consuming messages from Kafka
 func (c *Consumer) Start(ctx context.Context) error {
-	handler := consumerGroupHandler{handler: c.handler}
-
 	for {
 		// Check if context is cancelled
 		if ctx.Err() != nil {
@@ -98,7 +248,7 @@ func (c *Consumer) Start(ctx context.Context) error {
 		}
 
 		// Consume messages
-		err := c.consumerGroup.Consume(ctx, c.topics, handler)
+		err := c.consumerGroup.Consume(ctx, c.topics, c.handler)
 		if err != nil {
 			logger.Error("Error consuming messages", zap.Error(err))
 			return err
@@ -121,10 +271,21 @@ func (c *Consumer) Close() error {
 		}
 		logger.Info("Kafka consumer closed")
 	}
+	if c.producer != nil {
+		return c.producer.Close()
+	}
 	return nil
 }
 
-// UnmarshalEvent unmarshals a JSON event from bytes
+// UnmarshalEvent validates a JSON event against the schema of its event type
+// and version and unmarshals it. An event that does not match its schema will
+// not match on a retry either, so the error is permanent.
 func UnmarshalEvent(data []byte, event interface{}) error {
-	return json.Unmarshal(data, event)
+	if err := models.ValidateEvent(data); err != nil {
+		return Permanent(err)
+	}
+	if err := json.Unmarshal(data, event); err != nil {
+		return Permanent(fmt.Errorf("failed to unmarshal event: %w", err))
+	}
+	return nil
 }
@@ -0,0 +1,17 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// txDB is the part of database.DB the outbox and the event ledger use, so that
+// tests can run them without a database
+type txDB interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
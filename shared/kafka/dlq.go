@@ -0,0 +1,253 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/SureshAmal/NimbusU-backend/shared/config"
+	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"go.uber.org/zap"
+)
+
+// HeaderRedrivenFrom names the dead letter a redriven message was taken from
+const HeaderRedrivenFrom = "x-redriven-from"
+
+const (
+	dlqReadTimeout = 10 * time.Second
+	dlqIdleTimeout = 2 * time.Second
+
+	// MaxDeadLetters is the most dead letters List returns per topic
+	MaxDeadLetters = 500
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a message a consumer group gave up on, with the failure details
+// carried in its headers
+type DeadLetter struct {
+	Topic             string    `json:"topic"`
+	Partition         int32     `json:"partition"`
+	Offset            int64     `json:"offset"`
+	Key               string    `json:"key"`
+	Value             string    `json:"value"`
+	Error             string    `json:"error"`
+	ConsumerGroup     string    `json:"consumer_group"`
+	OriginalTopic     string    `json:"original_topic"`
+	OriginalPartition int32     `json:"original_partition"`
+	OriginalOffset    int64     `json:"original_offset"`
+	RetryStage        int       `json:"retry_stage"`
+	FailedAt          time.Time `json:"failed_at"`
+}
+
+func newDeadLetter(message *sarama.ConsumerMessage) *DeadLetter {
+	letter := &DeadLetter{
+		Topic:         message.Topic,
+		Partition:     message.Partition,
+		Offset:        message.Offset,
+		Key:           string(message.Key),
+		Value:         string(message.Value),
+		Error:         messageHeader(message, HeaderError),
+		ConsumerGroup: messageHeader(message, HeaderConsumerGroup),
+		OriginalTopic: messageHeader(message, HeaderOriginalTopic),
+	}
+
+	if partition, err := strconv.ParseInt(messageHeader(message, HeaderOriginalPartition), 10, 32); err == nil {
+		letter.OriginalPartition = int32(partition)
+	}
+	letter.OriginalOffset, _ = strconv.ParseInt(messageHeader(message, HeaderOriginalOffset), 10, 64)
+	letter.RetryStage, _ = strconv.Atoi(messageHeader(message, HeaderRetryStage))
+	letter.FailedAt, _ = time.Parse(time.RFC3339, messageHeader(message, HeaderFailedAt))
+
+	return letter
+}
+
+// DeadLetterQueue inspects dead-letter topics and re-drives their messages
+type DeadLetterQueue struct {
+	client   sarama.Client
+	producer *Producer
+}
+
+// NewDeadLetterQueue creates a new dead-letter queue browser. Redriven messages
+// are published with producer.
+func NewDeadLetterQueue(cfg config.KafkaConfig, producer *Producer) (*DeadLetterQueue, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_6_0_0
+
+	client, err := sarama.NewClient(cfg.Brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+
+	return &DeadLetterQueue{
+		client:   client,
+		producer: producer,
+	}, nil
+}
+
+// List returns up to limit of the most recent dead letters of a topic, newest
+// first. The limit is capped at MaxDeadLetters.
+func (q *DeadLetterQueue) List(ctx context.Context, topic string, limit int) ([]*DeadLetter, error) {
+	dlqTopic := DeadLetterTopic(topic)
+	limit = min(limit, MaxDeadLetters)
+
+	partitions, err := q.client.Partitions(dlqTopic)
+	if errors.Is(err, sarama.ErrUnknownTopicOrPartition) {
+		return []*DeadLetter{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead-letter partitions: %w", err)
+	}
+
+	letters := make([]*DeadLetter, 0)
+	for _, partition := range partitions {
+		oldest, newest, err := q.offsets(dlqTopic, partition)
+		if err != nil {
+			return nil, err
+		}
+
+		messages, err := q.read(ctx, dlqTopic, partition, max(oldest, newest-int64(limit)), newest)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			letters = append(letters, newDeadLetter(message))
+		}
+	}
+
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].FailedAt.After(letters[j].FailedAt)
+	})
+	if len(letters) > limit {
+		letters = letters[:limit]
+	}
+
+	return letters, nil
+}
+
+// Redrive publishes a dead letter back to its original topic. Only the consumer
+// group that dead-lettered it handles it again.
+func (q *DeadLetterQueue) Redrive(ctx context.Context, topic string, partition int32, offset int64) error {
+	dlqTopic := DeadLetterTopic(topic)
+
+	oldest, newest, err := q.offsets(dlqTopic, partition)
+	if errors.Is(err, sarama.ErrUnknownTopicOrPartition) {
+		return ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if offset < oldest || offset >= newest {
+		return ErrDeadLetterNotFound
+	}
+
+	messages, err := q.read(ctx, dlqTopic, partition, offset, offset+1)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 || messages[0].Offset != offset {
+		return ErrDeadLetterNotFound
+	}
+	letter := newDeadLetter(messages[0])
+
+	originalTopic := letter.OriginalTopic
+	if originalTopic == "" {
+		originalTopic = topic
+	}
+
+	headers := []sarama.RecordHeader{
+		{Key: []byte(HeaderRedrivenFrom), Value: []byte(fmt.Sprintf("%s/%d/%d", dlqTopic, partition, offset))},
+	}
+	if letter.ConsumerGroup != "" {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(HeaderConsumerGroup), Value: []byte(letter.ConsumerGroup)})
+	}
+
+	if err := q.producer.send(originalTopic, letter.Key, messages[0].Value, headers); err != nil {
+		return err
+	}
+
+	logger.Info("Dead letter redriven",
+		zap.String("topic", dlqTopic),
+		zap.Int32("partition", partition),
+		zap.Int64("offset", offset),
+		zap.String("original_topic", originalTopic),
+		zap.String("consumer_group", letter.ConsumerGroup),
+	)
+
+	return nil
+}
+
+// Close closes the Kafka client
+func (q *DeadLetterQueue) Close() error {
+	return q.client.Close()
+}
+
+// offsets returns the oldest offset of a partition and the offset the next message will get
+func (q *DeadLetterQueue) offsets(topic string, partition int32) (int64, int64, error) {
+	oldest, err := q.client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get dead-letter offsets: %w", err)
+	}
+	newest, err := q.client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get dead-letter offsets: %w", err)
+	}
+	return oldest, newest, nil
+}
+
+// read returns the messages of a partition from start up to, but excluding, end
+func (q *DeadLetterQueue) read(ctx context.Context, topic string, partition int32, start, end int64) ([]*sarama.ConsumerMessage, error) {
+	if start >= end {
+		return nil, nil
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(q.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letters: %w", err)
+	}
+	defer partitionConsumer.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, dlqReadTimeout)
+	defer cancel()
+
+	return readUntil(ctx, partitionConsumer, end, dlqIdleTimeout)
+}
+
+// readUntil collects the messages of a partition consumer up to, but excluding,
+// end. Transaction markers and compacted records leave gaps in the offsets, so
+// the message at end-1 may never arrive. Reading therefore also stops once the
+// consumer has caught up with the high water mark, or no message arrived for
+// idleTimeout.
+func readUntil(ctx context.Context, partitionConsumer sarama.PartitionConsumer, end int64, idleTimeout time.Duration) ([]*sarama.ConsumerMessage, error) {
+	idle := time.NewTimer(idleTimeout)
+	defer idle.Stop()
+
+	var messages []*sarama.ConsumerMessage
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to read dead letters: %w", ctx.Err())
+		case <-idle.C:
+			return messages, nil
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok || message.Offset >= end {
+				return messages, nil
+			}
+			messages = append(messages, message)
+			if message.Offset >= end-1 || message.Offset+1 >= partitionConsumer.HighWaterMarkOffset() {
+				return messages, nil
+			}
+			idle.Reset(idleTimeout)
+		}
+	}
+}
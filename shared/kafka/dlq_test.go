@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// fakePartitionConsumer delivers the messages at the given offsets, with the
+// high water mark after them
+type fakePartitionConsumer struct {
+	sarama.PartitionConsumer
+	messages      chan *sarama.ConsumerMessage
+	highWaterMark int64
+}
+
+func newFakePartitionConsumer(highWaterMark int64, offsets ...int64) *fakePartitionConsumer {
+	pc := &fakePartitionConsumer{
+		messages:      make(chan *sarama.ConsumerMessage, len(offsets)),
+		highWaterMark: highWaterMark,
+	}
+	for _, offset := range offsets {
+		pc.messages <- &sarama.ConsumerMessage{Offset: offset}
+	}
+	return pc
+}
+
+func (pc *fakePartitionConsumer) Messages() <-chan *sarama.ConsumerMessage {
+	return pc.messages
+}
+
+func (pc *fakePartitionConsumer) HighWaterMarkOffset() int64 {
+	return pc.highWaterMark
+}
+
+func TestReadUntil(t *testing.T) {
+	tests := []struct {
+		name          string
+		offsets       []int64
+		highWaterMark int64
+		end           int64
+		idleTimeout   time.Duration
+		want          []int64
+	}{
+		{name: "Stops At End", offsets: []int64{0, 1, 2, 3, 4}, highWaterMark: 5, end: 3, idleTimeout: time.Hour, want: []int64{0, 1, 2}},
+		{name: "Caught Up With High Water Mark", offsets: []int64{0, 1}, highWaterMark: 2, end: 5, idleTimeout: time.Hour, want: []int64{0, 1}},
+		{name: "Gap In Offsets", offsets: []int64{0, 2, 3}, highWaterMark: 4, end: 4, idleTimeout: time.Hour, want: []int64{0, 2, 3}},
+		{name: "Trailing Transaction Marker", offsets: []int64{0, 1}, highWaterMark: 3, end: 3, idleTimeout: 20 * time.Millisecond, want: []int64{0, 1}},
+		{name: "Only Newer Messages", offsets: []int64{6}, highWaterMark: 7, end: 6, idleTimeout: time.Hour, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			messages, err := readUntil(ctx, newFakePartitionConsumer(tt.highWaterMark, tt.offsets...), tt.end, tt.idleTimeout)
+			if err != nil {
+				t.Fatalf("readUntil() = %v", err)
+			}
+			var got []int64
+			for _, message := range messages {
+				got = append(got, message.Offset)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("readUntil() offsets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := readUntil(ctx, newFakePartitionConsumer(3, 0), 3, time.Hour)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("readUntil() = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	ledgerRetention     = 7 * 24 * time.Hour
+	ledgerPruneInterval = time.Hour
+)
+
+// EventLedger records the IDs of the events a consumer group has processed in
+// the processed_events table, so that redelivered events are skipped
+type EventLedger struct {
+	db            txDB
+	consumerGroup string
+}
+
+// NewEventLedger creates a new processed event ledger for a consumer group
+func NewEventLedger(db *database.DB, consumerGroup string) *EventLedger {
+	return &EventLedger{
+		db:            db,
+		consumerGroup: consumerGroup,
+	}
+}
+
+// Idempotent wraps a handler so that each event ID is handled once. The ID is
+// recorded in the transaction the handler runs in, so queries the handler makes
+// with its context commit or roll back together with the record. Messages
+// without an event ID are handled every time they are delivered.
+func (l *EventLedger) Idempotent(handler MessageHandler) MessageHandler {
+	return func(ctx context.Context, message []byte) error {
+		var envelope struct {
+			EventID uuid.UUID `json:"event_id"`
+		}
+		if err := json.Unmarshal(message, &envelope); err != nil {
+			return Permanent(fmt.Errorf("failed to decode event: %w", err))
+		}
+		if envelope.EventID == uuid.Nil {
+			return handler(ctx, message)
+		}
+
+		return l.db.WithinTransaction(ctx, func(ctx context.Context) error {
+			result, err := l.db.Exec(ctx,
+				`INSERT INTO processed_events (consumer_group, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+				l.consumerGroup, envelope.EventID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to record processed event: %w", err)
+			}
+			if result.RowsAffected() == 0 {
+				logger.Debug("Skipping duplicate event", zap.String("event_id", envelope.EventID.String()))
+				return nil
+			}
+
+			return handler(ctx, message)
+		})
+	}
+}
+
+// Start prunes the ledger periodically until ctx is done
+func (l *EventLedger) Start(ctx context.Context) {
+	ticker := time.NewTicker(ledgerPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Prune(ctx); err != nil {
+				logger.Error("Failed to prune processed events", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Prune deletes records older than the retention period. Redeliveries of events
+// that old are not expected anymore.
+func (l *EventLedger) Prune(ctx context.Context) error {
+	_, err := l.db.Exec(ctx,
+		`DELETE FROM processed_events WHERE consumer_group = $1 AND processed_at < $2`,
+		l.consumerGroup, time.Now().Add(-ledgerRetention),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prune processed events: %w", err)
+	}
+
+	return nil
+}
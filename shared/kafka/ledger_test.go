@@ -0,0 +1,214 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ledgerRow struct {
+	consumerGroup string
+	eventID       uuid.UUID
+}
+
+// fakeLedgerDB keeps the processed_events table in memory. Transactions work on
+// the table directly and restore it when they fail.
+type fakeLedgerDB struct {
+	processed map[ledgerRow]time.Time
+	inserts   int
+}
+
+func newFakeLedgerDB() *fakeLedgerDB {
+	return &fakeLedgerDB{processed: map[ledgerRow]time.Time{}}
+}
+
+func (db *fakeLedgerDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	snapshot := maps.Clone(db.processed)
+	if err := fn(ctx); err != nil {
+		db.processed = snapshot
+		return err
+	}
+	return nil
+}
+
+func (db *fakeLedgerDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	switch {
+	case strings.HasPrefix(sql, "INSERT INTO processed_events"):
+		db.inserts++
+		row := ledgerRow{consumerGroup: args[0].(string), eventID: args[1].(uuid.UUID)}
+		if _, ok := db.processed[row]; ok {
+			return pgconn.NewCommandTag("INSERT 0 0"), nil
+		}
+		db.processed[row] = time.Now()
+		return pgconn.NewCommandTag("INSERT 0 1"), nil
+	case strings.HasPrefix(sql, "DELETE FROM processed_events"):
+		for row, processedAt := range db.processed {
+			if row.consumerGroup == args[0].(string) && processedAt.Before(args[1].(time.Time)) {
+				delete(db.processed, row)
+			}
+		}
+		return pgconn.NewCommandTag("DELETE"), nil
+	}
+	return pgconn.CommandTag{}, errors.New("unexpected statement: " + sql)
+}
+
+func (db *fakeLedgerDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query: " + sql)
+}
+
+func (db *fakeLedgerDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	panic("unexpected query: " + sql)
+}
+
+// ledgerMessage encodes an event envelope with eventID
+func ledgerMessage(t *testing.T, eventID uuid.UUID) []byte {
+	t.Helper()
+	message, err := json.Marshal(map[string]any{"event_id": eventID, "event_type": "USER_CREATED"})
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v", err)
+	}
+	return message
+}
+
+func TestEventLedger_Idempotent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Duplicate Skipped", func(t *testing.T) {
+		db := newFakeLedgerDB()
+		ledger := &EventLedger{db: db, consumerGroup: "user-service"}
+		calls := 0
+		handler := ledger.Idempotent(func(ctx context.Context, message []byte) error {
+			calls++
+			return nil
+		})
+
+		message := ledgerMessage(t, uuid.New())
+		for range 2 {
+			if err := handler(ctx, message); err != nil {
+				t.Fatalf("handler() = %v", err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("handler calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("Other Consumer Group", func(t *testing.T) {
+		db := newFakeLedgerDB()
+		calls := 0
+		count := func(ctx context.Context, message []byte) error {
+			calls++
+			return nil
+		}
+
+		message := ledgerMessage(t, uuid.New())
+		for _, group := range []string{"user-service", "course-service"} {
+			ledger := &EventLedger{db: db, consumerGroup: group}
+			if err := ledger.Idempotent(count)(ctx, message); err != nil {
+				t.Fatalf("handler() = %v", err)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("handler calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("No Event ID", func(t *testing.T) {
+		db := newFakeLedgerDB()
+		ledger := &EventLedger{db: db, consumerGroup: "user-service"}
+		calls := 0
+		handler := ledger.Idempotent(func(ctx context.Context, message []byte) error {
+			calls++
+			return nil
+		})
+
+		message := []byte(`{"event_type":"USER_CREATED"}`)
+		for range 2 {
+			if err := handler(ctx, message); err != nil {
+				t.Fatalf("handler() = %v", err)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("handler calls = %d, want 2", calls)
+		}
+		if db.inserts != 0 {
+			t.Errorf("ledger inserts = %d, want 0", db.inserts)
+		}
+	})
+
+	t.Run("Undecodable Message", func(t *testing.T) {
+		ledger := &EventLedger{db: newFakeLedgerDB(), consumerGroup: "user-service"}
+		handler := ledger.Idempotent(func(ctx context.Context, message []byte) error {
+			t.Error("handler called with an undecodable message")
+			return nil
+		})
+
+		if err := handler(ctx, []byte(`{"event_id":`)); !IsPermanent(err) {
+			t.Errorf("handler() = %v, want a permanent error", err)
+		}
+	})
+
+	t.Run("Handler Error Rolls Back", func(t *testing.T) {
+		db := newFakeLedgerDB()
+		ledger := &EventLedger{db: db, consumerGroup: "user-service"}
+		handlerErr := errors.New("handler failed")
+		calls := 0
+		handler := ledger.Idempotent(func(ctx context.Context, message []byte) error {
+			calls++
+			if calls == 1 {
+				return handlerErr
+			}
+			return nil
+		})
+
+		message := ledgerMessage(t, uuid.New())
+		if err := handler(ctx, message); !errors.Is(err, handlerErr) {
+			t.Fatalf("handler() = %v, want %v", err, handlerErr)
+		}
+		if len(db.processed) != 0 {
+			t.Fatalf("ledger rows after a failed handler = %d, want 0", len(db.processed))
+		}
+
+		// The redelivery is handled again
+		if err := handler(ctx, message); err != nil {
+			t.Fatalf("handler() = %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("handler calls = %d, want 2", calls)
+		}
+	})
+}
+
+func TestEventLedger_Prune(t *testing.T) {
+	db := newFakeLedgerDB()
+	ledger := &EventLedger{db: db, consumerGroup: "user-service"}
+
+	expired := ledgerRow{consumerGroup: "user-service", eventID: uuid.New()}
+	retained := ledgerRow{consumerGroup: "user-service", eventID: uuid.New()}
+	otherGroup := ledgerRow{consumerGroup: "course-service", eventID: uuid.New()}
+	db.processed[expired] = time.Now().Add(-ledgerRetention - time.Hour)
+	db.processed[retained] = time.Now().Add(-ledgerRetention + time.Hour)
+	db.processed[otherGroup] = time.Now().Add(-ledgerRetention - time.Hour)
+
+	if err := ledger.Prune(context.Background()); err != nil {
+		t.Fatalf("Prune() = %v", err)
+	}
+
+	if _, ok := db.processed[expired]; ok {
+		t.Error("a record older than the retention period was kept")
+	}
+	if _, ok := db.processed[retained]; !ok {
+		t.Error("a record within the retention period was deleted")
+	}
+	if _, ok := db.processed[otherGroup]; !ok {
+		t.Error("a record of another consumer group was deleted")
+	}
+}
@@ -0,0 +1,241 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/database"
+	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	outboxPollInterval  = time.Second
+	outboxBatchSize     = 100
+	outboxMinBackoff    = time.Second
+	outboxMaxBackoff    = 5 * time.Minute
+	outboxRetention     = 7 * 24 * time.Hour
+	outboxPruneInterval = time.Hour
+
+	// outboxRelayLock makes a single relay publish at a time, which keeps
+	// events of the same key in order across service instances
+	outboxRelayLock = 727002
+)
+
+// Outbox publishes events by writing them to the outbox_events table. Called
+// within database.DB.WithinTransaction, the event is stored only if the
+// transaction commits, and OutboxRelay delivers it to Kafka afterwards.
+type Outbox struct {
+	db *database.DB
+}
+
+// NewOutbox creates a new outbox
+func NewOutbox(db *database.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+// PublishEvent stores an event for the relay to publish to a Kafka topic. The
+// event is validated and stamped with the event context of ctx, see EncodeEvent.
+func (o *Outbox) PublishEvent(ctx context.Context, topic string, key string, event interface{}) error {
+	payload, err := EncodeEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.db.Exec(ctx,
+		`INSERT INTO outbox_events (topic, event_key, payload) VALUES ($1, $2, $3)`,
+		topic, key, payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// OutboxRelay publishes pending outbox events to Kafka in insertion order per
+// topic and key, giving at-least-once delivery. Failed events are retried with
+// exponential backoff, holding back later events of the same key meanwhile.
+type OutboxRelay struct {
+	db        txDB
+	producer  messagePublisher
+	lastPrune time.Time
+}
+
+// messagePublisher sends a message to Kafka, see Producer.PublishMessage
+type messagePublisher interface {
+	PublishMessage(topic string, key string, value []byte) error
+}
+
+// NewOutboxRelay creates a new outbox relay
+func NewOutboxRelay(db *database.DB, producer *Producer) *OutboxRelay {
+	return &OutboxRelay{
+		db:       db,
+		producer: producer,
+	}
+}
+
+type outboxEvent struct {
+	id       int64
+	topic    string
+	key      string
+	payload  []byte
+	attempts int
+}
+
+// Start relays pending events until ctx is done
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Keep going without waiting while events are being published
+		for {
+			published, err := r.RelayPending(ctx)
+			if err != nil {
+				logger.Error("Failed to relay outbox events", zap.Error(err))
+				break
+			}
+			if published == 0 || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if time.Since(r.lastPrune) >= outboxPruneInterval {
+			if err := r.Prune(ctx); err != nil {
+				logger.Error("Failed to prune outbox events", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RelayPending publishes one batch of pending events and returns how many
+// events it published
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		var locked bool
+		if err := r.db.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLock).Scan(&locked); err != nil {
+			return fmt.Errorf("failed to lock outbox: %w", err)
+		}
+		if !locked {
+			// Another instance is relaying
+			return nil
+		}
+
+		events, err := r.pendingEvents(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := r.producer.PublishMessage(event.topic, event.key, event.payload); err != nil {
+				if err := r.scheduleRetry(ctx, event, err); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if _, err := r.db.Exec(ctx, `UPDATE outbox_events SET sent_at = now() WHERE id = $1`, event.id); err != nil {
+				return fmt.Errorf("failed to mark outbox event sent: %w", err)
+			}
+			published++
+		}
+
+		return nil
+	})
+
+	return published, err
+}
+
+// pendingEvents returns the oldest pending event of each topic and key, if it
+// is due. Later events of a key wait until the ones before them are sent, so
+// an event in backoff holds back only its own key.
+func (r *OutboxRelay) pendingEvents(ctx context.Context) ([]outboxEvent, error) {
+	query := `
+		SELECT o.id, o.topic, o.event_key, o.payload, o.attempts
+		FROM outbox_events o
+		WHERE o.sent_at IS NULL
+		  AND o.next_attempt_at <= now()
+		  AND NOT EXISTS (
+			SELECT 1 FROM outbox_events earlier
+			WHERE earlier.topic = o.topic
+			  AND earlier.event_key = o.event_key
+			  AND earlier.sent_at IS NULL
+			  AND earlier.id < o.id
+		  )
+		ORDER BY o.id
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, outboxBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []outboxEvent
+	for rows.Next() {
+		var event outboxEvent
+		if err := rows.Scan(&event.id, &event.topic, &event.key, &event.payload, &event.attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *OutboxRelay) scheduleRetry(ctx context.Context, event outboxEvent, publishErr error) error {
+	attempts := event.attempts + 1
+	nextAttemptAt := time.Now().Add(outboxBackoff(attempts))
+
+	query := `
+		UPDATE outbox_events
+		SET attempts = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, event.id, attempts, publishErr.Error(), nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to schedule outbox retry: %w", err)
+	}
+
+	logger.Warn("Outbox event publish failed, retrying later",
+		zap.Int64("id", event.id),
+		zap.String("topic", event.topic),
+		zap.Int("attempts", attempts),
+		zap.Time("next_attempt_at", nextAttemptAt),
+		zap.Error(publishErr),
+	)
+
+	return nil
+}
+
+// Prune deletes events that were sent longer than the retention period ago
+func (r *OutboxRelay) Prune(ctx context.Context) error {
+	r.lastPrune = time.Now()
+
+	_, err := r.db.Exec(ctx, `DELETE FROM outbox_events WHERE sent_at < $1`, time.Now().Add(-outboxRetention))
+	if err != nil {
+		return fmt.Errorf("failed to prune outbox events: %w", err)
+	}
+
+	return nil
+}
+
+// outboxBackoff doubles the delay with every failed attempt up to outboxMaxBackoff
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxMinBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
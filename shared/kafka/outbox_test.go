@@ -0,0 +1,355 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: outboxMinBackoff},
+		{attempts: 1, want: outboxMinBackoff},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 9, want: 256 * time.Second},
+		{attempts: 10, want: outboxMaxBackoff},
+		{attempts: 1000, want: outboxMaxBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempts), func(t *testing.T) {
+			if got := outboxBackoff(tt.attempts); got != tt.want {
+				t.Errorf("outboxBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeOutboxEvent is a row of the outbox_events table
+type fakeOutboxEvent struct {
+	id            int64
+	topic         string
+	key           string
+	payload       []byte
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+	sentAt        *time.Time
+}
+
+type fakeTxKey struct{}
+
+// fakeOutboxDB keeps the outbox_events table in memory and answers the
+// statements of the relay the way Postgres would. Transactions restore the
+// table when they fail, and hold the relay's advisory lock until they end.
+type fakeOutboxDB struct {
+	events    []fakeOutboxEvent
+	nextTx    int
+	lockOwner int
+}
+
+// add appends a pending event that is due now
+func (db *fakeOutboxDB) add(topic, key string) int64 {
+	id := int64(len(db.events) + 1)
+	db.events = append(db.events, fakeOutboxEvent{
+		id:            id,
+		topic:         topic,
+		key:           key,
+		payload:       []byte(fmt.Sprintf(`{"id":%d}`, id)),
+		nextAttemptAt: time.Now(),
+	})
+	return id
+}
+
+func (db *fakeOutboxDB) event(id int64) *fakeOutboxEvent {
+	return &db.events[id-1]
+}
+
+func (db *fakeOutboxDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(fakeTxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	db.nextTx++
+	tx := db.nextTx
+	snapshot := slices.Clone(db.events)
+	defer func() {
+		if db.lockOwner == tx {
+			db.lockOwner = 0
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, fakeTxKey{}, tx)); err != nil {
+		db.events = snapshot
+		return err
+	}
+	return nil
+}
+
+func (db *fakeOutboxDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	switch sql := strings.Join(strings.Fields(sql), " "); {
+	case strings.HasPrefix(sql, "UPDATE outbox_events SET sent_at = now()"):
+		now := time.Now()
+		db.event(args[0].(int64)).sentAt = &now
+	case strings.HasPrefix(sql, "UPDATE outbox_events SET attempts"):
+		event := db.event(args[0].(int64))
+		event.attempts = args[1].(int)
+		event.lastError = args[2].(string)
+		event.nextAttemptAt = args[3].(time.Time)
+	default:
+		return pgconn.CommandTag{}, errors.New("unexpected statement: " + sql)
+	}
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (db *fakeOutboxDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if !strings.Contains(sql, "FROM outbox_events o") {
+		return nil, errors.New("unexpected query: " + sql)
+	}
+
+	// The oldest unsent event of each topic and key, if it is due
+	now := time.Now()
+	heads := map[[2]string]bool{}
+	var rows [][]any
+	for _, event := range db.events {
+		if event.sentAt != nil {
+			continue
+		}
+		head := [2]string{event.topic, event.key}
+		if heads[head] {
+			continue
+		}
+		heads[head] = true
+		if !event.nextAttemptAt.After(now) && len(rows) < args[0].(int) {
+			rows = append(rows, []any{event.id, event.topic, event.key, event.payload, event.attempts})
+		}
+	}
+	return &fakeRows{rows: rows}, nil
+}
+
+func (db *fakeOutboxDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if !strings.Contains(sql, "pg_try_advisory_xact_lock") {
+		return fakeRow{err: errors.New("unexpected query: " + sql)}
+	}
+
+	tx, _ := ctx.Value(fakeTxKey{}).(int)
+	if db.lockOwner == 0 {
+		db.lockOwner = tx
+	}
+	return fakeRow{values: []any{db.lockOwner == tx}}
+}
+
+// fakeRow is the result of QueryRow
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+// fakeRows is the result of Query
+type fakeRows struct {
+	rows    [][]any
+	current []any
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.NewCommandTag("SELECT") }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+func (r *fakeRows) Values() ([]any, error)                       { return r.current, nil }
+
+func (r *fakeRows) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	r.current, r.rows = r.rows[0], r.rows[1:]
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return scanValues(r.current, dest)
+}
+
+func scanValues(values []any, dest []any) error {
+	if len(values) != len(dest) {
+		return fmt.Errorf("scanning %d values into %d destinations", len(values), len(dest))
+	}
+	for i, value := range values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// fakePublisher records the IDs of the events it publishes. An event is not
+// published when fail returns an error for it.
+type fakePublisher struct {
+	published []int64
+	fail      func(id int64) error
+	onPublish func()
+}
+
+func (p *fakePublisher) PublishMessage(topic string, key string, value []byte) error {
+	var payload struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(value, &payload); err != nil {
+		return err
+	}
+	if p.onPublish != nil {
+		p.onPublish()
+	}
+	if p.fail != nil {
+		if err := p.fail(payload.ID); err != nil {
+			return err
+		}
+	}
+	p.published = append(p.published, payload.ID)
+	return nil
+}
+
+// relayPending runs one relay pass and checks how many events it published
+func relayPending(t *testing.T, relay *OutboxRelay, want int) {
+	t.Helper()
+	published, err := relay.RelayPending(context.Background())
+	if err != nil {
+		t.Fatalf("RelayPending() = %v", err)
+	}
+	if published != want {
+		t.Errorf("RelayPending() = %d, want %d", published, want)
+	}
+}
+
+func TestOutboxRelay_RelayPending(t *testing.T) {
+	t.Run("Publishes In Order", func(t *testing.T) {
+		db := &fakeOutboxDB{}
+		first := db.add("user.events", "user-1")
+		other := db.add("user.events", "user-2")
+		second := db.add("user.events", "user-1")
+		publisher := &fakePublisher{}
+		relay := &OutboxRelay{db: db, producer: publisher}
+
+		// Later events of a key wait for the pass after the earlier one is sent
+		relayPending(t, relay, 2)
+		relayPending(t, relay, 1)
+		relayPending(t, relay, 0)
+
+		if want := []int64{first, other, second}; !slices.Equal(publisher.published, want) {
+			t.Errorf("published = %v, want %v", publisher.published, want)
+		}
+		for _, event := range db.events {
+			if event.sentAt == nil {
+				t.Errorf("event %d was not marked sent", event.id)
+			}
+		}
+	})
+
+	t.Run("Failed Event Holds Back Its Key", func(t *testing.T) {
+		db := &fakeOutboxDB{}
+		failing := db.add("user.events", "user-1")
+		other := db.add("user.events", "user-2")
+		held := db.add("user.events", "user-1")
+		publishErr := errors.New("broker unavailable")
+		publisher := &fakePublisher{fail: func(id int64) error {
+			if id == failing {
+				return publishErr
+			}
+			return nil
+		}}
+		relay := &OutboxRelay{db: db, producer: publisher}
+
+		relayPending(t, relay, 1)
+		// The failed event is backing off and the event behind it waits
+		relayPending(t, relay, 0)
+
+		if want := []int64{other}; !slices.Equal(publisher.published, want) {
+			t.Fatalf("published = %v, want %v", publisher.published, want)
+		}
+		if event := db.event(failing); event.sentAt != nil || event.attempts != 1 || event.lastError != publishErr.Error() {
+			t.Fatalf("failed event = %+v, want a pending event with 1 attempt and its error", event)
+		}
+
+		// Once the backoff passes and the broker is back, the key resumes in order
+		db.event(failing).nextAttemptAt = time.Now()
+		publisher.fail = nil
+		relayPending(t, relay, 1)
+		relayPending(t, relay, 1)
+
+		if want := []int64{other, failing, held}; !slices.Equal(publisher.published, want) {
+			t.Errorf("published = %v, want %v", publisher.published, want)
+		}
+	})
+
+	t.Run("Schedules Retries With Backoff", func(t *testing.T) {
+		db := &fakeOutboxDB{}
+		id := db.add("user.events", "user-1")
+		relay := &OutboxRelay{db: db, producer: &fakePublisher{fail: func(int64) error {
+			return errors.New("broker unavailable")
+		}}}
+
+		for attempts := 1; attempts <= 3; attempts++ {
+			before := time.Now()
+			relayPending(t, relay, 0)
+
+			event := db.event(id)
+			if event.attempts != attempts {
+				t.Errorf("attempts = %d, want %d", event.attempts, attempts)
+			}
+			if wait := event.nextAttemptAt.Sub(before); wait < outboxBackoff(attempts) || wait > outboxBackoff(attempts)+time.Second {
+				t.Errorf("retry after %s, want %s", wait, outboxBackoff(attempts))
+			}
+			event.nextAttemptAt = time.Now()
+		}
+	})
+
+	t.Run("Second Relay Kept Out", func(t *testing.T) {
+		db := &fakeOutboxDB{}
+		db.add("user.events", "user-1")
+		db.add("user.events", "user-2")
+
+		second := &OutboxRelay{db: db, producer: &fakePublisher{}}
+		var secondPublished int
+		publisher := &fakePublisher{onPublish: func() {
+			// Another instance polls while the first one is publishing
+			published, err := second.RelayPending(context.Background())
+			if err != nil {
+				t.Errorf("second RelayPending() = %v", err)
+			}
+			secondPublished += published
+		}}
+		first := &OutboxRelay{db: db, producer: publisher}
+
+		relayPending(t, first, 2)
+
+		if secondPublished != 0 {
+			t.Errorf("second relay published %d events while locked out", secondPublished)
+		}
+		if db.lockOwner != 0 {
+			t.Error("the advisory lock outlived the transaction")
+		}
+		// The lock is free again after the first relay is done
+		db.add("user.events", "user-3")
+		relayPending(t, second, 1)
+	})
+}
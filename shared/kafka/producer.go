@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/SureshAmal/NimbusU-backend/shared/config"
 	"github.com/SureshAmal/NimbusU-backend/shared/logger"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"go.uber.org/zap"
 )
 
@@ -35,25 +37,55 @@ func NewProducer(cfg config.KafkaConfig) (*Producer, error) {
 	return &Producer{producer: producer}, nil
 }
 
-// PublishEvent publishes an event to a Kafka topic
+// PublishEvent publishes an event to a Kafka topic. The event must match the
+// schema of its event type, see EncodeEvent.
 func (p *Producer) PublishEvent(topic string, key string, event interface{}) error {
-	// Marshal event to JSON
+	eventBytes, err := EncodeEvent(context.Background(), event)
+	if err != nil {
+		return err
+	}
+
+	return p.PublishMessage(topic, key, eventBytes)
+}
+
+// EncodeEvent stamps the envelope of an event with the event context of ctx,
+// marshals the event to JSON and validates it against the schema of its event
+// type, so an event that consumers would reject is never published
+func EncodeEvent(ctx context.Context, event interface{}) ([]byte, error) {
+	if e, ok := event.(interface{ Envelope() *models.BaseEvent }); ok {
+		e.Envelope().Stamp(ctx)
+	}
+
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	if err := models.ValidateEvent(eventBytes); err != nil {
+		return nil, err
+	}
+
+	return eventBytes, nil
+}
+
+// PublishMessage publishes an already encoded event to a Kafka topic
+func (p *Producer) PublishMessage(topic string, key string, value []byte) error {
+	return p.send(topic, key, value, nil)
+}
+
+// send publishes a message with the given headers in addition to the timestamp
+func (p *Producer) send(topic string, key string, value []byte, headers []sarama.RecordHeader) error {
 	// Create Kafka message
 	msg := &sarama.ProducerMessage{
 		Topic: topic,
 		Key:   sarama.StringEncoder(key),
-		Value: sarama.ByteEncoder(eventBytes),
-		Headers: []sarama.RecordHeader{
+		Value: sarama.ByteEncoder(value),
+		Headers: append([]sarama.RecordHeader{
 			{
 				Key:   []byte("timestamp"),
 				Value: []byte(time.Now().Format(time.RFC3339)),
 			},
-		},
+		}, headers...),
 	}
 
 	// Send message
@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Headers a consumer adds when it moves a failed message to a retry or dead-letter topic
+const (
+	HeaderError             = "x-error"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderConsumerGroup     = "x-consumer-group"
+	HeaderRetryStage        = "x-retry-stage"
+	HeaderRetryNotBefore    = "x-retry-not-before" // unix milliseconds
+	HeaderFailedAt          = "x-failed-at"
+)
+
+// RetryTopic returns the name of the retry topic of a topic for a delay,
+// e.g. user.events.retry.10m
+func RetryTopic(topic string, delay time.Duration) string {
+	var suffix string
+	switch {
+	case delay%time.Hour == 0:
+		suffix = fmt.Sprintf("%dh", delay/time.Hour)
+	case delay%time.Minute == 0:
+		suffix = fmt.Sprintf("%dm", delay/time.Minute)
+	default:
+		suffix = fmt.Sprintf("%ds", delay/time.Second)
+	}
+	return topic + ".retry." + suffix
+}
+
+// DeadLetterTopic returns the name of the dead-letter topic of a topic
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// permanentError marks a handler error that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps a handler error so the message is dead-lettered right away
+// instead of being retried, e.g. when it cannot be decoded
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether a handler error was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// messageHeader returns the value of a message header, or "" if it is missing
+func messageHeader(message *sarama.ConsumerMessage, key string) string {
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// failureHeaders describes where a failed message came from. A message that
+// already went through a retry topic keeps the origin of its first failure.
+func failureHeaders(message *sarama.ConsumerMessage, group string, stage int, cause error) []sarama.RecordHeader {
+	originalTopic := messageHeader(message, HeaderOriginalTopic)
+	originalPartition := messageHeader(message, HeaderOriginalPartition)
+	originalOffset := messageHeader(message, HeaderOriginalOffset)
+	if originalTopic == "" {
+		originalTopic = message.Topic
+		originalPartition = strconv.FormatInt(int64(message.Partition), 10)
+		originalOffset = strconv.FormatInt(message.Offset, 10)
+	}
+
+	return []sarama.RecordHeader{
+		{Key: []byte(HeaderError), Value: []byte(cause.Error())},
+		{Key: []byte(HeaderOriginalTopic), Value: []byte(originalTopic)},
+		{Key: []byte(HeaderOriginalPartition), Value: []byte(originalPartition)},
+		{Key: []byte(HeaderOriginalOffset), Value: []byte(originalOffset)},
+		{Key: []byte(HeaderConsumerGroup), Value: []byte(group)},
+		{Key: []byte(HeaderRetryStage), Value: []byte(strconv.Itoa(stage))},
+		{Key: []byte(HeaderFailedAt), Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	}
+}
@@ -0,0 +1,99 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+func TestRetryTopic(t *testing.T) {
+	tests := []struct {
+		delay time.Duration
+		want  string
+	}{
+		{delay: 30 * time.Second, want: "user.events.retry.30s"},
+		{delay: 90 * time.Second, want: "user.events.retry.90s"},
+		{delay: time.Minute, want: "user.events.retry.1m"},
+		{delay: 10 * time.Minute, want: "user.events.retry.10m"},
+		{delay: 90 * time.Minute, want: "user.events.retry.90m"},
+		{delay: time.Hour, want: "user.events.retry.1h"},
+		{delay: 24 * time.Hour, want: "user.events.retry.24h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.delay.String(), func(t *testing.T) {
+			if got := RetryTopic("user.events", tt.delay); got != tt.want {
+				t.Errorf("RetryTopic(%q, %s) = %q, want %q", "user.events", tt.delay, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailureHeaders(t *testing.T) {
+	cause := errors.New("handler failed")
+
+	tests := []struct {
+		name    string
+		message *sarama.ConsumerMessage
+		stage   int
+		want    map[string]string
+	}{
+		{
+			name:    "First Failure",
+			message: &sarama.ConsumerMessage{Topic: "course.events", Partition: 3, Offset: 42},
+			stage:   1,
+			want: map[string]string{
+				HeaderError:             "handler failed",
+				HeaderOriginalTopic:     "course.events",
+				HeaderOriginalPartition: "3",
+				HeaderOriginalOffset:    "42",
+				HeaderConsumerGroup:     "notification-service",
+				HeaderRetryStage:        "1",
+			},
+		},
+		{
+			name: "Failed Again In Retry Topic",
+			message: &sarama.ConsumerMessage{
+				Topic:     "course.events.retry.1m",
+				Partition: 0,
+				Offset:    7,
+				Headers: []*sarama.RecordHeader{
+					{Key: []byte(HeaderOriginalTopic), Value: []byte("course.events")},
+					{Key: []byte(HeaderOriginalPartition), Value: []byte("3")},
+					{Key: []byte(HeaderOriginalOffset), Value: []byte("42")},
+					{Key: []byte(HeaderRetryStage), Value: []byte("1")},
+				},
+			},
+			stage: 2,
+			want: map[string]string{
+				HeaderError:             "handler failed",
+				HeaderOriginalTopic:     "course.events",
+				HeaderOriginalPartition: "3",
+				HeaderOriginalOffset:    "42",
+				HeaderConsumerGroup:     "notification-service",
+				HeaderRetryStage:        "2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := failureHeaders(tt.message, "notification-service", tt.stage, cause)
+
+			got := make(map[string]string, len(headers))
+			for _, h := range headers {
+				got[string(h.Key)] = string(h.Value)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("header %s = %q, want %q", key, got[key], want)
+				}
+			}
+			if _, err := time.Parse(time.RFC3339, got[HeaderFailedAt]); err != nil {
+				t.Errorf("header %s = %q is not RFC 3339: %v", HeaderFailedAt, got[HeaderFailedAt], err)
+			}
+		})
+	}
+}
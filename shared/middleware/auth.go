@@ -6,11 +6,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
 	"github.com/SureshAmal/NimbusU-backend/shared/utils"
 )
 
-// AuthMiddleware validates JWT tokens and adds user info to context
-func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
+// AuthMiddleware validates JWT tokens, rejects revoked ones and adds user info to context
+func AuthMiddleware(jwtManager *utils.JWTManager, revocations RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -36,12 +37,25 @@ func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 			return
 		}
 
+		// Check revocation; if Redis fails, allow the token until it expires
+		if revoked, err := revocations.IsRevoked(c.Request.Context(), claims); err == nil && revoked {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Token has been revoked", nil)
+			c.Abort()
+			return
+		}
+
 		// Add user info to context
+		c.Set("claims", claims)
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("role_id", claims.RoleID)
 		c.Set("role_name", claims.RoleName)
 
+		// Events published for the request are attributed to the user
+		ec := models.EventContextFrom(c.Request.Context())
+		ec.ActorID = claims.UserID
+		c.Request = c.Request.WithContext(models.WithEventContext(c.Request.Context(), ec))
+
 		c.Next()
 	}
 }
@@ -56,6 +70,26 @@ func GetUserID(c *gin.Context) (uuid.UUID, bool) {
 	return id, ok
 }
 
+// GetClaims retrieves the validated token claims from Gin context
+func GetClaims(c *gin.Context) (*utils.Claims, bool) {
+	claims, exists := c.Get("claims")
+	if !exists {
+		return nil, false
+	}
+	tokenClaims, ok := claims.(*utils.Claims)
+	return tokenClaims, ok
+}
+
+// GetRoleID retrieves role ID from Gin context
+func GetRoleID(c *gin.Context) (uuid.UUID, bool) {
+	roleID, exists := c.Get("role_id")
+	if !exists {
+		return uuid.Nil, false
+	}
+	id, ok := roleID.(uuid.UUID)
+	return id, ok
+}
+
 // GetRoleName retrieves role name from Gin context
 func GetRoleName(c *gin.Context) (string, bool) {
 	roleName, exists := c.Get("role_name")
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/SureshAmal/NimbusU-backend/shared/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CorrelationIDHeader carries the correlation ID of a request across services
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// CorrelationMiddleware puts the correlation ID of the request, or a new one,
+// into the event context of the request so that events published while
+// handling it share it. The ID is echoed in the response.
+func CorrelationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = uuid.NewString()
+		}
+		c.Header(CorrelationIDHeader, correlationID)
+
+		ec := models.EventContextFrom(c.Request.Context())
+		ec.CorrelationID = correlationID
+		c.Request = c.Request.WithContext(models.WithEventContext(c.Request.Context(), ec))
+
+		c.Next()
+	}
+}
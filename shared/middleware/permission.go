@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// PermissionLoader loads the effective "resource:action" permissions of a role
+// from the source of truth
+type PermissionLoader func(ctx context.Context, roleID uuid.UUID) ([]string, error)
+
+// PermissionResolver resolves role permissions per request, caching them in Redis
+type PermissionResolver struct {
+	client *redis.Client
+	loader PermissionLoader
+	ttl    time.Duration
+}
+
+// NewPermissionResolver creates a new permission resolver
+func NewPermissionResolver(client *redis.Client, loader PermissionLoader, ttl time.Duration) *PermissionResolver {
+	return &PermissionResolver{
+		client: client,
+		loader: loader,
+		ttl:    ttl,
+	}
+}
+
+// PermissionKey builds the "resource:action" key a permission is matched by
+func PermissionKey(resource, action string) string {
+	return resource + ":" + action
+}
+
+func permissionCacheKey(roleID uuid.UUID) string {
+	return fmt.Sprintf("role_permissions:%s", roleID)
+}
+
+// GetPermissions returns the permissions of a role, loading and caching them on a miss
+func (pr *PermissionResolver) GetPermissions(ctx context.Context, roleID uuid.UUID) (map[string]bool, error) {
+	key := permissionCacheKey(roleID)
+
+	var permissions []string
+	cached, err := pr.client.Get(ctx, key).Result()
+	if err != nil || json.Unmarshal([]byte(cached), &permissions) != nil {
+		// Cache miss or Redis unavailable, fall back to the loader
+		permissions, err = pr.loader(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+
+		if data, err := json.Marshal(permissions); err == nil {
+			pr.client.Set(ctx, key, data, pr.ttl)
+		}
+	}
+
+	set := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		set[permission] = true
+	}
+	return set, nil
+}
+
+// InvalidateRole drops the cached permissions of a role so the next request reloads them
+func (pr *PermissionResolver) InvalidateRole(ctx context.Context, roleID uuid.UUID) error {
+	return pr.client.Del(ctx, permissionCacheKey(roleID)).Err()
+}
+
+// RequirePermission checks if the user's role grants the given resource action
+func (pr *PermissionResolver) RequirePermission(resource, action string) gin.HandlerFunc {
+	required := PermissionKey(resource, action)
+
+	return func(c *gin.Context) {
+		roleID, exists := GetRoleID(c)
+		if !exists {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "User role not found", nil)
+			c.Abort()
+			return
+		}
+
+		permissions, err := pr.GetPermissions(c.Request.Context(), roleID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to resolve permissions", err)
+			c.Abort()
+			return
+		}
+
+		if !permissions[required] {
+			utils.ErrorResponse(c, http.StatusForbidden, "Insufficient permissions", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
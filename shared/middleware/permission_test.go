@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const testPermissionTTL = 5 * time.Minute
+
+// countingLoader serves fixed role permissions and counts the loads
+type countingLoader struct {
+	permissions map[uuid.UUID][]string
+	err         error
+	loads       int
+}
+
+func (l *countingLoader) load(ctx context.Context, roleID uuid.UUID) ([]string, error) {
+	l.loads++
+	if l.err != nil {
+		return nil, l.err
+	}
+	return l.permissions[roleID], nil
+}
+
+func newTestPermissionResolver(t *testing.T, loader *countingLoader) (*PermissionResolver, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	// Without retries a stopped server fails requests right away
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1, DialerRetries: 1})
+	t.Cleanup(func() { client.Close() })
+	return NewPermissionResolver(client, loader.load, testPermissionTTL), server
+}
+
+// permissionRouter serves a route that requires courses:write, with the role
+// of the request taken from the X-Role-ID header
+func permissionRouter(resolver *PermissionResolver) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/courses",
+		func(c *gin.Context) {
+			if roleID, err := uuid.Parse(c.GetHeader("X-Role-ID")); err == nil {
+				c.Set("role_id", roleID)
+			}
+		},
+		resolver.RequirePermission("courses", "write"),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	return router
+}
+
+func requestAs(router *gin.Engine, roleID *uuid.UUID) int {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/courses", nil)
+	if roleID != nil {
+		req.Header.Set("X-Role-ID", roleID.String())
+	}
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestPermissionResolver_GetPermissions(t *testing.T) {
+	ctx := context.Background()
+	roleID := uuid.New()
+
+	t.Run("Cache Miss Loads And Caches", func(t *testing.T) {
+		loader := &countingLoader{permissions: map[uuid.UUID][]string{roleID: {"courses:read"}}}
+		resolver, server := newTestPermissionResolver(t, loader)
+
+		permissions, err := resolver.GetPermissions(ctx, roleID)
+		if err != nil {
+			t.Fatalf("GetPermissions() = %v", err)
+		}
+		if !permissions["courses:read"] || len(permissions) != 1 {
+			t.Errorf("GetPermissions() = %v, want [courses:read]", permissions)
+		}
+		if loader.loads != 1 {
+			t.Errorf("loads = %d, want 1", loader.loads)
+		}
+		if ttl := server.TTL(permissionCacheKey(roleID)); ttl != testPermissionTTL {
+			t.Errorf("cache ttl = %s, want %s", ttl, testPermissionTTL)
+		}
+	})
+
+	t.Run("Cache Hit", func(t *testing.T) {
+		loader := &countingLoader{}
+		resolver, server := newTestPermissionResolver(t, loader)
+		server.Set(permissionCacheKey(roleID), `["courses:read","courses:write"]`)
+
+		permissions, err := resolver.GetPermissions(ctx, roleID)
+		if err != nil {
+			t.Fatalf("GetPermissions() = %v", err)
+		}
+		if !permissions["courses:write"] {
+			t.Errorf("GetPermissions() = %v, want courses:write", permissions)
+		}
+		if loader.loads != 0 {
+			t.Errorf("loads = %d, want 0", loader.loads)
+		}
+	})
+
+	t.Run("Corrupt Cache Entry Reloaded", func(t *testing.T) {
+		loader := &countingLoader{permissions: map[uuid.UUID][]string{roleID: {"courses:read"}}}
+		resolver, server := newTestPermissionResolver(t, loader)
+		server.Set(permissionCacheKey(roleID), "not json")
+
+		permissions, err := resolver.GetPermissions(ctx, roleID)
+		if err != nil {
+			t.Fatalf("GetPermissions() = %v", err)
+		}
+		if !permissions["courses:read"] || loader.loads != 1 {
+			t.Errorf("GetPermissions() = %v with %d loads, want [courses:read] with 1", permissions, loader.loads)
+		}
+	})
+
+	t.Run("Loader Error", func(t *testing.T) {
+		loadErr := errors.New("database unavailable")
+		resolver, _ := newTestPermissionResolver(t, &countingLoader{err: loadErr})
+
+		if _, err := resolver.GetPermissions(ctx, roleID); !errors.Is(err, loadErr) {
+			t.Errorf("GetPermissions() = %v, want %v", err, loadErr)
+		}
+	})
+}
+
+func TestPermissionResolver_InvalidateRole(t *testing.T) {
+	ctx := context.Background()
+	roleID := uuid.New()
+	loader := &countingLoader{permissions: map[uuid.UUID][]string{roleID: {"courses:read"}}}
+	resolver, server := newTestPermissionResolver(t, loader)
+
+	if _, err := resolver.GetPermissions(ctx, roleID); err != nil {
+		t.Fatalf("GetPermissions() = %v", err)
+	}
+
+	// The role is granted a permission
+	loader.permissions[roleID] = []string{"courses:read", "courses:write"}
+	if err := resolver.InvalidateRole(ctx, roleID); err != nil {
+		t.Fatalf("InvalidateRole() = %v", err)
+	}
+	if server.Exists(permissionCacheKey(roleID)) {
+		t.Error("the cached permissions were kept")
+	}
+
+	permissions, err := resolver.GetPermissions(ctx, roleID)
+	if err != nil {
+		t.Fatalf("GetPermissions() = %v", err)
+	}
+	if !permissions["courses:write"] {
+		t.Errorf("GetPermissions() after invalidation = %v, want courses:write", permissions)
+	}
+	if loader.loads != 2 {
+		t.Errorf("loads = %d, want 2", loader.loads)
+	}
+}
+
+func TestPermissionResolver_RequirePermission(t *testing.T) {
+	writer := uuid.New()
+	reader := uuid.New()
+	permissions := map[uuid.UUID][]string{
+		writer: {"courses:read", "courses:write"},
+		reader: {"courses:read"},
+	}
+
+	t.Run("Granted", func(t *testing.T) {
+		resolver, _ := newTestPermissionResolver(t, &countingLoader{permissions: permissions})
+		if code := requestAs(permissionRouter(resolver), &writer); code != http.StatusOK {
+			t.Errorf("status = %d, want %d", code, http.StatusOK)
+		}
+	})
+
+	t.Run("Missing Permission", func(t *testing.T) {
+		resolver, _ := newTestPermissionResolver(t, &countingLoader{permissions: permissions})
+		if code := requestAs(permissionRouter(resolver), &reader); code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", code, http.StatusForbidden)
+		}
+	})
+
+	t.Run("Missing Role", func(t *testing.T) {
+		loader := &countingLoader{permissions: permissions}
+		resolver, _ := newTestPermissionResolver(t, loader)
+		if code := requestAs(permissionRouter(resolver), nil); code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", code, http.StatusUnauthorized)
+		}
+		if loader.loads != 0 {
+			t.Errorf("loads = %d, want 0", loader.loads)
+		}
+	})
+
+	t.Run("Redis Down", func(t *testing.T) {
+		loader := &countingLoader{permissions: permissions}
+		resolver, server := newTestPermissionResolver(t, loader)
+		server.Close()
+		router := permissionRouter(resolver)
+
+		// Permissions come from the loader, and are enforced all the same
+		if code := requestAs(router, &writer); code != http.StatusOK {
+			t.Errorf("status with the permission = %d, want %d", code, http.StatusOK)
+		}
+		if code := requestAs(router, &reader); code != http.StatusForbidden {
+			t.Errorf("status without the permission = %d, want %d", code, http.StatusForbidden)
+		}
+		if loader.loads != 2 {
+			t.Errorf("loads = %d, want 2", loader.loads)
+		}
+	})
+
+	t.Run("Redis And Loader Down", func(t *testing.T) {
+		resolver, server := newTestPermissionResolver(t, &countingLoader{err: errors.New("database unavailable")})
+		server.Close()
+
+		if code := requestAs(permissionRouter(resolver), &writer); code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", code, http.StatusInternalServerError)
+		}
+	})
+}
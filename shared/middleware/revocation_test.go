@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SureshAmal/NimbusU-backend/shared/utils"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const testMaxTokenAge = 15 * time.Minute
+
+func newTestRevocationList(t *testing.T) (*TokenRevocationList, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewTokenRevocationList(client, testMaxTokenAge), server
+}
+
+// testClaims returns the claims of a token issued to a user at issuedAt
+func testClaims(userID uuid.UUID, issuedAt time.Time) *utils.Claims {
+	return &utils.Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(testMaxTokenAge)),
+		},
+	}
+}
+
+func TestTokenRevocationList_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+	list, server := newTestRevocationList(t)
+
+	userID := uuid.New()
+	revoked := testClaims(userID, time.Now())
+	other := testClaims(userID, time.Now())
+
+	if err := list.RevokeToken(ctx, revoked.ID, revoked.ExpiresAt.Time); err != nil {
+		t.Fatalf("RevokeToken() = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		claims *utils.Claims
+		want   bool
+	}{
+		{name: "Denylisted Jti", claims: revoked, want: true},
+		{name: "Other Jti Of The User", claims: other, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := list.IsRevoked(ctx, tt.claims)
+			if err != nil {
+				t.Fatalf("IsRevoked() = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Kept Until Expiry", func(t *testing.T) {
+		ttl := server.TTL(revokedTokenKey(revoked.ID))
+		if ttl <= 0 || ttl > testMaxTokenAge {
+			t.Errorf("denylist ttl = %s, want up to %s", ttl, testMaxTokenAge)
+		}
+
+		server.FastForward(testMaxTokenAge)
+		got, err := list.IsRevoked(ctx, revoked)
+		if err != nil {
+			t.Fatalf("IsRevoked() = %v", err)
+		}
+		if got {
+			t.Error("IsRevoked() after expiry = true, want false")
+		}
+	})
+
+	t.Run("Expired Token Not Stored", func(t *testing.T) {
+		expired := testClaims(userID, time.Now().Add(-time.Hour))
+		if err := list.RevokeToken(ctx, expired.ID, expired.ExpiresAt.Time); err != nil {
+			t.Fatalf("RevokeToken() = %v", err)
+		}
+		if server.Exists(revokedTokenKey(expired.ID)) {
+			t.Error("an expired token was denylisted")
+		}
+	})
+}
+
+func TestTokenRevocationList_RevokeUserTokens(t *testing.T) {
+	ctx := context.Background()
+	list, server := newTestRevocationList(t)
+
+	userID := uuid.New()
+	if err := list.RevokeUserTokens(ctx, userID); err != nil {
+		t.Fatalf("RevokeUserTokens() = %v", err)
+	}
+	watermark, err := strconv.ParseInt(mustGet(t, server, tokensRevokedAtKey(userID)), 10, 64)
+	if err != nil {
+		t.Fatalf("watermark = %v", err)
+	}
+	revokedAt := time.UnixMilli(watermark)
+
+	noIssueTime := testClaims(userID, revokedAt)
+	noIssueTime.IssuedAt = nil
+
+	tests := []struct {
+		name   string
+		claims *utils.Claims
+		want   bool
+	}{
+		{name: "Issued Before", claims: testClaims(userID, revokedAt.Add(-time.Minute)), want: true},
+		{name: "Issued At The Revocation", claims: testClaims(userID, revokedAt), want: true},
+		{name: "Without Issue Time", claims: noIssueTime, want: true},
+		{name: "Issued After", claims: testClaims(userID, revokedAt.Add(time.Millisecond)), want: false},
+		{name: "Other User", claims: testClaims(uuid.New(), revokedAt.Add(-time.Minute)), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := list.IsRevoked(ctx, tt.claims)
+			if err != nil {
+				t.Fatalf("IsRevoked() = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Same Second", func(t *testing.T) {
+		// Revoke half way through a second: tokens from earlier in that second
+		// are rejected, a login later in the same second is not
+		userID := uuid.New()
+		second := time.Now().Truncate(time.Second)
+		server.Set(tokensRevokedAtKey(userID), strconv.FormatInt(second.Add(500*time.Millisecond).UnixMilli(), 10))
+
+		revoked, err := list.IsRevoked(ctx, testClaims(userID, second.Add(200*time.Millisecond)))
+		if err != nil {
+			t.Fatalf("IsRevoked() = %v", err)
+		}
+		if !revoked {
+			t.Error("IsRevoked(issued earlier in the second) = false, want true")
+		}
+
+		revoked, err = list.IsRevoked(ctx, testClaims(userID, second.Add(800*time.Millisecond)))
+		if err != nil {
+			t.Fatalf("IsRevoked() = %v", err)
+		}
+		if revoked {
+			t.Error("IsRevoked(issued later in the second) = true, want false")
+		}
+	})
+
+	t.Run("Kept For The Token Lifetime", func(t *testing.T) {
+		if ttl := server.TTL(tokensRevokedAtKey(userID)); ttl != testMaxTokenAge {
+			t.Errorf("watermark ttl = %s, want %s", ttl, testMaxTokenAge)
+		}
+	})
+}
+
+// mustGet returns the value of a key in server or fails the test
+func mustGet(t *testing.T, server *miniredis.Miniredis, key string) string {
+	t.Helper()
+	value, err := server.Get(key)
+	if err != nil {
+		t.Fatalf("Get(%s) = %v", key, err)
+	}
+	return value
+}
+
+func TestAuthMiddleware_Revoked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	list, _ := newTestRevocationList(t)
+
+	ring := utils.NewKeyRing()
+	key, err := utils.GenerateSigningKey(utils.AlgorithmEdDSA, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("GenerateSigningKey() = %v", err)
+	}
+	ring.SetKeys([]*utils.SigningKey{key})
+	jwtManager := utils.NewJWTManager(ring, int(testMaxTokenAge/time.Second), 3600)
+
+	router := gin.New()
+	router.GET("/me", AuthMiddleware(jwtManager, list), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	request := func(token string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	userID := uuid.New()
+	token, err := jwtManager.GenerateAccessToken(userID, "student@example.com", uuid.New(), "student")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() = %v", err)
+	}
+	if code := request(token); code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", code, http.StatusOK)
+	}
+
+	claims, err := jwtManager.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() = %v", err)
+	}
+	if err := list.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		t.Fatalf("RevokeToken() = %v", err)
+	}
+	if code := request(token); code != http.StatusUnauthorized {
+		t.Errorf("status of a revoked token = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	t.Run("Login Right After Revoking The User", func(t *testing.T) {
+		userID := uuid.New()
+		old, err := jwtManager.GenerateAccessToken(userID, "student@example.com", uuid.New(), "student")
+		if err != nil {
+			t.Fatalf("GenerateAccessToken() = %v", err)
+		}
+		if err := list.RevokeUserTokens(ctx, userID); err != nil {
+			t.Fatalf("RevokeUserTokens() = %v", err)
+		}
+		// Issue times have millisecond precision, so leave the revocation's millisecond
+		time.Sleep(2 * time.Millisecond)
+		relogin, err := jwtManager.GenerateAccessToken(userID, "student@example.com", uuid.New(), "student")
+		if err != nil {
+			t.Fatalf("GenerateAccessToken() = %v", err)
+		}
+
+		if code := request(old); code != http.StatusUnauthorized {
+			t.Errorf("status of a token issued before = %d, want %d", code, http.StatusUnauthorized)
+		}
+		if code := request(relogin); code != http.StatusOK {
+			t.Errorf("status of a token issued after = %d, want %d", code, http.StatusOK)
+		}
+	})
+}
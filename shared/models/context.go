@@ -0,0 +1,40 @@
+package models
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type eventContextKey struct{}
+
+// EventContext carries the tracing fields that events published while handling
+// a request or a consumed event inherit, see BaseEvent.Stamp
+type EventContext struct {
+	CorrelationID string
+	CausationID   string
+	ActorID       uuid.UUID
+	TenantID      string
+}
+
+// WithEventContext returns a copy of ctx carrying ec
+func WithEventContext(ctx context.Context, ec EventContext) context.Context {
+	return context.WithValue(ctx, eventContextKey{}, ec)
+}
+
+// EventContextFrom returns the event context of ctx, or an empty one
+func EventContextFrom(ctx context.Context) EventContext {
+	ec, _ := ctx.Value(eventContextKey{}).(EventContext)
+	return ec
+}
+
+// WithCause returns a copy of ctx whose event context makes events published
+// while handling cause part of its correlation chain, caused by it
+func WithCause(ctx context.Context, cause *BaseEvent) context.Context {
+	return WithEventContext(ctx, EventContext{
+		CorrelationID: cause.CorrelationID,
+		CausationID:   cause.EventID.String(),
+		ActorID:       cause.ActorID,
+		TenantID:      cause.TenantID,
+	})
+}
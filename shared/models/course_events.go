@@ -0,0 +1,324 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Department, program and subject events
+	EventDepartmentCreated EventType = "DEPARTMENT_CREATED"
+	EventDepartmentUpdated EventType = "DEPARTMENT_UPDATED"
+	EventDepartmentDeleted EventType = "DEPARTMENT_DELETED"
+	EventProgramCreated    EventType = "PROGRAM_CREATED"
+	EventProgramUpdated    EventType = "PROGRAM_UPDATED"
+	EventProgramDeleted    EventType = "PROGRAM_DELETED"
+	EventSubjectCreated    EventType = "SUBJECT_CREATED"
+	EventSubjectUpdated    EventType = "SUBJECT_UPDATED"
+	EventSubjectDeleted    EventType = "SUBJECT_DELETED"
+
+	// Semester events
+	EventSemesterCreated        EventType = "SEMESTER_CREATED"
+	EventSemesterUpdated        EventType = "SEMESTER_UPDATED"
+	EventSemesterDeleted        EventType = "SEMESTER_DELETED"
+	EventCurrentSemesterChanged EventType = "CURRENT_SEMESTER_CHANGED"
+	EventSemesterRolledOver     EventType = "SEMESTER_ROLLED_OVER"
+
+	// Course events
+	EventCourseCreated     EventType = "COURSE_CREATED"
+	EventCourseUpdated     EventType = "COURSE_UPDATED"
+	EventCourseDeleted     EventType = "COURSE_DELETED"
+	EventCourseActivated   EventType = "COURSE_ACTIVATED"
+	EventCourseDeactivated EventType = "COURSE_DEACTIVATED"
+
+	// Faculty and student events
+	EventFacultyCreated  EventType = "FACULTY_CREATED"
+	EventFacultyUpdated  EventType = "FACULTY_UPDATED"
+	EventFacultyDeleted  EventType = "FACULTY_DELETED"
+	EventStudentCreated  EventType = "STUDENT_CREATED"
+	EventStudentUpdated  EventType = "STUDENT_UPDATED"
+	EventStudentDeleted  EventType = "STUDENT_DELETED"
+	EventStudentPromoted EventType = "STUDENT_PROMOTED"
+
+	// Faculty assignment events
+	EventFacultyAssigned          EventType = "FACULTY_ASSIGNED"
+	EventFacultyAssignmentUpdated EventType = "FACULTY_ASSIGNMENT_UPDATED"
+	EventFacultyUnassigned        EventType = "FACULTY_UNASSIGNED"
+
+	// Enrollment events
+	EventStudentEnrolled     EventType = "STUDENT_ENROLLED"
+	EventWaitlistAdded       EventType = "WAITLIST_ADDED"
+	EventWaitlistPromoted    EventType = "WAITLIST_PROMOTED"
+	EventStudentDropped      EventType = "STUDENT_DROPPED"
+	EventStudentWithdrawn    EventType = "STUDENT_WITHDRAWN"
+	EventEnrollmentUpdated   EventType = "ENROLLMENT_UPDATED"
+	EventEnrollmentCompleted EventType = "ENROLLMENT_COMPLETED"
+
+	// Gradebook events
+	EventGradesSubmitted         EventType = "GRADES_SUBMITTED"
+	EventGradesReturned          EventType = "GRADES_RETURNED"
+	EventGradesApproved          EventType = "GRADES_APPROVED"
+	EventGradeAmendmentRequested EventType = "GRADE_AMENDMENT_REQUESTED"
+	EventGradeAmendmentApproved  EventType = "GRADE_AMENDMENT_APPROVED"
+	EventGradeAmendmentRejected  EventType = "GRADE_AMENDMENT_REJECTED"
+
+	// Academic calendar events
+	EventCalendarEventCreated EventType = "CALENDAR_EVENT_CREATED"
+	EventCalendarEventUpdated EventType = "CALENDAR_EVENT_UPDATED"
+	EventCalendarEventDeleted EventType = "CALENDAR_EVENT_DELETED"
+)
+
+// DepartmentEvent represents department events
+type DepartmentEvent struct {
+	BaseEvent
+	DepartmentID   uuid.UUID `json:"department_id"`
+	DepartmentName string    `json:"department_name,omitempty"`
+	DepartmentCode string    `json:"department_code,omitempty"`
+}
+
+// ProgramEvent represents program events
+type ProgramEvent struct {
+	BaseEvent
+	ProgramID    uuid.UUID `json:"program_id"`
+	ProgramName  string    `json:"program_name,omitempty"`
+	ProgramCode  string    `json:"program_code,omitempty"`
+	DepartmentID uuid.UUID `json:"department_id,omitempty"`
+}
+
+// SubjectEvent represents subject events
+type SubjectEvent struct {
+	BaseEvent
+	SubjectID    uuid.UUID `json:"subject_id"`
+	SubjectName  string    `json:"subject_name,omitempty"`
+	SubjectCode  string    `json:"subject_code,omitempty"`
+	DepartmentID uuid.UUID `json:"department_id,omitempty"`
+	Credits      int       `json:"credits,omitempty"`
+}
+
+// SemesterEvent represents semester events
+type SemesterEvent struct {
+	BaseEvent
+	SemesterID   uuid.UUID `json:"semester_id"`
+	SemesterName string    `json:"semester_name,omitempty"`
+	AcademicYear int       `json:"academic_year,omitempty"`
+}
+
+// SemesterRolloverEvent summarises a completed semester rollover: the courses
+// of the semester completed, the waitlisted enrollments dropped and the
+// students promoted or flagged for review
+type SemesterRolloverEvent struct {
+	BaseEvent
+	RolloverID         uuid.UUID `json:"rollover_id"`
+	SemesterID         uuid.UUID `json:"semester_id"`
+	NextSemesterID     uuid.UUID `json:"next_semester_id"`
+	CoursesCompleted   int       `json:"courses_completed"`
+	EnrollmentsDropped int       `json:"enrollments_dropped"`
+	StudentsPromoted   int       `json:"students_promoted"`
+	StudentsFlagged    int       `json:"students_flagged"`
+}
+
+// CourseEvent represents course events
+type CourseEvent struct {
+	BaseEvent
+	CourseID     uuid.UUID `json:"course_id"`
+	CourseCode   string    `json:"course_code,omitempty"`
+	CourseName   string    `json:"course_name,omitempty"`
+	SubjectID    uuid.UUID `json:"subject_id,omitempty"`
+	SemesterID   uuid.UUID `json:"semester_id,omitempty"`
+	DepartmentID uuid.UUID `json:"department_id,omitempty"`
+}
+
+// FacultyEvent represents faculty member events
+type FacultyEvent struct {
+	BaseEvent
+	FacultyID    uuid.UUID `json:"faculty_id"`
+	UserID       uuid.UUID `json:"user_id,omitempty"`
+	EmployeeID   string    `json:"employee_id,omitempty"`
+	DepartmentID uuid.UUID `json:"department_id,omitempty"`
+}
+
+// StudentEvent represents student events
+type StudentEvent struct {
+	BaseEvent
+	StudentID          uuid.UUID `json:"student_id"`
+	UserID             uuid.UUID `json:"user_id,omitempty"`
+	RegistrationNumber string    `json:"registration_number,omitempty"`
+	DepartmentID       uuid.UUID `json:"department_id,omitempty"`
+	ProgramID          uuid.UUID `json:"program_id,omitempty"`
+	NewSemester        int       `json:"new_semester,omitempty"`
+	CGPA               *float64  `json:"cgpa,omitempty"`
+}
+
+// FacultyAssignmentEvent represents events of faculty being assigned to or
+// removed from a course
+type FacultyAssignmentEvent struct {
+	BaseEvent
+	FacultyID       uuid.UUID `json:"faculty_id"`
+	CourseID        uuid.UUID `json:"course_id"`
+	FacultyCourseID uuid.UUID `json:"faculty_course_id,omitempty"`
+	Role            string    `json:"role,omitempty"`
+	IsPrimary       bool      `json:"is_primary,omitempty"`
+}
+
+// EnrollmentEvent represents course enrollment events
+type EnrollmentEvent struct {
+	BaseEvent
+	EnrollmentID     uuid.UUID `json:"enrollment_id"`
+	StudentID        uuid.UUID `json:"student_id"`
+	CourseID         uuid.UUID `json:"course_id"`
+	Status           string    `json:"status"`
+	WaitlistPosition *int      `json:"waitlist_position,omitempty"`
+	Grade            *string   `json:"grade,omitempty"`
+	GradePoints      *float64  `json:"grade_points,omitempty"`
+	Reason           string    `json:"reason,omitempty"`
+}
+
+// GradebookEvent represents events of the grades of a course roster being
+// submitted, returned to the faculty or approved and released
+type GradebookEvent struct {
+	BaseEvent
+	CourseID     uuid.UUID `json:"course_id"`
+	Status       string    `json:"status"`
+	StudentCount int       `json:"student_count,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+}
+
+// GradeAmendmentEvent represents events of a change to an approved grade being
+// requested, approved or rejected
+type GradeAmendmentEvent struct {
+	BaseEvent
+	AmendmentID   uuid.UUID `json:"amendment_id"`
+	EnrollmentID  uuid.UUID `json:"enrollment_id"`
+	StudentID     uuid.UUID `json:"student_id"`
+	CourseID      uuid.UUID `json:"course_id"`
+	Status        string    `json:"status"`
+	PreviousGrade *string   `json:"previous_grade,omitempty"`
+	NewGrade      string    `json:"new_grade"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+// CalendarEvent represents academic calendar events. The calendar entry's own
+// ID and type are prefixed to keep them apart from the envelope's.
+type CalendarEvent struct {
+	BaseEvent
+	CalendarEventID   uuid.UUID  `json:"calendar_event_id"`
+	SemesterID        uuid.UUID  `json:"semester_id,omitempty"`
+	EventName         string     `json:"event_name,omitempty"`
+	CalendarEventType string     `json:"calendar_event_type,omitempty"`
+	StartDate         *time.Time `json:"start_date,omitempty"`
+}
+
+// NewDepartmentEvent creates a new department event
+func NewDepartmentEvent(eventType EventType, departmentID uuid.UUID) *DepartmentEvent {
+	return &DepartmentEvent{
+		BaseEvent:    newBaseEvent(eventType, "course-service"),
+		DepartmentID: departmentID,
+	}
+}
+
+// NewProgramEvent creates a new program event
+func NewProgramEvent(eventType EventType, programID uuid.UUID) *ProgramEvent {
+	return &ProgramEvent{
+		BaseEvent: newBaseEvent(eventType, "course-service"),
+		ProgramID: programID,
+	}
+}
+
+// NewSubjectEvent creates a new subject event
+func NewSubjectEvent(eventType EventType, subjectID uuid.UUID) *SubjectEvent {
+	return &SubjectEvent{
+		BaseEvent: newBaseEvent(eventType, "course-service"),
+		SubjectID: subjectID,
+	}
+}
+
+// NewSemesterEvent creates a new semester event
+func NewSemesterEvent(eventType EventType, semesterID uuid.UUID) *SemesterEvent {
+	return &SemesterEvent{
+		BaseEvent:  newBaseEvent(eventType, "course-service"),
+		SemesterID: semesterID,
+	}
+}
+
+// NewSemesterRolloverEvent creates a new semester rollover summary event
+func NewSemesterRolloverEvent(rolloverID, semesterID uuid.UUID) *SemesterRolloverEvent {
+	return &SemesterRolloverEvent{
+		BaseEvent:  newBaseEvent(EventSemesterRolledOver, "course-service"),
+		RolloverID: rolloverID,
+		SemesterID: semesterID,
+	}
+}
+
+// NewCourseEvent creates a new course event
+func NewCourseEvent(eventType EventType, courseID uuid.UUID) *CourseEvent {
+	return &CourseEvent{
+		BaseEvent: newBaseEvent(eventType, "course-service"),
+		CourseID:  courseID,
+	}
+}
+
+// NewFacultyEvent creates a new faculty event
+func NewFacultyEvent(eventType EventType, facultyID uuid.UUID) *FacultyEvent {
+	return &FacultyEvent{
+		BaseEvent: newBaseEvent(eventType, "course-service"),
+		FacultyID: facultyID,
+	}
+}
+
+// NewStudentEvent creates a new student event
+func NewStudentEvent(eventType EventType, studentID uuid.UUID) *StudentEvent {
+	return &StudentEvent{
+		BaseEvent: newBaseEvent(eventType, "course-service"),
+		StudentID: studentID,
+	}
+}
+
+// NewFacultyAssignmentEvent creates a new faculty assignment event
+func NewFacultyAssignmentEvent(eventType EventType, facultyID, courseID uuid.UUID) *FacultyAssignmentEvent {
+	return &FacultyAssignmentEvent{
+		BaseEvent: newBaseEvent(eventType, "course-service"),
+		FacultyID: facultyID,
+		CourseID:  courseID,
+	}
+}
+
+// NewEnrollmentEvent creates a new enrollment event
+func NewEnrollmentEvent(eventType EventType, enrollmentID, studentID, courseID uuid.UUID, status string) *EnrollmentEvent {
+	return &EnrollmentEvent{
+		BaseEvent:    newBaseEvent(eventType, "course-service"),
+		EnrollmentID: enrollmentID,
+		StudentID:    studentID,
+		CourseID:     courseID,
+		Status:       status,
+	}
+}
+
+// NewGradebookEvent creates a new gradebook event
+func NewGradebookEvent(eventType EventType, courseID uuid.UUID, status string) *GradebookEvent {
+	return &GradebookEvent{
+		BaseEvent: newBaseEvent(eventType, "course-service"),
+		CourseID:  courseID,
+		Status:    status,
+	}
+}
+
+// NewGradeAmendmentEvent creates a new grade amendment event
+func NewGradeAmendmentEvent(eventType EventType, amendmentID, enrollmentID, studentID, courseID uuid.UUID, status string) *GradeAmendmentEvent {
+	return &GradeAmendmentEvent{
+		BaseEvent:    newBaseEvent(eventType, "course-service"),
+		AmendmentID:  amendmentID,
+		EnrollmentID: enrollmentID,
+		StudentID:    studentID,
+		CourseID:     courseID,
+		Status:       status,
+	}
+}
+
+// NewCalendarEvent creates a new academic calendar event
+func NewCalendarEvent(eventType EventType, calendarEventID uuid.UUID) *CalendarEvent {
+	return &CalendarEvent{
+		BaseEvent:       newBaseEvent(eventType, "course-service"),
+		CalendarEventID: calendarEventID,
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,19 +19,79 @@ const (
 	EventUserSuspended EventType = "USER_SUSPENDED"
 
 	// Auth events
-	EventLoginSuccess    EventType = "LOGIN_SUCCESS"
-	EventLoginFailed     EventType = "LOGIN_FAILED"
-	EventLogout          EventType = "LOGOUT"
-	EventPasswordChanged EventType = "PASSWORD_CHANGED"
+	EventLoginSuccess       EventType = "LOGIN_SUCCESS"
+	EventLoginFailed        EventType = "LOGIN_FAILED"
+	EventLogout             EventType = "LOGOUT"
+	EventPasswordChanged    EventType = "PASSWORD_CHANGED"
+	EventLoginLocked        EventType = "LOGIN_LOCKED"
+	EventRefreshTokenReused EventType = "REFRESH_TOKEN_REUSED"
+
+	// Role events
+	EventRoleCreated       EventType = "ROLE_CREATED"
+	EventRoleUpdated       EventType = "ROLE_UPDATED"
+	EventRoleDeleted       EventType = "ROLE_DELETED"
+	EventPermissionGranted EventType = "PERMISSION_GRANTED"
+	EventPermissionRevoked EventType = "PERMISSION_REVOKED"
+
+	// Notification commands
+	EventSendNotification EventType = "SEND_NOTIFICATION"
 )
 
-// BaseEvent represents common fields for all events
+// BaseEvent is the envelope every event carries. SchemaVersion is the version
+// of the event type's schema the payload conforms to (see ValidateEvent).
+// CorrelationID ties together all events caused by the same request, and
+// CausationID is the ID of the event that caused this one, if any.
 type BaseEvent struct {
-	EventID     uuid.UUID              `json:"event_id"`
-	EventType   EventType              `json:"event_type"`
-	Timestamp   time.Time              `json:"timestamp"`
-	ServiceName string                 `json:"service_name"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	EventID       uuid.UUID              `json:"event_id"`
+	EventType     EventType              `json:"event_type"`
+	SchemaVersion int                    `json:"schema_version"`
+	Timestamp     time.Time              `json:"timestamp"`
+	ServiceName   string                 `json:"service_name"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	CausationID   string                 `json:"causation_id,omitempty"`
+	ActorID       uuid.UUID              `json:"actor_id,omitempty"`
+	TenantID      string                 `json:"tenant_id,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// newBaseEvent creates the envelope of a new event at the current schema
+// version of its type
+func newBaseEvent(eventType EventType, serviceName string) BaseEvent {
+	return BaseEvent{
+		EventID:       uuid.New(),
+		EventType:     eventType,
+		SchemaVersion: eventSchemas[eventType].version,
+		Timestamp:     time.Now(),
+		ServiceName:   serviceName,
+	}
+}
+
+// Envelope returns the envelope of an event, letting producers stamp the
+// tracing fields of any event type that embeds BaseEvent
+func (e *BaseEvent) Envelope() *BaseEvent {
+	return e
+}
+
+// Stamp fills the tracing fields the event does not set yet from the event
+// context of ctx. An event without a correlation ID starts a new chain, so it
+// is correlated with itself.
+func (e *BaseEvent) Stamp(ctx context.Context) {
+	ec := EventContextFrom(ctx)
+	if e.CorrelationID == "" {
+		e.CorrelationID = ec.CorrelationID
+	}
+	if e.CorrelationID == "" {
+		e.CorrelationID = e.EventID.String()
+	}
+	if e.CausationID == "" {
+		e.CausationID = ec.CausationID
+	}
+	if e.ActorID == uuid.Nil {
+		e.ActorID = ec.ActorID
+	}
+	if e.TenantID == "" {
+		e.TenantID = ec.TenantID
+	}
 }
 
 // UserEvent represents user-related events
@@ -40,6 +101,7 @@ type UserEvent struct {
 	Email     string    `json:"email"`
 	FirstName string    `json:"first_name,omitempty"`
 	LastName  string    `json:"last_name,omitempty"`
+	Phone     string    `json:"phone,omitempty"`
 	RoleID    uuid.UUID `json:"role_id,omitempty"`
 	Status    string    `json:"status,omitempty"`
 }
@@ -55,29 +117,46 @@ type AuthEvent struct {
 	ErrorReason string    `json:"error_reason,omitempty"`
 }
 
+// RoleEvent represents role and role-permission change events
+type RoleEvent struct {
+	BaseEvent
+	RoleID         uuid.UUID `json:"role_id"`
+	RoleName       string    `json:"role_name"`
+	PermissionID   uuid.UUID `json:"permission_id,omitempty"`
+	PermissionName string    `json:"permission_name,omitempty"`
+}
+
+// NotificationCommand asks the notification service to notify a user, or every
+// user holding one of RecipientRoles
+type NotificationCommand struct {
+	BaseEvent
+	NotificationID   uuid.UUID              `json:"notification_id"`
+	RecipientUserID  uuid.UUID              `json:"recipient_user_id,omitempty"`
+	RecipientEmail   string                 `json:"recipient_email,omitempty"`
+	RecipientRoles   []string               `json:"recipient_roles,omitempty"`
+	NotificationType string                 `json:"notification_type"`
+	Title            string                 `json:"title"`
+	Message          string                 `json:"message"`
+	Channels         []string               `json:"channels"`
+	Priority         string                 `json:"priority"`
+	TemplateID       string                 `json:"template_id,omitempty"`
+	TemplateData     map[string]interface{} `json:"template_data,omitempty"`
+	ActionURL        string                 `json:"action_url,omitempty"`
+}
+
 // NewUserEvent creates a new user event
 func NewUserEvent(eventType EventType, userID uuid.UUID, email string) *UserEvent {
 	return &UserEvent{
-		BaseEvent: BaseEvent{
-			EventID:     uuid.New(),
-			EventType:   eventType,
-			Timestamp:   time.Now(),
-			ServiceName: "user-service",
-		},
-		UserID: userID,
-		Email:  email,
+		BaseEvent: newBaseEvent(eventType, "user-service"),
+		UserID:    userID,
+		Email:     email,
 	}
 }
 
 // NewAuthEvent creates a new auth event
 func NewAuthEvent(eventType EventType, userID uuid.UUID, email, ipAddress, userAgent string, success bool) *AuthEvent {
 	return &AuthEvent{
-		BaseEvent: BaseEvent{
-			EventID:     uuid.New(),
-			EventType:   eventType,
-			Timestamp:   time.Now(),
-			ServiceName: "user-service",
-		},
+		BaseEvent: newBaseEvent(eventType, "user-service"),
 		UserID:    userID,
 		Email:     email,
 		IPAddress: ipAddress,
@@ -85,3 +164,25 @@ func NewAuthEvent(eventType EventType, userID uuid.UUID, email, ipAddress, userA
 		Success:   success,
 	}
 }
+
+// NewRoleEvent creates a new role event
+func NewRoleEvent(eventType EventType, roleID uuid.UUID, roleName string) *RoleEvent {
+	return &RoleEvent{
+		BaseEvent: newBaseEvent(eventType, "user-service"),
+		RoleID:    roleID,
+		RoleName:  roleName,
+	}
+}
+
+// NewNotificationCommand creates a new notification command
+func NewNotificationCommand(serviceName, notificationType, title, message string) *NotificationCommand {
+	return &NotificationCommand{
+		BaseEvent:        newBaseEvent(EventSendNotification, serviceName),
+		NotificationID:   uuid.New(),
+		NotificationType: notificationType,
+		Title:            title,
+		Message:          message,
+		Channels:         []string{"email", "in_app"},
+		Priority:         "normal",
+	}
+}
@@ -0,0 +1,221 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+	schemagen "github.com/invopop/jsonschema"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var (
+	ErrUnknownEventType         = errors.New("unknown event type")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+)
+
+// eventSchema is the current schema version of an event type and the struct its
+// events are encoded from
+type eventSchema struct {
+	version   int
+	prototype interface{}
+}
+
+// eventSchemas registers every event type. The JSON Schema of an event type is
+// generated from its struct: fields tagged omitempty are optional, all others
+// are required, and unknown fields are allowed so consumers accept events with
+// fields added later. Bump the version of an event type when its struct loses,
+// renames or retypes a field.
+var eventSchemas = map[EventType]eventSchema{
+	EventUserCreated:   {1, UserEvent{}},
+	EventUserUpdated:   {1, UserEvent{}},
+	EventUserDeleted:   {1, UserEvent{}},
+	EventUserActivated: {1, UserEvent{}},
+	EventUserSuspended: {1, UserEvent{}},
+
+	EventLoginSuccess:       {1, AuthEvent{}},
+	EventLoginFailed:        {1, AuthEvent{}},
+	EventLogout:             {1, AuthEvent{}},
+	EventPasswordChanged:    {1, AuthEvent{}},
+	EventLoginLocked:        {1, AuthEvent{}},
+	EventRefreshTokenReused: {1, AuthEvent{}},
+
+	EventRoleCreated:       {1, RoleEvent{}},
+	EventRoleUpdated:       {1, RoleEvent{}},
+	EventRoleDeleted:       {1, RoleEvent{}},
+	EventPermissionGranted: {1, RoleEvent{}},
+	EventPermissionRevoked: {1, RoleEvent{}},
+
+	EventSendNotification: {1, NotificationCommand{}},
+
+	EventDepartmentCreated: {1, DepartmentEvent{}},
+	EventDepartmentUpdated: {1, DepartmentEvent{}},
+	EventDepartmentDeleted: {1, DepartmentEvent{}},
+	EventProgramCreated:    {1, ProgramEvent{}},
+	EventProgramUpdated:    {1, ProgramEvent{}},
+	EventProgramDeleted:    {1, ProgramEvent{}},
+	EventSubjectCreated:    {1, SubjectEvent{}},
+	EventSubjectUpdated:    {1, SubjectEvent{}},
+	EventSubjectDeleted:    {1, SubjectEvent{}},
+
+	EventSemesterCreated:        {1, SemesterEvent{}},
+	EventSemesterUpdated:        {1, SemesterEvent{}},
+	EventSemesterDeleted:        {1, SemesterEvent{}},
+	EventCurrentSemesterChanged: {1, SemesterEvent{}},
+	EventSemesterRolledOver:     {1, SemesterRolloverEvent{}},
+
+	EventCourseCreated:     {1, CourseEvent{}},
+	EventCourseUpdated:     {1, CourseEvent{}},
+	EventCourseDeleted:     {1, CourseEvent{}},
+	EventCourseActivated:   {1, CourseEvent{}},
+	EventCourseDeactivated: {1, CourseEvent{}},
+
+	EventFacultyCreated:  {1, FacultyEvent{}},
+	EventFacultyUpdated:  {1, FacultyEvent{}},
+	EventFacultyDeleted:  {1, FacultyEvent{}},
+	EventStudentCreated:  {1, StudentEvent{}},
+	EventStudentUpdated:  {1, StudentEvent{}},
+	EventStudentDeleted:  {1, StudentEvent{}},
+	EventStudentPromoted: {1, StudentEvent{}},
+
+	EventFacultyAssigned:          {1, FacultyAssignmentEvent{}},
+	EventFacultyAssignmentUpdated: {1, FacultyAssignmentEvent{}},
+	EventFacultyUnassigned:        {1, FacultyAssignmentEvent{}},
+
+	EventStudentEnrolled:     {1, EnrollmentEvent{}},
+	EventWaitlistAdded:       {1, EnrollmentEvent{}},
+	EventWaitlistPromoted:    {1, EnrollmentEvent{}},
+	EventStudentDropped:      {1, EnrollmentEvent{}},
+	EventStudentWithdrawn:    {1, EnrollmentEvent{}},
+	EventEnrollmentUpdated:   {1, EnrollmentEvent{}},
+	EventEnrollmentCompleted: {1, EnrollmentEvent{}},
+
+	EventGradesSubmitted:         {1, GradebookEvent{}},
+	EventGradesReturned:          {1, GradebookEvent{}},
+	EventGradesApproved:          {1, GradebookEvent{}},
+	EventGradeAmendmentRequested: {1, GradeAmendmentEvent{}},
+	EventGradeAmendmentApproved:  {1, GradeAmendmentEvent{}},
+	EventGradeAmendmentRejected:  {1, GradeAmendmentEvent{}},
+
+	EventCalendarEventCreated: {1, CalendarEvent{}},
+	EventCalendarEventUpdated: {1, CalendarEvent{}},
+	EventCalendarEventDeleted: {1, CalendarEvent{}},
+}
+
+var (
+	compileOnce     sync.Once
+	compiledSchemas map[EventType]*jsonschema.Schema
+	compileErr      error
+)
+
+// EventSchema returns the JSON Schema of the current version of an event type
+func EventSchema(eventType EventType) (*schemagen.Schema, error) {
+	def, ok := eventSchemas[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+	}
+
+	reflector := &schemagen.Reflector{
+		Anonymous:                 true,
+		DoNotReference:            true,
+		AllowAdditionalProperties: true,
+		Mapper: func(t reflect.Type) *schemagen.Schema {
+			if t == reflect.TypeOf(uuid.UUID{}) {
+				return &schemagen.Schema{Type: "string", Format: "uuid"}
+			}
+			return nil
+		},
+	}
+
+	schema := reflector.Reflect(def.prototype)
+	schema.Title = string(eventType)
+	if property, ok := schema.Properties.Get("event_type"); ok {
+		property.Const = string(eventType)
+	}
+	if property, ok := schema.Properties.Get("schema_version"); ok {
+		property.Const = def.version
+	}
+
+	return schema, nil
+}
+
+// ValidateEvent checks an encoded event against the schema of its event type
+// and version
+func ValidateEvent(data []byte) error {
+	var envelope struct {
+		EventType     EventType `json:"event_type"`
+		SchemaVersion int       `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	def, ok := eventSchemas[envelope.EventType]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownEventType, envelope.EventType)
+	}
+	if envelope.SchemaVersion != def.version {
+		return fmt.Errorf("%w: %s v%d", ErrUnsupportedSchemaVersion, envelope.EventType, envelope.SchemaVersion)
+	}
+
+	compileOnce.Do(compileEventSchemas)
+	if compileErr != nil {
+		return compileErr
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decode event: %w", err)
+	}
+	if err := compiledSchemas[envelope.EventType].Validate(instance); err != nil {
+		return fmt.Errorf("event %s does not match its schema: %w", envelope.EventType, err)
+	}
+
+	return nil
+}
+
+// compileEventSchemas generates and compiles the schemas of all event types
+func compileEventSchemas() {
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+
+	for eventType := range eventSchemas {
+		schema, err := EventSchema(eventType)
+		if err != nil {
+			compileErr = err
+			return
+		}
+		encoded, err := json.Marshal(schema)
+		if err != nil {
+			compileErr = fmt.Errorf("failed to encode %s schema: %w", eventType, err)
+			return
+		}
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(encoded))
+		if err != nil {
+			compileErr = fmt.Errorf("failed to decode %s schema: %w", eventType, err)
+			return
+		}
+		if err := compiler.AddResource(schemaURL(eventType), doc); err != nil {
+			compileErr = fmt.Errorf("failed to add %s schema: %w", eventType, err)
+			return
+		}
+	}
+
+	compiledSchemas = make(map[EventType]*jsonschema.Schema, len(eventSchemas))
+	for eventType := range eventSchemas {
+		compiled, err := compiler.Compile(schemaURL(eventType))
+		if err != nil {
+			compileErr = fmt.Errorf("failed to compile %s schema: %w", eventType, err)
+			return
+		}
+		compiledSchemas[eventType] = compiled
+	}
+}
+
+func schemaURL(eventType EventType) string {
+	return "urn:nimbusu:event:" + string(eventType)
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// envelopedEvent is any event type that embeds BaseEvent
+type envelopedEvent interface {
+	Envelope() *BaseEvent
+}
+
+// newEvents creates an event of each registered struct through its
+// constructor, as producers do
+var newEvents = map[reflect.Type]func(eventType EventType) envelopedEvent{
+	reflect.TypeOf(UserEvent{}): func(e EventType) envelopedEvent { return NewUserEvent(e, uuid.New(), "student@example.com") },
+	reflect.TypeOf(AuthEvent{}): func(e EventType) envelopedEvent {
+		return NewAuthEvent(e, uuid.New(), "student@example.com", "203.0.113.7", "test-agent", true)
+	},
+	reflect.TypeOf(RoleEvent{}): func(e EventType) envelopedEvent { return NewRoleEvent(e, uuid.New(), "student") },
+	reflect.TypeOf(NotificationCommand{}): func(e EventType) envelopedEvent {
+		return NewNotificationCommand("test-service", "info", "Title", "Message")
+	},
+	reflect.TypeOf(DepartmentEvent{}): func(e EventType) envelopedEvent { return NewDepartmentEvent(e, uuid.New()) },
+	reflect.TypeOf(ProgramEvent{}):    func(e EventType) envelopedEvent { return NewProgramEvent(e, uuid.New()) },
+	reflect.TypeOf(SubjectEvent{}):    func(e EventType) envelopedEvent { return NewSubjectEvent(e, uuid.New()) },
+	reflect.TypeOf(SemesterEvent{}):   func(e EventType) envelopedEvent { return NewSemesterEvent(e, uuid.New()) },
+	reflect.TypeOf(SemesterRolloverEvent{}): func(e EventType) envelopedEvent {
+		return NewSemesterRolloverEvent(uuid.New(), uuid.New())
+	},
+	reflect.TypeOf(CourseEvent{}):  func(e EventType) envelopedEvent { return NewCourseEvent(e, uuid.New()) },
+	reflect.TypeOf(FacultyEvent{}): func(e EventType) envelopedEvent { return NewFacultyEvent(e, uuid.New()) },
+	reflect.TypeOf(StudentEvent{}): func(e EventType) envelopedEvent { return NewStudentEvent(e, uuid.New()) },
+	reflect.TypeOf(FacultyAssignmentEvent{}): func(e EventType) envelopedEvent {
+		return NewFacultyAssignmentEvent(e, uuid.New(), uuid.New())
+	},
+	reflect.TypeOf(EnrollmentEvent{}): func(e EventType) envelopedEvent {
+		return NewEnrollmentEvent(e, uuid.New(), uuid.New(), uuid.New(), "enrolled")
+	},
+	reflect.TypeOf(GradebookEvent{}): func(e EventType) envelopedEvent { return NewGradebookEvent(e, uuid.New(), "submitted") },
+	reflect.TypeOf(GradeAmendmentEvent{}): func(e EventType) envelopedEvent {
+		return NewGradeAmendmentEvent(e, uuid.New(), uuid.New(), uuid.New(), uuid.New(), "pending")
+	},
+	reflect.TypeOf(CalendarEvent{}): func(e EventType) envelopedEvent { return NewCalendarEvent(e, uuid.New()) },
+}
+
+func TestValidateEvent_RegisteredEvents(t *testing.T) {
+	for eventType := range eventSchemas {
+		t.Run(string(eventType), func(t *testing.T) {
+			prototype := reflect.TypeOf(eventSchemas[eventType].prototype)
+			newEvent, ok := newEvents[prototype]
+			if !ok {
+				t.Fatalf("no constructor for %s", prototype.Name())
+			}
+			event := newEvent(eventType)
+			if got := event.Envelope().EventType; got != eventType {
+				t.Fatalf("constructor created a %s event", got)
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				t.Fatalf("failed to encode event: %v", err)
+			}
+			if err := ValidateEvent(data); err != nil {
+				t.Fatalf("ValidateEvent() = %v, want nil", err)
+			}
+
+			decoded := reflect.New(prototype).Interface().(envelopedEvent)
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("failed to decode event: %v", err)
+			}
+			if got := decoded.Envelope().EventType; got != eventType {
+				t.Errorf("decoded event_type = %q, want %q", got, eventType)
+			}
+			if got := decoded.Envelope().SchemaVersion; got != eventSchemas[eventType].version {
+				t.Errorf("decoded schema_version = %d, want %d", got, eventSchemas[eventType].version)
+			}
+		})
+	}
+}
+
+func TestValidateEvent_Invalid(t *testing.T) {
+	encode := func(t *testing.T, change func(fields map[string]interface{})) []byte {
+		t.Helper()
+		data, err := json.Marshal(NewUserEvent(EventUserCreated, uuid.New(), "student@example.com"))
+		if err != nil {
+			t.Fatalf("failed to encode event: %v", err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		change(fields)
+		data, err = json.Marshal(fields)
+		if err != nil {
+			t.Fatalf("failed to encode event: %v", err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		change  func(fields map[string]interface{})
+		wantErr error
+	}{
+		{
+			name:    "Unknown Event Type",
+			change:  func(fields map[string]interface{}) { fields["event_type"] = "USER_RENAMED" },
+			wantErr: ErrUnknownEventType,
+		},
+		{
+			name:    "Unsupported Schema Version",
+			change:  func(fields map[string]interface{}) { fields["schema_version"] = 2 },
+			wantErr: ErrUnsupportedSchemaVersion,
+		},
+		{
+			name:   "Missing Required Field",
+			change: func(fields map[string]interface{}) { delete(fields, "event_id") },
+		},
+		{
+			name:   "Invalid UUID",
+			change: func(fields map[string]interface{}) { fields["user_id"] = "not-a-uuid" },
+		},
+		{
+			name:   "Wrong Type",
+			change: func(fields map[string]interface{}) { fields["email"] = 42 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEvent(encode(t, tt.change))
+			if err == nil {
+				t.Fatal("ValidateEvent() = nil, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateEvent() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("Unknown Fields", func(t *testing.T) {
+		data := encode(t, func(fields map[string]interface{}) { fields["added_later"] = true })
+		if err := ValidateEvent(data); err != nil {
+			t.Errorf("ValidateEvent() = %v, want nil", err)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// JWKSPath is where the issuing service publishes its public keys
+const JWKSPath = "/.well-known/jwks.json"
+
+// jwksMinRefreshInterval bounds how often an unknown kid can trigger a refetch
+const jwksMinRefreshInterval = 30 * time.Second
+
+// JSONWebKey is the RFC 7517 representation of a public key
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Ed25519 (RFC 8037)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is the document served at JWKSPath
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// NewJSONWebKey converts a public key to its JWK representation
+func NewJSONWebKey(key *PublicKey) (JSONWebKey, error) {
+	jwk := JSONWebKey{
+		KeyID:     key.KeyID,
+		Use:       "sig",
+		Algorithm: key.Algorithm,
+	}
+
+	switch publicKey := key.Key.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	default:
+		return JSONWebKey{}, ErrUnsupportedKeyAlg
+	}
+
+	return jwk, nil
+}
+
+// PublicKey converts a JWK back to a verification key
+func (k JSONWebKey) PublicKey() (*PublicKey, error) {
+	switch {
+	case k.KeyType == "RSA" && k.Algorithm == AlgorithmRS256:
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa exponent: %w", err)
+		}
+		return &PublicKey{
+			KeyID:     k.KeyID,
+			Algorithm: k.Algorithm,
+			Key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		}, nil
+
+	case k.KeyType == "OKP" && k.Curve == "Ed25519" && k.Algorithm == AlgorithmEdDSA:
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 public key")
+		}
+		return &PublicKey{
+			KeyID:     k.KeyID,
+			Algorithm: k.Algorithm,
+			Key:       ed25519.PublicKey(x),
+		}, nil
+
+	default:
+		return nil, ErrUnsupportedKeyAlg
+	}
+}
+
+// JWKSClient verifies tokens against the key set published by the issuing service.
+// It never holds a private key, so it cannot sign tokens.
+type JWKSClient struct {
+	url        string
+	httpClient *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*PublicKey
+	lastRefresh time.Time
+}
+
+// NewJWKSClient creates a new JWKS client for the key set at url
+func NewJWKSClient(url string) *JWKSClient {
+	return &JWKSClient{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       map[string]*PublicKey{},
+	}
+}
+
+// Refresh fetches the key set and replaces the known keys
+func (c *JWKSClient) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	c.lastRefresh = time.Now()
+	c.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create jwks request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var set JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]*PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[key.KeyID] = key
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Start refreshes the key set every interval until ctx is done
+func (c *JWKSClient) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Refresh(ctx)
+		}
+	}
+}
+
+// SigningKey always fails, verifiers cannot issue tokens
+func (c *JWKSClient) SigningKey() (*SigningKey, error) {
+	return nil, ErrNoSigningKey
+}
+
+// VerificationKey returns the public key with the given kid, refetching the key
+// set once when the kid is unknown (e.g. right after a key rotation)
+func (c *JWKSClient) VerificationKey(keyID string) (*PublicKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[keyID]
+	canRefresh := time.Since(c.lastRefresh) >= jwksMinRefreshInterval
+	c.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if !canRefresh {
+		return nil, ErrUnknownKeyID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Refresh(ctx); err != nil {
+		return nil, ErrUnknownKeyID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if key, ok := c.keys[keyID]; ok {
+		return key, nil
+	}
+
+	return nil, ErrUnknownKeyID
+}
@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newJWKSServer serves the public keys of ring and counts the fetches
+func newJWKSServer(t *testing.T, ring *KeyRing) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(ring.JWKS())
+	}))
+	t.Cleanup(server.Close)
+	return server, &fetches
+}
+
+func TestJSONWebKey_RoundTrip(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			public := newTestSigningKey(t, algorithm, time.Now()).Public()
+
+			jwk, err := NewJSONWebKey(public)
+			if err != nil {
+				t.Fatalf("NewJSONWebKey() = %v", err)
+			}
+			got, err := jwk.PublicKey()
+			if err != nil {
+				t.Fatalf("PublicKey() = %v", err)
+			}
+			if got.KeyID != public.KeyID || got.Algorithm != public.Algorithm {
+				t.Errorf("PublicKey() = %s %s, want %s %s", got.KeyID, got.Algorithm, public.KeyID, public.Algorithm)
+			}
+			if !got.Key.(interface{ Equal(crypto.PublicKey) bool }).Equal(public.Key) {
+				t.Error("decoded key does not match the encoded key")
+			}
+		})
+	}
+}
+
+func TestJSONWebKey_BadKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		jwk  JSONWebKey
+	}{
+		{name: "Unsupported Key Type", jwk: JSONWebKey{KeyType: "EC", Algorithm: "ES256", Curve: "P-256", X: "AAAA"}},
+		{name: "RSA Key With EdDSA", jwk: JSONWebKey{KeyType: "RSA", Algorithm: AlgorithmEdDSA, N: "AQAB", E: "AQAB"}},
+		{name: "Ed25519 Key With RS256", jwk: JSONWebKey{KeyType: "OKP", Curve: "Ed25519", Algorithm: AlgorithmRS256, X: "AAAA"}},
+		{name: "Invalid RSA Modulus", jwk: JSONWebKey{KeyType: "RSA", Algorithm: AlgorithmRS256, N: "not base64!", E: "AQAB"}},
+		{name: "Invalid RSA Exponent", jwk: JSONWebKey{KeyType: "RSA", Algorithm: AlgorithmRS256, N: "AQAB", E: "not base64!"}},
+		{name: "Short Ed25519 Key", jwk: JSONWebKey{KeyType: "OKP", Curve: "Ed25519", Algorithm: AlgorithmEdDSA, X: "AAAA"}},
+		{name: "Other Curve", jwk: JSONWebKey{KeyType: "OKP", Curve: "X25519", Algorithm: AlgorithmEdDSA, X: "AAAA"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.jwk.PublicKey(); err == nil {
+				t.Error("PublicKey() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestJWKSClient_VerificationKey(t *testing.T) {
+	key := newTestSigningKey(t, AlgorithmEdDSA, time.Now().Add(-time.Hour))
+	ring := NewKeyRing(key)
+	server, fetches := newJWKSServer(t, ring)
+
+	t.Run("Known Kid", func(t *testing.T) {
+		client := NewJWKSClient(server.URL)
+		if err := client.Refresh(context.Background()); err != nil {
+			t.Fatalf("Refresh() = %v", err)
+		}
+		before := fetches.Load()
+
+		got, err := client.VerificationKey(key.KeyID)
+		if err != nil {
+			t.Fatalf("VerificationKey() = %v, want nil", err)
+		}
+		if got.KeyID != key.KeyID || got.Algorithm != AlgorithmEdDSA {
+			t.Errorf("VerificationKey() = %s %s, want %s %s", got.KeyID, got.Algorithm, key.KeyID, AlgorithmEdDSA)
+		}
+		if fetches.Load() != before {
+			t.Error("a known kid refetched the key set")
+		}
+	})
+
+	t.Run("Unknown Kid Refreshes", func(t *testing.T) {
+		client := NewJWKSClient(server.URL)
+		if err := client.Refresh(context.Background()); err != nil {
+			t.Fatalf("Refresh() = %v", err)
+		}
+
+		// The issuer rotates after the last refresh
+		rotated := newTestSigningKey(t, AlgorithmRS256, time.Now())
+		ring.SetKeys([]*SigningKey{key, rotated})
+		t.Cleanup(func() { ring.SetKeys([]*SigningKey{key}) })
+		client.lastRefresh = time.Now().Add(-jwksMinRefreshInterval)
+		before := fetches.Load()
+
+		got, err := client.VerificationKey(rotated.KeyID)
+		if err != nil {
+			t.Fatalf("VerificationKey() = %v, want nil", err)
+		}
+		if got.KeyID != rotated.KeyID {
+			t.Errorf("VerificationKey().KeyID = %s, want %s", got.KeyID, rotated.KeyID)
+		}
+		if fetches.Load() != before+1 {
+			t.Errorf("fetches = %d, want %d", fetches.Load(), before+1)
+		}
+	})
+
+	t.Run("Unknown Kid Rejected", func(t *testing.T) {
+		client := NewJWKSClient(server.URL)
+		before := fetches.Load()
+
+		if _, err := client.VerificationKey(uuid.New().String()); !errors.Is(err, ErrUnknownKeyID) {
+			t.Errorf("VerificationKey(unknown) = %v, want %v", err, ErrUnknownKeyID)
+		}
+		// A second unknown kid right after does not refetch
+		if _, err := client.VerificationKey(uuid.New().String()); !errors.Is(err, ErrUnknownKeyID) {
+			t.Errorf("VerificationKey(unknown) = %v, want %v", err, ErrUnknownKeyID)
+		}
+		if fetches.Load() != before+1 {
+			t.Errorf("fetches = %d, want %d", fetches.Load(), before+1)
+		}
+	})
+
+	t.Run("Bad Keys Skipped", func(t *testing.T) {
+		set := JSONWebKeySet{Keys: []JSONWebKey{
+			{KeyType: "OKP", KeyID: "short", Curve: "Ed25519", Algorithm: AlgorithmEdDSA, X: "AAAA"},
+			{KeyType: "EC", KeyID: "ec", Algorithm: "ES256"},
+		}}
+		good, err := NewJSONWebKey(key.Public())
+		if err != nil {
+			t.Fatalf("NewJSONWebKey() = %v", err)
+		}
+		set.Keys = append(set.Keys, good)
+		badServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(set)
+		}))
+		defer badServer.Close()
+
+		client := NewJWKSClient(badServer.URL)
+		if err := client.Refresh(context.Background()); err != nil {
+			t.Fatalf("Refresh() = %v", err)
+		}
+		for _, kid := range []string{"short", "ec"} {
+			if _, err := client.VerificationKey(kid); !errors.Is(err, ErrUnknownKeyID) {
+				t.Errorf("VerificationKey(%s) = %v, want %v", kid, err, ErrUnknownKeyID)
+			}
+		}
+		if _, err := client.VerificationKey(key.KeyID); err != nil {
+			t.Errorf("VerificationKey(good) = %v, want nil", err)
+		}
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		client := NewJWKSClient(failing.URL)
+		if err := client.Refresh(context.Background()); err == nil {
+			t.Error("Refresh() = nil, want an error")
+		}
+	})
+}
+
+func TestJWKSClient_ValidatesIssuedTokens(t *testing.T) {
+	ring := NewKeyRing(newTestSigningKey(t, AlgorithmRS256, time.Now().Add(-time.Hour)))
+	server, _ := newJWKSServer(t, ring)
+
+	issuer := NewJWTManager(ring, 900, 3600)
+	verifier := NewJWTManager(NewJWKSClient(server.URL), 900, 3600)
+
+	userID := uuid.New()
+	token, err := issuer.GenerateAccessToken(userID, "student@example.com", uuid.New(), "student")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() = %v", err)
+	}
+
+	claims, err := verifier.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() = %v, want nil", err)
+	}
+	if claims.UserID != userID {
+		t.Errorf("ValidateAccessToken().UserID = %s, want %s", claims.UserID, userID)
+	}
+
+	if _, err := verifier.GenerateAccessToken(userID, "student@example.com", uuid.New(), "student"); !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("verifier GenerateAccessToken() = %v, want %v", err, ErrNoSigningKey)
+	}
+}
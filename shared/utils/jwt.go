@@ -14,6 +14,19 @@ var (
 	ErrInvalidSignature = errors.New("invalid token signature")
 )
 
+// mfaTokenAudience marks MFA challenge tokens so they are never accepted as
+// access or refresh tokens
+const mfaTokenAudience = "mfa_challenge"
+
+// MFATokenDuration is how long a login MFA challenge stays valid
+const MFATokenDuration = 5 * time.Minute
+
+func init() {
+	// Issue and expiry times carry milliseconds, so that a token issued right
+	// after a user's tokens were revoked is not mistaken for a revoked one
+	jwt.TimePrecision = time.Millisecond
+}
+
 // Claims represents JWT claims
 type Claims struct {
 	UserID   uuid.UUID `json:"user_id"`
@@ -23,22 +36,62 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-// JWTManager manages JWT token creation and validation
+// JWTManager manages JWT token creation and validation. Tokens are signed with
+// RS256 or EdDSA keys and carry the kid of their key, so services that only hold
+// the public keys (see JWKSClient) can validate them.
 type JWTManager struct {
-	secretKey            string
+	keys                 KeyProvider
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
 }
 
 // NewJWTManager creates a new JWT manager
-func NewJWTManager(secretKey string, accessTokenExpiry, refreshTokenExpiry int) *JWTManager {
+func NewJWTManager(keys KeyProvider, accessTokenExpiry, refreshTokenExpiry int) *JWTManager {
 	return &JWTManager{
-		secretKey:            secretKey,
+		keys:                 keys,
 		accessTokenDuration:  time.Duration(accessTokenExpiry) * time.Second,
 		refreshTokenDuration: time.Duration(refreshTokenExpiry) * time.Second,
 	}
 }
 
+// sign signs claims with the current signing key and sets its kid header
+func (m *JWTManager) sign(claims jwt.Claims) (string, error) {
+	key, err := m.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	method, err := signingMethod(key.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.KeyID
+	return token.SignedString(key.PrivateKey)
+}
+
+// keyFunc resolves the verification key of a token from its kid header
+func (m *JWTManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+	key, err := m.keys.VerificationKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token must be signed with the algorithm its key was issued for
+	if token.Method.Alg() != key.Algorithm {
+		return nil, ErrInvalidSignature
+	}
+
+	return key.Key, nil
+}
+
+// parserOptions restricts parsing to the supported asymmetric algorithms
+func parserOptions(options ...jwt.ParserOption) []jwt.ParserOption {
+	return append([]jwt.ParserOption{jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA})}, options...)
+}
+
 // GenerateAccessToken generates a new access token
 func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email string, roleID uuid.UUID, roleName string) (string, error) {
 	claims := Claims{
@@ -54,8 +107,7 @@ func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email string, roleID
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secretKey))
+	return m.sign(claims)
 }
 
 // GenerateRefreshToken generates a new refresh token
@@ -68,18 +120,12 @@ func (m *JWTManager) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 		ID:        uuid.New().String(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secretKey))
+	return m.sign(claims)
 }
 
 // ValidateAccessToken validates and parses an access token
 func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidSignature
-		}
-		return []byte(m.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc, parserOptions()...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -89,7 +135,7 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || len(claims.Audience) > 0 {
 		return nil, ErrInvalidToken
 	}
 
@@ -98,12 +144,45 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
 
 // ValidateRefreshToken validates a refresh token and returns the user ID
 func (m *JWTManager) ValidateRefreshToken(tokenString string) (uuid.UUID, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidSignature
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, m.keyFunc, parserOptions()...)
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return uuid.Nil, ErrExpiredToken
 		}
-		return []byte(m.secretKey), nil
-	})
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	if !ok || !token.Valid || len(claims.Audience) > 0 {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	return userID, nil
+}
+
+// GenerateMFAToken generates a short-lived token proving the password step of a login
+func (m *JWTManager) GenerateMFAToken(userID uuid.UUID) (string, error) {
+	claims := jwt.RegisteredClaims{
+		Subject:   userID.String(),
+		Audience:  jwt.ClaimStrings{mfaTokenAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(MFATokenDuration)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		NotBefore: jwt.NewNumericDate(time.Now()),
+		ID:        uuid.New().String(),
+	}
+
+	return m.sign(claims)
+}
+
+// ValidateMFAToken validates an MFA challenge token and returns the user ID
+func (m *JWTManager) ValidateMFAToken(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, m.keyFunc, parserOptions(jwt.WithAudience(mfaTokenAudience))...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
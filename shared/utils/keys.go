@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Supported JWT signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+const rsaKeyBits = 2048
+
+var (
+	ErrNoSigningKey      = errors.New("no signing key available")
+	ErrUnknownKeyID      = errors.New("unknown signing key id")
+	ErrUnsupportedKeyAlg = errors.New("unsupported signing key algorithm")
+)
+
+// KeyProvider supplies the keys tokens are signed with and verified against
+type KeyProvider interface {
+	SigningKey() (*SigningKey, error)
+	VerificationKey(keyID string) (*PublicKey, error)
+}
+
+// SigningKey is a private key tokens are signed with, identified by the kid header
+type SigningKey struct {
+	KeyID       string
+	Algorithm   string
+	PrivateKey  crypto.Signer
+	ActivatesAt time.Time // the key signs tokens from this moment until a newer key activates
+}
+
+// PublicKey is the verification half of a signing key
+type PublicKey struct {
+	KeyID     string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// GenerateSigningKey generates a new signing key for the given algorithm
+func GenerateSigningKey(algorithm string, activatesAt time.Time) (*SigningKey, error) {
+	var privateKey crypto.Signer
+	var err error
+
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedKeyAlg
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	return &SigningKey{
+		KeyID:       uuid.New().String(),
+		Algorithm:   algorithm,
+		PrivateKey:  privateKey,
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+// Public returns the verification key of a signing key
+func (k *SigningKey) Public() *PublicKey {
+	return &PublicKey{
+		KeyID:     k.KeyID,
+		Algorithm: k.Algorithm,
+		Key:       k.PrivateKey.Public(),
+	}
+}
+
+// MarshalPrivateKeyPEM encodes a private key as a PKCS #8 PEM block
+func MarshalPrivateKeyPEM(privateKey crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// ParsePrivateKeyPEM decodes a PKCS #8 PEM encoded RSA or Ed25519 private key
+func ParsePrivateKeyPEM(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid private key pem")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, ErrUnsupportedKeyAlg
+	}
+}
+
+// signingMethod maps an algorithm name to its JWT signing method
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, ErrUnsupportedKeyAlg
+	}
+}
+
+// KeyRing holds the signing keys of the issuing service. The most recently
+// activated key signs new tokens, while every key in the ring stays valid for
+// verification until it is removed.
+type KeyRing struct {
+	mu   sync.RWMutex
+	keys []*SigningKey
+}
+
+// NewKeyRing creates a new key ring
+func NewKeyRing(keys ...*SigningKey) *KeyRing {
+	ring := &KeyRing{}
+	ring.SetKeys(keys)
+	return ring
+}
+
+// SetKeys replaces the keys of the ring
+func (r *KeyRing) SetKeys(keys []*SigningKey) {
+	sorted := make([]*SigningKey, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ActivatesAt.Before(sorted[j].ActivatesAt)
+	})
+
+	r.mu.Lock()
+	r.keys = sorted
+	r.mu.Unlock()
+}
+
+// SigningKey returns the most recently activated key
+func (r *KeyRing) SigningKey() (*SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if !r.keys[i].ActivatesAt.After(now) {
+			return r.keys[i], nil
+		}
+	}
+
+	return nil, ErrNoSigningKey
+}
+
+// VerificationKey returns the public key with the given kid
+func (r *KeyRing) VerificationKey(keyID string) (*PublicKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyID == keyID {
+			return key.Public(), nil
+		}
+	}
+
+	return nil, ErrUnknownKeyID
+}
+
+// JWKS returns the public keys of the ring, including keys that are not active yet
+// so verifiers already know them once they start signing
+func (r *KeyRing) JWKS() JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(r.keys))}
+	for _, key := range r.keys {
+		jwk, err := NewJSONWebKey(key.Public())
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
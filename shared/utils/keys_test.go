@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// newTestSigningKey generates a signing key or fails the test
+func newTestSigningKey(t *testing.T, algorithm string, activatesAt time.Time) *SigningKey {
+	t.Helper()
+	key, err := GenerateSigningKey(algorithm, activatesAt)
+	if err != nil {
+		t.Fatalf("GenerateSigningKey(%s) = %v", algorithm, err)
+	}
+	return key
+}
+
+// tokenKeyID returns the kid header of a token without verifying it
+func tokenKeyID(t *testing.T, tokenString string) string {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+	if err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+	keyID, _ := token.Header["kid"].(string)
+	return keyID
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	now := time.Now()
+	oldKey := newTestSigningKey(t, AlgorithmRS256, now.Add(-time.Hour))
+	newKey := newTestSigningKey(t, AlgorithmEdDSA, now.Add(-time.Minute))
+	nextKey := newTestSigningKey(t, AlgorithmEdDSA, now.Add(time.Hour))
+
+	ring := NewKeyRing(oldKey)
+	manager := NewJWTManager(ring, 900, 3600)
+
+	oldToken, err := manager.GenerateAccessToken(uuid.New(), "student@example.com", uuid.New(), "student")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() = %v", err)
+	}
+	if kid := tokenKeyID(t, oldToken); kid != oldKey.KeyID {
+		t.Fatalf("token kid = %q, want the old key %q", kid, oldKey.KeyID)
+	}
+
+	// Rotate: the newest active key signs, a key that is not active yet does not
+	ring.SetKeys([]*SigningKey{nextKey, newKey, oldKey})
+
+	newToken, err := manager.GenerateAccessToken(uuid.New(), "student@example.com", uuid.New(), "student")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() = %v", err)
+	}
+	if kid := tokenKeyID(t, newToken); kid != newKey.KeyID {
+		t.Errorf("token kid = %q, want the new key %q", kid, newKey.KeyID)
+	}
+	if _, err := manager.ValidateAccessToken(newToken); err != nil {
+		t.Errorf("ValidateAccessToken(new token) = %v, want nil", err)
+	}
+	if _, err := manager.ValidateAccessToken(oldToken); err != nil {
+		t.Errorf("ValidateAccessToken(old token) after rotation = %v, want nil", err)
+	}
+
+	// Retire the old key: its tokens no longer verify
+	ring.SetKeys([]*SigningKey{nextKey, newKey})
+
+	if _, err := manager.ValidateAccessToken(oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ValidateAccessToken(old token) after retirement = %v, want %v", err, ErrInvalidToken)
+	}
+	if _, err := manager.ValidateAccessToken(newToken); err != nil {
+		t.Errorf("ValidateAccessToken(new token) after retirement = %v, want nil", err)
+	}
+}
+
+func TestKeyRing_SigningKey(t *testing.T) {
+	now := time.Now()
+	active := newTestSigningKey(t, AlgorithmEdDSA, now.Add(-time.Minute))
+	pending := newTestSigningKey(t, AlgorithmEdDSA, now.Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		keys    []*SigningKey
+		want    *SigningKey
+		wantErr error
+	}{
+		{name: "Active Key", keys: []*SigningKey{pending, active}, want: active},
+		{name: "Only Pending Keys", keys: []*SigningKey{pending}, wantErr: ErrNoSigningKey},
+		{name: "Empty Ring", wantErr: ErrNoSigningKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewKeyRing(tt.keys...).SigningKey()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SigningKey() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SigningKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyRing_VerificationKey(t *testing.T) {
+	pending := newTestSigningKey(t, AlgorithmRS256, time.Now().Add(time.Hour))
+	ring := NewKeyRing(pending)
+
+	// Keys that do not sign yet are already known to verifiers
+	key, err := ring.VerificationKey(pending.KeyID)
+	if err != nil {
+		t.Fatalf("VerificationKey() = %v, want nil", err)
+	}
+	if key.Algorithm != AlgorithmRS256 {
+		t.Errorf("VerificationKey().Algorithm = %q, want %q", key.Algorithm, AlgorithmRS256)
+	}
+
+	if _, err := ring.VerificationKey(uuid.New().String()); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("VerificationKey(unknown) = %v, want %v", err, ErrUnknownKeyID)
+	}
+}
+
+func TestPrivateKeyPEM(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			key := newTestSigningKey(t, algorithm, time.Now())
+
+			encoded, err := MarshalPrivateKeyPEM(key.PrivateKey)
+			if err != nil {
+				t.Fatalf("MarshalPrivateKeyPEM() = %v", err)
+			}
+			decoded, err := ParsePrivateKeyPEM(encoded)
+			if err != nil {
+				t.Fatalf("ParsePrivateKeyPEM() = %v", err)
+			}
+			if !decoded.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(key.PrivateKey.Public()) {
+				t.Error("decoded key does not match the encoded key")
+			}
+		})
+	}
+
+	if _, err := ParsePrivateKeyPEM("not a pem block"); err == nil {
+		t.Error("ParsePrivateKeyPEM(invalid) = nil, want an error")
+	}
+}